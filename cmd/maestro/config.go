@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maestro/maestro.go/internal/application"
+	"github.com/maestro/maestro.go/internal/config"
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/alerting"
+	"github.com/maestro/maestro.go/internal/infrastructure/kafka"
+	"github.com/maestro/maestro.go/internal/infrastructure/secrets"
+	"github.com/maestro/maestro.go/internal/infrastructure/webhook"
+	"github.com/maestro/maestro.go/internal/ports"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type runtimeSettings struct {
+	configFile          string
+	apiKey              string
+	logLevel            zerolog.Level
+	workers             int
+	compensationWorkers int
+}
+
+type apiKeyHolder interface {
+	SetAPIKeys(keys []string)
+}
+
+func (s runtimeSettings) load() (*config.Config, error) {
+	cfg, err := config.Load(s.configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	level := s.logLevel
+	if configured, ok := cfg.Level(); ok {
+		level = configured
+	}
+	zerolog.SetGlobalLevel(level)
+
+	if cfg.Workers == 0 {
+		cfg.Workers = s.workers
+	}
+	if cfg.CompensationWorkers == 0 {
+		cfg.CompensationWorkers = s.compensationWorkers
+	}
+
+	return cfg, nil
+}
+
+func (s runtimeSettings) options(cfg *config.Config) []application.Option {
+	opts := []application.Option{
+		application.WithWorkerPoolSize(cfg.Workers),
+		application.WithCompensationPoolSize(cfg.CompensationWorkers),
+		application.WithServiceOverrides(cfg.Services),
+	}
+	if routes := cfg.Alerting; routes != nil {
+		router := alerting.NewRouter(routes.Webhook, routes.PagerDutyURL, routes.PagerDutyServices, log.Logger)
+		opts = append(opts, application.WithNotifier(router))
+	}
+	if len(cfg.Webhooks) > 0 || cfg.Callbacks != nil {
+		endpoints := make([]webhook.Endpoint, 0, len(cfg.Webhooks))
+		for _, hook := range cfg.Webhooks {
+			endpoints = append(endpoints, webhook.Endpoint{URL: hook.URL, Secret: hook.Secret})
+		}
+		var callbacks *webhook.CallbackPolicy
+		if cfg.Callbacks != nil {
+			callbacks = &webhook.CallbackPolicy{Secret: cfg.Callbacks.Secret, AllowedHosts: cfg.Callbacks.AllowedHosts}
+		}
+		opts = append(opts, application.WithWebhooks(webhook.NewDispatcher(endpoints, callbacks, log.Logger)))
+	}
+	if cfg.Kafka != nil {
+		opts = append(opts, application.WithTriggerSource(domain.TriggerKafka, kafka.NewConsumer(cfg.Kafka.Brokers, log.Logger)))
+	}
+	if cfg.SecretProviders != nil {
+		opts = append(opts, application.WithSecretProvider(secretProvider(cfg.SecretProviders)))
+	}
+	if fields := cfg.SensitiveFields(); len(fields) > 0 {
+		opts = append(opts, application.WithSensitiveFields(fields...))
+	}
+	return opts
+}
+
+// apiKeys adds the key given with --api-key, which stays valid across
+// reloads, to the ones read from the configuration file.
+func (s runtimeSettings) apiKeys(configured ...string) []string {
+	if s.apiKey == "" {
+		return configured
+	}
+	return append([]string{s.apiKey}, configured...)
+}
+
+func secretProvider(cfg *config.SecretsConfig) ports.SecretProvider {
+	ttl := secrets.DefaultCacheTTL
+	if cfg.CacheTTL != nil {
+		ttl = cfg.CacheTTL.Duration
+	}
+
+	providers := make([]ports.SecretProvider, 0, len(cfg.Providers))
+	for _, provider := range cfg.Providers {
+		switch provider.Type {
+		case "env":
+			prefix := provider.Prefix
+			if prefix == "" {
+				prefix = secrets.DefaultEnvPrefix
+			}
+			providers = append(providers, secrets.NewEnvProvider(prefix))
+		case "file":
+			providers = append(providers, secrets.NewFileProvider(provider.Dir))
+		case "vault":
+			providers = append(providers, secrets.NewVaultProvider(provider.Address, provider.Token, provider.Mount, provider.Path))
+		case "aws":
+			providers = append(providers, secrets.NewAWSProvider(provider.Region, provider.Prefix))
+		}
+	}
+	return secrets.NewChain(ttl, providers...)
+}
+
+func (s runtimeSettings) reloader(orch *application.Orchestrator, servers ...apiKeyHolder) func(context.Context) error {
+	return func(context.Context) error {
+		cfg, err := s.load()
+		if err != nil {
+			return err
+		}
+
+		orch.SetWorkerLimits(cfg.Workers, cfg.CompensationWorkers)
+		if err := orch.SetServiceOverrides(cfg.Services); err != nil {
+			return fmt.Errorf("failed to apply service overrides: %w", err)
+		}
+		if err := orch.SetSchedules(cfg.Schedules); err != nil {
+			return fmt.Errorf("failed to apply schedules: %w", err)
+		}
+		keys := s.apiKeys(cfg.APIKeys...)
+		for _, server := range servers {
+			server.SetAPIKeys(keys)
+		}
+		return nil
+	}
+}
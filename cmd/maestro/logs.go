@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+func showLogs(server, apiKey, executionID string, follow bool) {
+	logger := log.With().Str("command", "logs").Str("workflow_id", executionID).Logger()
+
+	path := "/executions/" + url.PathEscape(executionID)
+	if follow {
+		path += "/events"
+	}
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(server, "/")+path, nil)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid server URL")
+	}
+	if follow {
+		req.Header.Set("Accept", "text/event-stream")
+	}
+
+	resp := callServer(logger, req, apiKey, http.StatusOK)
+	defer resp.Body.Close()
+
+	if !follow {
+		var execution struct {
+			WorkflowName string                  `json:"workflow_name"`
+			Status       string                  `json:"status"`
+			Error        string                  `json:"error"`
+			Plan         *workflow.ExecutionPlan `json:"plan"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&execution); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to decode execution")
+		}
+
+		fmt.Printf("%s %s %s: %s\n", statusIcon(execution.Status), execution.WorkflowName, executionID, execution.Status)
+		if execution.Error != "" {
+			fmt.Printf("  error: %s\n", execution.Error)
+		}
+		printPlan(execution.Plan)
+		if failedStatus(execution.Status) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	var last workflow.Event
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 4<<20)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+
+		var event workflow.Event
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			logger.Warn().Err(err).Msg("Failed to decode event")
+			continue
+		}
+		printEvent(event)
+		last = event
+	}
+	if err := scanner.Err(); err != nil {
+		logger.Fatal().Err(err).Msg("Event stream interrupted")
+	}
+
+	if failedStatus(last.Status) {
+		os.Exit(1)
+	}
+}
+
+func printEvent(event workflow.Event) {
+	icon := "  "
+	switch event.Type {
+	case workflow.EventWorkflowSucceeded, workflow.EventStepSucceeded, workflow.EventStepCompensated, workflow.EventCompensationCompleted:
+		icon = "✅"
+	case workflow.EventWorkflowFailed, workflow.EventWorkflowCancelled, workflow.EventStepFailed,
+		workflow.EventStepCompensationFailed, workflow.EventCompensationFailed:
+		icon = "❌"
+	}
+
+	line := fmt.Sprintf("%s %s %s", event.Timestamp.Local().Format("15:04:05.000"), icon, event.Type)
+	if event.StepID != "" {
+		line += " " + event.StepID
+	}
+	if event.Service != "" {
+		line += " (" + event.Service + ")"
+	}
+	if event.Status != "" && event.StepID == "" {
+		line += " " + event.Status
+	}
+	if event.Duration != nil {
+		line += " in " + event.Duration.String()
+	}
+	if event.Fallback != "" {
+		line += ", fell back to " + event.Fallback
+	}
+	if event.Error != "" {
+		line += ": " + event.Error
+	}
+	fmt.Println(line)
+}
+
+func statusIcon(status string) string {
+	if failedStatus(status) {
+		return "❌"
+	}
+	if status == workflow.WorkflowStatusSuccess.String() {
+		return "✅"
+	}
+	return "  "
+}
+
+func failedStatus(status string) bool {
+	switch status {
+	case workflow.WorkflowStatusFailed.String(), workflow.WorkflowStatusCancelled.String(), workflow.WorkflowStatusCompensated.String():
+		return true
+	default:
+		return false
+	}
+}
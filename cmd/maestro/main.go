@@ -1,16 +1,34 @@
 package main
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"maps"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/maestro/maestro.go/internal/application"
+	"github.com/maestro/maestro.go/internal/config"
+	"github.com/maestro/maestro.go/internal/conformance"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/fixtures"
+	"github.com/maestro/maestro.go/internal/infrastructure/api"
+	"github.com/maestro/maestro.go/internal/infrastructure/cluster"
+	"github.com/maestro/maestro.go/internal/infrastructure/events"
+	"github.com/maestro/maestro.go/internal/infrastructure/grpc"
+	"github.com/maestro/maestro.go/internal/infrastructure/kv"
+	"github.com/maestro/maestro.go/internal/infrastructure/store"
+	"github.com/maestro/maestro.go/internal/infrastructure/tracing"
+	"github.com/maestro/maestro.go/internal/scaffold"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
@@ -19,17 +37,74 @@ func main() {
 	var (
 		command      string
 		workflowFile string
+		workflowDir  string
 		inputJSON    string
+		exportFile   string
+		captureFile  string
+		kvFile       string
+		apiKey       string
+		postgresDSN  string
+		environment  string
+		version      string
+		serviceMerge string
+		configFile   string
+		nodeID       string
+		peers        string
+		peerSecret   string
+		otlpEndpoint string
+		eventLog     string
+		tagPairs     []string
+		workers      int
+		compWorkers  int
+		retention    time.Duration
 		port         int
+		grpcPort     int
 		debug        bool
 		trace        bool
+		cmdHooks     bool
+		showPlan     bool
+		allowExec    bool
+		dryRun       bool
+		standby      bool
+		watchFiles   bool
 	)
 
-	flag.StringVar(&workflowFile, "workflow", "", "Path to workflow YAML file")
-	flag.StringVar(&workflowFile, "f", "", "Path to workflow YAML file (shorthand)")
+	flag.StringVar(&workflowFile, "workflow", "", "Path to workflow YAML or JSON file")
+	flag.StringVar(&workflowFile, "f", "", "Path to workflow YAML or JSON file (shorthand)")
+	flag.StringVar(&workflowDir, "workflows", os.Getenv("MAESTRO_WORKFLOWS"), "Load every workflow under this directory or glob (for serve command)")
 	flag.StringVar(&inputJSON, "input", "{}", "Input data as JSON")
 	flag.StringVar(&inputJSON, "i", "{}", "Input data as JSON (shorthand)")
+	flag.StringVar(&exportFile, "export", "", "Write an execution snapshot to this file (for execute command)")
+	flag.StringVar(&captureFile, "capture", "", "Record full request/response payloads of this execution to a file readable only by you (for execute command)")
+	flag.StringVar(&kvFile, "kv-file", "", "Persist the workflow key-value store to this file")
+	flag.StringVar(&postgresDSN, "postgres-dsn", os.Getenv("MAESTRO_POSTGRES_DSN"), "Checkpoint executions and keep workflow locks in this PostgreSQL database")
+	flag.StringVar(&configFile, "config", os.Getenv("MAESTRO_CONFIG"), "Server configuration file, reloaded on SIGHUP")
+	flag.StringVar(&nodeID, "node-id", os.Getenv("MAESTRO_NODE_ID"), "ID of this node in --peers (for serve command, default: hostname)")
+	flag.StringVar(&peers, "peers", os.Getenv("MAESTRO_PEERS"), "Cluster nodes as id=url,... executions are routed to their owner (for serve command)")
+	flag.StringVar(&peerSecret, "cluster-secret", os.Getenv("MAESTRO_CLUSTER_SECRET"), "Secret shared by the --peers nodes to authenticate forwarded requests (for serve command)")
+	defaultEnvironment := cmp.Or(os.Getenv("MAESTRO_ENV"), os.Getenv("MAESTRO_PROFILE"))
+	flag.StringVar(&environment, "env", defaultEnvironment, "Environment profile to execute workflows in")
+	flag.StringVar(&environment, "profile", defaultEnvironment, "Environment profile to execute workflows in (alias of --env)")
+	flag.StringVar(&version, "version", "", "Execute this loaded version of the workflow instead of the latest (for execute command)")
+	flag.StringVar(&serviceMerge, "service-merge", cmp.Or(os.Getenv("MAESTRO_SERVICE_MERGE"), workflow.ServiceMergeReuse), "How workflows declaring the same service share it: reuse, error or namespace")
+	flag.StringVar(&otlpEndpoint, "otlp-endpoint", os.Getenv("MAESTRO_OTLP_ENDPOINT"), "Export OpenTelemetry traces to this OTLP gRPC collector (host:port)")
+	flag.StringVar(&eventLog, "event-log", os.Getenv("MAESTRO_EVENT_LOG"), "Append lifecycle events as JSON lines to this file, - for stdout")
+	flag.Func("tag", "Tag this execution with key=value, repeatable (for execute and dev commands)", func(pair string) error {
+		tagPairs = append(tagPairs, pair)
+		return nil
+	})
+	flag.IntVar(&workers, "workers", 10, "Maximum number of concurrently executing steps")
+	flag.IntVar(&compWorkers, "compensation-workers", 0, "Maximum concurrently running compensations, 0 for no limit")
+	flag.DurationVar(&retention, "execution-retention", time.Hour, "How long finished executions stay in memory; with --postgres-dsn they are read back from the store after that")
 	flag.IntVar(&port, "port", 8080, "Port to listen on (for serve command)")
+	flag.BoolVar(&cmdHooks, "allow-command-hooks", os.Getenv("MAESTRO_ALLOW_COMMAND_HOOKS") == "true", "Allow workflows with before_each and after_each command hooks")
+	flag.StringVar(&apiKey, "api-key", os.Getenv("MAESTRO_API_KEY"), "API key accepted by the serve API, in addition to api_keys from --config")
+	flag.IntVar(&grpcPort, "grpc-port", 0, "gRPC port to listen on (for serve command, 0 disables)")
+	flag.BoolVar(&showPlan, "plan", false, "Print the execution plan with concurrency, slot waits and critical path (for execute and dev commands)")
+	flag.BoolVar(&allowExec, "allow-exec", os.Getenv("MAESTRO_ALLOW_EXEC") == "true", "Allow workflows with type: exec services")
+	flag.BoolVar(&dryRun, "dry-run", false, "Resolve templates and when conditions and print the steps that would run, without calling any service (for execute command)")
+	flag.BoolVar(&standby, "standby", os.Getenv("MAESTRO_STANDBY") == "true", "Run serve as a warm standby that takes over when the primary sharing --postgres-dsn disappears")
+	flag.BoolVar(&watchFiles, "watch", os.Getenv("MAESTRO_WATCH") == "true", "Reload workflow files when they change (for serve command)")
 	flag.BoolVar(&debug, "debug", false, "Enable debug logging")
 	flag.BoolVar(&trace, "trace", false, "Enable trace logging")
 	flag.Parse()
@@ -43,7 +118,17 @@ func main() {
 	}
 
 	zerolog.TimeFieldFormat = time.RFC3339
-	log.Logger = zerolog.New(os.Stdout).With().Timestamp().Logger().Level(logLevel)
+	zerolog.SetGlobalLevel(logLevel)
+	log.Logger = zerolog.New(os.Stdout).With().Timestamp().Logger()
+
+	tags, err := workflow.ParseTags(tagPairs)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid --tag")
+	}
+
+	if !slices.Contains(workflow.ServiceMergeModes, serviceMerge) {
+		log.Fatal().Str("service_merge", serviceMerge).Strs("modes", workflow.ServiceMergeModes).Msg("Invalid --service-merge")
+	}
 
 	if flag.NArg() < 1 {
 		printUsage()
@@ -52,29 +137,371 @@ func main() {
 
 	command = flag.Arg(0)
 
+	if otlpEndpoint != "" {
+		if err := tracing.Setup(context.Background(), otlpEndpoint, "maestro"); err != nil {
+			log.Fatal().Err(err).Msg("Failed to set up tracing")
+		}
+		defer shutdownTracing()
+	}
+
+	settings := runtimeSettings{
+		configFile:          configFile,
+		apiKey:              apiKey,
+		logLevel:            logLevel,
+		workers:             workers,
+		compensationWorkers: compWorkers,
+	}
+
+	orchOpts := []application.Option{
+		application.WithWorkerPoolSize(workers),
+		application.WithCompensationPoolSize(compWorkers),
+		application.WithExecutionRetention(retention),
+		application.WithDefaultEnvironment(environment),
+		application.WithCommandHooks(cmdHooks),
+		application.WithExecServices(allowExec),
+		application.WithServiceMerge(serviceMerge),
+	}
+	var storeOpts []store.Option
+	var serviceOverrides map[string]workflow.ServiceOverride
+	var cfg *config.Config
+	if configFile != "" {
+		var err error
+		cfg, err = settings.load()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to load configuration")
+		}
+		orchOpts = append(orchOpts, settings.options(cfg)...)
+		serviceOverrides = cfg.Services
+		if len(cfg.NamespaceKeys) > 0 {
+			keys, err := cfg.EncryptionKeys()
+			if err != nil {
+				log.Fatal().Err(err).Msg("Failed to load configuration")
+			}
+			storeOpts = append(storeOpts, store.WithNamespaceKeys(keys))
+		}
+	}
+	if eventLog != "" {
+		sink, closeLog, err := openEventLog(eventLog)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to open event log")
+		}
+		defer closeLog()
+		orchOpts = append(orchOpts, application.WithEventSink(sink))
+	}
+	if kvFile != "" {
+		store, err := kv.NewFileStore(kvFile)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to open key-value store")
+		}
+		orchOpts = append(orchOpts, application.WithKVStore(store))
+	}
+	var executionStore *store.PostgresStore
+	if postgresDSN != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		var err error
+		executionStore, err = store.NewPostgresStore(ctx, postgresDSN, storeOpts...)
+		cancel()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to open execution store")
+		}
+		defer executionStore.Close()
+		orchOpts = append(orchOpts, application.WithExecutionStore(executionStore))
+	}
+
 	switch command {
 	case "execute":
-		if flag.NArg() >= 2 {
-			workflowFile = flag.Arg(1)
-		} else if workflowFile == "" {
+		args := flag.Args()[1:]
+		var workflowFiles []string
+		for len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+			workflowFiles = append(workflowFiles, args[0])
+			args = args[1:]
+		}
+
+		executeFlags := flag.NewFlagSet("execute", flag.ExitOnError)
+		executeFlags.BoolVar(&dryRun, "dry-run", dryRun, "Print the steps that would run without calling any service")
+		executeFlags.BoolVar(&showPlan, "plan", showPlan, "Print the execution plan")
+		executeFlags.StringVar(&exportFile, "export", exportFile, "Write an execution snapshot to this file")
+		executeFlags.StringVar(&captureFile, "capture", captureFile, "Record full request/response payloads to this file")
+		executeFlags.StringVar(&version, "version", version, "Execute this loaded version of the workflow")
+		executeFlags.StringVar(&inputJSON, "input", inputJSON, "Input data as JSON")
+		executeFlags.StringVar(&inputJSON, "i", inputJSON, "Input data as JSON (shorthand)")
+		_ = executeFlags.Parse(args)
+		workflowFiles = append(workflowFiles, executeFlags.Args()...)
+		if workflowFile != "" {
+			workflowFiles = append([]string{workflowFile}, workflowFiles...)
+		}
+
+		if len(workflowFiles) == 0 {
 			fmt.Println("Error: workflow file required for execute command")
 			printUsage()
 			os.Exit(1)
 		}
-		executeWorkflow(workflowFile, inputJSON)
+		workflowFile, subWorkflowFiles := workflowFiles[0], workflowFiles[1:]
+		if dryRun {
+			orchOpts = append(orchOpts, application.WithExecutionStore(nil))
+			dryRunWorkflow(workflowFile, subWorkflowFiles, inputJSON, environment, version, tags, orchOpts)
+			return
+		}
+		executeWorkflow(workflowFile, subWorkflowFiles, inputJSON, exportFile, captureFile, environment, version, tags, showPlan, orchOpts)
+
+	case "dev":
+		args := flag.Args()[1:]
+		var workflowFiles []string
+		for len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+			workflowFiles = append(workflowFiles, args[0])
+			args = args[1:]
+		}
+
+		devFlags := flag.NewFlagSet("dev", flag.ExitOnError)
+		fixturesFile := devFlags.String("fixtures", "fixtures.yaml", "Canned service responses keyed by service.method")
+		devFlags.StringVar(&inputJSON, "input", inputJSON, "Input data as JSON")
+		devFlags.StringVar(&inputJSON, "i", inputJSON, "Input data as JSON (shorthand)")
+		_ = devFlags.Parse(args)
+		workflowFiles = append(workflowFiles, devFlags.Args()...)
+		if workflowFile != "" {
+			workflowFiles = append([]string{workflowFile}, workflowFiles...)
+		}
+
+		if len(workflowFiles) == 0 {
+			fmt.Println("Error: workflow file required for dev command")
+			printUsage()
+			os.Exit(1)
+		}
+		runDev(workflowFiles, *fixturesFile, inputJSON, environment, tags, showPlan, serviceOverrides, orchOpts)
 
 	case "serve":
-		serveOrchestrator(port)
+		args := flag.Args()[1:]
+		var workflowFiles []string
+		for len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+			workflowFiles = append(workflowFiles, args[0])
+			args = args[1:]
+		}
+
+		serveFlags := flag.NewFlagSet("serve", flag.ExitOnError)
+		serveFlags.StringVar(&workflowDir, "workflows", workflowDir, "Load every workflow under this directory or glob")
+		serveFlags.BoolVar(&watchFiles, "watch", watchFiles, "Reload workflow files when they change")
+		serveFlags.IntVar(&port, "port", port, "Port to listen on")
+		serveFlags.IntVar(&grpcPort, "grpc-port", grpcPort, "gRPC port to listen on, 0 disables")
+		_ = serveFlags.Parse(args)
+		workflowFiles = append(workflowFiles, serveFlags.Args()...)
+		if workflowFile != "" {
+			workflowFiles = append([]string{workflowFile}, workflowFiles...)
+		}
+		if standby {
+			if executionStore == nil {
+				log.Fatal().Msg("--standby requires --postgres-dsn")
+			}
+			orchOpts = append(orchOpts, application.WithStandby())
+		}
+		serveOrchestrator(port, grpcPort, workflowFiles, workflowDir, watchFiles, nodeID, peers, peerSecret, standby, settings, orchOpts)
+
+	case "test":
+		paths := flag.Args()[1:]
+		if len(paths) == 0 {
+			paths = []string{"."}
+		}
+		runTests(paths, debug || trace)
 
 	case "validate":
-		if flag.NArg() >= 2 {
-			workflowFile = flag.Arg(1)
-		} else if workflowFile == "" {
+		args := flag.Args()[1:]
+		if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+			workflowFile = args[0]
+			args = args[1:]
+		}
+
+		validateFlags := flag.NewFlagSet("validate", flag.ExitOnError)
+		sampleInput := validateFlags.String("input", "", "Check an input as JSON against the workflow's input_schema")
+		validateFlags.StringVar(sampleInput, "i", "", "Check an input as JSON against the workflow's input_schema (shorthand)")
+		_ = validateFlags.Parse(args)
+
+		if workflowFile == "" {
 			fmt.Println("Error: workflow file required for validate command")
 			printUsage()
 			os.Exit(1)
 		}
-		validateWorkflow(workflowFile)
+		validateWorkflow(workflowFile, *sampleInput)
+
+	case "explain":
+		args := flag.Args()[1:]
+		if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+			workflowFile = args[0]
+			args = args[1:]
+		}
+
+		explainFlags := flag.NewFlagSet("explain", flag.ExitOnError)
+		stepID := explainFlags.String("step", "", "Step to explain")
+		explainFlags.StringVar(&inputJSON, "input", inputJSON, "Sample input as JSON")
+		explainFlags.StringVar(&inputJSON, "i", inputJSON, "Sample input as JSON (shorthand)")
+		_ = explainFlags.Parse(args)
+
+		if workflowFile == "" || *stepID == "" {
+			fmt.Println("Error: workflow file and --step required for explain command")
+			printUsage()
+			os.Exit(1)
+		}
+		explainStep(workflowFile, *stepID, inputJSON)
+
+	case "scaffold":
+		args := flag.Args()[1:]
+		if len(args) == 0 || args[0] != "service" {
+			fmt.Println("Error: scaffold requires the service subcommand")
+			printUsage()
+			os.Exit(1)
+		}
+
+		scaffoldFlags := flag.NewFlagSet("scaffold service", flag.ExitOnError)
+		lang := scaffoldFlags.String("lang", "go", "Service language: "+strings.Join(scaffold.Languages, ", "))
+		name := scaffoldFlags.String("name", "", "Service name")
+		outDir := scaffoldFlags.String("out", "", "Output directory (default: the service name)")
+		servicePort := scaffoldFlags.Int("port", 50051, "Port the generated service listens on")
+		_ = scaffoldFlags.Parse(args[1:])
+
+		if *name == "" {
+			fmt.Println("Error: --name required for scaffold service command")
+			printUsage()
+			os.Exit(1)
+		}
+		scaffoldService(scaffold.Options{Lang: *lang, Name: *name, OutDir: *outDir, Port: *servicePort})
+
+	case "verify-service":
+		verifyFlags := flag.NewFlagSet("verify-service", flag.ExitOnError)
+		endpoint := verifyFlags.String("endpoint", "", "Service gRPC endpoint (host:port)")
+		method := verifyFlags.String("method", "", "Method to exercise with Execute")
+		compensateMethod := verifyFlags.String("compensate-method", "", "Method to exercise with Compensate")
+		verifyFlags.StringVar(&inputJSON, "input", inputJSON, "Sample payload as JSON")
+		verifyFlags.StringVar(&inputJSON, "i", inputJSON, "Sample payload as JSON (shorthand)")
+		timeout := verifyFlags.Duration("timeout", 5*time.Second, "Timeout for each call")
+		_ = verifyFlags.Parse(flag.Args()[1:])
+
+		if *endpoint == "" {
+			fmt.Println("Error: --endpoint required for verify-service command")
+			printUsage()
+			os.Exit(1)
+		}
+		verifyService(conformance.Options{
+			Endpoint:         *endpoint,
+			Method:           *method,
+			CompensateMethod: *compensateMethod,
+			Timeout:          *timeout,
+		}, inputJSON)
+
+	case "logs":
+		args := flag.Args()[1:]
+		var executionID string
+		if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+			executionID = args[0]
+			args = args[1:]
+		}
+
+		logsFlags := flag.NewFlagSet("logs", flag.ExitOnError)
+		follow := logsFlags.Bool("follow", false, "Stream the execution's events until it finishes")
+		server := logsFlags.String("server", defaultServerURL(), "URL of the maestro serve API (env: MAESTRO_SERVER)")
+		apiKey := logsFlags.String("api-key", os.Getenv("MAESTRO_API_KEY"), "API key for the serve API (env: MAESTRO_API_KEY)")
+		_ = logsFlags.Parse(args)
+		if executionID == "" && logsFlags.NArg() > 0 {
+			executionID = logsFlags.Arg(0)
+		}
+
+		if executionID == "" {
+			fmt.Println("Error: execution ID required for logs command")
+			printUsage()
+			os.Exit(1)
+		}
+		showLogs(*server, *apiKey, executionID, *follow)
+
+	case "schema":
+		printSchema()
+
+	case "export":
+		args := flag.Args()[1:]
+		var executionID string
+		if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+			executionID = args[0]
+			args = args[1:]
+		}
+
+		exportFlags := flag.NewFlagSet("export", flag.ExitOnError)
+		server := exportFlags.String("server", defaultServerURL(), "URL of the maestro serve API (env: MAESTRO_SERVER)")
+		out := exportFlags.String("out", "", "Write the snapshot to this file instead of <execution-id>.json")
+		key := exportFlags.String("api-key", apiKey, "API key for the serve API (env: MAESTRO_API_KEY)")
+		_ = exportFlags.Parse(args)
+		if executionID == "" && exportFlags.NArg() > 0 {
+			executionID = exportFlags.Arg(0)
+		}
+
+		if executionID == "" {
+			fmt.Println("Error: execution ID required for export command")
+			printUsage()
+			os.Exit(1)
+		}
+		exportFromServer(*server, *key, executionID, cmp.Or(*out, executionID+".json"))
+
+	case "import":
+		args := flag.Args()[1:]
+		var snapshotFile string
+		if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+			snapshotFile = args[0]
+			args = args[1:]
+		}
+
+		importFlags := flag.NewFlagSet("import", flag.ExitOnError)
+		server := importFlags.String("server", defaultServerURL(), "URL of the maestro serve API (env: MAESTRO_SERVER)")
+		key := importFlags.String("api-key", apiKey, "API key for the serve API (env: MAESTRO_API_KEY)")
+		_ = importFlags.Parse(args)
+		if snapshotFile == "" && importFlags.NArg() > 0 {
+			snapshotFile = importFlags.Arg(0)
+		}
+
+		if snapshotFile == "" {
+			fmt.Println("Error: snapshot file required for import command")
+			printUsage()
+			os.Exit(1)
+		}
+		importToServer(*server, *key, snapshotFile)
+
+	case "replay":
+		args := flag.Args()[1:]
+		if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+			workflowFile = args[0]
+			args = args[1:]
+		}
+
+		replayFlags := flag.NewFlagSet("replay", flag.ExitOnError)
+		sample := replayFlags.Int("sample", 20, "Number of recent executions to replay from --postgres-dsn")
+		status := replayFlags.String("status", "", "Only replay executions with this status")
+		reportFile := replayFlags.String("report", "", "Write the replay report as JSON to this file")
+		_ = replayFlags.Parse(args)
+
+		if workflowFile == "" || (replayFlags.NArg() == 0 && executionStore == nil) {
+			fmt.Println("Error: workflow file and snapshot files or --postgres-dsn required for replay command")
+			printUsage()
+			os.Exit(1)
+		}
+		replayExecutions(workflowFile, replayFlags.Args(), executionStore, *sample, *status, *reportFile)
+
+	case "preflight":
+		args := flag.Args()[1:]
+		var workflowFiles []string
+		for len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+			workflowFiles = append(workflowFiles, args[0])
+			args = args[1:]
+		}
+
+		preflightFlags := flag.NewFlagSet("preflight", flag.ExitOnError)
+		timeout := preflightFlags.Duration("timeout", workflow.DefaultPreflightTimeout, "Timeout for each dial and health check")
+		reportFile := preflightFlags.String("report", "", "Write the preflight report as JSON to this file, - for stdout")
+		_ = preflightFlags.Parse(args)
+		workflowFiles = append(workflowFiles, preflightFlags.Args()...)
+		if workflowFile != "" {
+			workflowFiles = append([]string{workflowFile}, workflowFiles...)
+		}
+
+		if len(workflowFiles) == 0 {
+			fmt.Println("Error: workflow files required for preflight command")
+			printUsage()
+			os.Exit(1)
+		}
+		runPreflight(workflowFiles, cfg, *timeout, *reportFile, orchOpts)
 
 	case "help":
 		printUsage()
@@ -93,25 +520,106 @@ Usage:
   maestro <command> [options]
 
 Commands:
-  execute <workflow.yaml>  Execute a workflow
-  serve                    Start the orchestrator server
-  validate <workflow.yaml> Validate a workflow file
+  execute <workflow.yaml> [sub-workflow.yaml...]
+                           Execute a workflow, loading the workflows it calls
+  serve [workflow.yaml...] Start the orchestrator server
+  dev <workflow.yaml> [sub-workflow.yaml...] [--fixtures file]
+                           Execute a workflow against in-process fake services
+                           that answer with canned responses from a fixtures file
+  test [dir|file...]       Run the *_test.yaml workflow tests under each path against
+                           mocked services and report which pass (default: .)
+  validate <workflow.yaml> [--input json]
+                           Validate a workflow file, and an input against its input_schema
+  export <execution-id> [--out file] [--server url] [--api-key key]
+                           Save a snapshot of an execution on a running server
+  import <snapshot.json> [--server url] [--api-key key]
+                           Load a snapshot into a running server; running executions
+                           resume there, finished ones are stored
+  replay <workflow.yaml> [--sample n] [--status s] [--report file] [snapshot.json...]
+                           Replay recorded executions against a new workflow version
+                           in shadow mode and report output and path differences
+  preflight <workflow.yaml...> [--timeout d] [--report file]
+                           Load workflows, dial their services, run health checks and
+                           check config secrets; exits non-zero if anything fails
+  explain <workflow.yaml> --step <id>
+                           Show the template data a step sees and how its input resolves
+  scaffold service --lang go|python|node --name <name> [--out dir] [--port n]
+                           Generate a MaestroService stub (Execute/Compensate/HealthCheck)
+  verify-service --endpoint host:port [--method m] [--compensate-method m]
+                           Check a service against the MaestroService contract
+  logs <execution-id> [--follow] [--server url] [--api-key key]
+                           Show how an execution on a running server went; with --follow,
+                           stream its step events live until it finishes
+  schema                   Print the JSON Schema of the workflow format
   help                     Show this help message
 
 Options:
-  -f, --workflow   Path to workflow YAML file
+  -f, --workflow   Path to workflow YAML or JSON file
+  --workflows      Load every workflow under a directory or glob for serve, skipping *_test.yaml (env: MAESTRO_WORKFLOWS)
   -i, --input      Input data as JSON (default: {})
+  --export         Write an execution snapshot to a file after execute
+  --plan           Print which steps ran concurrently, slot wait vs. service time and the critical path
+  --dry-run        Print the steps execute would run, with their resolved inputs, without calling any service
+  --capture        Record unredacted request/response payloads of this execute run to a 0600 file
+  --kv-file        Persist the workflow key-value store to a file
+  --postgres-dsn   Checkpoint executions and keep workflow locks in PostgreSQL (env: MAESTRO_POSTGRES_DSN)
+  --config         Server configuration file, reloaded on SIGHUP or POST /admin/reload (env: MAESTRO_CONFIG)
+  --node-id        ID of this node in --peers (env: MAESTRO_NODE_ID, default: hostname)
+  --peers          Cluster nodes as id=url,... for serve (env: MAESTRO_PEERS)
+  --cluster-secret Secret shared by the --peers nodes to authenticate each other (env: MAESTRO_CLUSTER_SECRET)
+  --version        Execute this loaded version of the workflow instead of the latest (for execute)
+  --env, --profile Environment profile to execute in (env: MAESTRO_ENV or MAESTRO_PROFILE)
+  --service-merge  How workflows declaring the same service share it: reuse (default), error or namespace (env: MAESTRO_SERVICE_MERGE)
+  --tag            Tag the execution with key=value for logs, traces and history filters (repeatable)
+  --otlp-endpoint  Export OpenTelemetry traces to an OTLP gRPC collector (env: MAESTRO_OTLP_ENDPOINT)
+  --event-log      Append lifecycle events as JSON lines to a file, - for stdout (env: MAESTRO_EVENT_LOG)
+  --workers        Maximum concurrently executing steps (default: 10)
+  --compensation-workers
+                   Maximum concurrently running compensations, which never wait for
+                   --workers slots (default: 0, no limit)
+  --execution-retention
+                   How long finished executions stay in memory before only the store has them (default: 1h)
   --port           Port to listen on for serve command (default: 8080)
+  --allow-command-hooks
+                   Allow workflows whose before_each and after_each hooks run commands (env: MAESTRO_ALLOW_COMMAND_HOOKS)
+  --api-key        API key accepted by serve, in addition to api_keys from --config, and sent by export and import (env: MAESTRO_API_KEY)
+  --grpc-port      gRPC port for serve command, 0 disables (default: 0)
+  --allow-exec     Allow workflows with type: exec services, which run local commands (env: MAESTRO_ALLOW_EXEC)
+  --watch          Reload workflow files into serve when they change (env: MAESTRO_WATCH)
+  --standby        Serve as a warm standby of the primary sharing --postgres-dsn (env: MAESTRO_STANDBY)
   --debug          Enable debug logging
   --trace          Enable trace logging
 
 Examples:
   maestro execute user_onboarding.yaml --input '{"email":"user@example.com"}'
-  maestro serve --port 8080
-  maestro validate workflows/order_processing.yaml`)
+  maestro serve --port 8080 workflows/order_processing.yaml
+  maestro --workflows ./workflows/ serve
+  maestro --postgres-dsn $DSN --standby serve workflows/*.yaml
+  maestro dev order_processing.yaml --fixtures fixtures.yaml -i '{"amount":42}'
+  maestro test workflows/
+  maestro validate workflows/order_processing.yaml
+  maestro validate order_processing.yaml --input '{"order_id":"42","amount":10}'
+  maestro execute order_processing.yaml --export snapshot.json
+  maestro execute order_processing.yaml --dry-run -i '{"order_id":"42"}'
+  maestro execute order_processing_v2.yaml order_processing_v1.yaml --version 1.3
+  maestro export 3f9c2a1e-8b7d-4c2e-9f1a-5d6e7b8c9a0b --out snapshot.json
+  maestro import snapshot.json --server http://staging:8080
+  maestro logs 3f9c2a1e-8b7d-4c2e-9f1a-5d6e7b8c9a0b --follow
+  maestro --postgres-dsn $DSN replay order_processing_v2.yaml --sample 50 --status success
+  maestro --config maestro.yaml preflight workflows/*.yaml --report -
+  maestro explain order_processing.yaml --step charge_payment -i '{"amount":42}'
+  maestro scaffold service --lang python --name inventory
+  maestro verify-service --endpoint localhost:50051 --method Reserve --compensate-method Release`)
 }
 
-func executeWorkflow(workflowFile, inputJSON string) {
+func executeWorkflow(
+	workflowFile string,
+	subWorkflowFiles []string,
+	inputJSON, exportFile, captureFile, environment, version string,
+	tags map[string]string,
+	showPlan bool,
+	orchOpts []application.Option,
+) {
 	logger := log.With().Str("command", "execute").Logger()
 	logger.Info().Str("workflow", workflowFile).Msg("Executing workflow")
 
@@ -120,17 +628,19 @@ func executeWorkflow(workflowFile, inputJSON string) {
 		logger.Fatal().Err(err).Msg("Failed to parse input JSON")
 	}
 
-	orch := application.New(logger)
+	orch := application.New(logger, orchOpts...)
 
-	if err := orch.LoadWorkflow(workflowFile); err != nil {
-		logger.Fatal().Err(err).Msg("Failed to load workflow")
+	for _, file := range subWorkflowFiles {
+		if _, err := orch.LoadWorkflow(file); err != nil {
+			logger.Fatal().Err(err).Str("workflow", file).Msg("Failed to load sub-workflow")
+		}
 	}
 
-	workflows := orch.ListWorkflows()
-	if len(workflows) == 0 {
-		logger.Fatal().Msg("No workflows loaded")
+	wf, err := orch.LoadWorkflow(workflowFile)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to load workflow")
 	}
-	workflowName := workflows[0]
+	workflowName := wf.Name
 
 	logger.Info().
 		Str("workflow", workflowName).
@@ -147,11 +657,90 @@ func executeWorkflow(workflowFile, inputJSON string) {
 		cancel()
 	}()
 
+	if environment != "" {
+		ctx = application.WithEnvironment(ctx, environment)
+	}
+	if version != "" {
+		ctx = application.WithVersion(ctx, version)
+	}
+	if len(tags) > 0 {
+		ctx = application.WithTags(ctx, tags)
+	}
+
+	var capture *workflow.Capture
+	if captureFile != "" {
+		logger.Warn().Str("file", captureFile).Msg("Capturing unredacted request and response payloads")
+		capture = &workflow.Capture{WorkflowName: workflowName}
+		ctx = application.WithCapture(ctx, capture)
+	}
+
 	result, err := orch.ExecuteWorkflow(ctx, workflowName, input)
+	orch.FlushAlerts()
+	orch.FlushCancellations()
+	flushWebhooks(orch)
+	shutdownTracing()
+	if exportFile != "" && result != nil {
+		exportSnapshot(logger, orch, result.WorkflowID, exportFile)
+	}
+	if capture != nil {
+		if result != nil {
+			capture.WorkflowID = result.WorkflowID
+		}
+		writeCapture(logger, capture, captureFile)
+	}
+	if showPlan && result != nil {
+		execution, ok, err := orch.GetExecution(result.WorkflowID)
+		if err != nil {
+			logger.Warn().Err(err).Msg("Failed to load execution plan")
+		} else if ok {
+			printPlan(workflow.NewExecutionPlan(execution.Context.CopyTimings()))
+		}
+	}
 	if err != nil {
 		logger.Error().
 			Err(err).
 			Msg("Workflow execution failed")
+		var preflight *workflow.PreflightError
+		if errors.As(err, &preflight) {
+			fmt.Println("\n❌ Preflight failed, no step was run:")
+			for _, problem := range preflight.Problems {
+				fmt.Printf("  %s (%s): %s\n", problem.Service, strings.Join(problem.Steps, ", "), problem.Problem)
+			}
+		}
+		var invalid *workflow.InputError
+		if errors.As(err, &invalid) {
+			fmt.Println("\n❌ Input does not match input_schema, no step was run:")
+			for _, problem := range invalid.Problems {
+				fmt.Printf("  %s\n", problem)
+			}
+		}
+		var contract *workflow.ContractError
+		if errors.As(err, &contract) {
+			fmt.Printf("\n❌ Output of step %s does not match its output_schema:\n", contract.StepID)
+			for _, problem := range contract.Problems {
+				fmt.Printf("  %s\n", problem)
+			}
+		}
+		var tmplErr *workflow.TemplateError
+		if errors.As(err, &tmplErr) {
+			fmt.Printf("\n❌ Template of step %s failed to resolve:\n", tmplErr.StepID)
+			if tmplErr.Key != "" {
+				fmt.Printf("  key:        %s\n", tmplErr.Key)
+			}
+			fmt.Printf("  expression: %s\n", tmplErr.Expression)
+			fmt.Printf("  cause:      %s\n", tmplErr.Cause)
+			fmt.Printf("  available:  %s\n", strings.Join(tmplErr.Available, ", "))
+		}
+		if result != nil && len(result.UnfinishedCompensations) > 0 {
+			fmt.Println("\n❌ Unfinished compensations:")
+			for _, unfinished := range result.UnfinishedCompensations {
+				if unfinished.Error == "" {
+					fmt.Printf("  %s: not attempted\n", unfinished.StepID)
+					continue
+				}
+				fmt.Printf("  %s: %d attempts, last error: %s\n", unfinished.StepID, unfinished.Attempts, unfinished.Error)
+			}
+		}
 		os.Exit(1)
 	}
 
@@ -159,7 +748,7 @@ func executeWorkflow(workflowFile, inputJSON string) {
 		Str("workflow_id", result.WorkflowID).
 		Str("status", result.Status.String()).
 		Dur("duration", result.CompletedAt.Sub(result.StartedAt)).
-		Interface("output", result.Output).
+		Interface("output", orch.RedactOutputs(workflowName, result.Output)).
 		Msg("Workflow completed")
 
 	if outputJSON, err := json.MarshalIndent(result.Output, "", "  "); err == nil {
@@ -168,32 +757,830 @@ func executeWorkflow(workflowFile, inputJSON string) {
 	}
 }
 
-func serveOrchestrator(port int) {
+func dryRunWorkflow(
+	workflowFile string,
+	subWorkflowFiles []string,
+	inputJSON, environment, version string,
+	tags map[string]string,
+	orchOpts []application.Option,
+) {
+	logger := log.With().Str("command", "execute").Logger()
+
+	var input map[string]interface{}
+	if err := json.Unmarshal([]byte(inputJSON), &input); err != nil {
+		logger.Fatal().Err(err).Msg("Failed to parse input JSON")
+	}
+
+	orch := application.New(logger, orchOpts...)
+	for _, file := range subWorkflowFiles {
+		if _, err := orch.LoadWorkflow(file); err != nil {
+			logger.Fatal().Err(err).Str("workflow", file).Msg("Failed to load sub-workflow")
+		}
+	}
+	wf, err := orch.LoadWorkflow(workflowFile)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to load workflow")
+	}
+
+	ctx := context.Background()
+	if environment != "" {
+		ctx = application.WithEnvironment(ctx, environment)
+	}
+	if version != "" {
+		ctx = application.WithVersion(ctx, version)
+	}
+	if len(tags) > 0 {
+		ctx = application.WithTags(ctx, tags)
+	}
+
+	plan := &workflow.DryRun{}
+	_, err = orch.ExecuteWorkflow(application.WithDryRun(ctx, plan), wf.Name, input)
+	var preflight *workflow.PreflightError
+	if errors.As(err, &preflight) {
+		fmt.Println("\n❌ Preflight failed, the workflow would not start:")
+		for _, problem := range preflight.Problems {
+			fmt.Printf("  %s (%s): %s\n", problem.Service, strings.Join(problem.Steps, ", "), problem.Problem)
+		}
+		os.Exit(1)
+	}
+	if err != nil && len(plan.Steps()) == 0 {
+		logger.Fatal().Err(err).Msg("Dry run failed")
+	}
+
+	fmt.Printf("\nDry run of %s %s, no service was called:\n", wf.Name, wf.Version)
+	for i, step := range plan.Steps() {
+		printPlannedStep(i+1, step)
+	}
+	fmt.Println("\nOutputs of earlier steps are not known in a dry run; fields read from them resolve to <no value>.")
+
+	if plan.Failed() {
+		os.Exit(1)
+	}
+}
+
+func printPlannedStep(n int, step workflow.PlannedStep) {
+	mark := "  "
+	if step.Error != "" {
+		mark = "❌"
+	}
+
+	switch step.Kind {
+	case "":
+		fmt.Printf("%s %2d. %s: skipped, when %s is false\n", mark, n, step.StepID, step.Condition)
+		return
+	case workflow.PlannedCall:
+		fmt.Printf("%s %2d. %s: %s.%s", mark, n, step.StepID, step.Service, step.Method)
+		if step.Endpoint != "" {
+			fmt.Printf(" (%s %s)", step.Type, step.Endpoint)
+		}
+		fmt.Println()
+	case workflow.PlannedWorkflow:
+		fmt.Printf("%s %2d. %s: sub-workflow %s\n", mark, n, step.StepID, step.Service)
+	default:
+		fmt.Printf("%s %2d. %s: %s step, not run\n", mark, n, step.StepID, step.Kind)
+	}
+
+	if len(step.Headers) > 0 {
+		fmt.Printf("        headers: %s\n", plainJSON(step.Headers))
+	}
+	if step.Input != nil {
+		fmt.Printf("        input:   %s\n", plainJSON(step.Input))
+	}
+	if step.Error != "" {
+		fmt.Printf("        error:   %s\n", step.Error)
+	}
+}
+
+func plainJSON(value any) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(value); err != nil {
+		return fmt.Sprint(value)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func runDev(
+	workflowFiles []string,
+	fixturesFile, inputJSON, environment string,
+	tags map[string]string,
+	showPlan bool,
+	overrides map[string]workflow.ServiceOverride,
+	orchOpts []application.Option,
+) {
+	logger := log.With().Str("command", "dev").Logger()
+
+	file, err := fixtures.Load(fixturesFile)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to load fixtures")
+	}
+
+	parser := application.NewParser()
+	services := make(map[string]workflow.Service)
+	for _, workflowFile := range workflowFiles {
+		wf, err := parser.ParseFile(workflowFile)
+		if err != nil {
+			logger.Fatal().Err(err).Str("workflow", workflowFile).Msg("Failed to load workflow")
+		}
+		maps.Copy(services, wf.Services)
+	}
+
+	servers, err := fixtures.Start(file, services, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to start fake services")
+	}
+	defer servers.Close()
+
+	fmt.Println("\nFake services:")
+	for _, fake := range servers.Fakes() {
+		fmt.Printf("  ✅ %s (%s) at %s\n", fake.Service, fake.Type, fake.Endpoint)
+	}
+	for _, name := range servers.Skipped() {
+		fmt.Printf("  ❌ %s has no fixtures, calling %s\n", name, services[name].Endpoint)
+	}
+	fmt.Println()
+
+	orchOpts = append(orchOpts, application.WithServiceOverrides(servers.Overrides(overrides)))
+	executeWorkflow(workflowFiles[0], workflowFiles[1:], inputJSON, "", "", environment, "", tags, showPlan, orchOpts)
+}
+
+func serveOrchestrator(
+	port, grpcPort int,
+	workflowFiles []string,
+	workflowDir string,
+	watchFiles bool,
+	nodeID, peers, peerSecret string,
+	standby bool,
+	settings runtimeSettings,
+	orchOpts []application.Option,
+) {
 	logger := log.With().Str("command", "serve").Logger()
-	logger.Info().Int("port", port).Msg("Starting orchestrator server")
+	logger.Info().Int("port", port).Int("grpc_port", grpcPort).Msg("Starting orchestrator server")
+
+	if nodeID != "" {
+		orchOpts = append(orchOpts, application.WithNodeID(nodeID))
+	}
+	orch := application.New(logger, orchOpts...)
+	for _, file := range workflowFiles {
+		if _, err := orch.LoadWorkflow(file); err != nil {
+			logger.Fatal().Err(err).Str("workflow", file).Msg("Failed to load workflow")
+		}
+	}
+	if workflowDir != "" {
+		_, err := orch.LoadWorkflowDir(workflowDir)
+		var dirErr *workflow.WorkflowDirError
+		switch {
+		case errors.As(err, &dirErr):
+			for _, failure := range dirErr.Failures {
+				logger.Error().Err(failure.Err).Str("workflow", failure.File).Msg("Failed to load workflow")
+			}
+		case err != nil:
+			logger.Fatal().Err(err).Str("workflows", workflowDir).Msg("Failed to load workflows")
+		}
+	}
+
+	if watchFiles {
+		watchCtx, stopWatching := context.WithCancel(context.Background())
+		defer stopWatching()
+		watcher, err := watchWorkflows(watchCtx, orch, workflowDir, logger)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to watch workflow files")
+		}
+		defer watcher.Close()
+	}
+
+	apiKeys := settings.apiKeys()
+
+	server := api.NewServer(orch, port, logger)
+	server.SetAPIKeys(apiKeys)
+
+	clusterCtx, stopCluster := context.WithCancel(context.Background())
+	defer stopCluster()
+	activate := func(ctx context.Context) {
+		go orch.RunRetention(ctx)
+		go orch.RunSagaRecovery(ctx)
+		go orch.RunHandoffPickup(ctx)
+		go orch.RunReruns(ctx)
+		go orch.RunSchedules(ctx)
+		go orch.RunTriggers(ctx)
+	}
+	standbyDone := make(chan struct{})
+	if standby {
+		go func() {
+			defer close(standbyDone)
+			orch.RunStandby(clusterCtx, activate)
+		}()
+	} else {
+		close(standbyDone)
+		activate(clusterCtx)
+	}
+	if peers != "" {
+		c, err := joinCluster(nodeID, peers, peerSecret, logger)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to join cluster")
+		}
+		server.SetCluster(c)
+		go c.Run(clusterCtx)
+	}
+
+	errChan := make(chan error, 2)
+	go func() {
+		errChan <- server.Start()
+	}()
+
+	var grpcServer *api.GRPCServer
+	if grpcPort > 0 {
+		grpcServer = api.NewGRPCServer(orch, grpcPort, logger)
+		grpcServer.SetAPIKeys(apiKeys)
+		go func() {
+			errChan <- grpcServer.Start()
+		}()
+	}
+
+	if settings.configFile != "" {
+		keyHolders := []apiKeyHolder{server}
+		if grpcServer != nil {
+			keyHolders = append(keyHolders, grpcServer)
+		}
+		reload := settings.reloader(orch, keyHolders...)
+		if err := reload(context.Background()); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to apply configuration")
+		}
+		server.SetReloader(func(ctx context.Context) error {
+			if err := reload(ctx); err != nil {
+				logger.Error().Err(err).Msg("Configuration reload failed")
+				return err
+			}
+			logger.Info().Str("config", settings.configFile).Msg("Configuration reloaded")
+			return nil
+		})
+
+		hupChan := make(chan os.Signal, 1)
+		signal.Notify(hupChan, syscall.SIGHUP)
+		go func() {
+			for range hupChan {
+				if err := reload(context.Background()); err != nil {
+					logger.Error().Err(err).Msg("Configuration reload failed")
+					continue
+				}
+				logger.Info().Str("config", settings.configFile).Msg("Configuration reloaded")
+			}
+		}()
+	}
+	if !server.HasAPIKeys() {
+		logger.Warn().Msg("No api_keys configured, workflow registration and admin routes are disabled")
+	}
 
 	fmt.Printf("\n Maestro Orchestrator Server\n")
 	fmt.Printf("   Listening on port %d\n", port)
+	if grpcServer != nil {
+		fmt.Printf("   gRPC on port %d\n", grpcPort)
+	}
 	fmt.Printf("   Press Ctrl+C to stop\n\n")
 
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-	<-sigChan
+
+	select {
+	case <-sigChan:
+	case err := <-errChan:
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Orchestrator server failed")
+		}
+	}
 
 	logger.Info().Msg("Shutting down orchestrator server")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		logger.Error().Err(err).Msg("Failed to shut down HTTP API")
+	}
+	if grpcServer != nil {
+		if err := grpcServer.Shutdown(ctx); err != nil {
+			logger.Error().Err(err).Msg("Failed to shut down gRPC API")
+		}
+	}
+	stopCluster()
+	<-standbyDone
+	orch.FlushAlerts()
+	flushWebhooks(orch)
+	shutdownTracing()
+}
+
+func flushWebhooks(orch *application.Orchestrator) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	orch.FlushWebhooks(ctx)
+}
+
+func openEventLog(path string) (*events.JSONLSink, func(), error) {
+	if path == "-" {
+		return events.NewJSONLSink(os.Stdout, log.Logger), func() {}, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, nil, err
+	}
+	return events.NewJSONLSink(file, log.Logger), func() { file.Close() }, nil
+}
+
+func shutdownTracing() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := tracing.Shutdown(ctx); err != nil {
+		log.Warn().Err(err).Msg("Failed to flush traces")
+	}
+}
+
+func joinCluster(nodeID, peers, secret string, logger zerolog.Logger) (*cluster.Cluster, error) {
+	nodes, err := cluster.ParsePeers(peers)
+	if err != nil {
+		return nil, err
+	}
+
+	if nodeID == "" {
+		nodeID, err = os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine node ID: %w", err)
+		}
+	}
+
+	c, err := cluster.New(nodeID, nodes, secret, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info().
+		Str("node_id", nodeID).
+		Int("nodes", len(nodes)).
+		Msg("Cluster mode enabled, executions are routed by workflow ID")
+	return c, nil
 }
 
-func validateWorkflow(workflowFile string) {
+func runTests(paths []string, verbose bool) {
+	logger := zerolog.Nop()
+	if verbose {
+		logger = log.With().Str("command", "test").Logger()
+	}
+
+	files, err := application.FindTestSuites(paths)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to find tests")
+	}
+	if len(files) == 0 {
+		fmt.Printf("No *%s files found\n", application.TestSuiteSuffix)
+		os.Exit(1)
+	}
+
+	passed, failed := 0, 0
+	for _, file := range files {
+		fmt.Println(file)
+
+		suite, err := application.LoadTestSuite(file)
+		if err != nil {
+			fmt.Printf("  ❌ %v\n", err)
+			failed++
+			continue
+		}
+		outcomes, err := application.RunTestSuite(context.Background(), suite, logger)
+		if err != nil {
+			fmt.Printf("  ❌ %v\n", err)
+			failed += len(suite.Tests)
+			continue
+		}
+
+		for _, outcome := range outcomes {
+			if outcome.Passed() {
+				passed++
+				fmt.Printf("  ✅ %s (%s)\n", outcome.Name, outcome.Duration.Round(time.Millisecond))
+				continue
+			}
+			failed++
+			fmt.Printf("  ❌ %s (%s)\n", outcome.Name, outcome.Duration.Round(time.Millisecond))
+			for _, failure := range outcome.Failures {
+				fmt.Printf("     %s\n", failure)
+			}
+		}
+	}
+
+	fmt.Printf("\n%d passed, %d failed\n", passed, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func validateWorkflow(workflowFile, inputJSON string) {
 	logger := log.With().Str("command", "validate").Logger()
 	logger.Info().Str("workflow", workflowFile).Msg("Validating workflow")
 
+	data, err := os.ReadFile(workflowFile)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to read workflow file")
+		os.Exit(1)
+	}
+
+	schemaErrors, err := application.ValidateSchema(data, grpc.BuiltinProtocols())
+	if err != nil {
+		logger.Error().Err(err).Msg("Workflow validation failed")
+		os.Exit(1)
+	}
+	if len(schemaErrors) > 0 {
+		for _, schemaErr := range schemaErrors {
+			fmt.Printf("%s:%s\n", workflowFile, schemaErr.Error())
+		}
+		logger.Error().Int("errors", len(schemaErrors)).Msg("Workflow does not match schema")
+		os.Exit(1)
+	}
+
 	orch := application.New(logger)
 
-	if err := orch.LoadWorkflow(workflowFile); err != nil {
+	wf, err := orch.LoadWorkflow(workflowFile)
+	if err != nil {
 		logger.Error().Err(err).Msg("Workflow validation failed")
 		os.Exit(1)
 	}
 
+	if inputJSON != "" {
+		var input map[string]interface{}
+		if err := json.Unmarshal([]byte(inputJSON), &input); err != nil {
+			logger.Error().Err(err).Msg("Failed to parse input JSON")
+			os.Exit(1)
+		}
+		if err := application.ValidateInput(wf, input); err != nil {
+			var invalid *workflow.InputError
+			if errors.As(err, &invalid) {
+				fmt.Println("❌ Input does not match input_schema:")
+				for _, problem := range invalid.Problems {
+					fmt.Printf("  %s\n", problem)
+				}
+			}
+			logger.Error().Err(err).Msg("Input validation failed")
+			os.Exit(1)
+		}
+	}
+
 	logger.Info().Msg("✅ Workflow is valid")
 	fmt.Println("✅ Workflow validation successful")
 }
+
+func exportSnapshot(logger zerolog.Logger, orch *application.Orchestrator, workflowID, exportFile string) {
+	snapshot, err := orch.ExportExecution(workflowID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to export execution")
+		return
+	}
+
+	if err := application.WriteSnapshot(exportFile, snapshot); err != nil {
+		logger.Error().Err(err).Msg("Failed to write snapshot")
+		return
+	}
+
+	logger.Info().
+		Str("workflow_id", workflowID).
+		Str("file", exportFile).
+		Msg("Execution snapshot exported")
+}
+
+func printPlan(plan *workflow.ExecutionPlan) {
+	if plan == nil {
+		return
+	}
+
+	fmt.Println("\nExecution plan:")
+	for i, group := range plan.Concurrent {
+		fmt.Printf("  %d. %s\n", i+1, strings.Join(group, ", "))
+	}
+
+	fmt.Printf("\n  %-24s %-10s %10s %10s %10s\n", "STEP", "STATUS", "TOTAL", "SLOT WAIT", "SERVICE")
+	for _, step := range plan.Steps {
+		fmt.Printf("  %-24s %-10s %10s %10s %10s\n",
+			step.StepID,
+			step.Status,
+			step.Duration.Round(time.Millisecond),
+			step.SlotWait.Round(time.Millisecond),
+			step.ServiceTime.Round(time.Millisecond))
+	}
+
+	fmt.Printf("\n  Critical path (%s): %s\n",
+		plan.CriticalPathDuration.Round(time.Millisecond),
+		strings.Join(plan.CriticalPath, " -> "))
+	fmt.Printf("  Max concurrency %d, slot wait %s, service time %s\n",
+		plan.MaxConcurrency,
+		plan.SlotWait.Round(time.Millisecond),
+		plan.ServiceTime.Round(time.Millisecond))
+}
+
+func writeCapture(logger zerolog.Logger, capture *workflow.Capture, captureFile string) {
+	if err := application.WriteCapture(captureFile, capture); err != nil {
+		logger.Error().Err(err).Msg("Failed to write capture")
+		return
+	}
+
+	logger.Info().
+		Str("workflow_id", capture.WorkflowID).
+		Str("file", captureFile).
+		Int("exchanges", len(capture.CopyExchanges())).
+		Msg("Execution capture written")
+}
+
+func replayExecutions(
+	workflowFile string,
+	snapshotFiles []string,
+	executionStore *store.PostgresStore,
+	sample int,
+	status, reportFile string,
+) {
+	logger := log.With().Str("command", "replay").Logger()
+	ctx := context.Background()
+
+	orch := application.New(logger)
+	wf, err := orch.LoadWorkflow(workflowFile)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to load workflow")
+	}
+
+	type recordedExecution struct {
+		execution *workflow.Execution
+		steps     []*workflow.StepResult
+	}
+	var history []recordedExecution
+
+	for _, file := range snapshotFiles {
+		snapshot, err := application.ReadSnapshot(file)
+		if err != nil {
+			logger.Fatal().Err(err).Str("snapshot", file).Msg("Failed to read snapshot")
+		}
+		execution, err := snapshot.Execution()
+		if err != nil {
+			logger.Fatal().Err(err).Str("snapshot", file).Msg("Failed to read snapshot")
+		}
+		history = append(history, recordedExecution{execution: execution})
+	}
+
+	if executionStore != nil && len(snapshotFiles) == 0 {
+		executions, err := executionStore.ListExecutions(ctx, workflow.ExecutionFilter{
+			WorkflowName: wf.Name,
+			Status:       status,
+			Limit:        sample,
+		})
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to list executions")
+		}
+		for _, execution := range executions {
+			steps, err := executionStore.LoadStepResults(ctx, execution.Context.WorkflowID)
+			if err != nil {
+				logger.Fatal().Err(err).Str("workflow_id", execution.Context.WorkflowID).Msg("Failed to load step journal")
+			}
+			history = append(history, recordedExecution{execution: execution, steps: steps})
+		}
+	}
+
+	var diffs []*application.ReplayDiff
+	diverged := 0
+	for _, recorded := range history {
+		execution := recorded.execution
+		if execution.WorkflowName != wf.Name {
+			logger.Warn().
+				Str("workflow_id", execution.Context.WorkflowID).
+				Str("workflow", execution.WorkflowName).
+				Msg("Skipping execution of another workflow")
+			continue
+		}
+		if status != "" && execution.Result.Status.String() != status {
+			continue
+		}
+
+		diff, err := orch.ReplayExecution(ctx, wf.Name, execution, recorded.steps)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to replay execution")
+		}
+		diffs = append(diffs, diff)
+		if diff.Diverged() {
+			diverged++
+		}
+	}
+
+	fmt.Printf("Replayed %d executions against %s %s\n", len(diffs), wf.Name, wf.Version)
+	for _, diff := range diffs {
+		if !diff.Diverged() {
+			fmt.Printf("\n✅ %s (%s) matches\n", diff.WorkflowID, diff.OriginalVersion)
+		} else {
+			fmt.Printf("\n❌ %s (%s) diverges\n", diff.WorkflowID, diff.OriginalVersion)
+		}
+		if diff.OriginalStatus != diff.ReplayStatus {
+			fmt.Printf("  status: %s -> %s\n", diff.OriginalStatus, diff.ReplayStatus)
+		}
+		if diff.ReplayError != "" {
+			fmt.Printf("  error: %s\n", diff.ReplayError)
+		}
+		if len(diff.MissingSteps) > 0 {
+			fmt.Printf("  steps no longer run: %s\n", strings.Join(diff.MissingSteps, ", "))
+		}
+		if len(diff.AddedSteps) > 0 {
+			fmt.Printf("  steps newly run: %s\n", strings.Join(diff.AddedSteps, ", "))
+		}
+		for _, change := range diff.OutputChanges {
+			before, _ := json.Marshal(change.Original)
+			after, _ := json.Marshal(change.Replayed)
+			fmt.Printf("  output %s: %s -> %s\n", change.Key, before, after)
+		}
+		if len(diff.Unrecorded) > 0 {
+			fmt.Printf("  no recording, replayed with empty output: %s\n", strings.Join(diff.Unrecorded, ", "))
+		}
+	}
+
+	if reportFile != "" {
+		data, err := json.MarshalIndent(diffs, "", "  ")
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to encode replay report")
+		}
+		if err := os.WriteFile(reportFile, data, 0o644); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to write replay report")
+		}
+	}
+
+	if diverged > 0 {
+		fmt.Printf("\n%d of %d executions diverge\n", diverged, len(diffs))
+		os.Exit(1)
+	}
+}
+
+func runPreflight(workflowFiles []string, cfg *config.Config, timeout time.Duration, reportFile string, orchOpts []application.Option) {
+	logger := log.With().Str("command", "preflight").Logger()
+	orch := application.New(logger, orchOpts...)
+
+	report := &workflow.PreflightReport{}
+	for _, file := range workflowFiles {
+		start := time.Now()
+		check := workflow.PreflightCheck{Name: "load", Target: file, Status: workflow.PreflightPass}
+		wf, err := orch.LoadWorkflow(file)
+		if err != nil {
+			check.Status = workflow.PreflightFail
+			check.Detail = err.Error()
+		} else {
+			check.Workflows = []string{wf.Name}
+			report.Workflows = append(report.Workflows, wf.Name)
+		}
+		check.Duration = workflow.Duration{Duration: time.Since(start)}
+		report.Checks = append(report.Checks, check)
+	}
+
+	report.Checks = append(report.Checks, orch.Preflight(context.Background(), timeout)...)
+
+	if cfg != nil {
+		secrets := cfg.Secrets()
+		for _, name := range slices.Sorted(maps.Keys(secrets)) {
+			check := workflow.PreflightCheck{Name: "secret", Target: name, Status: workflow.PreflightPass}
+			if secrets[name] == "" {
+				check.Status = workflow.PreflightFail
+				check.Detail = "resolves to an empty value"
+			}
+			report.Checks = append(report.Checks, check)
+		}
+	}
+
+	if reportFile != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to encode preflight report")
+		}
+		if reportFile == "-" {
+			fmt.Println(string(data))
+		} else if err := os.WriteFile(reportFile, data, 0o644); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to write preflight report")
+		}
+	}
+
+	if reportFile != "-" {
+		fmt.Printf("Preflight of %d workflow(s)\n", len(report.Workflows))
+		for _, check := range report.Checks {
+			fmt.Printf("  %-4s  %-12s %-28s %s\n", strings.ToUpper(string(check.Status)), check.Name, check.Target, check.Detail)
+		}
+	}
+
+	if !report.Passed() {
+		if reportFile != "-" {
+			fmt.Println("\n❌ Preflight failed")
+		}
+		os.Exit(1)
+	}
+	if reportFile != "-" {
+		fmt.Println("\n✅ Preflight passed")
+	}
+}
+
+func explainStep(workflowFile, stepID, inputJSON string) {
+	var input map[string]interface{}
+	if err := json.Unmarshal([]byte(inputJSON), &input); err != nil {
+		log.Fatal().Err(err).Msg("Failed to parse input JSON")
+	}
+
+	wf, err := application.NewParser().ParseFile(workflowFile)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load workflow")
+	}
+
+	explanation, err := application.ExplainStep(wf, stepID, input)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to explain step")
+	}
+
+	fmt.Printf("Step %s", explanation.StepID)
+	if explanation.Service != "" {
+		fmt.Printf(" (%s.%s)", explanation.Service, explanation.Method)
+	}
+	fmt.Println()
+
+	fmt.Println("\nTemplate data:")
+	fmt.Println("  .input                sample input")
+	inputKeys := slices.Sorted(maps.Keys(input))
+	for _, key := range inputKeys {
+		value, _ := json.Marshal(input[key])
+		fmt.Printf("      .input.%s = %s\n", key, value)
+	}
+	for _, key := range explanation.Available {
+		source := fmt.Sprintf("output of step %s", key.StepID)
+		if key.Service != "" {
+			source += fmt.Sprintf(" (%s.%s)", key.Service, key.Method)
+		}
+		if key.Conditional {
+			source += ", only if its when condition held"
+		}
+		fmt.Printf("  .%-20s %s\n", key.Name, source)
+	}
+
+	if len(explanation.Inputs) == 0 {
+		return
+	}
+
+	fmt.Println("\nInput:")
+	problems := 0
+	for _, item := range explanation.Inputs {
+		if item.Template == "" {
+			value, _ := json.Marshal(item.Value)
+			fmt.Printf("  %s: %s\n", item.Key, value)
+			continue
+		}
+
+		fmt.Printf("  %s: %s\n", item.Key, item.Template)
+		switch {
+		case item.Resolved != "":
+			fmt.Printf("      -> %q\n", item.Resolved)
+		case len(item.Problems) == 0:
+			fmt.Println("      -> resolved at runtime from prior step outputs")
+		}
+		for _, problem := range item.Problems {
+			fmt.Printf("      ! %s\n", problem)
+			problems++
+		}
+	}
+
+	if problems > 0 {
+		os.Exit(1)
+	}
+}
+
+func scaffoldService(opts scaffold.Options) {
+	files, err := scaffold.Service(opts)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to scaffold service")
+	}
+
+	fmt.Printf("Generated %s service %s:\n", opts.Lang, opts.Name)
+	for _, file := range files {
+		fmt.Printf("  %s\n", file)
+	}
+}
+
+func verifyService(opts conformance.Options, inputJSON string) {
+	if err := json.Unmarshal([]byte(inputJSON), &opts.Input); err != nil {
+		log.Fatal().Err(err).Msg("Failed to parse input JSON")
+	}
+
+	report, err := conformance.Verify(context.Background(), opts)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to verify service")
+	}
+
+	fmt.Printf("Service %s\n", report.Endpoint)
+	for _, check := range report.Checks {
+		fmt.Printf("  %-4s  %-24s %s\n", strings.ToUpper(string(check.Status)), check.Name, check.Detail)
+	}
+
+	if !report.Passed() {
+		fmt.Println("\n❌ Service is not compliant")
+		os.Exit(1)
+	}
+	fmt.Println("\n✅ Service is compliant")
+}
+
+func printSchema() {
+	schema, err := application.WorkflowSchemaJSON(grpc.BuiltinProtocols())
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to generate workflow schema")
+	}
+	fmt.Println(string(schema))
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/maestro/maestro.go/internal/application"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func defaultServerURL() string {
+	return cmp.Or(os.Getenv("MAESTRO_SERVER"), "http://localhost:8080")
+}
+
+func callServer(logger zerolog.Logger, req *http.Request, apiKey string, expected int) *http.Response {
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to reach server")
+	}
+
+	if resp.StatusCode != expected {
+		var body struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body)
+		resp.Body.Close()
+		fmt.Printf("❌ %s: %s\n", resp.Status, body.Error)
+		os.Exit(1)
+	}
+	return resp
+}
+
+func exportFromServer(server, apiKey, executionID, snapshotFile string) {
+	logger := log.With().Str("command", "export").Str("workflow_id", executionID).Logger()
+
+	path := "/executions/" + url.PathEscape(executionID) + "/snapshot"
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(server, "/")+path, nil)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid server URL")
+	}
+
+	resp := callServer(logger, req, apiKey, http.StatusOK)
+	defer resp.Body.Close()
+
+	var snapshot workflow.ExecutionSnapshot
+	if err := json.NewDecoder(resp.Body).Decode(&snapshot); err != nil {
+		logger.Fatal().Err(err).Msg("Failed to decode snapshot")
+	}
+	if err := application.WriteSnapshot(snapshotFile, &snapshot); err != nil {
+		logger.Fatal().Err(err).Msg("Failed to write snapshot")
+	}
+
+	fmt.Printf("%s %s: %s, saved to %s\n", snapshot.WorkflowName, executionID, snapshot.Status, snapshotFile)
+}
+
+func importToServer(server, apiKey, snapshotFile string) {
+	logger := log.With().Str("command", "import").Str("snapshot", snapshotFile).Logger()
+
+	snapshot, err := application.ReadSnapshot(snapshotFile)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to read snapshot")
+	}
+	body, err := json.Marshal(snapshot)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to encode snapshot")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(server, "/")+"/executions/import", bytes.NewReader(body))
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid server URL")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := callServer(logger, req, apiKey, http.StatusCreated)
+	resp.Body.Close()
+
+	fmt.Printf("%s %s imported as %s, %d steps already completed\n",
+		snapshot.WorkflowName, snapshot.WorkflowID, snapshot.Status, len(snapshot.CompletedSteps))
+	printPlan(workflow.NewExecutionPlan(snapshot.Timings))
+}
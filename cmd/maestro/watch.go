@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/maestro/maestro.go/internal/application"
+	"github.com/maestro/maestro.go/internal/infrastructure/watch"
+	"github.com/rs/zerolog"
+)
+
+func watchWorkflows(ctx context.Context, orch *application.Orchestrator, workflowDir string, logger zerolog.Logger) (*watch.Watcher, error) {
+	watcher, err := watch.New(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if workflowDir != "" && !strings.ContainsAny(workflowDir, "*?[") {
+		if err := watcher.AddDir(workflowDir, application.IsWorkflowFile); err != nil {
+			_ = watcher.Close()
+			return nil, err
+		}
+	}
+	for _, file := range orch.WorkflowFiles() {
+		if err := watcher.AddFile(file); err != nil {
+			_ = watcher.Close()
+			return nil, err
+		}
+	}
+
+	go watcher.Run(ctx, func(file string) {
+		reloaded, err := orch.ReloadWorkflowFile(file)
+		for _, wf := range reloaded {
+			logger.Info().
+				Str("workflow", wf.Name).
+				Str("version", wf.Version).
+				Str("file", file).
+				Msg("Workflow reloaded")
+		}
+		if err != nil {
+			logger.Error().Err(err).Str("file", file).Msg("Failed to reload workflow, keeping the previous definition")
+		}
+		for _, file := range orch.WorkflowFiles() {
+			if err := watcher.AddFile(file); err != nil {
+				logger.Warn().Err(err).Str("file", file).Msg("Failed to watch workflow file")
+			}
+		}
+	})
+
+	logger.Info().Strs("files", orch.WorkflowFiles()).Msg("Watching workflow files for changes")
+	return watcher, nil
+}
@@ -0,0 +1,52 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
+)
+
+const alertTimeout = 10 * time.Second
+
+func (o *Orchestrator) raiseAlerts(r *run) {
+	if o.notifier == nil || r.handedOff() || r.ctx.Value(ctxkeys.Shadow) != nil {
+		return
+	}
+
+	alerts := r.wf.Alerts(r.execCtx.WorkflowID, r.execCtx.Namespace, r.result)
+	if len(alerts) == 0 {
+		return
+	}
+
+	o.pendingAlerts.Add(1)
+	go func() {
+		defer o.pendingAlerts.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), alertTimeout)
+		defer cancel()
+
+		for _, alert := range alerts {
+			if err := o.notifier.Notify(ctx, alert); err != nil {
+				r.logger.Error().
+					Err(err).
+					Str("event", alert.Event).
+					Str("pagerduty_service", alert.PagerDutyService).
+					Msg("Failed to send alert")
+				continue
+			}
+			r.logger.Info().
+				Str("event", alert.Event).
+				Str("severity", alert.Severity).
+				Msg("Alert sent")
+		}
+	}()
+}
+
+func (o *Orchestrator) FlushAlerts() {
+	o.pendingAlerts.Wait()
+}
+
+func (o *Orchestrator) FlushCancellations() {
+	o.executor.FlushCancellations()
+}
@@ -0,0 +1,180 @@
+package application
+
+import (
+	"sync"
+	"time"
+
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/metrics"
+)
+
+var (
+	breakerOpenMetric = &workflow.MetricConfig{
+		Name: "maestro_workflow_breaker_open",
+		Type: metrics.MetricTypeGauge,
+		Help: "1 while a workflow refuses new executions because one of its steps keeps failing",
+	}
+	breakerTripsMetric = &workflow.MetricConfig{
+		Name: "maestro_workflow_breaker_trips_total",
+		Type: metrics.MetricTypeCounter,
+		Help: "Number of times a workflow breaker opened",
+	}
+)
+
+type breakerState struct {
+	step   string
+	streak int
+	open   *workflow.BreakerOpenError
+}
+
+type breakers struct {
+	mu     sync.Mutex
+	states map[string]*breakerState
+}
+
+func newBreakers() *breakers {
+	return &breakers{states: make(map[string]*breakerState)}
+}
+
+func (o *Orchestrator) checkBreaker(wf *workflow.Workflow) error {
+	if wf.Breaker == nil {
+		return nil
+	}
+
+	b := o.breakers
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.states[wf.Name]
+	if !ok || state.open == nil {
+		return nil
+	}
+
+	now := time.Now()
+	if now.Before(state.open.Until) {
+		open := *state.open
+		return &open
+	}
+
+	state.open.Until = now.Add(wf.Breaker.CooldownPeriod())
+	o.logger.Info().
+		Str("workflow", wf.Name).
+		Str("step_id", state.step).
+		Msg("Workflow breaker cooldown over, letting a trial execution through")
+	return nil
+}
+
+func (o *Orchestrator) recordBreakerOutcome(r *run) {
+	policy := r.wf.Breaker
+	if policy == nil || r.ctx.Value(ctxkeys.Shadow) != nil {
+		return
+	}
+
+	var failed bool
+	switch r.result.Status {
+	case workflow.WorkflowStatusSuccess:
+	case workflow.WorkflowStatusFailed, workflow.WorkflowStatusCompensated:
+		if r.failedStep == "" {
+			return
+		}
+		failed = true
+	default:
+		return
+	}
+
+	b := o.breakers
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	name := r.wf.Name
+	state, ok := b.states[name]
+	if !ok || !failed || state.step != r.failedStep {
+		if ok && state.open != nil {
+			o.closeBreaker(name, state)
+		}
+		delete(b.states, name)
+		if !failed {
+			return
+		}
+		state = &breakerState{step: r.failedStep}
+		b.states[name] = state
+	}
+	state.streak++
+
+	var lastError string
+	if r.result.Error != nil {
+		lastError = r.result.Error.Error()
+	}
+	now := time.Now()
+
+	if state.open != nil {
+		state.open.Failures = state.streak
+		state.open.LastError = lastError
+		state.open.Until = now.Add(policy.CooldownPeriod())
+		return
+	}
+	if state.streak < policy.Failures {
+		return
+	}
+
+	state.open = &workflow.BreakerOpenError{
+		Workflow:  name,
+		Step:      state.step,
+		Failures:  state.streak,
+		LastError: lastError,
+		Since:     now,
+		Until:     now.Add(policy.CooldownPeriod()),
+	}
+
+	labels := map[string]string{"workflow": name, "step": state.step}
+	_ = o.metrics.Record(breakerOpenMetric, 1, labels)
+	_ = o.metrics.Record(breakerTripsMetric, 1, labels)
+
+	o.logger.Error().
+		Str("workflow", name).
+		Str("version", r.wf.Version).
+		Str("step_id", state.step).
+		Int("failures", state.streak).
+		Dur("cooldown", policy.CooldownPeriod()).
+		Msg("Workflow breaker opened, refusing new executions")
+}
+
+func (o *Orchestrator) closeBreaker(name string, state *breakerState) {
+	_ = o.metrics.Record(breakerOpenMetric, 0, map[string]string{"workflow": name, "step": state.step})
+
+	o.logger.Info().
+		Str("workflow", name).
+		Str("step_id", state.step).
+		Msg("Workflow breaker closed")
+}
+
+func (o *Orchestrator) resetBreaker(name string) bool {
+	b := o.breakers
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.states[name]
+	if !ok {
+		return false
+	}
+	delete(b.states, name)
+	if state.open == nil {
+		return false
+	}
+	o.closeBreaker(name, state)
+	return true
+}
+
+func (o *Orchestrator) BreakerOpen(name string) (*workflow.BreakerOpenError, bool) {
+	b := o.breakers
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.states[name]
+	if !ok || state.open == nil {
+		return nil, false
+	}
+	open := *state.open
+	return &open, true
+}
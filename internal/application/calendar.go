@@ -0,0 +1,44 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/metrics"
+)
+
+var calendarDeferralsMetric = &workflow.MetricConfig{
+	Name: "maestro_calendar_deferrals_total",
+	Type: metrics.MetricTypeCounter,
+	Help: "Executions held back by their workflow's calendar, by workflow and reason",
+}
+
+func (o *Orchestrator) checkCalendar(wf *workflow.Workflow) error {
+	calendar := wf.Calendar
+	if calendar == nil {
+		return nil
+	}
+
+	location, err := calendar.Location()
+	if err != nil {
+		return fmt.Errorf("workflow %s: invalid calendar timezone: %w", wf.Name, err)
+	}
+
+	now := time.Now()
+	local := now.In(location)
+	if !calendar.Open(local) {
+		return o.deferExecution(wf.Name, calendar.NextOpen(local), workflow.ErrOutsideWindow, "outside_window")
+	}
+	if !o.calendarLimiter.allow(wf.Name, calendar.Limit(local), now) {
+		return o.deferExecution(wf.Name, now.Add(o.calendarLimiter.wait(wf.Name, now)), workflow.ErrThrottled, "throttled")
+	}
+	return nil
+}
+
+func (o *Orchestrator) deferExecution(name string, retryAt time.Time, err error, reason string) error {
+	if err := o.metrics.Record(calendarDeferralsMetric, 1, map[string]string{"workflow": name, "reason": reason}); err != nil {
+		o.logger.Warn().Err(err).Msg("Failed to record calendar deferral")
+	}
+	return workflow.NewCalendarError(name, retryAt, err)
+}
@@ -0,0 +1,38 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+)
+
+func WithCapture(ctx context.Context, capture *workflow.Capture) context.Context {
+	return context.WithValue(ctx, ctxkeys.Capture, capture)
+}
+
+func WriteCapture(filename string, capture *workflow.Capture) error {
+	data, err := json.MarshalIndent(capture, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode capture: %w", err)
+	}
+
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create capture file: %w", err)
+	}
+	defer file.Close()
+
+	if err := file.Chmod(0o600); err != nil {
+		return fmt.Errorf("failed to restrict capture file permissions: %w", err)
+	}
+
+	if _, err := file.Write(data); err != nil {
+		return fmt.Errorf("failed to write capture file: %w", err)
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,107 @@
+package application
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+)
+
+const finalCheckpointAttempts = 5
+
+func (o *Orchestrator) checkpoint(ctx context.Context, execution *workflow.Execution) error {
+	if o.store == nil || isDryRun(ctx) {
+		return nil
+	}
+
+	// Running and suspended executions are stored as-is so they can resume
+	// with their real inputs and outputs; only finished ones are redacted.
+	if execution.Result.Status.IsTerminal() {
+		redacted, err := o.redactorFor(ctx).Execution(execution)
+		if err != nil {
+			return fmt.Errorf("failed to redact execution %s: %w", execution.Context.WorkflowID, err)
+		}
+		execution = redacted
+	}
+
+	if err := o.store.SaveExecution(context.WithoutCancel(ctx), execution); err != nil {
+		return fmt.Errorf("failed to checkpoint execution %s: %w", execution.Context.WorkflowID, err)
+	}
+	return nil
+}
+
+func (o *Orchestrator) checkpointStep(ctx context.Context, execution *workflow.Execution, result *workflow.StepResult) error {
+	if o.store == nil || isDryRun(ctx) {
+		return nil
+	}
+
+	storeCtx := context.WithValue(context.WithoutCancel(ctx), ctxkeys.Namespace, execution.Context.Namespace)
+	if err := o.store.SaveStepResult(storeCtx, execution.Context.WorkflowID, o.redactorFor(ctx).StepResult(result)); err != nil {
+		return fmt.Errorf("failed to checkpoint result of step %s: %w", result.StepID, err)
+	}
+
+	return o.checkpoint(ctx, execution)
+}
+
+func (o *Orchestrator) checkpointOutcome(r *run) {
+	backoff := time.Second
+	for attempt := 1; ; attempt++ {
+		err := o.checkpoint(r.ctx, r.execution)
+		if err == nil {
+			return
+		}
+		if attempt == finalCheckpointAttempts || r.lease.isFenced() {
+			r.logger.Error().
+				Err(err).
+				Str("status", r.result.Status.String()).
+				Msg("Failed to checkpoint final state, the execution may be recovered from its last checkpoint")
+			return
+		}
+
+		r.logger.Warn().
+			Err(err).
+			Int("attempt", attempt).
+			Dur("retry_in", backoff).
+			Msg("Failed to checkpoint final state, holding the execution")
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (o *Orchestrator) ListExecutions(ctx context.Context, filter workflow.ExecutionFilter) ([]*workflow.Execution, error) {
+	if o.store != nil {
+		return o.store.ListExecutions(ctx, filter)
+	}
+
+	var executions []*workflow.Execution
+	o.executions.Range(func(_, value any) bool {
+		execution := value.(*workflow.Execution).Copy()
+		if filter.WorkflowName != "" && execution.WorkflowName != filter.WorkflowName {
+			return true
+		}
+		if filter.Status != "" && execution.Result.Status.String() != filter.Status {
+			return true
+		}
+		if !workflow.MatchTags(execution.Context.Tags, filter.Tags) {
+			return true
+		}
+		if filter.RerunOf != "" && execution.Context.RerunOf != filter.RerunOf {
+			return true
+		}
+		executions = append(executions, execution)
+		return true
+	})
+
+	slices.SortFunc(executions, func(a, b *workflow.Execution) int {
+		return cmp.Compare(b.Result.StartedAt.UnixNano(), a.Result.StartedAt.UnixNano())
+	})
+	if filter.Limit > 0 && len(executions) > filter.Limit {
+		executions = executions[:filter.Limit]
+	}
+
+	return executions, nil
+}
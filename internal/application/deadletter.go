@@ -0,0 +1,156 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/metrics"
+)
+
+var deadLettersMetric = &workflow.MetricConfig{
+	Name: "maestro_dead_letters_total",
+	Type: metrics.MetricTypeCounter,
+	Help: "Trigger messages that could not start a workflow and were parked in the dead-letter queue",
+}
+
+type DeadLetterReplay struct {
+	DeadLetterID string `json:"dead_letter_id"`
+	WorkflowID   string `json:"workflow_id,omitempty"`
+	DeliveryID   string `json:"delivery_id,omitempty"`
+}
+
+func (o *Orchestrator) Trigger(ctx context.Context, msg *workflow.TriggerMessage) (string, error) {
+	workflowID, err := o.startTriggered(ctx, msg)
+	if errors.Is(err, workflow.ErrTriggerFiltered) {
+		o.logger.Debug().
+			Str("source", msg.Source).
+			Str("workflow", msg.Workflow).
+			Msg("Trigger message filtered out")
+		if err := o.metrics.Record(filteredTriggersMetric, 1, map[string]string{"workflow": msg.Workflow}); err != nil {
+			o.logger.Warn().Err(err).Msg("Failed to record filtered trigger")
+		}
+		return "", err
+	}
+	if err == nil || ctx.Err() != nil {
+		return workflowID, err
+	}
+	var deferred *workflow.CalendarError
+	if errors.As(err, &deferred) {
+		return "", err
+	}
+
+	now := time.Now()
+	letter := &workflow.DeadLetter{
+		ID:            uuid.New().String(),
+		Kind:          workflow.DeadLetterTrigger,
+		Source:        msg.Source,
+		Workflow:      msg.Workflow,
+		Payload:       msg.Payload,
+		Headers:       msg.Headers,
+		Reason:        err.Error(),
+		Attempts:      1,
+		CreatedAt:     now,
+		LastAttemptAt: now,
+	}
+	if wf, ok := o.GetWorkflow(msg.Workflow); ok {
+		letter.Namespace = wf.Namespace
+	}
+
+	if parkErr := o.deadLetters.ParkDeadLetter(context.WithoutCancel(ctx), letter); parkErr != nil {
+		return "", fmt.Errorf("%w; failed to park message: %w", err, parkErr)
+	}
+
+	o.logger.Warn().
+		Err(err).
+		Str("dead_letter_id", letter.ID).
+		Str("source", msg.Source).
+		Str("workflow", msg.Workflow).
+		Msg("Trigger message parked in dead-letter queue")
+	if err := o.metrics.Record(deadLettersMetric, 1, nil); err != nil {
+		o.logger.Warn().Err(err).Msg("Failed to record dead letter")
+	}
+
+	return "", fmt.Errorf("%w: %w", workflow.ErrDeadLettered, err)
+}
+
+func (o *Orchestrator) startTriggered(ctx context.Context, msg *workflow.TriggerMessage) (string, error) {
+	wf, ok := o.GetWorkflow(msg.Workflow)
+	if !ok {
+		return "", fmt.Errorf("workflow %s not found", msg.Workflow)
+	}
+
+	input, err := triggerInput(wf.Trigger, msg)
+	if err != nil {
+		return "", err
+	}
+	key, err := triggerIdempotencyKey(wf.Trigger, msg)
+	if err != nil {
+		return "", err
+	}
+	if key != "" {
+		ctx = WithIdempotencyKey(ctx, key)
+	}
+	return o.StartWorkflow(ctx, msg.Workflow, input)
+}
+
+func (o *Orchestrator) ListDeadLetters(ctx context.Context, filter workflow.DeadLetterFilter) ([]*workflow.DeadLetter, error) {
+	return o.deadLetters.ListDeadLetters(ctx, filter)
+}
+
+func (o *Orchestrator) GetDeadLetter(ctx context.Context, id string) (*workflow.DeadLetter, error) {
+	return o.deadLetters.GetDeadLetter(ctx, id)
+}
+
+func (o *Orchestrator) DeleteDeadLetter(ctx context.Context, id string) error {
+	return o.deadLetters.DeleteDeadLetter(ctx, id)
+}
+
+func (o *Orchestrator) ReplayDeadLetter(ctx context.Context, id string) (*DeadLetterReplay, error) {
+	letter, err := o.deadLetters.GetDeadLetter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	replay := &DeadLetterReplay{DeadLetterID: id}
+	switch letter.Kind {
+	case workflow.DeadLetterTrigger:
+		workflowID, err := o.startTriggered(ctx, letter.Trigger())
+		if err != nil {
+			letter.Attempts++
+			letter.Reason = err.Error()
+			letter.LastAttemptAt = time.Now()
+			if parkErr := o.deadLetters.ParkDeadLetter(context.WithoutCancel(ctx), letter); parkErr != nil {
+				o.logger.Warn().Err(parkErr).Str("dead_letter_id", id).Msg("Failed to record replay attempt")
+			}
+			return nil, err
+		}
+		replay.WorkflowID = workflowID
+	case workflow.DeadLetterWebhook:
+		if o.webhooks == nil {
+			return nil, ErrWebhooksDisabled
+		}
+		delivery, err := o.webhooks.Resend(letter)
+		if err != nil {
+			return nil, err
+		}
+		replay.DeliveryID = delivery.ID
+	default:
+		return nil, fmt.Errorf("%w: %s", workflow.ErrUnknownDeadLetter, letter.Kind)
+	}
+
+	if err := o.deadLetters.DeleteDeadLetter(context.WithoutCancel(ctx), id); err != nil {
+		o.logger.Warn().Err(err).Str("dead_letter_id", id).Msg("Failed to remove replayed dead letter")
+	}
+
+	o.logger.Info().
+		Str("dead_letter_id", id).
+		Str("kind", letter.Kind).
+		Str("workflow_id", replay.WorkflowID).
+		Str("delivery_id", replay.DeliveryID).
+		Msg("Dead letter replayed")
+	return replay, nil
+}
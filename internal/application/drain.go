@@ -0,0 +1,263 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/metrics"
+)
+
+const (
+	drainPollInterval     = 100 * time.Millisecond
+	handoffPickupInterval = 15 * time.Second
+)
+
+var errDrained = errors.New("execution suspended for handoff")
+
+var resumedExecutionsMetric = &workflow.MetricConfig{
+	Name: "maestro_resumed_executions_total",
+	Type: metrics.MetricTypeCounter,
+	Help: "Executions suspended by a draining instance and resumed on this one",
+}
+
+type DrainReport struct {
+	Suspended []*workflow.ExecutionSnapshot
+	Running   []string
+}
+
+func (o *Orchestrator) Drain(ctx context.Context) *DrainReport {
+	if !o.draining.Swap(true) {
+		o.logger.Warn().Msg("Draining, no new executions or steps will be started")
+	}
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for o.inFlight() > 0 && ctx.Err() == nil {
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+	}
+
+	report := &DrainReport{}
+	o.runningWorkflows.Range(func(key, _ any) bool {
+		report.Running = append(report.Running, key.(string))
+		return true
+	})
+	o.suspended.Range(func(_, value any) bool {
+		report.Suspended = append(report.Suspended, workflow.NewExecutionSnapshot(value.(*workflow.Execution)))
+		return true
+	})
+	return report
+}
+
+func (o *Orchestrator) Draining() bool {
+	return o.draining.Load()
+}
+
+func (o *Orchestrator) HandedOff(workflowID string) {
+	o.suspended.Delete(workflowID)
+	o.executions.Delete(workflowID)
+}
+
+func (o *Orchestrator) inFlight() int {
+	n := 0
+	o.runningWorkflows.Range(func(_, _ any) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+func (o *Orchestrator) suspends(r *run) bool {
+	return !r.child && o.draining.Load()
+}
+
+func (o *Orchestrator) suspend(r *run) {
+	r.result.SetStatus(workflow.WorkflowStatusSuspended)
+	o.suspended.Store(r.execCtx.WorkflowID, r.execution)
+
+	r.logger.Info().
+		Int("completed_steps", len(r.execCtx.CompletedSteps())).
+		Msg("Execution suspended at step boundary for handoff")
+}
+
+func (r *run) handedOff() bool {
+	return r.lease.isFenced() || r.result.Status == workflow.WorkflowStatusSuspended
+}
+
+func (o *Orchestrator) ResumeExecution(ctx context.Context, snapshot *workflow.ExecutionSnapshot) error {
+	execution, err := snapshot.Execution()
+	if err != nil {
+		return err
+	}
+
+	r, err := o.resumeRun(context.WithoutCancel(ctx), execution)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		_, _ = o.execute(r)
+	}()
+	return nil
+}
+
+// resumeRun continues an execution that was suspended by a draining instance
+// or exported while it was running. The steps it already completed are
+// skipped, so the instance it came from must no longer be running it.
+func (o *Orchestrator) resumeRun(ctx context.Context, execution *workflow.Execution) (*run, error) {
+	status := execution.Result.Status
+	if status != workflow.WorkflowStatusSuspended && status != workflow.WorkflowStatusRunning {
+		return nil, fmt.Errorf("execution %s is %s, only suspended or running executions can be resumed", execution.Context.WorkflowID, status)
+	}
+
+	r, err := o.continueRun(ctx, execution, o.claimExecution)
+	if err != nil {
+		return nil, err
+	}
+
+	r.logger.Info().
+		Str("status", status.String()).
+		Int("completed_steps", len(r.completed)).
+		Msg("Resuming execution")
+
+	if status != workflow.WorkflowStatusSuspended {
+		return r, nil
+	}
+	if err := o.metrics.Record(resumedExecutionsMetric, 1, nil); err != nil {
+		o.logger.Warn().Err(err).Msg("Failed to record resumed execution")
+	}
+	return r, nil
+}
+
+func (o *Orchestrator) continueRun(
+	ctx context.Context,
+	execution *workflow.Execution,
+	claim func(context.Context, string) (context.Context, *lease, error),
+) (*run, error) {
+	if o.draining.Load() {
+		return nil, workflow.ErrDraining
+	}
+	if o.passive.Load() {
+		return nil, workflow.ErrStandby
+	}
+
+	workflowID := execution.Context.WorkflowID
+	expected := execution.Result.Status
+	if _, exists := o.runningWorkflows.Load(workflowID); exists {
+		return nil, fmt.Errorf("execution %s is already running", workflowID)
+	}
+
+	o.mu.RLock()
+	latest, exists := o.workflows[execution.WorkflowName]
+	wf, started := o.workflowVersion(execution.WorkflowName, execution.WorkflowVersion)
+	overrides := o.overrides
+	o.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("workflow %s is not loaded", execution.WorkflowName)
+	}
+	if !started {
+		return nil, fmt.Errorf("execution %s was started on version %s of workflow %s, version %s is loaded",
+			workflowID, execution.WorkflowVersion, latest.Name, latest.Version)
+	}
+	loaded := wf
+	if len(overrides) > 0 {
+		wf = wf.WithServiceOverrides(overrides)
+	}
+	if env := execution.Context.Environment; env != "" {
+		scoped, err := wf.ForEnvironment(env)
+		if err != nil {
+			return nil, err
+		}
+		wf = scoped
+	}
+
+	ctx, lease, err := claim(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+	if o.store != nil {
+		stored, found, err := o.store.LoadExecution(ctx, workflowID)
+		if err != nil {
+			return nil, err
+		}
+		if found && stored.Result.Status != expected {
+			return nil, fmt.Errorf("execution %s is %s: %w", workflowID, stored.Result.Status, workflow.ErrFenced)
+		}
+	}
+
+	callbacks, err := o.completionCallbacks(ctx, wf)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resume execution %s: %w", workflowID, err)
+	}
+
+	execution.Result.SetStatus(workflow.WorkflowStatusRunning)
+	o.executions.Store(workflowID, execution)
+	r := o.newRun(ctx, wf, loaded, execution, lease)
+	r.callbacks = callbacks
+	r.completed = execution.Context.CompletedSteps()
+	return r, nil
+}
+
+func (o *Orchestrator) RunHandoffPickup(ctx context.Context) {
+	if o.store == nil {
+		return
+	}
+
+	ticker := time.NewTicker(handoffPickupInterval)
+	defer ticker.Stop()
+
+	for {
+		o.pickUpSuspended(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (o *Orchestrator) pickUpSuspended(ctx context.Context) {
+	if o.draining.Load() {
+		return
+	}
+
+	executions, err := o.store.ListExecutions(ctx, workflow.ExecutionFilter{
+		Status: workflow.WorkflowStatusSuspended.String(),
+	})
+	if err != nil {
+		o.logger.Error().
+			Err(err).
+			Msg("Failed to list suspended executions")
+		return
+	}
+
+	for _, execution := range executions {
+		if ctx.Err() != nil {
+			return
+		}
+
+		r, err := o.resumeRun(context.WithoutCancel(ctx), execution)
+		if errors.Is(err, workflow.ErrFenced) {
+			continue
+		}
+		if err != nil {
+			o.logger.Error().
+				Err(err).
+				Str("workflow_id", execution.Context.WorkflowID).
+				Msg("Failed to resume suspended execution")
+			continue
+		}
+
+		go func() {
+			_, _ = o.execute(r)
+		}()
+	}
+}
@@ -0,0 +1,17 @@
+package application
+
+import (
+	"context"
+
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+)
+
+func WithDryRun(ctx context.Context, plan *workflow.DryRun) context.Context {
+	ctx = context.WithValue(ctx, ctxkeys.Shadow, workflow.NewShadowRun(nil, nil))
+	return context.WithValue(ctx, ctxkeys.DryRun, plan)
+}
+
+func isDryRun(ctx context.Context) bool {
+	return ctx.Value(ctxkeys.DryRun) != nil
+}
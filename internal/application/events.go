@@ -0,0 +1,25 @@
+package application
+
+import (
+	workflow "github.com/maestro/maestro.go/internal/domain"
+)
+
+func (o *Orchestrator) emitWorkflowFinished(r *run) {
+	if r.handedOff() {
+		return
+	}
+
+	event := workflow.Event{
+		Type:     workflow.WorkflowFinishedEvent(r.result.Status),
+		Status:   r.result.Status.String(),
+		Duration: &workflow.Duration{Duration: r.result.CompletedAt.Sub(r.result.StartedAt)},
+	}
+	if r.result.Error != nil {
+		event.Error = r.result.Error.Error()
+	}
+	o.executor.Emit(r.ctx, event)
+}
+
+func (o *Orchestrator) SubscribeEvents(buffer int) (<-chan workflow.Event, func()) {
+	return o.events.Subscribe(buffer)
+}
@@ -0,0 +1,33 @@
+package executor
+
+import (
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+func (e *Executor) executeAssertStep(
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+) (*domain.StepResult, error) {
+	ok, err := e.evaluateCondition(step.Assert.Condition, execCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		e.logger.Warn().
+			Str("workflow_id", execCtx.WorkflowID).
+			Str("step_id", step.ID).
+			Str("condition", step.Assert.Condition).
+			Msg("Assertion failed")
+		return nil, &domain.AssertionError{
+			StepID:    step.ID,
+			Condition: step.Assert.Condition,
+			Message:   step.Assert.Message,
+		}
+	}
+
+	return &domain.StepResult{
+		StepID: step.ID,
+		Output: true,
+	}, nil
+}
@@ -0,0 +1,100 @@
+package executor
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+func (e *Executor) coerceInput(service string, step *domain.Step, input map[string]any) error {
+	schema, ok := e.registry.InputSchema(service, step.Method)
+	if !ok {
+		return nil
+	}
+
+	if err := coerceFields(input, schema); err != nil {
+		return fmt.Errorf("step %s: input %w", step.ID, err)
+	}
+	return nil
+}
+
+func coerceFields(values map[string]any, schema map[string]domain.FieldSchema) error {
+	for key, value := range values {
+		field, ok := schema[key]
+		if !ok {
+			continue
+		}
+
+		coerced, err := coerceValue(value, field)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		values[key] = coerced
+	}
+	return nil
+}
+
+func coerceValue(value any, field domain.FieldSchema) (any, error) {
+	s, isString := value.(string)
+
+	switch field.Type {
+	case "object":
+		if nested, ok := value.(map[string]any); ok && len(field.Properties) > 0 {
+			if err := coerceFields(nested, field.Properties); err != nil {
+				return nil, err
+			}
+		}
+		return value, nil
+
+	case "array":
+		if items, ok := value.([]any); ok && field.Items != nil {
+			for i, item := range items {
+				coerced, err := coerceValue(item, *field.Items)
+				if err != nil {
+					return nil, fmt.Errorf("[%d]: %w", i, err)
+				}
+				items[i] = coerced
+			}
+		}
+		return value, nil
+
+	case "integer":
+		if !isString {
+			return value, nil
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected integer, got %q", s)
+		}
+		value = n
+
+	case "number":
+		if !isString {
+			return value, nil
+		}
+		n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected number, got %q", s)
+		}
+		value = n
+
+	case "boolean":
+		if !isString {
+			return value, nil
+		}
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("expected boolean, got %q", s)
+		}
+		value = b
+	}
+
+	if len(field.Enum) > 0 && !slices.Contains(field.Enum, fmt.Sprint(value)) {
+		return nil, fmt.Errorf("value %v is not one of %s", value, strings.Join(field.Enum, ", "))
+	}
+
+	return value, nil
+}
@@ -0,0 +1,132 @@
+package executor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+func TestCoerceValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		field   domain.FieldSchema
+		want    any
+		wantErr string
+	}{
+		{
+			name:  "integer from string",
+			value: " 42 ",
+			field: domain.FieldSchema{Type: "integer"},
+			want:  int64(42),
+		},
+		{
+			name:  "integer already typed",
+			value: 42,
+			field: domain.FieldSchema{Type: "integer"},
+			want:  42,
+		},
+		{
+			name:    "invalid integer",
+			value:   "4.2",
+			field:   domain.FieldSchema{Type: "integer"},
+			wantErr: `expected integer, got "4.2"`,
+		},
+		{
+			name:  "number from string",
+			value: "19.99",
+			field: domain.FieldSchema{Type: "number"},
+			want:  19.99,
+		},
+		{
+			name:    "invalid number",
+			value:   "cheap",
+			field:   domain.FieldSchema{Type: "number"},
+			wantErr: `expected number, got "cheap"`,
+		},
+		{
+			name:  "boolean from string",
+			value: "true",
+			field: domain.FieldSchema{Type: "boolean"},
+			want:  true,
+		},
+		{
+			name:    "invalid boolean",
+			value:   "yes please",
+			field:   domain.FieldSchema{Type: "boolean"},
+			wantErr: `expected boolean, got "yes please"`,
+		},
+		{
+			name:  "string is left alone",
+			value: "3",
+			field: domain.FieldSchema{Type: "string"},
+			want:  "3",
+		},
+		{
+			name:  "enum value",
+			value: "express",
+			field: domain.FieldSchema{Type: "string", Enum: []string{"standard", "express"}},
+			want:  "express",
+		},
+		{
+			name:  "enum checked after conversion",
+			value: "2",
+			field: domain.FieldSchema{Type: "integer", Enum: []string{"1", "2"}},
+			want:  int64(2),
+		},
+		{
+			name:    "value outside enum",
+			value:   "overnight",
+			field:   domain.FieldSchema{Type: "string", Enum: []string{"standard", "express"}},
+			wantErr: "value overnight is not one of standard, express",
+		},
+		{
+			name:  "nested object properties",
+			value: map[string]any{"zip": "75001", "city": "Paris"},
+			field: domain.FieldSchema{Type: "object", Properties: map[string]domain.FieldSchema{
+				"zip": {Type: "integer"},
+			}},
+			want: map[string]any{"zip": int64(75001), "city": "Paris"},
+		},
+		{
+			name:  "invalid nested property",
+			value: map[string]any{"zip": "Paris"},
+			field: domain.FieldSchema{Type: "object", Properties: map[string]domain.FieldSchema{
+				"zip": {Type: "integer"},
+			}},
+			wantErr: `zip: expected integer, got "Paris"`,
+		},
+		{
+			name:  "array items",
+			value: []any{"1", "2"},
+			field: domain.FieldSchema{Type: "array", Items: &domain.FieldSchema{Type: "integer"}},
+			want:  []any{int64(1), int64(2)},
+		},
+		{
+			name:    "invalid array item",
+			value:   []any{"1", "two"},
+			field:   domain.FieldSchema{Type: "array", Items: &domain.FieldSchema{Type: "integer"}},
+			wantErr: `[1]: expected integer, got "two"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := coerceValue(tt.value, tt.field)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("coerceValue() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("coerceValue() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("coerceValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,8 +4,15 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"time"
 
 	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/grpc"
+	"github.com/maestro/maestro.go/internal/infrastructure/tracing"
+	"github.com/maestro/maestro.go/internal/ports"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func (e *Executor) CompensateStep(
@@ -13,24 +20,42 @@ func (e *Executor) CompensateStep(
 	step *domain.ExecutedStep,
 	execCtx *domain.ExecutionContext,
 	wf *domain.Workflow,
-) error {
-	if step.Compensation == nil || step.Compensated {
+) (err error) {
+	if step.Compensated {
+		return nil
+	}
+	if step.SubWorkflowID == "" && step.Compensation == nil && step.Transition == nil {
 		return nil
 	}
 
+	ctx, span := tracing.Tracer().Start(ctx, "compensate "+step.StepID, trace.WithAttributes(
+		attribute.String("maestro.workflow_id", execCtx.WorkflowID),
+		attribute.String("maestro.step_id", step.StepID),
+		attribute.String("maestro.sub_workflow_id", step.SubWorkflowID),
+	))
+	defer func() {
+		tracing.End(span, err)
+		e.emitCompensationFinished(ctx, step, err)
+	}()
+
+	if step.SubWorkflowID != "" {
+		return e.compensateSubWorkflow(ctx, step, execCtx)
+	}
+	if step.Transition != nil {
+		return e.revertTransition(ctx, step, execCtx)
+	}
+
 	workflowID := GetWorkflowID(ctx)
 	logger := e.logger.With().
 		Str("workflow_id", workflowID).
 		Str("step_id", step.StepID).
+		Str("service", step.CompensationService()).
 		Str("method", step.Compensation.Method).
 		Logger()
 
-	logger.Info().Msg("Compensating step")
-
 	resolvedInput := make(map[string]any)
-	templateData := make(map[string]any, len(execCtx.StepOutputs)+1)
-	templateData["input"] = execCtx.Input
-	maps.Copy(templateData, execCtx.StepOutputs)
+	templateData := buildTemplateData(execCtx)
+	maps.Copy(templateData, step.Scope)
 
 	for key, value := range step.Compensation.Input {
 		if strVal, ok := value.(string); ok && domain.IsTemplate(strVal) {
@@ -44,23 +69,125 @@ func (e *Executor) CompensateStep(
 		}
 	}
 
-	_, err := e.client.InvokeMethod(
-		ctx,
-		step.StepID,
-		step.Compensation.Method,
-		resolvedInput,
-		workflowID,
-		step.StepID+"_compensate",
-	)
+	opts := grpc.CallOptions{Tombstone: step.Compensation.Tombstone}
+	if step.Compensation.Key != "" {
+		if opts.Key, err = e.resolveTemplate(step.Compensation.Key, templateData); err != nil {
+			return fmt.Errorf("failed to resolve compensation key: %w", err)
+		}
+	}
+
+	opts.Headers = withIdempotencyKey(opts.Headers, compensationIdempotencyKey(workflowID, step.Iteration+step.StepID))
+
+	retry := compensationRetry(step, wf)
+	attempts := 1
+	if retry != nil && retry.Attempts > 1 {
+		attempts = retry.Attempts
+	}
+
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			backoff := e.calculateBackoff(attempt-1, retry)
+			logger.Warn().
+				Int("attempt", attempt).
+				Dur("backoff", backoff).
+				Msg("Retrying compensation after backoff")
+			recordRetry(ctx, attempt, backoff)
+
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		attemptCtx, attemptSpan := startAttemptSpan(ctx, "compensate "+step.StepID, attempt, attempts)
+		err = e.invokeCompensation(attemptCtx, step, resolvedInput, opts, workflowID, logger)
+		tracing.End(attemptSpan, err)
+		if err == nil || ctx.Err() != nil {
+			break
+		}
+	}
 
 	if err != nil {
 		logger.Error().
 			Err(err).
+			Int("attempts", step.CompensationAttempts).
 			Msg("Compensation failed")
 		return err
 	}
 
-	step.Compensated = true
+	execCtx.MarkCompensated(step)
 	logger.Info().Msg("Step compensated successfully")
 	return nil
 }
+
+func (e *Executor) invokeCompensation(
+	ctx context.Context,
+	step *domain.ExecutedStep,
+	input map[string]any,
+	opts grpc.CallOptions,
+	workflowID string,
+	logger zerolog.Logger,
+) error {
+	if slots := e.compensationSlots(); slots != nil {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		defer func() { <-slots }()
+	}
+
+	step.CompensationAttempts++
+	logger.Info().
+		Int("attempt", step.CompensationAttempts).
+		Msg("Compensating step")
+
+	call := &ports.StepCall{
+		WorkflowID:   workflowID,
+		StepID:       step.StepID,
+		Service:      step.CompensationService(),
+		Method:       step.Compensation.Method,
+		Attempt:      step.CompensationAttempts,
+		Compensation: true,
+		Input:        input,
+		Headers:      opts.Headers,
+	}
+	_, err := e.invokeThrough(ctx, call, func(ctx context.Context, call *ports.StepCall) (any, error) {
+		callOpts := opts
+		callOpts.Headers = call.Headers
+		return e.client.Call(
+			ctx,
+			e.serviceName(ctx, call.Service),
+			call.Method,
+			call.Input,
+			call.WorkflowID,
+			call.StepID+"_compensate",
+			callOpts,
+		)
+	})
+	return err
+}
+
+func compensationRetry(step *domain.ExecutedStep, wf *domain.Workflow) *domain.RetryConfig {
+	if step.Compensation.Retry != nil {
+		return step.Compensation.Retry
+	}
+	if wf.Compensation != nil {
+		return wf.Compensation.Retry
+	}
+	return nil
+}
+
+func (e *Executor) emitCompensationFinished(ctx context.Context, step *domain.ExecutedStep, err error) {
+	event := domain.Event{
+		Type:    domain.EventStepCompensated,
+		StepID:  step.StepID,
+		Service: step.CompensationService(),
+	}
+	if err != nil {
+		event.Type = domain.EventStepCompensationFailed
+		event.Error = err.Error()
+	}
+	e.Emit(ctx, event)
+}
@@ -1,24 +1,37 @@
 package executor
 
 import (
+	"errors"
+	"fmt"
+
+	"github.com/maestro/maestro.go/internal/application/expression"
 	"github.com/maestro/maestro.go/internal/domain"
 )
 
 func (e *Executor) evaluateCondition(condition string, execCtx *domain.ExecutionContext) (bool, error) {
-	resolvedCondition, err := e.resolveTemplate(condition, map[string]any{
-		"input": execCtx.Input,
-	})
+	vars := buildTemplateData(execCtx)
+
+	if expr, ok := expression.Unwrap(condition); ok {
+		return evaluateBool(expr, vars)
+	}
+
+	resolvedCondition, err := e.resolveTemplate(condition, vars)
 	if err != nil {
 		return false, err
 	}
 
-	for stepID, output := range execCtx.StepOutputs {
-		if stepID == resolvedCondition {
-			if b, ok := output.(bool); ok {
-				return b, nil
-			}
-		}
+	expr, ok := expression.Unwrap(resolvedCondition)
+	if !ok {
+		return false, fmt.Errorf("condition %q rendered to %q, which is not a valid expression", condition, resolvedCondition)
 	}
 
-	return resolvedCondition == "true", nil
+	return evaluateBool(expr, vars)
+}
+
+func evaluateBool(expr string, vars map[string]any) (bool, error) {
+	ok, err := expression.EvaluateBool(expr, vars)
+	if errors.Is(err, expression.ErrUndefined) {
+		return false, nil
+	}
+	return ok, err
 }
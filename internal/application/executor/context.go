@@ -4,6 +4,7 @@ import (
 	"context"
 
 	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	"github.com/maestro/maestro.go/internal/domain"
 )
 
 func GetWorkflowID(ctx context.Context) string {
@@ -20,9 +21,70 @@ func GetWorkflowName(ctx context.Context) string {
 	return ""
 }
 
+func GetWorkflowVersion(ctx context.Context) string {
+	if val := ctx.Value(ctxkeys.WorkflowVersion); val != nil {
+		return val.(string)
+	}
+	return ""
+}
+
+func GetEnvironment(ctx context.Context) string {
+	if val := ctx.Value(ctxkeys.Environment); val != nil {
+		return val.(string)
+	}
+	return ""
+}
+
 func GetStepID(ctx context.Context) string {
 	if val := ctx.Value(ctxkeys.StepID); val != nil {
 		return val.(string)
 	}
 	return ""
 }
+
+func redactor(ctx context.Context) *domain.Redactor {
+	redactor, _ := ctx.Value(ctxkeys.Redactor).(*domain.Redactor)
+	return redactor
+}
+
+func stepClock(ctx context.Context) *domain.StepClock {
+	clock, _ := ctx.Value(ctxkeys.StepClock).(*domain.StepClock)
+	return clock
+}
+
+func (e *Executor) serviceName(ctx context.Context, service string) string {
+	workflow, version := GetWorkflowName(ctx), GetWorkflowVersion(ctx)
+	service = e.workflowService(workflow, version, service)
+
+	if env := GetEnvironment(ctx); env != "" {
+		scoped := domain.EnvironmentServiceName(service, env)
+		if name, ok := e.versionService(workflow, version, scoped); ok {
+			return name
+		}
+	}
+	name, _ := e.versionService(workflow, version, service)
+	return name
+}
+
+func (e *Executor) workflowService(workflow, version, service string) string {
+	if workflow == "" {
+		return service
+	}
+
+	scoped := domain.WorkflowServiceName(workflow, service)
+	if _, ok := e.versionService(workflow, version, scoped); ok {
+		return scoped
+	}
+	return service
+}
+
+func (e *Executor) versionService(workflow, version, service string) (string, bool) {
+	if workflow != "" && version != "" {
+		versioned := domain.VersionServiceName(workflow, version, service)
+		if _, err := e.registry.GetService(versioned); err == nil {
+			return versioned, true
+		}
+	}
+	_, err := e.registry.GetService(service)
+	return service, err == nil
+}
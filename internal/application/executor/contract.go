@@ -0,0 +1,57 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maestro/maestro.go/internal/application/validation"
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/metrics"
+)
+
+var contractViolationsMetric = &domain.MetricConfig{
+	Name: "maestro_contract_violations_total",
+	Type: metrics.MetricTypeCounter,
+	Help: "Step outputs that did not match their output_schema",
+}
+
+func (e *Executor) checkOutputSchema(
+	ctx context.Context,
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+	wf *domain.Workflow,
+	result *domain.StepResult,
+) error {
+	if len(step.OutputSchema) == 0 || result == nil || result.Skipped || result.Error != nil {
+		return nil
+	}
+	if _, ok := dryRun(ctx); ok {
+		return nil
+	}
+
+	root := step.Output
+	if root == "" {
+		root = step.ID
+	}
+	problems, err := validation.Validate(step.OutputSchema, result.Output, root)
+	if err != nil {
+		return fmt.Errorf("step %s: cannot validate output: %w", step.ID, err)
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+
+	e.logger.Error().
+		Str("workflow_id", execCtx.WorkflowID).
+		Str("step_id", step.ID).
+		Strs("problems", problems).
+		Msg("Step output violates its output_schema")
+
+	if e.metrics != nil {
+		labels := map[string]string{"workflow": wf.Name, "step": step.ID}
+		if err := e.metrics.Record(contractViolationsMetric, 1, labels); err != nil {
+			e.logger.Warn().Err(err).Str("step_id", step.ID).Msg("Failed to record contract violation metric")
+		}
+	}
+	return &domain.ContractError{StepID: step.ID, Problems: problems}
+}
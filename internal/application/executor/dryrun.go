@@ -0,0 +1,101 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+func dryRun(ctx context.Context) (*domain.DryRun, bool) {
+	plan, ok := ctx.Value(ctxkeys.DryRun).(*domain.DryRun)
+	return plan, ok
+}
+
+func (e *Executor) planStep(
+	ctx context.Context,
+	plan *domain.DryRun,
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+	wf *domain.Workflow,
+) *domain.StepResult {
+	planned := domain.PlannedStep{StepID: step.ID}
+
+	switch {
+	case step.AcquireLock != nil || step.ReleaseLock != nil:
+		planned.Kind = domain.PlannedLock
+	case step.KV != nil:
+		planned.Kind = domain.PlannedKV
+	case step.Transition != nil:
+		planned.Kind = domain.PlannedState
+	case step.Assert != nil:
+		planned.Kind = domain.PlannedAssert
+	case step.Wait != nil:
+		planned.Kind = domain.PlannedWait
+	case step.Workflow != "":
+		planned.Kind = domain.PlannedWorkflow
+		planned.Service = step.Workflow
+		input, err := e.resolveStepInput(step, execCtx)
+		if err != nil {
+			planned.Error = fmt.Sprintf("failed to resolve input: %v", err)
+		}
+		planned.Input = input
+	case step.Service == "" && step.EmitMetric != nil:
+		planned.Kind = domain.PlannedMetric
+	default:
+		planned.Kind = domain.PlannedCall
+		if err := e.planCall(&planned, step, execCtx, wf); err != nil {
+			planned.Error = err.Error()
+		}
+	}
+
+	plan.Record(redactor(ctx).PlannedStep(planned))
+
+	result := &domain.StepResult{StepID: step.ID}
+	switch planned.Kind {
+	case domain.PlannedCall, domain.PlannedWorkflow, domain.PlannedState:
+		result.Output = map[string]any{}
+	}
+	return result
+}
+
+func (e *Executor) planCall(
+	planned *domain.PlannedStep,
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+	wf *domain.Workflow,
+) error {
+	planned.Service = step.Service
+	planned.Method = step.Method
+
+	service, exists := wf.Services[step.Service]
+	if !exists {
+		return fmt.Errorf("service %s not found", step.Service)
+	}
+	planned.Type = service.Type
+	planned.Endpoint = service.Endpoint
+
+	input, err := e.resolveStepInput(step, execCtx)
+	if err != nil {
+		return fmt.Errorf("failed to resolve input: %w", err)
+	}
+	planned.Input = input
+	name, _ := e.versionService(wf.Name, wf.Version, e.workflowService(wf.Name, wf.Version, step.Service))
+	if err := e.coerceInput(name, step, input); err != nil {
+		return err
+	}
+
+	method, err := e.resolveMethod(step, service, execCtx)
+	if err != nil {
+		return err
+	}
+	planned.Method = method
+
+	opts, err := e.callOptions(step, execCtx)
+	if err != nil {
+		return err
+	}
+	planned.Headers = opts.Headers
+	return nil
+}
@@ -0,0 +1,59 @@
+package executor
+
+import (
+	"context"
+	"time"
+
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+func (e *Executor) Emit(ctx context.Context, event domain.Event) {
+	if e.events == nil || ctx.Value(ctxkeys.Shadow) != nil {
+		return
+	}
+
+	if event.WorkflowID == "" {
+		event.WorkflowID = GetWorkflowID(ctx)
+	}
+	if event.WorkflowName == "" {
+		event.WorkflowName = GetWorkflowName(ctx)
+	}
+	if event.Namespace == "" {
+		event.Namespace, _ = ctx.Value(ctxkeys.Namespace).(string)
+	}
+	event.Error = redactor(ctx).String(event.Error)
+	event.Timestamp = time.Now()
+	e.events.Emit(event)
+}
+
+func (e *Executor) emitStepFinished(
+	ctx context.Context,
+	step *domain.Step,
+	startedAt time.Time,
+	result *domain.StepResult,
+	err error,
+) {
+	event := domain.Event{
+		Type:     domain.EventStepSucceeded,
+		StepID:   step.ID,
+		Service:  step.Service,
+		Duration: &domain.Duration{Duration: time.Since(startedAt)},
+	}
+
+	switch {
+	case err != nil:
+		event.Type = domain.EventStepFailed
+		event.Error = err.Error()
+	case result != nil && result.Skipped:
+		event.Type = domain.EventStepSkipped
+		event.Duration = nil
+	case result != nil && result.Error != nil:
+		event.Type = domain.EventStepFailed
+		event.Error = result.Error.Error()
+	case result != nil:
+		event.Fallback = result.Fallback
+	}
+
+	e.Emit(ctx, event)
+}
@@ -2,26 +2,60 @@ package executor
 
 import (
 	"context"
+	"sync"
+	"time"
 
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
 	"github.com/maestro/maestro.go/internal/domain"
 	"github.com/maestro/maestro.go/internal/infrastructure/grpc"
+	"github.com/maestro/maestro.go/internal/infrastructure/metrics"
+	"github.com/maestro/maestro.go/internal/ports"
 	"github.com/rs/zerolog"
 )
 
 type Executor struct {
 	registry   *grpc.ServiceRegistry
 	client     *grpc.DynamicClient
+	metrics    *metrics.Registry
+	locks      ports.LockManager
+	kv         ports.KVStore
+	secrets    ports.SecretProvider
+	redactor   *domain.Redactor
 	logger     zerolog.Logger
-	workerPool chan struct{}
+	workerPool *workerPool
+	compPool   chan struct{}
+	signals    map[string]chan any
+	groups     map[string]chan struct{}
+	active     map[string]*activeStep
+	renewals   map[string]context.CancelFunc
+	responses  *responseCache
+	events     ports.EventSink
+	workflows  ports.WorkflowRunner
+	middleware []ports.StepMiddleware
+	mu         sync.Mutex
 }
 
-func NewExecutor(registry *grpc.ServiceRegistry, logger zerolog.Logger) *Executor {
-	return &Executor{
+const defaultWorkerPoolSize = 10
+
+func NewExecutor(registry *grpc.ServiceRegistry, logger zerolog.Logger, opts ...Option) *Executor {
+	e := &Executor{
 		registry:   registry,
 		client:     grpc.NewDynamicClient(registry, logger),
 		logger:     logger,
-		workerPool: make(chan struct{}, 10),
+		workerPool: newWorkerPool(defaultWorkerPoolSize),
+		renewals:   make(map[string]context.CancelFunc),
+		signals:    make(map[string]chan any),
+		groups:     make(map[string]chan struct{}),
+		active:     make(map[string]*activeStep),
+		responses:  newResponseCache(),
+	}
+
+	for _, opt := range opts {
+		opt(e)
 	}
+	e.client.OnFailover(e.recordFailover)
+
+	return e
 }
 
 func (e *Executor) ExecuteStep(
@@ -29,6 +63,58 @@ func (e *Executor) ExecuteStep(
 	step *domain.Step,
 	execCtx *domain.ExecutionContext,
 	wf *domain.Workflow,
+) (*domain.StepResult, error) {
+	startedAt := time.Now()
+	stepCtx, active := ctx, (*activeStep)(nil)
+	if len(step.Parallel) == 0 {
+		stepCtx, active = e.trackStep(ctx, execCtx.WorkflowID, step.ID)
+	}
+
+	result, err := e.executeStep(stepCtx, step, execCtx, wf)
+	if err == nil {
+		if err = e.checkOutputSchema(ctx, step, execCtx, wf, result); err != nil {
+			result = nil
+		}
+	}
+	if err != nil {
+		e.recordTemplateError(step, execCtx, wf, err)
+	}
+	if active != nil {
+		var decision domain.StepOverride
+		overridden := false
+		if err != nil && ctx.Err() == nil && step.OnError == domain.OnErrorHold {
+			decision, overridden = e.holdStep(ctx, step, execCtx, active, err)
+		}
+		if pending, ok := e.untrackStep(execCtx.WorkflowID, step.ID, active); ok {
+			decision, overridden = pending, true
+		}
+		if overridden {
+			var heldErr error
+			if active.held {
+				heldErr = err
+			}
+			result, err = e.applyOverride(ctx, step, execCtx, decision, heldErr)
+			e.emitStepFinished(ctx, step, startedAt, result, err)
+			return result, err
+		}
+	}
+	if err != nil && ctx.Err() == nil {
+		switch step.OnError {
+		case domain.OnErrorContinue, domain.OnErrorFallback:
+			result, err = e.recoverStep(ctx, step, execCtx, wf, err)
+		}
+	}
+	if len(step.Parallel) == 0 {
+		e.emitStepFinished(ctx, step, startedAt, result, err)
+	}
+	return result, err
+}
+
+func (e *Executor) executeStep(
+	ctx context.Context,
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+	wf *domain.Workflow,
 ) (*domain.StepResult, error) {
 	if len(step.Parallel) > 0 {
 		return e.executeParallelSteps(ctx, step.Parallel, execCtx, wf)
@@ -44,12 +130,100 @@ func (e *Executor) ExecuteStep(
 				Str("step_id", step.ID).
 				Str("condition", step.When).
 				Msg("Skipping step due to condition")
+			if plan, ok := dryRun(ctx); ok {
+				plan.Record(domain.PlannedStep{StepID: step.ID, Skipped: true, Condition: step.When})
+			}
 			return &domain.StepResult{
-				StepID: step.ID,
-				Output: nil,
+				StepID:  step.ID,
+				Output:  nil,
+				Skipped: true,
 			}, nil
 		}
 	}
 
-	return e.executeSingleStep(ctx, step, execCtx, wf)
+	if plan, ok := dryRun(ctx); ok && step.Foreach == nil {
+		return e.planStep(ctx, plan, step, execCtx, wf), nil
+	}
+
+	if shadow, ok := ctx.Value(ctxkeys.Shadow).(*domain.ShadowRun); ok && step.Assert == nil {
+		if result, ok := shadow.Replay(step); ok {
+			if result.Error != nil {
+				return nil, result.Error
+			}
+			return result, nil
+		}
+	}
+
+	ctx, span := e.startStepSpan(ctx, step, execCtx)
+	e.Emit(ctx, domain.Event{Type: domain.EventStepStarted, StepID: step.ID, Service: step.Service})
+
+	if step.AcquireLock != nil || step.ReleaseLock != nil {
+		result, err := e.executeLockStep(ctx, step, execCtx)
+		e.endStepSpan(span, step, execCtx, result, err)
+		return result, err
+	}
+
+	if step.KV != nil {
+		result, err := e.executeKVStep(ctx, step, execCtx)
+		e.endStepSpan(span, step, execCtx, result, err)
+		return result, err
+	}
+
+	if step.Transition != nil {
+		result, err := e.executeTransitionStep(ctx, step, execCtx, wf)
+		e.endStepSpan(span, step, execCtx, result, err)
+		return result, err
+	}
+
+	if step.Workflow != "" {
+		result, err := e.executeSubWorkflow(ctx, step, execCtx)
+		e.endStepSpan(span, step, execCtx, result, err)
+		return result, err
+	}
+
+	if step.Foreach != nil {
+		result, err := e.executeForeach(ctx, step, execCtx, wf)
+		e.endStepSpan(span, step, execCtx, result, err)
+		return result, err
+	}
+
+	if step.Assert != nil {
+		result, err := e.executeAssertStep(step, execCtx)
+		e.endStepSpan(span, step, execCtx, result, err)
+		return result, err
+	}
+
+	if step.Wait != nil {
+		result, err := e.executeWaitStep(ctx, step, execCtx)
+		e.endStepSpan(span, step, execCtx, result, err)
+		return result, err
+	}
+
+	if step.Service == "" && step.EmitMetric != nil {
+		e.emitMetric(step, execCtx, nil)
+		result := &domain.StepResult{
+			StepID: step.ID,
+			Output: nil,
+		}
+		e.endStepSpan(span, step, execCtx, result, nil)
+		return result, nil
+	}
+
+	if err := e.runBeforeHooks(ctx, step, execCtx, wf); err != nil {
+		e.endStepSpan(span, step, execCtx, nil, err)
+		return nil, err
+	}
+
+	result, err := e.executeSingleStep(ctx, step, execCtx, wf)
+	e.runAfterHooks(ctx, step, execCtx, wf, result, err)
+	e.endStepSpan(span, step, execCtx, result, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if step.EmitMetric != nil {
+		e.emitMetric(step, execCtx, result)
+	}
+
+	return result, nil
 }
@@ -0,0 +1,22 @@
+package executor
+
+import (
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/metrics"
+)
+
+var failoverMetric = &domain.MetricConfig{
+	Name: "maestro_service_failovers_total",
+	Type: metrics.MetricTypeCounter,
+	Help: "Calls routed to a secondary region after the previous endpoint failed",
+}
+
+func (e *Executor) recordFailover(service, region, reason string) {
+	if e.metrics == nil {
+		return
+	}
+	labels := map[string]string{"service": service, "region": region, "reason": reason}
+	if err := e.metrics.Record(failoverMetric, 1, labels); err != nil {
+		e.logger.Warn().Err(err).Str("service", service).Msg("Failed to record failover metric")
+	}
+}
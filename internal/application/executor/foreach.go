@@ -0,0 +1,157 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+
+	"github.com/maestro/maestro.go/internal/application/templating"
+	"github.com/maestro/maestro.go/internal/domain"
+	"golang.org/x/sync/errgroup"
+)
+
+func (e *Executor) executeForeach(
+	ctx context.Context,
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+	wf *domain.Workflow,
+) (*domain.StepResult, error) {
+	items, err := e.resolveItems(step.Foreach.Items, buildTemplateData(execCtx))
+	if err != nil {
+		return nil, fmt.Errorf("step %s: %w", step.ID, err)
+	}
+
+	e.logger.Info().
+		Str("workflow_id", execCtx.WorkflowID).
+		Str("step_id", step.ID).
+		Int("items", len(items)).
+		Msg("Executing foreach step")
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(step.Foreach.Concurrency, 1))
+
+	outputs := make([]any, len(items))
+	for index, item := range items {
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			output, err := e.executeIteration(gctx, step, index, item, execCtx, wf)
+			if err != nil {
+				return fmt.Errorf("iteration %d: %w", index, err)
+			}
+			outputs[index] = output
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("foreach %s failed: %w", step.ID, err)
+	}
+
+	return &domain.StepResult{
+		StepID: step.ID,
+		Output: outputs,
+	}, nil
+}
+
+func (e *Executor) executeIteration(
+	ctx context.Context,
+	step *domain.Step,
+	index int,
+	item any,
+	execCtx *domain.ExecutionContext,
+	wf *domain.Workflow,
+) (any, error) {
+	scope := map[string]any{"item": item, "index": index}
+
+	iterCtx := &domain.ExecutionContext{
+		WorkflowID:     execCtx.WorkflowID,
+		WorkflowName:   execCtx.WorkflowName,
+		Namespace:      execCtx.Namespace,
+		Environment:    execCtx.Environment,
+		TemplateEngine: execCtx.TemplateEngine,
+		Input:          execCtx.Input,
+		Variables:      execCtx.Variables,
+		StepOutputs:    execCtx.CopyStepOutputs(),
+	}
+	maps.Copy(iterCtx.StepOutputs, scope)
+
+	ctx = withIteration(ctx, step.ID, index)
+	defer func() {
+		for _, executed := range iterCtx.CopyExecutedSteps() {
+			executed.Scope = maps.Clone(scope)
+			if executed.Iteration == "" {
+				executed.Iteration = iteration(ctx)
+			}
+			execCtx.AppendExecutedStep(executed)
+		}
+	}()
+
+	nested := step.Foreach.Steps
+	outputs := make(map[string]any, len(nested))
+
+	for i := range nested {
+		result, err := e.ExecuteStep(ctx, &nested[i], iterCtx, wf)
+		if err != nil {
+			return nil, err
+		}
+
+		var output any
+		if result != nil {
+			output = result.Output
+		}
+
+		key := nested[i].Output
+		if key != "" {
+			iterCtx.SetStepOutput(key, output)
+			scope[key] = output
+		} else {
+			key = nested[i].ID
+		}
+		outputs[key] = output
+
+		if nested[i].Compensate != nil && !result.Recovered() {
+			iterCtx.AppendExecutedStep(domain.NewExecutedStep(&nested[i], output))
+		}
+	}
+
+	if len(nested) == 1 {
+		for _, output := range outputs {
+			return output, nil
+		}
+	}
+	return outputs, nil
+}
+
+func (e *Executor) resolveItems(tmpl string, data map[string]any) ([]any, error) {
+	name, _ := data[templateEngineKey].(templateEngine)
+	value, ok := templating.Value(string(name), tmpl, data)
+	if !ok {
+		rendered, err := e.resolveTemplate(tmpl, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve foreach items: %w", err)
+		}
+		if err := json.Unmarshal([]byte(strings.TrimSpace(rendered)), &value); err != nil {
+			return nil, fmt.Errorf("foreach items must resolve to a list, got %q", rendered)
+		}
+	}
+
+	if value == nil {
+		return nil, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("foreach items must resolve to a list, got %T", value)
+	}
+
+	items := make([]any, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items, nil
+}
@@ -0,0 +1,39 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+)
+
+func (e *Executor) SetConcurrencyGroup(name string, limit int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if current, ok := e.groups[name]; ok && cap(current) == limit {
+		return
+	}
+
+	e.groups[name] = make(chan struct{}, limit)
+
+	e.logger.Debug().
+		Str("group", name).
+		Int("limit", limit).
+		Msg("Concurrency group configured")
+}
+
+func (e *Executor) acquireConcurrencyGroup(ctx context.Context, name string) (func(), error) {
+	e.mu.Lock()
+	slots, ok := e.groups[name]
+	e.mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("concurrency group %s is not defined", name)
+	}
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
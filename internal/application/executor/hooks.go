@@ -0,0 +1,137 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+func (e *Executor) runBeforeHooks(
+	ctx context.Context,
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+	wf *domain.Workflow,
+) error {
+	templateData := hookTemplateData(step, execCtx, nil, nil)
+
+	for i := range wf.BeforeEach {
+		if err := e.runHook(ctx, &wf.BeforeEach[i], step, templateData, "before"); err != nil {
+			return fmt.Errorf("before_each hook %d failed: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (e *Executor) runAfterHooks(
+	ctx context.Context,
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+	wf *domain.Workflow,
+	result *domain.StepResult,
+	stepErr error,
+) {
+	templateData := hookTemplateData(step, execCtx, result, stepErr)
+
+	for i := range wf.AfterEach {
+		if err := e.runHook(ctx, &wf.AfterEach[i], step, templateData, "after"); err != nil {
+			e.logger.Warn().
+				Err(err).
+				Str("workflow_id", execCtx.WorkflowID).
+				Str("step_id", step.ID).
+				Int("hook", i).
+				Msg("after_each hook failed")
+		}
+	}
+}
+
+func (e *Executor) runHook(
+	ctx context.Context,
+	hook *domain.Hook,
+	step *domain.Step,
+	templateData map[string]any,
+	phase string,
+) error {
+	input, err := e.resolveInput(hook.Input, templateData)
+	if err != nil {
+		return fmt.Errorf("failed to resolve hook input: %w", err)
+	}
+
+	workflowID := GetWorkflowID(ctx)
+
+	if len(hook.Command) > 0 {
+		return runHookCommand(ctx, hook.Command, workflowID, step.ID, phase, input)
+	}
+
+	_, err = e.client.InvokeMethod(
+		ctx,
+		e.serviceName(ctx, hook.Service),
+		hook.Method,
+		input,
+		workflowID,
+		step.ID+"_"+phase,
+	)
+	return err
+}
+
+// runHookCommand runs a command hook with the resolved hook input as JSON on
+// stdin and the workflow, step and phase in its environment. Only PATH and
+// HOME are passed on from the server's environment.
+func runHookCommand(ctx context.Context, argv []string, workflowID, stepID, phase string, input map[string]any) error {
+	encoded, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("failed to encode hook input: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Stdin = bytes.NewReader(encoded)
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + os.Getenv("HOME"),
+		"MAESTRO_WORKFLOW_ID=" + workflowID,
+		"MAESTRO_STEP_ID=" + stepID,
+		"MAESTRO_HOOK_PHASE=" + phase,
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("hook command failed: %w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return nil
+}
+
+func hookTemplateData(
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+	result *domain.StepResult,
+	stepErr error,
+) map[string]any {
+	hook := map[string]any{
+		"step": map[string]any{
+			"id":      step.ID,
+			"service": step.Service,
+			"method":  step.Method,
+		},
+	}
+
+	if result != nil || stepErr != nil {
+		stepResult := map[string]any{"success": stepErr == nil}
+		if result != nil {
+			stepResult["output"] = result.Output
+		}
+		if stepErr != nil {
+			stepResult["error"] = stepErr.Error()
+		}
+		hook["result"] = stepResult
+	}
+
+	templateData := buildTemplateData(execCtx)
+	templateData["hook"] = hook
+	return templateData
+}
@@ -0,0 +1,48 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/google/uuid"
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	"github.com/maestro/maestro.go/internal/infrastructure/grpc"
+)
+
+// Compensations hash into a namespace of their own, so no step ID can
+// produce the key of another step's compensation.
+var (
+	stepIdempotencyNamespace         = uuid.NewSHA1(uuid.NameSpaceURL, []byte("maestro/steps"))
+	compensationIdempotencyNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("maestro/compensations"))
+)
+
+func withIteration(ctx context.Context, stepID string, index int) context.Context {
+	return context.WithValue(ctx, ctxkeys.Iteration, fmt.Sprintf("%s%s[%d]/", iteration(ctx), stepID, index))
+}
+
+func iteration(ctx context.Context) string {
+	path, _ := ctx.Value(ctxkeys.Iteration).(string)
+	return path
+}
+
+func stepIdempotencyKey(workflowID, path string) string {
+	return uuid.NewSHA1(stepIdempotencyNamespace, []byte(workflowID+"/"+path)).String()
+}
+
+func compensationIdempotencyKey(workflowID, path string) string {
+	return uuid.NewSHA1(compensationIdempotencyNamespace, []byte(workflowID+"/"+path)).String()
+}
+
+func withIdempotencyKey(headers map[string]string, key string) map[string]string {
+	if _, ok := headers[grpc.IdempotencyKeyHeader]; ok {
+		return headers
+	}
+
+	headers = maps.Clone(headers)
+	if headers == nil {
+		headers = make(map[string]string, 1)
+	}
+	headers[grpc.IdempotencyKeyHeader] = key
+	return headers
+}
@@ -0,0 +1,71 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/grpc"
+)
+
+func (e *Executor) invokeService(
+	ctx context.Context,
+	step *domain.Step,
+	input map[string]any,
+	messages []map[string]any,
+	opts grpc.CallOptions,
+	method string,
+	workflowID string,
+) (any, error) {
+	serviceName := e.serviceName(ctx, step.Service)
+
+	switch {
+	case step.Stream:
+		return e.client.InvokeServerStream(ctx, serviceName, method, input, workflowID, step.ID, opts)
+	case step.StreamInput != "":
+		return e.client.InvokeClientStream(ctx, serviceName, method, messages, workflowID, step.ID, opts)
+	default:
+		return e.client.Call(ctx, serviceName, method, input, workflowID, step.ID, opts)
+	}
+}
+
+// resolveMethod renders a templated method. SQL methods are returned as
+// written: they are statement text or a query name, and values reach them
+// only through bound :name parameters.
+func (e *Executor) resolveMethod(step *domain.Step, service domain.Service, execCtx *domain.ExecutionContext) (string, error) {
+	if service.Type == "sql" || !strings.Contains(step.Method, "{{") {
+		return step.Method, nil
+	}
+
+	method, err := e.resolveTemplate(step.Method, buildTemplateData(execCtx))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve method: %w", templateFailure(err, "method"))
+	}
+	return method, nil
+}
+
+func (e *Executor) callOptions(step *domain.Step, execCtx *domain.ExecutionContext) (grpc.CallOptions, error) {
+	opts := grpc.CallOptions{FullResponse: step.HTTPResponse}
+	if len(step.Headers) == 0 && step.Key == "" {
+		return opts, nil
+	}
+
+	data := buildTemplateData(execCtx)
+	if step.Key != "" {
+		key, err := e.resolveTemplate(step.Key, data)
+		if err != nil {
+			return opts, fmt.Errorf("failed to resolve key: %w", templateFailure(err, "key"))
+		}
+		opts.Key = key
+	}
+	opts.Headers = make(map[string]string, len(step.Headers))
+	for name, tmpl := range step.Headers {
+		value, err := e.resolveTemplate(tmpl, data)
+		if err != nil {
+			return opts, fmt.Errorf("failed to resolve header %s: %w", name, templateFailure(err, "headers."+name))
+		}
+		opts.Headers[name] = value
+	}
+	return opts, nil
+}
@@ -0,0 +1,110 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/kv"
+)
+
+func (e *Executor) executeKVStep(
+	ctx context.Context,
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+) (*domain.StepResult, error) {
+	if e.kv == nil {
+		return nil, fmt.Errorf("step %s: no kv store configured", step.ID)
+	}
+
+	config := step.KV
+	templateData := buildTemplateData(execCtx)
+
+	key := config.Key
+	if domain.ContainsTemplate(key) {
+		resolved, err := e.resolveTemplate(key, templateData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve kv key: %w", err)
+		}
+		key = resolved
+	}
+
+	namespace := domain.ScopedKVNamespace(domain.KVScope(execCtx.Namespace, execCtx.WorkflowName), config.Namespace)
+
+	var output any
+	switch config.Op {
+	case domain.KVOpGet:
+		value, found, err := e.kv.Get(ctx, namespace, key)
+		if err != nil {
+			return nil, fmt.Errorf("kv get %s/%s failed: %w", config.Namespace, key, err)
+		}
+		output = map[string]any{"key": key, "value": value, "found": found}
+
+	case domain.KVOpSet:
+		value := config.Value
+		if strVal, ok := value.(string); ok && domain.ContainsTemplate(strVal) {
+			resolved, err := e.resolveTemplate(strVal, templateData)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve kv value: %w", err)
+			}
+			value = resolved
+		}
+		if err := e.kv.Set(ctx, namespace, key, value, config.TTL.Duration); err != nil {
+			return nil, fmt.Errorf("kv set %s/%s failed: %w", config.Namespace, key, err)
+		}
+		output = map[string]any{"key": key, "value": value}
+
+	case domain.KVOpDelete:
+		if err := e.kv.Delete(ctx, namespace, key); err != nil {
+			return nil, fmt.Errorf("kv delete %s/%s failed: %w", config.Namespace, key, err)
+		}
+		output = map[string]any{"key": key}
+
+	case domain.KVOpIncrement:
+		delta := config.Delta
+		if delta == 0 {
+			delta = 1
+		}
+		value, err := e.kv.Increment(ctx, namespace, key, delta, config.TTL.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("kv incr %s/%s failed: %w", config.Namespace, key, err)
+		}
+		output = map[string]any{"key": key, "value": value}
+
+	default:
+		return nil, fmt.Errorf("step %s: unknown kv operation %s", step.ID, config.Op)
+	}
+
+	e.logger.Debug().
+		Str("workflow_id", execCtx.WorkflowID).
+		Str("step_id", step.ID).
+		Str("op", config.Op).
+		Str("namespace", config.Namespace).
+		Str("key", key).
+		Msg("KV operation executed")
+
+	return &domain.StepResult{
+		StepID: step.ID,
+		Output: output,
+	}, nil
+}
+
+func (e *Executor) kvGet(scope kvScope, namespace, key string) (any, error) {
+	if e.kv == nil {
+		return nil, fmt.Errorf("no kv store configured")
+	}
+	if scope == "" {
+		return nil, fmt.Errorf("kv is not available in this template")
+	}
+	value, _, err := e.kv.Get(context.Background(), domain.ScopedKVNamespace(string(scope), namespace), key)
+	return value, err
+}
+
+func (e *Executor) kvCounter(scope kvScope, namespace, key string) (int64, error) {
+	value, err := e.kvGet(scope, namespace, key)
+	if err != nil {
+		return 0, err
+	}
+	counter, _ := kv.Counter(value)
+	return counter, nil
+}
@@ -0,0 +1,34 @@
+package executor
+
+func (e *Executor) FlushCancellations() {
+	e.client.FlushCancellations()
+}
+
+func (e *Executor) SetWorkerPoolSize(size int) {
+	if size <= 0 {
+		return
+	}
+
+	e.workerPool.Resize(size)
+}
+
+func (e *Executor) SetCompensationPoolSize(size int) {
+	if size < 0 {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	switch {
+	case size == 0:
+		e.compPool = nil
+	case cap(e.compPool) != size:
+		e.compPool = make(chan struct{}, size)
+	}
+}
+
+func (e *Executor) compensationSlots() chan struct{} {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.compPool
+}
@@ -0,0 +1,237 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+const (
+	defaultLockTTL      = time.Minute
+	lockPollingInterval = 100 * time.Millisecond
+)
+
+func (e *Executor) executeLockStep(
+	ctx context.Context,
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+) (*domain.StepResult, error) {
+	if e.locks == nil {
+		return nil, fmt.Errorf("step %s: no lock manager configured", step.ID)
+	}
+
+	templateData := buildTemplateData(execCtx)
+
+	if step.AcquireLock != nil {
+		key, err := e.resolveLockKey(step.AcquireLock, templateData)
+		if err != nil {
+			return nil, err
+		}
+		token, err := e.acquireLock(ctx, key, step.AcquireLock, execCtx)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.StepResult{
+			StepID: step.ID,
+			Output: map[string]any{"key": key, "acquired": true, "fencing_token": token},
+		}, nil
+	}
+
+	key, err := e.resolveLockKey(step.ReleaseLock, templateData)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.releaseLock(ctx, key, execCtx); err != nil {
+		return nil, err
+	}
+	return &domain.StepResult{
+		StepID: step.ID,
+		Output: map[string]any{"key": key, "released": true},
+	}, nil
+}
+
+func (e *Executor) resolveLockKey(config *domain.LockConfig, templateData map[string]any) (string, error) {
+	if !domain.ContainsTemplate(config.Key) {
+		return config.Key, nil
+	}
+
+	key, err := e.resolveTemplate(config.Key, templateData)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve lock key: %w", err)
+	}
+	return key, nil
+}
+
+func (e *Executor) acquireLock(
+	ctx context.Context,
+	key string,
+	config *domain.LockConfig,
+	execCtx *domain.ExecutionContext,
+) (int64, error) {
+	ttl := config.TTL.Duration
+	if ttl <= 0 {
+		ttl = defaultLockTTL
+	}
+
+	deadline := time.Now().Add(config.Wait.Duration)
+	for {
+		token, acquired, err := e.locks.Acquire(ctx, key, execCtx.WorkflowID, ttl)
+		if err != nil {
+			return 0, fmt.Errorf("failed to acquire lock %s: %w", key, err)
+		}
+
+		if acquired {
+			e.mu.Lock()
+			if !slices.Contains(execCtx.HeldLocks, key) {
+				execCtx.HeldLocks = append(execCtx.HeldLocks, key)
+			}
+			e.mu.Unlock()
+			e.renewLock(key, execCtx.WorkflowID, token, ttl)
+
+			e.logger.Debug().
+				Str("workflow_id", execCtx.WorkflowID).
+				Str("lock", key).
+				Int64("fencing_token", token).
+				Dur("ttl", ttl).
+				Msg("Lock acquired")
+			return token, nil
+		}
+
+		if time.Now().After(deadline) {
+			return 0, fmt.Errorf("lock %s is held by another execution", key)
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(lockPollingInterval):
+		}
+	}
+}
+
+func (e *Executor) renewLock(key, owner string, token int64, ttl time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	e.mu.Lock()
+	if stop, ok := e.renewals[owner+"/"+key]; ok {
+		stop()
+	}
+	e.renewals[owner+"/"+key] = cancel
+	e.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			err := e.locks.Renew(ctx, key, owner, token, ttl)
+			switch {
+			case err == nil, ctx.Err() != nil:
+			case errors.Is(err, domain.ErrLockLost):
+				e.logger.Error().
+					Err(err).
+					Str("workflow_id", owner).
+					Str("lock", key).
+					Int64("fencing_token", token).
+					Msg("Lock lost, another execution may hold it now")
+				return
+			default:
+				e.logger.Warn().
+					Err(err).
+					Str("workflow_id", owner).
+					Str("lock", key).
+					Msg("Failed to renew lock")
+			}
+		}
+	}()
+}
+
+func (e *Executor) stopRenewal(key, owner string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if stop, ok := e.renewals[owner+"/"+key]; ok {
+		stop()
+		delete(e.renewals, owner+"/"+key)
+	}
+}
+
+func (e *Executor) ResumeLocks(ctx context.Context, execCtx *domain.ExecutionContext) {
+	if e.locks == nil {
+		return
+	}
+
+	e.mu.Lock()
+	held := slices.Clone(execCtx.HeldLocks)
+	e.mu.Unlock()
+
+	for _, key := range held {
+		token, acquired, err := e.locks.Acquire(ctx, key, execCtx.WorkflowID, defaultLockTTL)
+		if err != nil || !acquired {
+			e.logger.Error().
+				Err(err).
+				Str("workflow_id", execCtx.WorkflowID).
+				Str("lock", key).
+				Msg("Failed to reacquire lock on resume")
+			continue
+		}
+		e.renewLock(key, execCtx.WorkflowID, token, defaultLockTTL)
+	}
+}
+
+func (e *Executor) StopLockRenewals(execCtx *domain.ExecutionContext) {
+	e.mu.Lock()
+	held := slices.Clone(execCtx.HeldLocks)
+	e.mu.Unlock()
+
+	for _, key := range held {
+		e.stopRenewal(key, execCtx.WorkflowID)
+	}
+}
+
+func (e *Executor) releaseLock(ctx context.Context, key string, execCtx *domain.ExecutionContext) error {
+	e.stopRenewal(key, execCtx.WorkflowID)
+	if err := e.locks.Release(ctx, key, execCtx.WorkflowID); err != nil {
+		return fmt.Errorf("failed to release lock %s: %w", key, err)
+	}
+
+	e.mu.Lock()
+	execCtx.HeldLocks = slices.DeleteFunc(execCtx.HeldLocks, func(held string) bool {
+		return held == key
+	})
+	e.mu.Unlock()
+
+	e.logger.Debug().
+		Str("workflow_id", execCtx.WorkflowID).
+		Str("lock", key).
+		Msg("Lock released")
+	return nil
+}
+
+func (e *Executor) ReleaseLocks(ctx context.Context, execCtx *domain.ExecutionContext) {
+	if e.locks == nil {
+		return
+	}
+
+	e.mu.Lock()
+	held := slices.Clone(execCtx.HeldLocks)
+	e.mu.Unlock()
+
+	for _, key := range held {
+		if err := e.releaseLock(ctx, key, execCtx); err != nil {
+			e.logger.Warn().
+				Err(err).
+				Str("workflow_id", execCtx.WorkflowID).
+				Str("lock", key).
+				Msg("Failed to release lock")
+		}
+	}
+}
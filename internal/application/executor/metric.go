@@ -0,0 +1,72 @@
+package executor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+func (e *Executor) emitMetric(step *domain.Step, execCtx *domain.ExecutionContext, result *domain.StepResult) {
+	if e.metrics == nil {
+		return
+	}
+
+	logger := e.logger.With().
+		Str("step_id", step.ID).
+		Str("metric", step.EmitMetric.Name).
+		Logger()
+
+	value, labels, err := e.resolveMetric(step, execCtx, result)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to resolve metric")
+		return
+	}
+
+	if err := e.metrics.Record(step.EmitMetric, value, labels); err != nil {
+		logger.Warn().Err(err).Msg("Failed to record metric")
+		return
+	}
+
+	logger.Debug().
+		Float64("value", value).
+		Interface("labels", labels).
+		Msg("Metric recorded")
+}
+
+func (e *Executor) resolveMetric(
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+	result *domain.StepResult,
+) (float64, map[string]string, error) {
+	templateData := buildTemplateData(execCtx)
+	if result != nil && step.Output != "" {
+		templateData[step.Output] = result.Output
+	}
+
+	labels, err := e.resolveStringMap(step.EmitMetric.Labels, templateData)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to resolve labels: %w", err)
+	}
+
+	rawValue := step.EmitMetric.Value
+	if rawValue == "" {
+		return 1, labels, nil
+	}
+
+	if domain.IsTemplate(rawValue) {
+		resolved, err := e.resolveTemplate(rawValue, templateData)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to resolve value: %w", err)
+		}
+		rawValue = resolved
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(rawValue), 64)
+	if err != nil {
+		return 0, nil, fmt.Errorf("metric value %q is not a number: %w", rawValue, err)
+	}
+
+	return value, labels, nil
+}
@@ -0,0 +1,31 @@
+package executor
+
+import (
+	"context"
+	"maps"
+
+	"github.com/maestro/maestro.go/internal/ports"
+)
+
+func (e *Executor) Use(middleware ...ports.StepMiddleware) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.middleware = append(e.middleware, middleware...)
+}
+
+func (e *Executor) invokeThrough(ctx context.Context, call *ports.StepCall, invoke ports.StepInvoker) (any, error) {
+	e.mu.Lock()
+	middleware := e.middleware
+	e.mu.Unlock()
+
+	call.Input = maps.Clone(call.Input)
+	call.Headers = maps.Clone(call.Headers)
+	if call.Headers == nil {
+		call.Headers = make(map[string]string)
+	}
+
+	for i := len(middleware) - 1; i >= 0; i-- {
+		invoke = middleware[i](invoke)
+	}
+	return invoke(ctx, call)
+}
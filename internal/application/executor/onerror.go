@@ -0,0 +1,51 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+func (e *Executor) recoverStep(
+	ctx context.Context,
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+	wf *domain.Workflow,
+	stepErr error,
+) (*domain.StepResult, error) {
+	if step.OnError == domain.OnErrorContinue {
+		e.logger.Warn().
+			Err(stepErr).
+			Str("workflow_id", execCtx.WorkflowID).
+			Str("step_id", step.ID).
+			Msg("Step failed, continuing")
+		return &domain.StepResult{StepID: step.ID, Error: stepErr}, nil
+	}
+
+	fallback := step.Fallback
+	e.logger.Warn().
+		Err(stepErr).
+		Str("workflow_id", execCtx.WorkflowID).
+		Str("step_id", step.ID).
+		Str("fallback", fallback.ID).
+		Msg("Step failed, running fallback")
+
+	result, err := e.ExecuteStep(ctx, fallback, execCtx, wf)
+	if err != nil {
+		return nil, fmt.Errorf("step %s failed: %w; fallback %s failed: %w", step.ID, stepErr, fallback.ID, err)
+	}
+
+	var output any
+	if result != nil {
+		output = result.Output
+	}
+	if fallback.Output != "" {
+		execCtx.SetStepOutput(fallback.Output, output)
+	}
+	if fallback.Compensate != nil && !result.Recovered() {
+		execCtx.AppendExecutedStep(domain.NewExecutedStep(fallback, output))
+	}
+
+	return &domain.StepResult{StepID: step.ID, Output: output, Fallback: fallback.ID}, nil
+}
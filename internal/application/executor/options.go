@@ -0,0 +1,73 @@
+package executor
+
+import (
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/metrics"
+	"github.com/maestro/maestro.go/internal/ports"
+)
+
+type Option func(*Executor)
+
+func WithMetrics(registry *metrics.Registry) Option {
+	return func(e *Executor) {
+		e.metrics = registry
+	}
+}
+
+func WithLockManager(locks ports.LockManager) Option {
+	return func(e *Executor) {
+		e.locks = locks
+	}
+}
+
+func WithKVStore(store ports.KVStore) Option {
+	return func(e *Executor) {
+		e.kv = store
+	}
+}
+
+func WithSecretProvider(secrets ports.SecretProvider) Option {
+	return func(e *Executor) {
+		e.secrets = secrets
+	}
+}
+
+func WithRedactor(redactor *domain.Redactor) Option {
+	return func(e *Executor) {
+		e.redactor = redactor
+	}
+}
+
+func WithWorkerPoolSize(size int) Option {
+	return func(e *Executor) {
+		if size > 0 {
+			e.workerPool = newWorkerPool(size)
+		}
+	}
+}
+
+func WithCompensationPoolSize(size int) Option {
+	return func(e *Executor) {
+		if size > 0 {
+			e.compPool = make(chan struct{}, size)
+		}
+	}
+}
+
+func WithWorkflowRunner(runner ports.WorkflowRunner) Option {
+	return func(e *Executor) {
+		e.workflows = runner
+	}
+}
+
+func WithEventSink(sink ports.EventSink) Option {
+	return func(e *Executor) {
+		e.events = sink
+	}
+}
+
+func WithStepMiddleware(middleware ...ports.StepMiddleware) Option {
+	return func(e *Executor) {
+		e.middleware = append(e.middleware, middleware...)
+	}
+}
@@ -0,0 +1,153 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type activeStep struct {
+	cancel    context.CancelFunc
+	decisions chan domain.StepOverride
+	held      bool
+}
+
+func (e *Executor) trackStep(ctx context.Context, workflowID, stepID string) (context.Context, *activeStep) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	key := signalKey(workflowID, stepID)
+	if _, exists := e.active[key]; exists {
+		return ctx, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	active := &activeStep{cancel: cancel, decisions: make(chan domain.StepOverride, 1)}
+	e.active[key] = active
+	return ctx, active
+}
+
+func (e *Executor) untrackStep(workflowID, stepID string, active *activeStep) (domain.StepOverride, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	delete(e.active, signalKey(workflowID, stepID))
+	active.cancel()
+
+	select {
+	case decision := <-active.decisions:
+		return decision, true
+	default:
+		return domain.StepOverride{}, false
+	}
+}
+
+func (e *Executor) OverrideStep(workflowID, stepID string, decision domain.StepOverride) (held bool, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	active, ok := e.active[signalKey(workflowID, stepID)]
+	if !ok {
+		return false, fmt.Errorf("step %s of workflow %s: %w", stepID, workflowID, domain.ErrStepNotActive)
+	}
+
+	select {
+	case active.decisions <- decision:
+	default:
+		return false, fmt.Errorf("an override is already pending for step %s of workflow %s", stepID, workflowID)
+	}
+	if !active.held {
+		active.cancel()
+	}
+	return active.held, nil
+}
+
+func (e *Executor) holdStep(
+	ctx context.Context,
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+	active *activeStep,
+	stepErr error,
+) (domain.StepOverride, bool) {
+	e.mu.Lock()
+	if len(active.decisions) > 0 {
+		e.mu.Unlock()
+		return domain.StepOverride{}, false
+	}
+	active.held = true
+	e.mu.Unlock()
+
+	e.logger.Warn().
+		Err(stepErr).
+		Str("workflow_id", execCtx.WorkflowID).
+		Str("step_id", step.ID).
+		Msg("Step failed, holding for operator override")
+	e.Emit(ctx, domain.Event{Type: domain.EventStepHeld, StepID: step.ID, Service: step.Service, Error: stepErr.Error()})
+
+	select {
+	case decision := <-active.decisions:
+		return decision, true
+	case <-ctx.Done():
+		return domain.StepOverride{}, false
+	}
+}
+
+func (e *Executor) applyOverride(
+	ctx context.Context,
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+	decision domain.StepOverride,
+	stepErr error,
+) (*domain.StepResult, error) {
+	entry := domain.AuditEntry{
+		Time:     time.Now(),
+		Action:   domain.AuditStepOverridden,
+		StepID:   step.ID,
+		Operator: decision.Operator,
+		Reason:   decision.Reason,
+		Output:   decision.Output,
+	}
+	if decision.Fail {
+		entry.Action = domain.AuditStepFailed
+		entry.Output = nil
+	}
+	execCtx.AppendAudit(entry)
+
+	logger := e.logger.Warn().
+		Str("workflow_id", execCtx.WorkflowID).
+		Str("step_id", step.ID).
+		Str("operator", decision.Operator).
+		Str("reason", decision.Reason)
+
+	if decision.Fail {
+		logger.Msg("Step failed by operator")
+		failed := &domain.StepFailedByOperatorError{StepID: step.ID, Operator: decision.Operator, Reason: decision.Reason}
+		if stepErr != nil {
+			return nil, fmt.Errorf("%w: %w", failed, stepErr)
+		}
+		return nil, failed
+	}
+
+	logger.Msg("Step output overridden by operator")
+	e.Emit(ctx, domain.Event{Type: domain.EventStepOverridden, StepID: step.ID, Service: step.Service})
+	return &domain.StepResult{StepID: step.ID, Output: decision.Output}, nil
+}
+
+func (e *Executor) HeldSteps(workflowID string) []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	prefix := workflowID + "/"
+	var held []string
+	for key, active := range e.active {
+		if active.held && strings.HasPrefix(key, prefix) {
+			held = append(held, strings.TrimPrefix(key, prefix))
+		}
+	}
+	sort.Strings(held)
+	return held
+}
@@ -30,17 +30,14 @@ func (e *Executor) executeParallelSteps(
 
 			mu.Lock()
 			results[idx] = result
+			mu.Unlock()
+
 			if step.Output != "" && result != nil {
-				execCtx.StepOutputs[step.Output] = result.Output
+				execCtx.SetStepOutput(step.Output, result.Output)
 			}
-			if step.Compensate != nil {
-				execCtx.ExecutedSteps = append(execCtx.ExecutedSteps, domain.ExecutedStep{
-					StepID:       step.ID,
-					Output:       result.Output,
-					Compensation: step.Compensate,
-				})
+			if step.Compensate != nil && !result.Recovered() {
+				execCtx.AppendExecutedStep(domain.NewExecutedStep(&step, result.Output))
 			}
-			mu.Unlock()
 
 			return nil
 		})
@@ -0,0 +1,113 @@
+package executor
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type workerPool struct {
+	mu       sync.Mutex
+	capacity int
+	used     int
+	slowUsed int
+	waiters  []*poolWaiter
+}
+
+type poolWaiter struct {
+	weight  int
+	slow    bool
+	granted bool
+	ready   chan struct{}
+}
+
+func newWorkerPool(capacity int) *workerPool {
+	return &workerPool{capacity: capacity}
+}
+
+func (p *workerPool) Resize(capacity int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.capacity = capacity
+	p.grant()
+}
+
+func (p *workerPool) Acquire(ctx context.Context, hints *domain.ResourceHints) (func(), error) {
+	weight, slow := hints.StepWeight(), hints.IsSlow()
+
+	p.mu.Lock()
+	w := &poolWaiter{weight: weight, slow: slow, ready: make(chan struct{})}
+	if p.fits(w) {
+		p.take(w)
+		p.mu.Unlock()
+		return p.releaser(w), nil
+	}
+	p.waiters = append(p.waiters, w)
+	p.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return p.releaser(w), nil
+	case <-ctx.Done():
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if w.granted {
+			p.release(w)
+		} else {
+			p.waiters = slices.DeleteFunc(p.waiters, func(other *poolWaiter) bool { return other == w })
+		}
+		return nil, ctx.Err()
+	}
+}
+
+func (p *workerPool) releaser(w *poolWaiter) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			p.release(w)
+		})
+	}
+}
+
+func (p *workerPool) fits(w *poolWaiter) bool {
+	weight := min(w.weight, p.capacity)
+	if p.used+weight > p.capacity {
+		return false
+	}
+	if w.slow && p.slowUsed > 0 && p.slowUsed+weight > max(p.capacity/2, 1) {
+		return false
+	}
+	return true
+}
+
+func (p *workerPool) take(w *poolWaiter) {
+	w.weight = min(w.weight, p.capacity)
+	p.used += w.weight
+	if w.slow {
+		p.slowUsed += w.weight
+	}
+	w.granted = true
+}
+
+func (p *workerPool) release(w *poolWaiter) {
+	p.used -= w.weight
+	if w.slow {
+		p.slowUsed -= w.weight
+	}
+	p.grant()
+}
+
+func (p *workerPool) grant() {
+	p.waiters = slices.DeleteFunc(p.waiters, func(w *poolWaiter) bool {
+		if !p.fits(w) {
+			return false
+		}
+		p.take(w)
+		close(w.ready)
+		return true
+	})
+}
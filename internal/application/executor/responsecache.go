@@ -0,0 +1,111 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"sync"
+	"time"
+
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+const (
+	maxCachedResponses = 10000
+
+	staleMarker    = "_stale"
+	cachedAtMarker = "_cached_at"
+)
+
+type cachedResponse struct {
+	output   any
+	storedAt time.Time
+}
+
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedResponse
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{entries: make(map[string]cachedResponse)}
+}
+
+func (c *responseCache) store(key string, output any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxCachedResponses {
+		c.evictOldest()
+	}
+	c.entries[key] = cachedResponse{output: output, storedAt: time.Now()}
+}
+
+func (c *responseCache) load(key string, maxAge time.Duration) (cachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return cachedResponse{}, false
+	}
+	if maxAge > 0 && time.Since(entry.storedAt) > maxAge {
+		delete(c.entries, key)
+		return cachedResponse{}, false
+	}
+	return entry, true
+}
+
+func (c *responseCache) evictOldest() {
+	var oldestKey string
+	var oldest time.Time
+	for key, entry := range c.entries {
+		if oldestKey == "" || entry.storedAt.Before(oldest) {
+			oldestKey, oldest = key, entry.storedAt
+		}
+	}
+	delete(c.entries, oldestKey)
+}
+
+func responseCacheKey(namespace, service, method string, input map[string]any) (string, bool) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return "", false
+	}
+	return namespace + "\x00" + service + "\x00" + method + "\x00" + string(data), true
+}
+
+func (e *Executor) cacheResponse(ctx context.Context, step *domain.Step, key string, output any) {
+	if step.CachedFallback == nil || key == "" {
+		return
+	}
+	if _, ok := ctx.Value(ctxkeys.Shadow).(*domain.ShadowRun); ok {
+		return
+	}
+	e.responses.store(key, output)
+}
+
+func (e *Executor) cachedFallback(step *domain.Step, key string) (map[string]any, bool) {
+	if step.CachedFallback == nil || key == "" {
+		return nil, false
+	}
+
+	entry, ok := e.responses.load(key, step.CachedFallback.MaxAge.Duration)
+	if !ok {
+		return nil, false
+	}
+	return staleOutput(entry), true
+}
+
+func staleOutput(entry cachedResponse) map[string]any {
+	output, ok := entry.output.(map[string]any)
+	if ok {
+		output = maps.Clone(output)
+	} else {
+		output = map[string]any{"value": entry.output}
+	}
+	output[staleMarker] = true
+	output[cachedAtMarker] = entry.storedAt.UTC().Format(time.RFC3339)
+	return output
+}
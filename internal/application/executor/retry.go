@@ -1,20 +1,44 @@
 package executor
 
 import (
+	"math/rand/v2"
 	"time"
 
 	"github.com/maestro/maestro.go/internal/domain"
 )
 
+const (
+	baseRetryDelay = time.Second
+	maxRetryDelay  = 30 * time.Second
+)
+
+func retryPolicy(step *domain.Step, service *domain.Service) *domain.RetryConfig {
+	if step.Retry != nil {
+		return step.Retry
+	}
+	return service.Retry
+}
+
 func (e *Executor) calculateBackoff(attempt int, retry *domain.RetryConfig) time.Duration {
-	if retry == nil || retry.Backoff != "exponential" {
-		return time.Second
+	if retry == nil {
+		return baseRetryDelay
 	}
 
-	baseDelay := time.Second
-	maxDelay := 30 * time.Second
-	delay := baseDelay * time.Duration(1<<uint(attempt))
+	maxDelay := maxRetryDelay
+	if retry.MaxDelay.Duration > 0 {
+		maxDelay = retry.MaxDelay.Duration
+	}
 
-	return min(delay, maxDelay)
-}
+	delay := baseRetryDelay
+	if retry.Backoff == domain.BackoffExponential {
+		delay = baseRetryDelay * time.Duration(1<<uint(min(attempt, 30)))
+	}
+	delay = min(delay, maxDelay)
 
+	if retry.Jitter > 0 {
+		spread := float64(delay) * min(retry.Jitter, 1)
+		delay = time.Duration(float64(delay) - spread + rand.Float64()*spread)
+	}
+
+	return delay
+}
@@ -0,0 +1,58 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	secretsRoot    = "secrets"
+	secretsTimeout = 10 * time.Second
+)
+
+var secretRef = regexp.MustCompile(`(?:^|[^\w.])\.?secrets\.([A-Za-z_]\w*)`)
+
+func secretRefs(tmpl string) []string {
+	var names []string
+	for rest := tmpl; ; {
+		start := strings.Index(rest, "{{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(rest[start:], "}}")
+		if end < 0 {
+			break
+		}
+		for _, match := range secretRef.FindAllStringSubmatch(rest[start+2:start+end], -1) {
+			names = append(names, match[1])
+		}
+		rest = rest[start+end+2:]
+	}
+
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+func (e *Executor) resolveSecrets(names []string) (map[string]any, error) {
+	if e.secrets == nil {
+		return nil, fmt.Errorf("no secret provider configured")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), secretsTimeout)
+	defer cancel()
+
+	secrets := make(map[string]any, len(names))
+	for _, name := range names {
+		value, err := e.secrets.GetSecret(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		e.redactor.AddSecret(value)
+		secrets[name] = value
+	}
+	return secrets, nil
+}
@@ -0,0 +1,55 @@
+package executor
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSecretRefs(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+		want []string
+	}{
+		{
+			name: "dotted reference",
+			tmpl: "Bearer {{ .secrets.api_token }}",
+			want: []string{"api_token"},
+		},
+		{
+			name: "reference without leading dot",
+			tmpl: "{{ secrets.db_password }}",
+			want: []string{"db_password"},
+		},
+		{
+			name: "several references are sorted and deduplicated",
+			tmpl: "{{ .secrets.b }}:{{ .secrets.a }}@{{ printf \"%s\" .secrets.b }}",
+			want: []string{"a", "b"},
+		},
+		{
+			name: "longer identifier ending in secrets",
+			tmpl: "{{ .mysecrets.x }}",
+		},
+		{
+			name: "nested field named secrets",
+			tmpl: "{{ .input.secrets.x }}",
+		},
+		{
+			name: "outside of an action",
+			tmpl: "secrets.x",
+		},
+		{
+			name: "unterminated action",
+			tmpl: "{{ .secrets.x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := secretRefs(tt.tmpl)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("secretRefs(%q) = %v, want %v", tt.tmpl, got, tt.want)
+			}
+		})
+	}
+}
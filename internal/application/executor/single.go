@@ -6,6 +6,9 @@ import (
 	"time"
 
 	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/grpc"
+	"github.com/maestro/maestro.go/internal/infrastructure/tracing"
+	"github.com/maestro/maestro.go/internal/ports"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -16,16 +19,34 @@ func (e *Executor) executeSingleStep(
 	execCtx *domain.ExecutionContext,
 	wf *domain.Workflow,
 ) (*domain.StepResult, error) {
-	e.workerPool <- struct{}{}
-	defer func() { <-e.workerPool }()
+	clock := stepClock(ctx)
+	waitStart := time.Now()
+
+	if step.ConcurrencyGroup != "" {
+		release, err := e.acquireConcurrencyGroup(ctx, step.ConcurrencyGroup)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
+	release, err := e.workerPool.Acquire(ctx, step.Resources)
+	clock.AddSlotWait(time.Since(waitStart))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 
 	workflowID := GetWorkflowID(ctx)
-	logger := e.logger.With().
+	loggerCtx := e.logger.With().
 		Str("workflow_id", workflowID).
 		Str("step_id", step.ID).
 		Str("service", step.Service).
-		Str("method", step.Method).
-		Logger()
+		Str("method", step.Method)
+	if len(execCtx.Tags) > 0 {
+		loggerCtx = loggerCtx.Interface("tags", execCtx.Tags)
+	}
+	logger := loggerCtx.Logger()
 
 	logger.Info().Msg("Executing step")
 	startTime := time.Now()
@@ -40,39 +61,81 @@ func (e *Executor) executeSingleStep(
 		return nil, fmt.Errorf("failed to resolve input: %w", err)
 	}
 
+	if err := e.coerceInput(e.serviceName(ctx, step.Service), step, resolvedInput); err != nil {
+		return nil, err
+	}
+
+	messages, err := e.resolveStreamMessages(step, resolvedInput, execCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := e.callOptions(step, execCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	method, err := e.resolveMethod(step, service, execCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Headers = withIdempotencyKey(opts.Headers, stepIdempotencyKey(workflowID, iteration(ctx)+step.ID))
+
+	var cacheKey string
+	if step.CachedFallback != nil {
+		cacheKey, _ = responseCacheKey(execCtx.Namespace, e.serviceName(ctx, step.Service), method, resolvedInput)
+	}
+
 	var result any
 	var execErr error
 
+	retry := retryPolicy(step, &service)
 	retryAttempts := 1
-	if service.Retry != nil && service.Retry.Attempts > 1 {
-		retryAttempts = service.Retry.Attempts
+	if retry != nil && retry.Attempts > 1 {
+		retryAttempts = retry.Attempts
 	}
 
 	for attempt := 1; attempt <= retryAttempts; attempt++ {
 		if attempt > 1 {
-			backoffDuration := e.calculateBackoff(attempt-1, service.Retry)
+			backoffDuration := e.calculateBackoff(attempt-1, retry)
 			logger.Warn().
 				Int("attempt", attempt).
 				Dur("backoff", backoffDuration).
 				Msg("Retrying step after backoff")
-			time.Sleep(backoffDuration)
+			recordRetry(ctx, attempt, backoffDuration)
+
+			select {
+			case <-time.After(backoffDuration):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
 		}
 
-		stepCtx := ctx
+		stepCtx, attemptSpan := startAttemptSpan(ctx, "step "+step.ID, attempt, retryAttempts)
 		if service.Timeout.Duration > 0 {
 			var cancel context.CancelFunc
-			stepCtx, cancel = context.WithTimeout(ctx, service.Timeout.Duration)
+			stepCtx, cancel = context.WithTimeout(stepCtx, service.Timeout.Duration)
 			defer cancel()
 		}
 
-		result, execErr = e.client.InvokeMethod(
-			stepCtx,
-			step.Service,
-			step.Method,
-			resolvedInput,
-			workflowID,
-			step.ID,
-		)
+		invokeStart := time.Now()
+		call := &ports.StepCall{
+			WorkflowID: workflowID,
+			StepID:     step.ID,
+			Service:    step.Service,
+			Method:     method,
+			Attempt:    attempt,
+			Input:      resolvedInput,
+			Headers:    opts.Headers,
+		}
+		result, execErr = e.invokeThrough(stepCtx, call, func(ctx context.Context, call *ports.StepCall) (any, error) {
+			callOpts := opts
+			callOpts.Headers = call.Headers
+			return e.invokeService(ctx, step, call.Input, messages, callOpts, call.Method, call.WorkflowID)
+		})
+		clock.AddServiceTime(time.Since(invokeStart))
+		tracing.End(attemptSpan, execErr)
 
 		if execErr == nil {
 			break
@@ -91,6 +154,19 @@ func (e *Executor) executeSingleStep(
 		}
 	}
 
+	if execErr != nil && grpc.IsCircuitOpen(execErr) {
+		if stale, ok := e.cachedFallback(step, cacheKey); ok {
+			logger.Warn().
+				Err(execErr).
+				Interface("cached_at", stale[cachedAtMarker]).
+				Msg("Circuit breaker open, serving cached response")
+			return &domain.StepResult{
+				StepID: step.ID,
+				Output: stale,
+			}, nil
+		}
+	}
+
 	if execErr != nil {
 		logger.Error().
 			Err(execErr).
@@ -99,9 +175,11 @@ func (e *Executor) executeSingleStep(
 		return nil, execErr
 	}
 
+	e.cacheResponse(ctx, step, cacheKey, result)
+
 	logger.Info().
 		Dur("duration", time.Since(startTime)).
-		Interface("output", result).
+		Interface("output", redactor(ctx).Output(step.Output, result)).
 		Msg("Step executed successfully")
 
 	return &domain.StepResult{
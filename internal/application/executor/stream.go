@@ -0,0 +1,38 @@
+package executor
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+func (e *Executor) resolveStreamMessages(
+	step *domain.Step,
+	input map[string]any,
+	execCtx *domain.ExecutionContext,
+) ([]map[string]any, error) {
+	if step.StreamInput == "" {
+		return nil, nil
+	}
+
+	items, err := e.resolveItems(step.StreamInput, buildTemplateData(execCtx))
+	if err != nil {
+		return nil, fmt.Errorf("stream_input: %w", err)
+	}
+
+	messages := make([]map[string]any, len(items))
+	for i, item := range items {
+		message := maps.Clone(input)
+		if message == nil {
+			message = make(map[string]any)
+		}
+		if fields, ok := item.(map[string]any); ok {
+			maps.Copy(message, fields)
+		} else {
+			message["item"] = item
+		}
+		messages[i] = message
+	}
+	return messages, nil
+}
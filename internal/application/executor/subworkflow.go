@@ -0,0 +1,68 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+func (e *Executor) executeSubWorkflow(
+	ctx context.Context,
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+) (*domain.StepResult, error) {
+	if e.workflows == nil {
+		return nil, fmt.Errorf("step %s: sub-workflows are not supported by this executor", step.ID)
+	}
+
+	input, err := e.resolveStepInput(step, execCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve input: %w", err)
+	}
+
+	logger := e.logger.With().
+		Str("workflow_id", execCtx.WorkflowID).
+		Str("step_id", step.ID).
+		Str("sub_workflow", step.Workflow).
+		Logger()
+
+	logger.Info().Msg("Executing sub-workflow")
+
+	childID, output, err := e.workflows.RunWorkflow(ctx, step.Workflow, input)
+	if err != nil {
+		return nil, fmt.Errorf("sub-workflow %s failed: %w", step.Workflow, err)
+	}
+
+	executed := domain.NewExecutedStep(step, output)
+	executed.SubWorkflowID = childID
+	execCtx.AppendExecutedStep(executed)
+
+	logger.Info().
+		Str("sub_workflow_id", childID).
+		Msg("Sub-workflow completed")
+
+	return &domain.StepResult{
+		StepID: step.ID,
+		Output: output,
+	}, nil
+}
+
+func (e *Executor) compensateSubWorkflow(ctx context.Context, step *domain.ExecutedStep, execCtx *domain.ExecutionContext) error {
+	if e.workflows == nil {
+		return fmt.Errorf("sub-workflows are not supported by this executor")
+	}
+
+	e.logger.Info().
+		Str("workflow_id", GetWorkflowID(ctx)).
+		Str("step_id", step.StepID).
+		Str("sub_workflow_id", step.SubWorkflowID).
+		Msg("Compensating sub-workflow")
+
+	if err := e.workflows.CompensateWorkflow(ctx, step.SubWorkflowID); err != nil {
+		return err
+	}
+
+	execCtx.MarkCompensated(step)
+	return nil
+}
@@ -1,42 +1,149 @@
 package executor
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
 	"maps"
-	"text/template"
+	"slices"
 
+	"github.com/maestro/maestro.go/internal/application/templating"
 	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/metrics"
 )
 
-func (e *Executor) resolveTemplate(tmpl string, data any) (string, error) {
-	t, err := template.New("executor").Parse(tmpl)
+type tenantNamespace string
+
+type templateEngine string
+
+type kvScope string
+
+const (
+	templateEngineKey = "$template_engine"
+	kvScopeKey        = "$kv_scope"
+)
+
+func (e *Executor) resolveTemplate(tmpl string, data map[string]any) (string, error) {
+	var tenant string
+	if namespace, ok := data["namespace"].(tenantNamespace); ok {
+		tenant = string(namespace)
+	}
+
+	scope, _ := data[kvScopeKey].(kvScope)
+
+	name, _ := data[templateEngineKey].(templateEngine)
+	engine, err := templating.Lookup(string(name))
 	if err != nil {
-		return "", fmt.Errorf("failed to parse template: %w", err)
+		return "", err
+	}
+	available := availableKeys(data)
+
+	if name != "" {
+		data = maps.Clone(data)
+		delete(data, templateEngineKey)
+		delete(data, kvScopeKey)
+		if tenant != "" {
+			data["namespace"] = tenant
+		}
+	}
+
+	funcs := templating.Funcs{
+		"kv": func(namespace, key string) (any, error) {
+			return e.kvGet(scope, namespace, key)
+		},
+		"counter": func(namespace, key string) (int64, error) {
+			return e.kvCounter(scope, namespace, key)
+		},
+	}
+	if names := secretRefs(tmpl); len(names) > 0 {
+		secrets, err := e.resolveSecrets(names)
+		if err != nil {
+			return "", domain.NewTemplateError(tmpl, available, err)
+		}
+		data = maps.Clone(data)
+		data[secretsRoot] = secrets
+		funcs[secretsRoot] = func() map[string]any { return secrets }
 	}
 
-	var buf bytes.Buffer
-	if err := t.Execute(&buf, data); err != nil {
-		return "", fmt.Errorf("failed to execute template: %w", err)
+	rendered, err := engine.Render(tmpl, data, funcs)
+	if err != nil {
+		return "", domain.NewTemplateError(tmpl, available, err)
 	}
+	return rendered, nil
+}
 
-	return buf.String(), nil
+func availableKeys(data map[string]any) []string {
+	var keys []string
+	for root, value := range data {
+		if root == templateEngineKey || root == kvScopeKey {
+			continue
+		}
+		nested, ok := value.(map[string]any)
+		if !ok || len(nested) == 0 {
+			keys = append(keys, root)
+			continue
+		}
+		for key := range nested {
+			keys = append(keys, root+"."+key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
 }
 
-func (e *Executor) resolveStepInput(step *domain.Step, ctx *domain.ExecutionContext) (map[string]any, error) {
-	resolvedInput := make(map[string]any)
+func templateFailure(err error, key string) error {
+	var tmplErr *domain.TemplateError
+	if errors.As(err, &tmplErr) && tmplErr.Key == "" {
+		tmplErr.Key = key
+	}
+	return err
+}
 
-	templateData := make(map[string]any, len(ctx.StepOutputs)+1)
+func buildTemplateData(ctx *domain.ExecutionContext) map[string]any {
+	templateData := ctx.CopyStepOutputs()
 	templateData["input"] = ctx.Input
-	maps.Copy(templateData, ctx.StepOutputs)
+	templateData["vars"] = ctx.Variables
+	if ctx.Namespace != "" {
+		templateData["namespace"] = tenantNamespace(ctx.Namespace)
+	}
+	templateData[kvScopeKey] = kvScope(domain.KVScope(ctx.Namespace, ctx.WorkflowName))
+	if len(ctx.Tags) > 0 {
+		templateData["tags"] = ctx.Tags
+	}
+	if ctx.TemplateEngine != "" {
+		templateData[templateEngineKey] = templateEngine(ctx.TemplateEngine)
+	}
+	return templateData
+}
+
+func (e *Executor) resolveStringMap(values map[string]string, templateData map[string]any) (map[string]string, error) {
+	resolved := make(map[string]string, len(values))
+	for key, value := range values {
+		if domain.ContainsTemplate(value) {
+			v, err := e.resolveTemplate(value, templateData)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve template for key %s: %w", key, templateFailure(err, key))
+			}
+			value = v
+		}
+		resolved[key] = value
+	}
+	return resolved, nil
+}
+
+func (e *Executor) resolveStepInput(step *domain.Step, ctx *domain.ExecutionContext) (map[string]any, error) {
+	return e.resolveInput(step.Input, buildTemplateData(ctx))
+}
+
+func (e *Executor) resolveInput(input map[string]any, templateData map[string]any) (map[string]any, error) {
+	resolvedInput := make(map[string]any)
 
-	for key, value := range step.Input {
+	for key, value := range input {
 		switch v := value.(type) {
 		case string:
 			if domain.IsTemplate(v) {
 				resolved, err := e.resolveTemplate(v, templateData)
 				if err != nil {
-					return nil, fmt.Errorf("failed to resolve template for key %s: %w", key, err)
+					return nil, fmt.Errorf("failed to resolve template for key %s: %w", key, templateFailure(err, "input."+key))
 				}
 				resolvedInput[key] = resolved
 			} else {
@@ -49,3 +156,39 @@ func (e *Executor) resolveStepInput(step *domain.Step, ctx *domain.ExecutionCont
 
 	return resolvedInput, nil
 }
+
+var templateErrorsMetric = &domain.MetricConfig{
+	Name: "maestro_template_errors_total",
+	Type: metrics.MetricTypeCounter,
+	Help: "Step templates that failed to resolve",
+}
+
+func (e *Executor) recordTemplateError(
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+	wf *domain.Workflow,
+	err error,
+) {
+	var tmplErr *domain.TemplateError
+	if !errors.As(err, &tmplErr) || tmplErr.StepID != "" {
+		return
+	}
+	tmplErr.StepID = step.ID
+
+	e.logger.Error().
+		Str("workflow_id", execCtx.WorkflowID).
+		Str("step_id", step.ID).
+		Str("key", tmplErr.Key).
+		Str("expression", tmplErr.Expression).
+		Str("cause", tmplErr.Cause).
+		Strs("available", tmplErr.Available).
+		Msg("Template failed to resolve")
+
+	if e.metrics == nil {
+		return
+	}
+	labels := map[string]string{"workflow": wf.Name, "step": step.ID}
+	if err := e.metrics.Record(templateErrorsMetric, 1, labels); err != nil {
+		e.logger.Warn().Err(err).Str("step_id", step.ID).Msg("Failed to record template error metric")
+	}
+}
@@ -0,0 +1,106 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func (e *Executor) startStepSpan(
+	ctx context.Context,
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+) (context.Context, trace.Span) {
+	ctx, span := tracing.Tracer().Start(ctx, "step "+step.ID, trace.WithAttributes(
+		attribute.String("maestro.workflow_id", execCtx.WorkflowID),
+		attribute.String("maestro.step_id", step.ID),
+		attribute.String("maestro.service", step.Service),
+		attribute.String("maestro.method", step.Method),
+	))
+
+	if step.Trace == nil || len(step.Trace.Attributes) == 0 {
+		return ctx, span
+	}
+
+	attrs, err := e.resolveStringMap(step.Trace.Attributes, buildTemplateData(execCtx))
+	if err != nil {
+		e.logger.Warn().
+			Err(err).
+			Str("step_id", step.ID).
+			Msg("Failed to resolve trace attributes")
+		return ctx, span
+	}
+	span.SetAttributes(toAttributes(attrs)...)
+
+	return ctx, span
+}
+
+func (e *Executor) endStepSpan(
+	span trace.Span,
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+	result *domain.StepResult,
+	err error,
+) {
+	defer span.End()
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	if step.Trace == nil {
+		return
+	}
+
+	templateData := buildTemplateData(execCtx)
+	if result != nil && step.Output != "" {
+		templateData[step.Output] = result.Output
+	}
+
+	for _, event := range step.Trace.Events {
+		attrs, err := e.resolveStringMap(event.Attributes, templateData)
+		if err != nil {
+			e.logger.Warn().
+				Err(err).
+				Str("step_id", step.ID).
+				Str("event", event.Name).
+				Msg("Failed to resolve trace event attributes")
+			continue
+		}
+		span.AddEvent(event.Name, trace.WithAttributes(toAttributes(attrs)...))
+	}
+}
+
+func startAttemptSpan(ctx context.Context, name string, attempt, attempts int) (context.Context, trace.Span) {
+	if attempts <= 1 {
+		return ctx, noop.Span{}
+	}
+	return tracing.Tracer().Start(ctx, fmt.Sprintf("%s attempt %d", name, attempt), trace.WithAttributes(
+		attribute.Int("maestro.attempt", attempt),
+		attribute.Int("maestro.max_attempts", attempts),
+	))
+}
+
+func recordRetry(ctx context.Context, attempt int, backoff time.Duration) {
+	trace.SpanFromContext(ctx).AddEvent("retry", trace.WithAttributes(
+		attribute.Int("maestro.attempt", attempt),
+		attribute.String("maestro.backoff", backoff.String()),
+	))
+}
+
+func toAttributes(values map[string]string) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(values))
+	for key, value := range values {
+		attrs = append(attrs, attribute.String(key, value))
+	}
+	return attrs
+}
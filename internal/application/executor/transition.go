@@ -0,0 +1,197 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+var transitionLock = &domain.LockConfig{
+	TTL:  domain.Duration{Duration: 30 * time.Second},
+	Wait: domain.Duration{Duration: 10 * time.Second},
+}
+
+func (e *Executor) executeTransitionStep(
+	ctx context.Context,
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+	wf *domain.Workflow,
+) (*domain.StepResult, error) {
+	if e.kv == nil {
+		return nil, fmt.Errorf("step %s: no kv store configured", step.ID)
+	}
+
+	config := step.Transition
+	machine, ok := config.Machine(wf.States)
+	if !ok {
+		return nil, fmt.Errorf("step %s: no states declared for entity %s", step.ID, config.Entity)
+	}
+	templateData := buildTemplateData(execCtx)
+
+	id := config.ID
+	if domain.ContainsTemplate(id) {
+		resolved, err := e.resolveTemplate(id, templateData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve transition id: %w", err)
+		}
+		id = resolved
+	}
+
+	to := config.To
+	if domain.ContainsTemplate(to) {
+		resolved, err := e.resolveTemplate(to, templateData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve transition target: %w", err)
+		}
+		to = resolved
+	}
+
+	namespace := domain.StateNamespace(execCtx.Namespace, config.Entity)
+	change := &domain.StateChange{Entity: config.Entity, ID: id, To: to}
+
+	err := e.withStateLock(ctx, namespace, id, execCtx, func() error {
+		current, found, err := e.currentState(ctx, namespace, config.Entity, id)
+		if err != nil {
+			return err
+		}
+		if !found {
+			current = machine.Initial
+			change.Created = true
+		}
+		change.From = current
+
+		if !machine.Allows(current, to) {
+			allowed := "none"
+			if targets := machine.Transitions[current]; len(targets) > 0 {
+				allowed = strings.Join(targets, ", ")
+			}
+			if current == "" {
+				return fmt.Errorf("%w: %s %s has no state yet and cannot start in %s", domain.ErrIllegalTransition, config.Entity, id, to)
+			}
+			return fmt.Errorf("%w: %s %s cannot go from %s to %s (allowed: %s)", domain.ErrIllegalTransition, config.Entity, id, current, to, allowed)
+		}
+
+		if err := e.kv.Set(ctx, namespace, id, to, 0); err != nil {
+			return fmt.Errorf("failed to store state of %s %s: %w", config.Entity, id, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("step %s: %w", step.ID, err)
+	}
+
+	executed := domain.NewExecutedStep(step, nil)
+	executed.Transition = change
+	execCtx.AppendExecutedStep(executed)
+
+	e.logger.Info().
+		Str("workflow_id", execCtx.WorkflowID).
+		Str("step_id", step.ID).
+		Str("entity", config.Entity).
+		Str("id", id).
+		Str("from", change.From).
+		Str("to", to).
+		Msg("Entity state changed")
+
+	return &domain.StepResult{
+		StepID: step.ID,
+		Output: map[string]any{
+			"entity": config.Entity,
+			"id":     id,
+			"from":   change.From,
+			"to":     to,
+		},
+	}, nil
+}
+
+func (e *Executor) revertTransition(
+	ctx context.Context,
+	step *domain.ExecutedStep,
+	execCtx *domain.ExecutionContext,
+) error {
+	change := step.Transition
+	if e.kv == nil {
+		return fmt.Errorf("no kv store configured to revert %s %s", change.Entity, change.ID)
+	}
+
+	namespace := domain.StateNamespace(execCtx.Namespace, change.Entity)
+	logger := e.logger.With().
+		Str("workflow_id", execCtx.WorkflowID).
+		Str("step_id", step.StepID).
+		Str("entity", change.Entity).
+		Str("id", change.ID).
+		Logger()
+
+	return e.withStateLock(ctx, namespace, change.ID, execCtx, func() error {
+		current, found, err := e.currentState(ctx, namespace, change.Entity, change.ID)
+		if err != nil {
+			return err
+		}
+		if !found || current != change.To {
+			logger.Warn().
+				Str("state", current).
+				Str("expected", change.To).
+				Msg("Entity state changed since this execution set it, not reverting")
+			return nil
+		}
+
+		if change.Created {
+			err = e.kv.Delete(ctx, namespace, change.ID)
+		} else {
+			err = e.kv.Set(ctx, namespace, change.ID, change.From, 0)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to revert state of %s %s: %w", change.Entity, change.ID, err)
+		}
+
+		logger.Info().
+			Str("from", change.To).
+			Str("to", change.From).
+			Msg("Entity state reverted")
+		return nil
+	})
+}
+
+func (e *Executor) currentState(ctx context.Context, namespace, entity, id string) (string, bool, error) {
+	value, found, err := e.kv.Get(ctx, namespace, id)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read state of %s %s: %w", entity, id, err)
+	}
+	if !found {
+		return "", false, nil
+	}
+	state, ok := value.(string)
+	if !ok {
+		return "", false, fmt.Errorf("state of %s %s is not a string: %v", entity, id, value)
+	}
+	return state, true, nil
+}
+
+func (e *Executor) withStateLock(
+	ctx context.Context,
+	namespace, id string,
+	execCtx *domain.ExecutionContext,
+	fn func() error,
+) error {
+	if e.locks == nil {
+		return fn()
+	}
+
+	key := "state:" + namespace + "/" + id
+	if _, err := e.acquireLock(ctx, key, transitionLock, execCtx); err != nil {
+		return err
+	}
+	defer func() {
+		if err := e.releaseLock(context.WithoutCancel(ctx), key, execCtx); err != nil {
+			e.logger.Warn().
+				Err(err).
+				Str("workflow_id", execCtx.WorkflowID).
+				Str("lock", key).
+				Msg("Failed to release state lock")
+		}
+	}()
+	return fn()
+}
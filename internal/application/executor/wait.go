@@ -0,0 +1,145 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+func (e *Executor) executeWaitStep(
+	ctx context.Context,
+	step *domain.Step,
+	execCtx *domain.ExecutionContext,
+) (*domain.StepResult, error) {
+	wait := step.Wait
+	key := signalKey(execCtx.WorkflowID, wait.Signal)
+	signal := e.signalChannel(key)
+
+	var expired <-chan time.Time
+	if wait.ExpireAfter.Duration > 0 {
+		timerKey := iteration(ctx) + step.ID
+		deadline := execCtx.WaitDeadline(timerKey, wait.ExpireAfter.Duration)
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		expired = timer.C
+		defer func() {
+			if ctx.Err() == nil {
+				execCtx.ClearWaitDeadline(timerKey)
+			}
+		}()
+	}
+
+	e.logger.Debug().
+		Str("workflow_id", execCtx.WorkflowID).
+		Str("step_id", step.ID).
+		Str("signal", wait.Signal).
+		Dur("expire_after", wait.ExpireAfter.Duration).
+		Msg("Waiting for signal")
+
+	select {
+	case payload := <-signal:
+		e.releaseSignalChannel(key, signal)
+		return &domain.StepResult{
+			StepID: step.ID,
+			Output: payload,
+		}, nil
+
+	case <-expired:
+		return e.expireWait(step, execCtx)
+
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (e *Executor) expireWait(step *domain.Step, execCtx *domain.ExecutionContext) (*domain.StepResult, error) {
+	wait := step.Wait
+
+	e.logger.Warn().
+		Str("workflow_id", execCtx.WorkflowID).
+		Str("step_id", step.ID).
+		Str("signal", wait.Signal).
+		Str("on_expire", wait.OnExpire).
+		Msg("Waiting step expired")
+
+	switch wait.OnExpire {
+	case domain.WaitExpireSkip:
+		return &domain.StepResult{
+			StepID: step.ID,
+			Output: nil,
+		}, nil
+
+	case domain.WaitExpireDefault:
+		return &domain.StepResult{
+			StepID: step.ID,
+			Output: wait.Default,
+		}, nil
+
+	default:
+		return nil, &domain.WaitExpiredError{
+			StepID:     step.ID,
+			Signal:     wait.Signal,
+			After:      wait.ExpireAfter.Duration,
+			Compensate: wait.OnExpire == domain.WaitExpireCompensate,
+		}
+	}
+}
+
+func (e *Executor) DeliverSignal(workflowID, name string, payload any) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	key := signalKey(workflowID, name)
+	signal, ok := e.signals[key]
+	if !ok {
+		signal = make(chan any, 1)
+		e.signals[key] = signal
+	}
+
+	select {
+	case signal <- payload:
+		return nil
+	default:
+		return fmt.Errorf("signal %s is already pending for workflow %s", name, workflowID)
+	}
+}
+
+func (e *Executor) ClearSignals(workflowID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	prefix := workflowID + "/"
+	for key := range e.signals {
+		if strings.HasPrefix(key, prefix) {
+			delete(e.signals, key)
+		}
+	}
+}
+
+func (e *Executor) signalChannel(key string) chan any {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	signal, ok := e.signals[key]
+	if !ok {
+		signal = make(chan any, 1)
+		e.signals[key] = signal
+	}
+	return signal
+}
+
+func (e *Executor) releaseSignalChannel(key string, signal chan any) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.signals[key] == signal && len(signal) == 0 {
+		delete(e.signals, key)
+	}
+}
+
+func signalKey(workflowID, name string) string {
+	return workflowID + "/" + name
+}
@@ -0,0 +1,188 @@
+package application
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/maestro/maestro.go/internal/application/templating"
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type StepExplanation struct {
+	StepID    string
+	Service   string
+	Method    string
+	Input     map[string]interface{}
+	Available []TemplateKey
+	Inputs    []InputExplanation
+}
+
+type TemplateKey struct {
+	Name        string
+	StepID      string
+	Service     string
+	Method      string
+	Conditional bool
+}
+
+type InputExplanation struct {
+	Key      string
+	Value    interface{}
+	Template string
+	Refs     []string
+	Resolved string
+	Problems []string
+}
+
+type laterOutput struct {
+	stepID   string
+	parallel bool
+}
+
+func ExplainStep(wf *domain.Workflow, stepID string, input map[string]interface{}) (*StepExplanation, error) {
+	var (
+		target    *domain.Step
+		available []TemplateKey
+		later     = make(map[string]laterOutput)
+	)
+
+	for i := range wf.Steps {
+		step := &wf.Steps[i]
+		if target != nil {
+			collectLaterOutputs(step, false, later)
+			continue
+		}
+
+		if step.ID == stepID && len(step.Parallel) == 0 {
+			target = step
+			continue
+		}
+
+		if found := findParallelStep(step.Parallel, stepID); found != nil {
+			target = found
+			for j := range step.Parallel {
+				if &step.Parallel[j] != found {
+					collectLaterOutputs(&step.Parallel[j], true, later)
+				}
+			}
+			continue
+		}
+
+		available = collectAvailableOutputs(step, available)
+	}
+
+	if target == nil {
+		return nil, fmt.Errorf("step %s not found in workflow %s", stepID, wf.Name)
+	}
+
+	explanation := &StepExplanation{
+		StepID:    target.ID,
+		Service:   target.Service,
+		Method:    target.Method,
+		Input:     input,
+		Available: available,
+	}
+
+	availableNames := make(map[string]bool, len(available))
+	for _, key := range available {
+		availableNames[key.Name] = true
+	}
+
+	parser := NewParser()
+	keys := make([]string, 0, len(target.Input))
+	for key := range target.Input {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		value := target.Input[key]
+		str, ok := value.(string)
+		if !ok || !domain.IsTemplate(str) {
+			explanation.Inputs = append(explanation.Inputs, InputExplanation{Key: key, Value: value})
+			continue
+		}
+
+		item := InputExplanation{Key: key, Template: str}
+		refs, analyzable := templating.Roots(wf.TemplateEngine, str)
+		if !analyzable {
+			item.Problems = append(item.Problems, "uses functions or constructs that are only resolved at runtime")
+			explanation.Inputs = append(explanation.Inputs, item)
+			continue
+		}
+
+		slices.Sort(refs)
+		item.Refs = slices.Compact(refs)
+
+		inputOnly := true
+		for _, ref := range item.Refs {
+			if ref == "input" {
+				continue
+			}
+			inputOnly = false
+
+			switch {
+			case availableNames[ref], ref == "secrets":
+			case later[ref].parallel:
+				item.Problems = append(item.Problems, fmt.Sprintf(".%s is produced by step %s, which runs in parallel with this step", ref, later[ref].stepID))
+			case later[ref].stepID != "":
+				item.Problems = append(item.Problems, fmt.Sprintf(".%s is produced by step %s, which runs after this step", ref, later[ref].stepID))
+			default:
+				item.Problems = append(item.Problems, fmt.Sprintf(".%s is not produced by any step (map has no entry for key %q)", ref, ref))
+			}
+		}
+
+		if inputOnly {
+			resolved, err := parser.RenderTemplate(wf.TemplateEngine, str, map[string]interface{}{"input": input})
+			if err != nil {
+				item.Problems = append(item.Problems, err.Error())
+			} else {
+				item.Resolved = resolved
+				if strings.Contains(resolved, "<no value>") {
+					item.Problems = append(item.Problems, "sample input has no value for a referenced field")
+				}
+			}
+		}
+
+		explanation.Inputs = append(explanation.Inputs, item)
+	}
+
+	return explanation, nil
+}
+
+func findParallelStep(steps []domain.Step, stepID string) *domain.Step {
+	for i := range steps {
+		if steps[i].ID == stepID {
+			return &steps[i]
+		}
+	}
+	return nil
+}
+
+func collectAvailableOutputs(step *domain.Step, keys []TemplateKey) []TemplateKey {
+	if step.Output != "" {
+		keys = append(keys, TemplateKey{
+			Name:        step.Output,
+			StepID:      step.ID,
+			Service:     step.Service,
+			Method:      step.Method,
+			Conditional: step.When != "",
+		})
+	}
+	for i := range step.Parallel {
+		keys = collectAvailableOutputs(&step.Parallel[i], keys)
+	}
+	return keys
+}
+
+func collectLaterOutputs(step *domain.Step, parallel bool, later map[string]laterOutput) {
+	if step.Output != "" {
+		if _, exists := later[step.Output]; !exists {
+			later[step.Output] = laterOutput{stepID: step.ID, parallel: parallel}
+		}
+	}
+	for i := range step.Parallel {
+		collectLaterOutputs(&step.Parallel[i], parallel, later)
+	}
+}
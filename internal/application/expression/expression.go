@@ -0,0 +1,162 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	celast "github.com/google/cel-go/common/ast"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/ext"
+	"github.com/maestro/maestro.go/internal/domain"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var (
+	environment = sync.OnceValues(func() (*cel.Env, error) {
+		opts := []cel.EnvOption{
+			cel.CrossTypeNumericComparisons(true),
+			ext.Strings(),
+			ext.Math(),
+		}
+		return cel.NewEnv(append(opts, functionOptions()...)...)
+	})
+	programs sync.Map
+
+	jsonValueType = reflect.TypeOf(&structpb.Value{})
+
+	ErrUndefined = errors.New("undefined value")
+)
+
+func Unwrap(text string) (string, bool) {
+	expr := strings.TrimSpace(text)
+	if domain.IsTemplate(expr) {
+		expr = strings.TrimSpace(expr[2 : len(expr)-2])
+	}
+	if _, err := parse(expr); err != nil {
+		return expr, false
+	}
+	return expr, true
+}
+
+func Check(expr string) error {
+	_, err := parse(expr)
+	return err
+}
+
+func Evaluate(expr string, vars map[string]any) (any, error) {
+	out, err := eval(expr, vars)
+	if err != nil {
+		return nil, err
+	}
+	return out.Value(), nil
+}
+
+func EvaluateJSON(expr string, vars map[string]any) (any, error) {
+	out, err := eval(expr, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	native, err := out.ConvertToNative(jsonValueType)
+	if err != nil {
+		return nil, fmt.Errorf("expression %q evaluated to a %s, which has no JSON form: %w", expr, out.Type().TypeName(), err)
+	}
+	return native.(*structpb.Value).AsInterface(), nil
+}
+
+func EvaluateBool(expr string, vars map[string]any) (bool, error) {
+	value, err := Evaluate(expr, vars)
+	if err != nil {
+		return false, err
+	}
+
+	if b, ok := value.(bool); ok {
+		return b, nil
+	}
+
+	return false, fmt.Errorf("expression %q evaluated to %v, expected a boolean", expr, value)
+}
+
+func Roots(expr string) ([]string, error) {
+	ast, err := parse(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	var idents []string
+	locals := make(map[string]bool)
+	celast.PreOrderVisit(ast.NativeRep().Expr(), celast.NewExprVisitor(func(e celast.Expr) {
+		switch e.Kind() {
+		case celast.ComprehensionKind:
+			comprehension := e.AsComprehension()
+			locals[comprehension.IterVar()] = true
+			locals[comprehension.AccuVar()] = true
+		case celast.IdentKind:
+			idents = append(idents, strings.TrimPrefix(e.AsIdent(), "."))
+		}
+	}))
+
+	var roots []string
+	seen := make(map[string]bool)
+	for _, name := range idents {
+		if !locals[name] && !seen[name] {
+			seen[name] = true
+			roots = append(roots, name)
+		}
+	}
+	return roots, nil
+}
+
+func eval(expr string, vars map[string]any) (ref.Val, error) {
+	program, err := compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	out, _, err := program.Eval(vars)
+	if err != nil {
+		if msg := err.Error(); strings.HasPrefix(msg, "no such key") || strings.HasPrefix(msg, "no such attribute") {
+			return nil, fmt.Errorf("failed to evaluate %q: %w: %s", expr, ErrUndefined, msg)
+		}
+		return nil, fmt.Errorf("failed to evaluate %q: %w", expr, err)
+	}
+	return out, nil
+}
+
+func parse(expr string) (*cel.Ast, error) {
+	env, err := environment()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create expression environment: %w", err)
+	}
+
+	ast, issues := env.Parse(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", expr, issues.Err())
+	}
+
+	return ast, nil
+}
+
+func compile(expr string) (cel.Program, error) {
+	if cached, ok := programs.Load(expr); ok {
+		return cached.(cel.Program), nil
+	}
+
+	ast, err := parse(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	env, _ := environment()
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile %q: %w", expr, err)
+	}
+
+	programs.Store(expr, program)
+	return program, nil
+}
@@ -0,0 +1,56 @@
+package expression
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/maestro/maestro.go/internal/application/functions"
+)
+
+var binaryFunctions = map[string]func(a, b any) (string, error){
+	"addDuration":     functions.AddDuration,
+	"durationBetween": functions.DurationBetween,
+	"moneyAdd":        functions.MoneyAdd,
+	"moneySub":        functions.MoneySub,
+	"moneyMul":        functions.MoneyMul,
+	"percent":         functions.Percent,
+	"roundTo":         functions.RoundTo,
+	"inZone": func(timestamp, zone any) (string, error) {
+		return functions.InZone(timestamp, fmt.Sprint(zone))
+	},
+	"formatTime": func(timestamp, layout any) (string, error) {
+		return functions.FormatTime(timestamp, fmt.Sprint(layout))
+	},
+	"money": func(amount, currency any) (string, error) {
+		return functions.Money(amount, fmt.Sprint(currency))
+	},
+}
+
+func functionOptions() []cel.EnvOption {
+	opts := []cel.EnvOption{
+		cel.Function("now",
+			cel.Overload("now", nil, cel.StringType,
+				cel.FunctionBinding(func(...ref.Val) ref.Val {
+					return types.String(functions.Now())
+				}),
+			),
+		),
+	}
+
+	for name, fn := range binaryFunctions {
+		opts = append(opts, cel.Function(name,
+			cel.Overload(name+"_dyn_dyn", []*cel.Type{cel.DynType, cel.DynType}, cel.StringType,
+				cel.BinaryBinding(func(a, b ref.Val) ref.Val {
+					result, err := fn(a.Value(), b.Value())
+					if err != nil {
+						return types.NewErr("%s: %s", name, err.Error())
+					}
+					return types.String(result)
+				}),
+			),
+		))
+	}
+	return opts
+}
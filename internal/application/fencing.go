@@ -0,0 +1,97 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/ports"
+)
+
+const executionLeaseTTL = 30 * time.Second
+
+type lease struct {
+	leaser ports.ExecutionLeaser
+	owner  string
+	token  int64
+	fenced atomic.Bool
+}
+
+func (o *Orchestrator) claimExecution(ctx context.Context, workflowID string) (context.Context, *lease, error) {
+	leaser, ok := o.store.(ports.ExecutionLeaser)
+	if !ok {
+		return ctx, nil, nil
+	}
+
+	token, err := leaser.ClaimExecution(ctx, workflowID, o.nodeID, executionLeaseTTL)
+	if err != nil {
+		return ctx, nil, fmt.Errorf("failed to claim execution %s: %w", workflowID, err)
+	}
+
+	return context.WithValue(ctx, ctxkeys.FencingToken, token), &lease{
+		leaser: leaser,
+		owner:  o.nodeID,
+		token:  token,
+	}, nil
+}
+
+func (o *Orchestrator) keepLease(r *run) func() {
+	if r.lease == nil {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(executionLeaseTTL / 3)
+		defer ticker.Stop()
+
+		workflowID := r.execCtx.WorkflowID
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			err := r.lease.leaser.RenewExecution(context.WithoutCancel(r.ctx), workflowID, r.lease.owner, r.lease.token, executionLeaseTTL)
+			if errors.Is(err, workflow.ErrFenced) {
+				r.logger.Error().
+					Err(err).
+					Int64("fencing_token", r.lease.token).
+					Msg("Execution fenced, another node took over")
+				r.lease.fenced.Store(true)
+				r.cancel()
+				return
+			}
+			if err != nil {
+				r.logger.Warn().
+					Err(err).
+					Msg("Failed to renew execution lease")
+			}
+		}
+	}()
+
+	return sync.OnceFunc(func() { close(done) })
+}
+
+func (l *lease) isFenced() bool {
+	return l != nil && l.fenced.Load()
+}
+
+func (o *Orchestrator) releaseLease(r *run) {
+	if r.lease == nil || r.lease.isFenced() {
+		return
+	}
+
+	err := r.lease.leaser.ReleaseExecution(context.WithoutCancel(r.ctx), r.execCtx.WorkflowID, r.lease.owner, r.lease.token)
+	if err != nil {
+		r.logger.Warn().
+			Err(err).
+			Msg("Failed to release execution lease")
+	}
+}
@@ -0,0 +1,50 @@
+package application
+
+import (
+	"context"
+
+	workflow "github.com/maestro/maestro.go/internal/domain"
+)
+
+func (o *Orchestrator) runFinally(r *run) {
+	if len(r.wf.Finally) == 0 || r.handedOff() {
+		return
+	}
+
+	ctx, execCtx, result := context.WithoutCancel(r.ctx), r.execCtx, r.result
+	execCtx.Variables["status"] = result.Status.String()
+	if result.Error != nil {
+		execCtx.Variables["error"] = result.Error.Error()
+	}
+
+	for i := range r.wf.Finally {
+		step := &r.wf.Finally[i]
+
+		stepResult, err := o.executor.ExecuteStep(ctx, step, execCtx, r.wf)
+		if err != nil {
+			r.logger.Error().
+				Err(err).
+				Str("step_id", step.ID).
+				Msg("Finally step failed")
+			o.checkpointFinally(r, &workflow.StepResult{StepID: step.ID, Error: err})
+			continue
+		}
+		if stepResult == nil {
+			continue
+		}
+
+		if step.Output != "" {
+			execCtx.SetStepOutput(step.Output, stepResult.Output)
+		}
+		o.checkpointFinally(r, stepResult)
+	}
+}
+
+func (o *Orchestrator) checkpointFinally(r *run, result *workflow.StepResult) {
+	if err := o.checkpointStep(context.WithoutCancel(r.ctx), r.execution, result); err != nil {
+		r.logger.Error().
+			Err(err).
+			Str("step_id", result.StepID).
+			Msg("Failed to checkpoint finally step")
+	}
+}
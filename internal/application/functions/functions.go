@@ -0,0 +1,39 @@
+package functions
+
+import (
+	"fmt"
+	"strconv"
+	"text/template"
+)
+
+var Template = template.FuncMap{
+	"now":             Now,
+	"addDuration":     AddDuration,
+	"inZone":          InZone,
+	"formatTime":      FormatTime,
+	"durationBetween": DurationBetween,
+	"money":           Money,
+	"moneyAdd":        MoneyAdd,
+	"moneySub":        MoneySub,
+	"moneyMul":        MoneyMul,
+	"percent":         Percent,
+	"roundTo":         RoundTo,
+}
+
+func text(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case fmt.Stringer:
+		return v.String(), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v), nil
+	case nil:
+		return "", fmt.Errorf("value is missing")
+	}
+	return "", fmt.Errorf("unsupported value %v (%T)", value, value)
+}
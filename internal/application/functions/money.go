@@ -0,0 +1,117 @@
+package functions
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const maxDecimals = 18
+
+var minorUnits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+func Money(amount any, currency string) (string, error) {
+	places, err := currencyDecimals(currency)
+	if err != nil {
+		return "", err
+	}
+	return RoundTo(amount, places)
+}
+
+func MoneyAdd(a, b any) (string, error) {
+	return combine(a, b, (*big.Rat).Add)
+}
+
+func MoneySub(a, b any) (string, error) {
+	return combine(a, b, (*big.Rat).Sub)
+}
+
+func MoneyMul(a, b any) (string, error) {
+	return combine(a, b, (*big.Rat).Mul)
+}
+
+func Percent(amount, rate any) (string, error) {
+	return combine(amount, rate, func(z, x, y *big.Rat) *big.Rat {
+		z.Mul(x, y)
+		return z.Quo(z, big.NewRat(100, 1))
+	})
+}
+
+func RoundTo(amount any, places any) (string, error) {
+	value, err := decimal(amount)
+	if err != nil {
+		return "", err
+	}
+	n, err := decimal(places)
+	if err != nil || !n.IsInt() || n.Sign() < 0 || n.Num().Int64() > maxDecimals {
+		return "", fmt.Errorf("invalid number of decimal places %v", places)
+	}
+	digits := int(n.Num().Int64())
+
+	scale := pow10(digits)
+	scaled := new(big.Rat).Mul(value, new(big.Rat).SetInt(scale))
+
+	quotient, remainder := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	remainder.Abs(remainder).Mul(remainder, big.NewInt(2))
+	if remainder.Cmp(scaled.Denom()) >= 0 {
+		if scaled.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	return new(big.Rat).SetFrac(quotient, scale).FloatString(digits), nil
+}
+
+func combine(a, b any, op func(z, x, y *big.Rat) *big.Rat) (string, error) {
+	x, err := decimal(a)
+	if err != nil {
+		return "", err
+	}
+	y, err := decimal(b)
+	if err != nil {
+		return "", err
+	}
+	return format(op(new(big.Rat), x, y)), nil
+}
+
+func decimal(value any) (*big.Rat, error) {
+	s, err := text(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok || strings.ContainsAny(s, "/eE") {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+	return r, nil
+}
+
+func format(r *big.Rat) string {
+	places := 0
+	for places < maxDecimals && new(big.Int).Mod(pow10(places), r.Denom()).Sign() != 0 {
+		places++
+	}
+	return r.FloatString(places)
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func currencyDecimals(currency string) (int, error) {
+	code := strings.ToUpper(strings.TrimSpace(currency))
+	if len(code) != 3 {
+		return 0, fmt.Errorf("invalid currency code %q", currency)
+	}
+	if places, ok := minorUnits[code]; ok {
+		return places, nil
+	}
+	return 2, nil
+}
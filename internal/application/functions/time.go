@@ -0,0 +1,84 @@
+package functions
+
+import (
+	"fmt"
+	"time"
+)
+
+func Now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func AddDuration(timestamp any, duration any) (string, error) {
+	t, err := parseTime(timestamp)
+	if err != nil {
+		return "", err
+	}
+	d, err := parseDuration(duration)
+	if err != nil {
+		return "", err
+	}
+	return t.Add(d).Format(time.RFC3339), nil
+}
+
+func InZone(timestamp any, zone string) (string, error) {
+	t, err := parseTime(timestamp)
+	if err != nil {
+		return "", err
+	}
+	location, err := time.LoadLocation(zone)
+	if err != nil {
+		return "", fmt.Errorf("unknown time zone %q", zone)
+	}
+	return t.In(location).Format(time.RFC3339), nil
+}
+
+func FormatTime(timestamp any, layout string) (string, error) {
+	t, err := parseTime(timestamp)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(layout), nil
+}
+
+func DurationBetween(from, to any) (string, error) {
+	start, err := parseTime(from)
+	if err != nil {
+		return "", err
+	}
+	end, err := parseTime(to)
+	if err != nil {
+		return "", err
+	}
+	return end.Sub(start).String(), nil
+}
+
+func parseTime(value any) (time.Time, error) {
+	if t, ok := value.(time.Time); ok {
+		return t, nil
+	}
+	s, err := text(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp: %w", err)
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q (expected RFC 3339)", s)
+	}
+	return t, nil
+}
+
+func parseDuration(value any) (time.Duration, error) {
+	if d, ok := value.(time.Duration); ok {
+		return d, nil
+	}
+	s, err := text(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration: %w", err)
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
@@ -0,0 +1,144 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/metrics"
+)
+
+const (
+	duplicatePollInterval = 250 * time.Millisecond
+	duplicateLookupGrace  = 10 * time.Second
+)
+
+var deduplicatedExecutionsMetric = &workflow.MetricConfig{
+	Name: "maestro_deduplicated_executions_total",
+	Type: metrics.MetricTypeCounter,
+	Help: "Submissions answered with the execution already started for their idempotency key",
+}
+
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, ctxkeys.Idempotency, key)
+}
+
+func (o *Orchestrator) prepareIdempotentRun(
+	ctx context.Context,
+	workflowName, key string,
+	input map[string]interface{},
+) (*run, error) {
+	ctx = WithIdempotencyKey(ctx, "")
+
+	_, child := ctx.Value(ctxkeys.WorkflowID).(string)
+	version, _ := ctx.Value(ctxkeys.RequestedVersion).(string)
+	wf, exists := o.GetWorkflowVersion(workflowName, version)
+	if !exists || child || isDryRun(ctx) || ctx.Value(ctxkeys.Shadow) != nil {
+		return o.prepareRun(ctx, workflowName, input)
+	}
+
+	hash, err := inputHash(input)
+	if err != nil {
+		return nil, fmt.Errorf("cannot run workflow %s: %w", workflowName, err)
+	}
+
+	workflowID, _ := ctx.Value(ctxkeys.AssignedID).(string)
+	if workflowID == "" {
+		workflowID = uuid.New().String()
+	}
+	ttl := wf.IdempotencyTTL.Duration
+	if ttl <= 0 {
+		ttl = workflow.DefaultIdempotencyTTL
+	}
+
+	now := time.Now()
+	record, claimed, err := o.idempotency.ClaimIdempotencyKey(ctx, &workflow.IdempotencyRecord{
+		Workflow:   workflowName,
+		Version:    wf.Version,
+		Key:        key,
+		WorkflowID: workflowID,
+		InputHash:  hash,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(ttl),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		if record.InputHash != hash || (version != "" && record.Version != version) {
+			return nil, fmt.Errorf("idempotency key %s of workflow %s: %w", key, workflowName, workflow.ErrIdempotencyConflict)
+		}
+
+		o.logger.Info().
+			Str("workflow", workflowName).
+			Str("workflow_id", record.WorkflowID).
+			Str("idempotency_key", key).
+			Msg("Duplicate submission, returning the original execution")
+		if err := o.metrics.Record(deduplicatedExecutionsMetric, 1, map[string]string{"workflow": workflowName}); err != nil {
+			o.logger.Warn().Err(err).Msg("Failed to record deduplicated execution")
+		}
+		return nil, &workflow.DuplicateExecutionError{Key: key, WorkflowID: record.WorkflowID}
+	}
+
+	r, err := o.prepareRun(WithVersion(WithWorkflowID(ctx, workflowID), wf.Version), workflowName, input)
+	if err != nil {
+		if releaseErr := o.idempotency.ReleaseIdempotencyKey(context.WithoutCancel(ctx), workflowName, key, workflowID); releaseErr != nil {
+			o.logger.Error().
+				Err(releaseErr).
+				Str("workflow_id", workflowID).
+				Str("idempotency_key", key).
+				Msg("Failed to release idempotency key of an execution that did not start")
+		}
+		return nil, err
+	}
+
+	r.logger = r.logger.With().Str("idempotency_key", key).Logger()
+	return r, nil
+}
+
+func (o *Orchestrator) awaitExecution(ctx context.Context, workflowID string) (*workflow.WorkflowResult, error) {
+	ticker := time.NewTicker(duplicatePollInterval)
+	defer ticker.Stop()
+
+	started := time.Now()
+	for {
+		result, ok, err := o.GetWorkflowStatus(workflowID)
+		switch {
+		case err != nil:
+			return nil, err
+		case ok && result.Status == workflow.WorkflowStatusSuspended:
+			return result, nil
+		case ok && result.Status.IsTerminal():
+			if result.Status == workflow.WorkflowStatusSuccess {
+				return result, nil
+			}
+			if result.Error == nil {
+				return result, fmt.Errorf("execution %s %s", workflowID, result.Status)
+			}
+			return result, result.Error
+		case !ok && time.Since(started) > duplicateLookupGrace:
+			return nil, fmt.Errorf("execution %s is no longer available", workflowID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func inputHash(input map[string]interface{}) (string, error) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode input: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,26 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/maestro/maestro.go/internal/application/validation"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+)
+
+func ValidateInput(wf *workflow.Workflow, input map[string]interface{}) error {
+	if len(wf.InputSchema) == 0 {
+		return nil
+	}
+	if input == nil {
+		input = make(map[string]interface{})
+	}
+
+	problems, err := validation.Validate(wf.InputSchema, input, "input")
+	if err != nil {
+		return fmt.Errorf("cannot validate input of workflow %s: %w", wf.Name, err)
+	}
+	if len(problems) > 0 {
+		return &workflow.InputError{Workflow: wf.Name, Problems: problems}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package application
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/ports"
+)
+
+type options struct {
+	lockManager          ports.LockManager
+	kvStore              ports.KVStore
+	secrets              ports.SecretProvider
+	sensitiveFields      []*regexp.Regexp
+	executionStore       ports.ExecutionStore
+	notifier             ports.Notifier
+	webhooks             ports.WebhookDispatcher
+	eventSink            ports.EventSink
+	triggerSources       map[string]ports.TriggerSource
+	stepMiddleware       []ports.StepMiddleware
+	workerPoolSize       int
+	compensationPoolSize int
+	defaultEnvironment   string
+	serviceOverrides     map[string]domain.ServiceOverride
+	serviceMerge         string
+	nodeID               string
+	execServices         bool
+	executionRetention   time.Duration
+	commandHooks         bool
+	standby              bool
+}
+
+type Option func(*options)
+
+func WithLockManager(locks ports.LockManager) Option {
+	return func(o *options) {
+		o.lockManager = locks
+	}
+}
+
+func WithKVStore(store ports.KVStore) Option {
+	return func(o *options) {
+		o.kvStore = store
+	}
+}
+
+func WithSecretProvider(secrets ports.SecretProvider) Option {
+	return func(o *options) {
+		o.secrets = secrets
+	}
+}
+
+func WithSensitiveFields(fields ...*regexp.Regexp) Option {
+	return func(o *options) {
+		o.sensitiveFields = append(o.sensitiveFields, fields...)
+	}
+}
+
+func WithExecutionStore(store ports.ExecutionStore) Option {
+	return func(o *options) {
+		o.executionStore = store
+	}
+}
+
+func WithExecutionRetention(retention time.Duration) Option {
+	return func(o *options) {
+		o.executionRetention = retention
+	}
+}
+
+func WithWorkerPoolSize(size int) Option {
+	return func(o *options) {
+		o.workerPoolSize = size
+	}
+}
+
+func WithCompensationPoolSize(size int) Option {
+	return func(o *options) {
+		o.compensationPoolSize = size
+	}
+}
+
+func WithDefaultEnvironment(name string) Option {
+	return func(o *options) {
+		o.defaultEnvironment = name
+	}
+}
+
+func WithServiceOverrides(overrides map[string]domain.ServiceOverride) Option {
+	return func(o *options) {
+		o.serviceOverrides = overrides
+	}
+}
+
+func WithCommandHooks(allowed bool) Option {
+	return func(o *options) {
+		o.commandHooks = allowed
+	}
+}
+
+func WithExecServices(allowed bool) Option {
+	return func(o *options) {
+		o.execServices = allowed
+	}
+}
+
+func WithServiceMerge(mode string) Option {
+	return func(o *options) {
+		o.serviceMerge = mode
+	}
+}
+
+func WithNodeID(id string) Option {
+	return func(o *options) {
+		o.nodeID = id
+	}
+}
+
+func WithStandby() Option {
+	return func(o *options) {
+		o.standby = true
+	}
+}
+
+func WithNotifier(notifier ports.Notifier) Option {
+	return func(o *options) {
+		o.notifier = notifier
+	}
+}
+
+func WithWebhooks(webhooks ports.WebhookDispatcher) Option {
+	return func(o *options) {
+		o.webhooks = webhooks
+	}
+}
+
+func WithEventSink(sink ports.EventSink) Option {
+	return func(o *options) {
+		o.eventSink = sink
+	}
+}
+
+func WithTriggerSource(kind string, source ports.TriggerSource) Option {
+	return func(o *options) {
+		if o.triggerSources == nil {
+			o.triggerSources = make(map[string]ports.TriggerSource)
+		}
+		o.triggerSources[kind] = source
+	}
+}
+
+func WithStepMiddleware(middleware ...ports.StepMiddleware) Option {
+	return func(o *options) {
+		o.stepMiddleware = append(o.stepMiddleware, middleware...)
+	}
+}
@@ -1,60 +1,315 @@
 package application
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/maestro/maestro.go/internal/application/executor"
 	ctxkeys "github.com/maestro/maestro.go/internal/context"
 	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/deadletter"
+	"github.com/maestro/maestro.go/internal/infrastructure/events"
 	"github.com/maestro/maestro.go/internal/infrastructure/grpc"
+	"github.com/maestro/maestro.go/internal/infrastructure/idempotency"
+	"github.com/maestro/maestro.go/internal/infrastructure/kv"
+	"github.com/maestro/maestro.go/internal/infrastructure/local"
+	"github.com/maestro/maestro.go/internal/infrastructure/lock"
+	"github.com/maestro/maestro.go/internal/infrastructure/metrics"
+	"github.com/maestro/maestro.go/internal/infrastructure/rerun"
+	"github.com/maestro/maestro.go/internal/infrastructure/secrets"
+	"github.com/maestro/maestro.go/internal/ports"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Orchestrator struct {
-	mu               sync.RWMutex
-	workflows        map[string]*workflow.Workflow
-	parser           *Parser
-	executor         *executor.Executor
-	sagaCoordinator  *SagaCoordinator
-	registry         *grpc.ServiceRegistry
-	logger           zerolog.Logger
-	runningWorkflows sync.Map
+	mu                 sync.RWMutex
+	workflows          map[string]*workflow.Workflow
+	versions           map[string]map[string]*workflow.Workflow
+	sources            map[string]string
+	parser             *Parser
+	executor           *executor.Executor
+	sagaCoordinator    *SagaCoordinator
+	registry           *grpc.ServiceRegistry
+	metrics            *metrics.Registry
+	store              ports.ExecutionStore
+	notifier           ports.Notifier
+	events             *events.Broker
+	webhooks           ports.WebhookDispatcher
+	redactor           *workflow.Redactor
+	deadLetters        ports.DeadLetterQueue
+	reruns             ports.RerunQueue
+	idempotency        ports.IdempotencyStore
+	rerunLimiter       *rateLimiter
+	calendarLimiter    *rateLimiter
+	defaultEnvironment string
+	overrides          map[string]workflow.ServiceOverride
+	execServices       bool
+	executionRetention time.Duration
+	commandHooks       bool
+	serviceMerge       string
+	nodeID             string
+	logger             zerolog.Logger
+	retiring           map[string]struct{}
+	releases           []pendingRelease
+	quarantine         *quarantine
+	breakers           *breakers
+	schedules          *scheduler
+	triggers           *triggerSet
+	runningWorkflows   sync.Map
+	activeWorkflows    sync.Map
+	executions         sync.Map
+	cancelFuncs        sync.Map
+	pendingAlerts      sync.WaitGroup
+	draining           atomic.Bool
+	passive            atomic.Bool
+	standby            *standby
+	suspended          sync.Map
 }
 
-func New(logger zerolog.Logger) *Orchestrator {
-	registry := grpc.NewServiceRegistry()
-	exec := executor.NewExecutor(registry, logger)
-	sagaCoordinator := NewSagaCoordinator(exec, logger)
+func New(logger zerolog.Logger, opts ...Option) *Orchestrator {
+	cfg := options{
+		kvStore: kv.NewMemoryStore(),
+		secrets: secrets.NewChain(secrets.DefaultCacheTTL, secrets.NewEnvProvider(secrets.DefaultEnvPrefix)),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	o := &Orchestrator{
+		workflows:          make(map[string]*workflow.Workflow),
+		versions:           make(map[string]map[string]*workflow.Workflow),
+		sources:            make(map[string]string),
+		retiring:           make(map[string]struct{}),
+		quarantine:         newQuarantine(),
+		breakers:           newBreakers(),
+		rerunLimiter:       newRateLimiter(),
+		calendarLimiter:    newRateLimiter(),
+		schedules:          newScheduler(),
+		triggers:           newTriggerSet(cfg.triggerSources),
+		parser:             NewParser(),
+		registry:           grpc.NewServiceRegistry(),
+		metrics:            metrics.NewRegistry(),
+		store:              cfg.executionStore,
+		notifier:           cfg.notifier,
+		events:             events.NewBroker(),
+		webhooks:           cfg.webhooks,
+		redactor:           workflow.NewRedactor(cfg.sensitiveFields),
+		defaultEnvironment: cfg.defaultEnvironment,
+		overrides:          cfg.serviceOverrides,
+		execServices:       cfg.execServices,
+		executionRetention: cmp.Or(cfg.executionRetention, defaultExecutionRetention),
+		commandHooks:       cfg.commandHooks,
+		serviceMerge:       cfg.serviceMerge,
+		nodeID:             cfg.nodeID,
+		logger:             logger,
+	}
+	if o.nodeID == "" {
+		o.nodeID = uuid.New().String()
+	}
+	if cfg.standby {
+		o.standby = &standby{}
+		o.passive.Store(true)
+	}
+	o.parser.AllowServiceTypes(o.registry.HasProtocol)
+
+	o.deadLetters = deadletter.NewMemoryQueue()
+	if queue, ok := o.store.(ports.DeadLetterQueue); ok {
+		o.deadLetters = queue
+	}
+	if o.webhooks != nil {
+		o.webhooks.SetDeadLetterQueue(o.deadLetters)
+	}
+
+	o.reruns = rerun.NewMemoryQueue()
+	if queue, ok := o.store.(ports.RerunQueue); ok {
+		o.reruns = queue
+	}
+
+	locks := cfg.lockManager
+	if locks == nil {
+		locks = lock.NewMemoryLockManager()
+		if shared, ok := o.store.(ports.LockManager); ok {
+			locks = shared
+		}
+	}
+
+	o.idempotency = idempotency.NewMemoryStore()
+	if keys, ok := o.store.(ports.IdempotencyStore); ok {
+		o.idempotency = keys
+	}
 
-	return &Orchestrator{
-		workflows:       make(map[string]*workflow.Workflow),
-		parser:          NewParser(),
-		executor:        exec,
-		sagaCoordinator: sagaCoordinator,
-		registry:        registry,
-		logger:          logger,
+	var sink ports.EventSink = o.events
+	if cfg.eventSink != nil {
+		sink = events.Multi{cfg.eventSink, o.events}
 	}
+
+	o.executor = executor.NewExecutor(o.registry, logger,
+		executor.WithMetrics(o.metrics),
+		executor.WithLockManager(locks),
+		executor.WithKVStore(cfg.kvStore),
+		executor.WithSecretProvider(cfg.secrets),
+		executor.WithRedactor(o.redactor),
+		executor.WithWorkerPoolSize(cfg.workerPoolSize),
+		executor.WithCompensationPoolSize(cfg.compensationPoolSize),
+		executor.WithWorkflowRunner(o),
+		executor.WithEventSink(sink),
+		executor.WithStepMiddleware(cfg.stepMiddleware...),
+	)
+	sagas, _ := o.store.(ports.SagaStore)
+	o.sagaCoordinator = NewSagaCoordinator(o.executor, sagas, logger)
+
+	return o
+}
+
+func (o *Orchestrator) UseStepMiddleware(middleware ...ports.StepMiddleware) {
+	o.executor.Use(middleware...)
 }
 
-func (o *Orchestrator) LoadWorkflow(filename string) error {
+func (o *Orchestrator) Metrics() *metrics.Registry {
+	return o.metrics
+}
+
+func (o *Orchestrator) LoadWorkflow(filename string) (*workflow.Workflow, error) {
 	wf, err := o.parser.ParseFile(filename)
 	if err != nil {
-		return fmt.Errorf("failed to load workflow: %w", err)
+		return nil, fmt.Errorf("failed to load workflow: %w", err)
+	}
+
+	if err := o.registerWorkflow(wf); err != nil {
+		return nil, err
+	}
+	o.setSource(wf, filename)
+	return wf, nil
+}
+
+func (o *Orchestrator) LoadWorkflowData(data []byte, format string) (*workflow.Workflow, error) {
+	if format == "" {
+		format = DetectFormat(data)
+	}
+
+	wf, err := o.parser.ParseFormat(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workflow: %w", err)
 	}
 
+	if err := o.registerWorkflow(wf); err != nil {
+		return nil, err
+	}
+	o.setSource(wf, "")
+	return wf, nil
+}
+
+func (o *Orchestrator) registerWorkflow(wf *workflow.Workflow) error {
+	if wf.HasCommandHooks() && !o.commandHooks {
+		return fmt.Errorf("workflow %s uses command hooks, which are disabled (start maestro with --allow-command-hooks)", wf.Name)
+	}
+	if wf.HasExecServices() && !o.execServices {
+		return fmt.Errorf("workflow %s uses exec services, which are disabled (start maestro with --allow-exec)", wf.Name)
+	}
+
+	defer o.syncTriggers()
 	o.mu.Lock()
 	defer o.mu.Unlock()
-	o.workflows[wf.Name] = wf
 
-	for name, service := range wf.Services {
+	latest := o.workflows[wf.Name]
+	if latest != nil && workflow.CompareVersions(wf.Version, latest.Version) < 0 {
+		if _, err := o.registerVersionServices(wf, o.versions[wf.Name][wf.Version]); err != nil {
+			return err
+		}
+		o.storeVersion(wf)
+		o.logger.Info().
+			Str("workflow", wf.Name).
+			Str("version", wf.Version).
+			Str("latest", latest.Version).
+			Msg("Older workflow version loaded, new executions keep using the latest")
+		return nil
+	}
+
+	var owned map[string]workflow.Service
+	if latest != nil {
+		owned = o.serviceRegistrations(latest, o.overrides)
+	}
+	registrations := o.serviceRegistrations(wf, o.overrides)
+	shared, err := o.sharedServices(wf.Name, registrations)
+	if err != nil {
+		return err
+	}
+
+	undoDemotion := func() {}
+	if latest != nil && latest.Version != wf.Version {
+		if undoDemotion, err = o.registerVersionServices(latest, nil); err != nil {
+			return fmt.Errorf("failed to keep services of version %s: %w", latest.Version, err)
+		}
+	}
+
+	var registered []string
+	replaced := make(map[string]workflow.Service)
+	unretired := make(map[string]struct{})
+	rollback := func() {
+		undoDemotion()
+		for _, name := range registered {
+			_ = o.registry.UnregisterService(name)
+		}
+		for name, service := range replaced {
+			if release, err := o.registry.ReplaceService(name, &service); err == nil {
+				o.releaseAfterInFlight(release)
+			}
+		}
+		maps.Copy(o.retiring, unretired)
+	}
+
+	for name, service := range registrations {
+		if _, ok := shared[name]; ok {
+			continue
+		}
+		_, retiring := o.retiring[name]
+		if _, ok := owned[name]; ok || retiring {
+			if entry, err := o.registry.GetService(name); err == nil {
+				replaced[name] = *entry.Config
+			}
+			release, err := o.registry.ReplaceService(name, &service)
+			if err != nil {
+				rollback()
+				return fmt.Errorf("failed to replace service %s: %w", name, err)
+			}
+			o.releaseAfterInFlight(release)
+			if retiring {
+				delete(o.retiring, name)
+				unretired[name] = struct{}{}
+			}
+			continue
+		}
 		if err := o.registry.RegisterService(name, &service); err != nil {
+			rollback()
 			return fmt.Errorf("failed to register service %s: %w", name, err)
 		}
+		registered = append(registered, name)
+	}
+
+	for name, limit := range wf.ConcurrencyGroups {
+		o.executor.SetConcurrencyGroup(name, limit)
+	}
+
+	o.workflows[wf.Name] = wf
+	o.storeVersion(wf)
+
+	for name := range owned {
+		if _, ok := registrations[name]; !ok {
+			o.retiring[name] = struct{}{}
+		}
 	}
+	o.evictVersions(wf.Name)
+	o.reclaimServices()
 
 	o.logger.Info().
 		Str("workflow", wf.Name).
@@ -65,106 +320,550 @@ func (o *Orchestrator) LoadWorkflow(filename string) error {
 	return nil
 }
 
+func serviceRegistrations(wf *workflow.Workflow, overrides map[string]workflow.ServiceOverride) map[string]workflow.Service {
+	base := wf.WithServiceOverrides(overrides)
+	services := maps.Clone(base.Services)
+	for envName, env := range wf.Environments {
+		for name, override := range env.Services {
+			services[workflow.EnvironmentServiceName(name, envName)] = base.Services[name].WithOverride(override)
+		}
+	}
+	for name, service := range maps.Clone(services) {
+		maps.Copy(services, service.RegionServices(name))
+	}
+	return services
+}
+
+func (o *Orchestrator) serviceRegistrations(wf *workflow.Workflow, overrides map[string]workflow.ServiceOverride) map[string]workflow.Service {
+	services := serviceRegistrations(wf, overrides)
+	if o.serviceMerge != workflow.ServiceMergeNamespace {
+		return services
+	}
+
+	scoped := make(map[string]workflow.Service, len(services))
+	for name, service := range services {
+		scoped[workflow.WorkflowServiceName(wf.Name, name)] = service
+	}
+	return scoped
+}
+
+func (o *Orchestrator) versionRegistrations(wf *workflow.Workflow, overrides map[string]workflow.ServiceOverride) map[string]workflow.Service {
+	services := o.serviceRegistrations(wf, overrides)
+	versioned := make(map[string]workflow.Service, len(services))
+	for name, service := range services {
+		versioned[workflow.VersionServiceName(wf.Name, wf.Version, name)] = service
+	}
+	return versioned
+}
+
+func (o *Orchestrator) registerVersionServices(wf, previous *workflow.Workflow) (func(), error) {
+	var owned map[string]workflow.Service
+	if previous != nil {
+		owned = o.versionRegistrations(previous, o.overrides)
+	}
+	registrations := o.versionRegistrations(wf, o.overrides)
+
+	var registered []string
+	replaced := make(map[string]workflow.Service)
+	undo := func() {
+		for _, name := range registered {
+			_ = o.registry.UnregisterService(name)
+		}
+		for name, service := range replaced {
+			if release, err := o.registry.ReplaceService(name, &service); err == nil {
+				o.releaseAfterInFlight(release)
+			}
+		}
+	}
+
+	for name, service := range registrations {
+		entry, err := o.registry.GetService(name)
+		if err != nil {
+			if err := o.registry.RegisterService(name, &service); err != nil {
+				undo()
+				return nil, fmt.Errorf("failed to register service %s: %w", name, err)
+			}
+			registered = append(registered, name)
+			continue
+		}
+		delete(o.retiring, name)
+		if reflect.DeepEqual(*entry.Config, service) {
+			continue
+		}
+		replaced[name] = *entry.Config
+		release, err := o.registry.ReplaceService(name, &service)
+		if err != nil {
+			undo()
+			return nil, fmt.Errorf("failed to replace service %s: %w", name, err)
+		}
+		o.releaseAfterInFlight(release)
+	}
+
+	for name := range owned {
+		if _, ok := registrations[name]; !ok {
+			o.retiring[name] = struct{}{}
+		}
+	}
+	return undo, nil
+}
+
+func (o *Orchestrator) sharedServices(name string, registrations map[string]workflow.Service) (map[string]struct{}, error) {
+	shared := make(map[string]struct{})
+	for _, other := range o.workflows {
+		if other.Name == name {
+			continue
+		}
+		for service, config := range o.serviceRegistrations(other, o.overrides) {
+			candidate, ok := registrations[service]
+			if !ok {
+				continue
+			}
+			if o.serviceMerge == workflow.ServiceMergeError {
+				return nil, fmt.Errorf("service %s is already registered by workflow %s", service, other.Name)
+			}
+			if !reflect.DeepEqual(candidate, config) {
+				return nil, fmt.Errorf("service %s is already registered by workflow %s with a different definition", service, other.Name)
+			}
+			shared[service] = struct{}{}
+		}
+	}
+	return shared, nil
+}
+
+func (o *Orchestrator) SetServiceOverrides(overrides map[string]workflow.ServiceOverride) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, wf := range o.workflows {
+		current := o.serviceRegistrations(wf, o.overrides)
+		updated := o.serviceRegistrations(wf, overrides)
+		for _, version := range o.versions[wf.Name] {
+			if version.Version != wf.Version {
+				maps.Copy(current, o.versionRegistrations(version, o.overrides))
+				maps.Copy(updated, o.versionRegistrations(version, overrides))
+			}
+		}
+		for name, service := range updated {
+			if reflect.DeepEqual(current[name], service) {
+				continue
+			}
+			release, err := o.registry.ReplaceService(name, &service)
+			if err != nil {
+				return fmt.Errorf("failed to apply override for service %s: %w", name, err)
+			}
+			o.releaseAfterInFlight(release)
+		}
+	}
+
+	o.overrides = overrides
+	return nil
+}
+
+func (o *Orchestrator) RegisterHandler(name string, handler local.Handler) error {
+	if err := o.registry.Handlers().Register(name, handler); err != nil {
+		return err
+	}
+	o.logger.Info().Str("handler", name).Msg("Local handler registered")
+	return nil
+}
+
+func (o *Orchestrator) UnregisterHandler(name string) {
+	o.registry.Handlers().Unregister(name)
+}
+
+func (o *Orchestrator) RegisterProtocol(kind string, factory ports.ProtocolFactory) error {
+	if err := o.registry.RegisterProtocol(kind, factory); err != nil {
+		return err
+	}
+	o.logger.Info().Str("protocol", kind).Msg("Protocol adapter registered")
+	return nil
+}
+
+func (o *Orchestrator) SetWorkerLimits(workers, compensationWorkers int) {
+	o.executor.SetWorkerPoolSize(workers)
+	o.executor.SetCompensationPoolSize(compensationWorkers)
+}
+
+func WithEnvironment(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, ctxkeys.Environment, name)
+}
+
+func WithVersion(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, ctxkeys.RequestedVersion, version)
+}
+
+func WithWorkflowID(ctx context.Context, workflowID string) context.Context {
+	return context.WithValue(ctx, ctxkeys.AssignedID, workflowID)
+}
+
+func WithCallback(ctx context.Context, url string) context.Context {
+	return context.WithValue(ctx, ctxkeys.Callback, url)
+}
+
+func WithTags(ctx context.Context, tags map[string]string) context.Context {
+	return context.WithValue(ctx, ctxkeys.Tags, tags)
+}
+
+type run struct {
+	ctx        context.Context
+	cancel     context.CancelFunc
+	wf         *workflow.Workflow
+	execCtx    *workflow.ExecutionContext
+	result     *workflow.WorkflowResult
+	execution  *workflow.Execution
+	lease      *lease
+	span       trace.Span
+	completed  map[string]bool
+	logger     zerolog.Logger
+	callbacks  []string
+	child      bool
+	failedStep string
+}
+
 func (o *Orchestrator) ExecuteWorkflow(
 	ctx context.Context,
 	workflowName string,
 	input map[string]interface{},
 ) (*workflow.WorkflowResult, error) {
+	r, err := o.prepareRun(ctx, workflowName, input)
+	var duplicate *workflow.DuplicateExecutionError
+	if errors.As(err, &duplicate) {
+		return o.awaitExecution(ctx, duplicate.WorkflowID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return o.execute(r)
+}
+
+func (o *Orchestrator) StartWorkflow(
+	ctx context.Context,
+	workflowName string,
+	input map[string]interface{},
+) (string, error) {
+	r, err := o.prepareRun(ctx, workflowName, input)
+	var duplicate *workflow.DuplicateExecutionError
+	if errors.As(err, &duplicate) {
+		return duplicate.WorkflowID, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	go func() {
+		_, _ = o.execute(r)
+	}()
+
+	return r.execCtx.WorkflowID, nil
+}
+
+func (o *Orchestrator) prepareRun(
+	ctx context.Context,
+	workflowName string,
+	input map[string]interface{},
+) (*run, error) {
+	if key, _ := ctx.Value(ctxkeys.Idempotency).(string); key != "" {
+		return o.prepareIdempotentRun(ctx, workflowName, key, input)
+	}
+
+	version, _ := ctx.Value(ctxkeys.RequestedVersion).(string)
+	ctx = context.WithValue(ctx, ctxkeys.RequestedVersion, "")
+
 	o.mu.RLock()
-	wf, exists := o.workflows[workflowName]
+	wf, exists := o.workflowVersion(workflowName, version)
+	overrides := o.overrides
 	o.mu.RUnlock()
 
 	if !exists {
+		if version != "" {
+			return nil, &workflow.VersionNotFoundError{Workflow: workflowName, Version: version}
+		}
 		return nil, fmt.Errorf("workflow %s not found", workflowName)
 	}
+	parentID, child := ctx.Value(ctxkeys.WorkflowID).(string)
+	if o.draining.Load() && !child {
+		return nil, workflow.ErrDraining
+	}
+	if o.passive.Load() && !child {
+		return nil, workflow.ErrStandby
+	}
+	if err := o.checkQuarantine(workflowName); err != nil {
+		return nil, err
+	}
+	if err := o.checkBreaker(wf); err != nil {
+		return nil, err
+	}
+	if !child && !isDryRun(ctx) && ctx.Value(ctxkeys.Shadow) == nil {
+		if err := o.checkCalendar(wf); err != nil {
+			return nil, err
+		}
+	}
+	if parent, ok := ctx.Value(ctxkeys.Namespace).(string); ok {
+		if err := workflow.CheckNamespace(parent, wf.Namespace); err != nil {
+			return nil, fmt.Errorf("cannot run workflow %s: %w", workflowName, err)
+		}
+	}
+	if err := ValidateInput(wf, input); err != nil {
+		return nil, err
+	}
+	loaded := wf
+	if len(overrides) > 0 {
+		wf = wf.WithServiceOverrides(overrides)
+	}
 
-	workflowID := uuid.New().String()
-	logger := o.logger.With().
-		Str("workflow_id", workflowID).
-		Str("workflow_name", workflowName).
-		Logger()
+	environment, _ := ctx.Value(ctxkeys.Environment).(string)
+	explicit := environment != ""
+	if !explicit {
+		environment = o.defaultEnvironment
+	}
+	if environment != "" {
+		scoped, err := wf.ForEnvironment(environment)
+		switch {
+		case err == nil:
+			wf = scoped
+		case explicit:
+			return nil, err
+		default:
+			environment = ""
+		}
+	}
 
-	logger.Info().
-		Interface("input", input).
-		Msg("Starting workflow execution")
+	if err := o.preflight(ctx, wf, environment); err != nil {
+		o.logger.Warn().
+			Err(err).
+			Str("workflow", workflowName).
+			Str("environment", environment).
+			Msg("Workflow failed preflight")
+		return nil, err
+	}
 
-	execCtx := &workflow.ExecutionContext{
-		WorkflowID:    workflowID,
-		Input:         input,
-		Variables:     make(map[string]interface{}),
-		StepOutputs:   make(map[string]interface{}),
-		ExecutedSteps: []workflow.ExecutedStep{},
+	workflowID, _ := ctx.Value(ctxkeys.AssignedID).(string)
+	if workflowID == "" {
+		workflowID = uuid.New().String()
+	} else if _, exists := o.executions.Load(workflowID); exists {
+		return nil, fmt.Errorf("execution %s already exists", workflowID)
 	}
+	ctx = context.WithValue(ctx, ctxkeys.AssignedID, "")
 
-	if wf.Timeout.Duration > 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, wf.Timeout.Duration)
-		defer cancel()
+	callbacks, err := o.completionCallbacks(ctx, wf)
+	if err != nil {
+		return nil, fmt.Errorf("cannot run workflow %s: %w", workflowName, err)
 	}
+	ctx = context.WithValue(ctx, ctxkeys.Callback, "")
 
-	ctx = context.WithValue(ctx, ctxkeys.WorkflowID, workflowID)
-	ctx = context.WithValue(ctx, ctxkeys.WorkflowName, workflowName)
+	tags, _ := ctx.Value(ctxkeys.Tags).(map[string]string)
+	if err := workflow.ValidateTags(tags); err != nil {
+		return nil, fmt.Errorf("cannot run workflow %s: %w", workflowName, err)
+	}
+
+	rerunOf, _ := ctx.Value(ctxkeys.Rerun).(*workflow.Rerun)
+	ctx = context.WithValue(ctx, ctxkeys.Rerun, (*workflow.Rerun)(nil))
+
+	ctx, lease, err := o.claimExecution(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	execCtx := &workflow.ExecutionContext{
+		WorkflowID:     workflowID,
+		WorkflowName:   wf.Name,
+		ParentID:       parentID,
+		Namespace:      wf.Namespace,
+		Environment:    environment,
+		TemplateEngine: wf.TemplateEngine,
+		Tags:           tags,
+		Input:          input,
+		Variables:      make(map[string]interface{}),
+		StepOutputs:    make(map[string]interface{}),
+		ExecutedSteps:  []workflow.ExecutedStep{},
+	}
+
+	if rerunOf != nil {
+		execCtx.RerunOf = rerunOf.OriginalID
+		execCtx.RerunAttempt = rerunOf.Attempt
+	}
 
-	startedAt := time.Now()
 	result := &workflow.WorkflowResult{
 		WorkflowID: workflowID,
 		Status:     workflow.WorkflowStatusRunning,
-		StartedAt:  startedAt,
+		StartedAt:  time.Now(),
+	}
+
+	execution := &workflow.Execution{
+		WorkflowName:    wf.Name,
+		WorkflowVersion: wf.Version,
+		Context:         execCtx,
+		Result:          result,
+		Retention:       wf.RetentionPolicy(),
+	}
+
+	if err := o.checkpoint(ctx, execution); err != nil {
+		o.releaseLease(&run{ctx: ctx, execCtx: execCtx, lease: lease, logger: o.logger})
+		return nil, err
+	}
+	o.executions.Store(workflowID, execution)
+
+	r := o.newRun(ctx, wf, loaded, execution, lease)
+	r.callbacks = callbacks
+	r.child = child
+	return r, nil
+}
+
+// newRun sets up the context of an execution that is about to run, either
+// freshly prepared or resumed, and registers it as running.
+func (o *Orchestrator) newRun(
+	ctx context.Context,
+	wf, loaded *workflow.Workflow,
+	execution *workflow.Execution,
+	lease *lease,
+) *run {
+	execCtx, result := execution.Context, execution.Result
+	workflowID, environment := execCtx.WorkflowID, execCtx.Environment
+	loggerCtx := o.logger.With().
+		Str("workflow_id", workflowID).
+		Str("workflow_name", wf.Name).
+		Str("namespace", wf.Namespace).
+		Str("environment", environment)
+	if parentID, ok := ctx.Value(ctxkeys.WorkflowID).(string); ok {
+		loggerCtx = loggerCtx.Str("parent_workflow_id", parentID)
+	}
+	if len(execCtx.Tags) > 0 {
+		loggerCtx = loggerCtx.Interface("tags", execCtx.Tags)
+	}
+	logger := loggerCtx.Logger()
+
+	ctx, cancel := context.WithCancel(ctx)
+	if wf.Timeout.Duration > 0 {
+		var timeoutCancel context.CancelFunc
+		ctx, timeoutCancel = context.WithDeadline(ctx, result.StartedAt.Add(wf.Timeout.Duration))
+		parentCancel := cancel
+		cancel = func() {
+			timeoutCancel()
+			parentCancel()
+		}
 	}
 
+	ctx = withCallStack(ctx, wf.Name)
+	ctx = context.WithValue(ctx, ctxkeys.WorkflowID, workflowID)
+	ctx = context.WithValue(ctx, ctxkeys.WorkflowName, wf.Name)
+	ctx = context.WithValue(ctx, ctxkeys.WorkflowVersion, loaded.Version)
+	ctx = context.WithValue(ctx, ctxkeys.Namespace, wf.Namespace)
+	ctx = context.WithValue(ctx, ctxkeys.Environment, environment)
+	ctx = context.WithValue(ctx, ctxkeys.Redactor, o.redactor.ForWorkflow(wf))
+
 	o.runningWorkflows.Store(workflowID, result)
-	defer o.runningWorkflows.Delete(workflowID)
+	o.activeWorkflows.Store(workflowID, loaded)
+	o.cancelFuncs.Store(workflowID, cancel)
+	o.sagaCoordinator.SaveState(ctx, execCtx, workflow.SagaStatusRunning)
 
-	for _, step := range wf.Steps {
-		select {
-		case <-ctx.Done():
-			result.Status = workflow.WorkflowStatusCancelled
-			result.Error = ctx.Err()
-			result.CompletedAt = time.Now()
-			return result, ctx.Err()
-		default:
+	ctx, span := startWorkflowSpan(ctx, wf, workflowID, environment, execCtx.Tags)
+
+	return &run{
+		ctx:       ctx,
+		span:      span,
+		cancel:    cancel,
+		wf:        wf,
+		execCtx:   execCtx,
+		result:    result,
+		execution: execution,
+		lease:     lease,
+		logger:    logger,
+	}
+}
+
+func (o *Orchestrator) execute(r *run) (*workflow.WorkflowResult, error) {
+	ctx, wf, execCtx, result, logger := r.ctx, r.wf, r.execCtx, r.result, r.logger
+	workflowID := execCtx.WorkflowID
+
+	defer o.evictExecution(r)
+	defer r.cancel()
+	defer r.endSpan()
+	defer o.reclaimOrphanedServices(wf.Name)
+	defer o.activeWorkflows.Delete(workflowID)
+	defer o.runningWorkflows.Delete(workflowID)
+	defer o.cancelFuncs.Delete(workflowID)
+	defer func() {
+		if r.handedOff() {
+			o.executor.StopLockRenewals(execCtx)
+			return
 		}
+		o.executor.ReleaseLocks(context.WithoutCancel(ctx), execCtx)
+	}()
+	defer o.executor.ClearSignals(workflowID)
+	stopLease := o.keepLease(r)
+	defer o.recordOutcome(wf, result)
+	defer o.recordBreakerOutcome(r)
+	defer o.raiseAlerts(r)
+	defer o.publishCompletion(r)
+	defer o.emitWorkflowFinished(r)
+	defer func() {
+		defer stopLease()
+		if r.lease.isFenced() {
+			return
+		}
+		o.checkpointOutcome(r)
+		o.sagaCoordinator.SaveState(ctx, execCtx, workflow.SagaStatusOf(result.Status))
+		if result.Status == workflow.WorkflowStatusSuspended {
+			stopLease()
+			o.releaseLease(r)
+		}
+	}()
+	defer o.scheduleRerun(r)
+	defer o.runFinally(r)
 
-		stepResult, err := o.executor.ExecuteStep(ctx, &step, execCtx, wf)
-		if err != nil {
-			logger.Error().
-				Err(err).
-				Str("step_id", step.ID).
-				Msg("Step execution failed")
-
-			compensationErr := o.sagaCoordinator.Compensate(ctx, execCtx, wf)
-			if compensationErr != nil {
-				logger.Error().
-					Err(compensationErr).
-					Msg("Compensation failed")
-				result.Status = workflow.WorkflowStatusFailed
-			} else {
-				result.Status = workflow.WorkflowStatusCompensated
-			}
+	logger.Info().
+		Interface("input", o.redactorFor(ctx).Input(execCtx.Input)).
+		Msg("Starting workflow execution")
+	if len(execCtx.HeldLocks) > 0 {
+		o.executor.ResumeLocks(ctx, execCtx)
+	}
+	o.executor.Emit(ctx, workflow.Event{Type: workflow.EventWorkflowStarted})
 
-			result.Error = err
-			result.CompletedAt = time.Now()
+	graph, err := NewValidator().BuildGraph(wf)
+	if err != nil {
+		result.Complete(workflow.WorkflowStatusFailed, err)
+		return result, err
+	}
+
+	stepErr, err := o.runSteps(r, graph)
+	if errors.Is(err, errDrained) {
+		o.suspend(r)
+		return result, nil
+	}
+	if err != nil {
+		result.Complete(workflow.WorkflowStatusCancelled, err)
+		return result, err
+	}
+
+	if stepErr != nil {
+		err := stepErr
+
+		var expired *workflow.WaitExpiredError
+		if errors.As(err, &expired) && !expired.Compensate {
+			result.Complete(workflow.WorkflowStatusFailed, err)
 			return result, err
 		}
 
-		if stepResult != nil {
-			if step.Output != "" {
-				execCtx.StepOutputs[step.Output] = stepResult.Output
-			}
-
-			if step.Compensate != nil {
-				execCtx.ExecutedSteps = append(execCtx.ExecutedSteps, workflow.ExecutedStep{
-					StepID:       step.ID,
-					Output:       stepResult.Output,
-					Compensation: step.Compensate,
-				})
+		result.SetStatus(workflow.WorkflowStatusCompensating)
+		compensationErr := o.sagaCoordinator.Compensate(context.WithoutCancel(ctx), execCtx, wf)
+		if compensationErr != nil {
+			logger.Error().
+				Err(compensationErr).
+				Msg("Compensation failed")
+			var unfinished *workflow.CompensationError
+			if errors.As(compensationErr, &unfinished) {
+				result.SetUnfinishedCompensations(unfinished.Unfinished)
 			}
+			result.Complete(workflow.WorkflowStatusFailed, err)
+		} else {
+			result.Complete(workflow.WorkflowStatusCompensated, err)
 		}
+		return result, err
 	}
 
 	resultOutput := make(map[string]interface{})
 	for key, tmpl := range wf.Output {
-		value, err := o.parser.ResolveTemplate(tmpl, map[string]interface{}{
+		value, err := o.parser.RenderTemplate(wf.TemplateEngine, tmpl, map[string]interface{}{
 			"input": execCtx.Input,
 		})
 		if err != nil {
@@ -185,36 +884,148 @@ func (o *Orchestrator) ExecuteWorkflow(
 		}
 	}
 
-	result.Status = workflow.WorkflowStatusSuccess
-	result.Output = resultOutput
-	result.CompletedAt = time.Now()
+	result.Succeed(resultOutput)
 
 	logger.Info().
 		Str("status", result.Status.String()).
 		Dur("duration", result.CompletedAt.Sub(result.StartedAt)).
-		Interface("output", result.Output).
+		Interface("output", o.redactorFor(ctx).Outputs(result.Output)).
 		Msg("Workflow execution completed")
 
 	return result, nil
 }
 
-func (o *Orchestrator) GetWorkflowStatus(workflowID string) (*workflow.WorkflowResult, bool) {
+func (o *Orchestrator) GetWorkflowStatus(workflowID string) (*workflow.WorkflowResult, bool, error) {
 	if result, ok := o.runningWorkflows.Load(workflowID); ok {
-		return result.(*workflow.WorkflowResult), true
+		return result.(*workflow.WorkflowResult).Copy(), true, nil
 	}
-	return nil, false
+	execution, ok, err := o.GetExecution(workflowID)
+	if !ok {
+		return nil, false, err
+	}
+	return execution.Result, true, nil
 }
 
 func (o *Orchestrator) CancelWorkflow(workflowID string) error {
-	if result, ok := o.runningWorkflows.Load(workflowID); ok {
-		wfResult := result.(*workflow.WorkflowResult)
-		wfResult.Status = workflow.WorkflowStatusCancelled
-		wfResult.CompletedAt = time.Now()
+	if _, ok := o.runningWorkflows.Load(workflowID); ok {
+		if cancel, ok := o.cancelFuncs.Load(workflowID); ok {
+			cancel.(context.CancelFunc)()
+		}
 		return nil
 	}
 	return fmt.Errorf("workflow %s not found", workflowID)
 }
 
+func (o *Orchestrator) SignalWorkflow(workflowID, signal string, payload interface{}) error {
+	if _, ok := o.runningWorkflows.Load(workflowID); !ok {
+		return fmt.Errorf("workflow %s is not running", workflowID)
+	}
+
+	if err := o.executor.DeliverSignal(workflowID, signal, payload); err != nil {
+		return err
+	}
+
+	o.logger.Info().
+		Str("workflow_id", workflowID).
+		Str("signal", signal).
+		Msg("Signal delivered")
+
+	return nil
+}
+
+func (o *Orchestrator) OverrideStep(workflowID, stepID string, decision workflow.StepOverride) error {
+	if _, ok := o.runningWorkflows.Load(workflowID); !ok {
+		return fmt.Errorf("workflow %s is not running", workflowID)
+	}
+
+	held, err := o.executor.OverrideStep(workflowID, stepID, decision)
+	if err != nil {
+		return err
+	}
+
+	o.logger.Info().
+		Str("workflow_id", workflowID).
+		Str("step_id", stepID).
+		Str("operator", decision.Operator).
+		Bool("held", held).
+		Bool("fail", decision.Fail).
+		Msg("Step override delivered")
+
+	return nil
+}
+
+func (o *Orchestrator) HeldSteps(workflowID string) []string {
+	return o.executor.HeldSteps(workflowID)
+}
+
+func (o *Orchestrator) GetExecution(workflowID string) (*workflow.Execution, bool, error) {
+	if execution, ok := o.executions.Load(workflowID); ok {
+		return execution.(*workflow.Execution).Copy(), true, nil
+	}
+	return o.loadExecution(workflowID)
+}
+
+func (o *Orchestrator) execution(workflowID string) (*workflow.Execution, bool, error) {
+	if execution, ok := o.executions.Load(workflowID); ok {
+		return execution.(*workflow.Execution), true, nil
+	}
+	return o.loadExecution(workflowID)
+}
+
+func (o *Orchestrator) loadExecution(workflowID string) (*workflow.Execution, bool, error) {
+	if o.store == nil {
+		return nil, false, nil
+	}
+
+	execution, ok, err := o.store.LoadExecution(context.Background(), workflowID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load execution %s: %w", workflowID, err)
+	}
+	return execution, ok, nil
+}
+
+func (o *Orchestrator) GetWorkflow(name string) (*workflow.Workflow, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	wf, ok := o.workflows[name]
+	return wf, ok
+}
+
+func (o *Orchestrator) GetWorkflowVersion(name, version string) (*workflow.Workflow, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return o.workflowVersion(name, version)
+}
+
+func (o *Orchestrator) WorkflowVersions(name string) []string {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	versions := slices.Collect(maps.Keys(o.versions[name]))
+	slices.SortFunc(versions, workflow.CompareVersions)
+	return versions
+}
+
+func (o *Orchestrator) workflowVersion(name, version string) (*workflow.Workflow, bool) {
+	if version == "" {
+		wf, ok := o.workflows[name]
+		return wf, ok
+	}
+	wf, ok := o.versions[name][version]
+	return wf, ok
+}
+
+func (o *Orchestrator) storeVersion(wf *workflow.Workflow) {
+	versions := o.versions[wf.Name]
+	if versions == nil {
+		versions = make(map[string]*workflow.Workflow)
+		o.versions[wf.Name] = versions
+	}
+	versions[wf.Version] = wf
+}
+
 func (o *Orchestrator) ListWorkflows() []string {
 	o.mu.RLock()
 	defer o.mu.RUnlock()
@@ -0,0 +1,204 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/rs/zerolog"
+)
+
+const conditionalWorkflow = `
+name: payouts
+version: "1.0.0"
+services:
+  bank:
+    type: http
+    endpoint: %s
+steps:
+  - id: verify
+    service: bank
+    method: verify
+    output: verification
+  - id: pay
+    service: bank
+    method: pay
+    when: %q
+`
+
+func TestWhenReadsPriorOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		when    string
+		wantPay bool
+	}{
+		{name: "a true condition runs the step", when: "{{ verification.id == 'verify-1' }}", wantPay: true},
+		{name: "a false condition skips the step", when: "{{ verification.id == 'rejected' }}"},
+		{name: "a go template condition", when: "{{ if eq .verification.id \"verify-1\" }}true{{ else }}false{{ end }}", wantPay: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &recordingService{}
+			server := httptest.NewServer(service)
+			defer server.Close()
+
+			o := New(zerolog.Nop())
+			definition := fmt.Sprintf(conditionalWorkflow, server.URL, tt.when)
+			if _, err := o.LoadWorkflowData([]byte(definition), FormatYAML); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := o.ExecuteWorkflow(context.Background(), "payouts", map[string]interface{}{}); err != nil {
+				t.Fatalf("ExecuteWorkflow() error = %v", err)
+			}
+
+			paid := slices.Contains(service.recorded(), "start pay")
+			if paid != tt.wantPay {
+				t.Errorf("pay called = %v, want %v (events %v)", paid, tt.wantPay, service.recorded())
+			}
+		})
+	}
+}
+
+const versionedWorkflow = `
+name: invoices
+version: "%s"
+services:
+  billing:
+    type: http
+    endpoint: %s
+steps:
+  - id: issue
+    service: billing
+    method: issue
+`
+
+func loadVersion(t *testing.T, o *Orchestrator, version, endpoint string) {
+	t.Helper()
+	if _, err := o.LoadWorkflowData([]byte(fmt.Sprintf(versionedWorkflow, version, endpoint)), FormatYAML); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVersionEviction(t *testing.T) {
+	tests := []struct {
+		name       string
+		load       []string
+		inFlight   bool
+		run        string
+		wantDuring []string
+		want       []string
+	}{
+		{
+			name: "a replaced version nothing runs on is evicted",
+			load: []string{"1.0.0", "2.0.0"},
+			want: []string{"2.0.0"},
+		},
+		{
+			name:       "a replaced version is kept until its execution ends",
+			load:       []string{"1.0.0", "2.0.0"},
+			inFlight:   true,
+			wantDuring: []string{"1.0.0", "2.0.0"},
+			want:       []string{"2.0.0"},
+		},
+		{
+			name: "an older version loaded later stays routable",
+			load: []string{"2.0.0", "1.0.0"},
+			want: []string{"1.0.0", "2.0.0"},
+		},
+		{
+			name: "an older version is evicted once its execution ends",
+			load: []string{"2.0.0", "1.0.0"},
+			run:  "1.0.0",
+			want: []string{"2.0.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &recordingService{}
+			if tt.inFlight {
+				service.delay = map[string]time.Duration{"issue": 100 * time.Millisecond}
+			}
+			server := httptest.NewServer(service)
+			defer server.Close()
+
+			o := New(zerolog.Nop())
+			loadVersion(t, o, tt.load[0], server.URL)
+
+			done := make(chan error, 1)
+			if tt.inFlight {
+				go func() {
+					_, err := o.ExecuteWorkflow(context.Background(), "invoices", map[string]interface{}{})
+					done <- err
+				}()
+				for !slices.Contains(service.recorded(), "start issue") {
+					time.Sleep(5 * time.Millisecond)
+				}
+			} else {
+				done <- nil
+			}
+
+			for _, version := range tt.load[1:] {
+				loadVersion(t, o, version, server.URL)
+			}
+			if tt.wantDuring != nil {
+				if got := o.WorkflowVersions("invoices"); !slices.Equal(got, tt.wantDuring) {
+					t.Errorf("versions while running = %v, want %v", got, tt.wantDuring)
+				}
+			}
+			if err := <-done; err != nil {
+				t.Fatalf("ExecuteWorkflow() error = %v", err)
+			}
+
+			if tt.run != "" {
+				ctx := WithVersion(context.Background(), tt.run)
+				if _, err := o.ExecuteWorkflow(ctx, "invoices", map[string]interface{}{}); err != nil {
+					t.Fatalf("ExecuteWorkflow(%s) error = %v", tt.run, err)
+				}
+			}
+
+			if got := o.WorkflowVersions("invoices"); !slices.Equal(got, tt.want) {
+				t.Errorf("versions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdempotencyKeyVersion(t *testing.T) {
+	service := &recordingService{}
+	server := httptest.NewServer(service)
+	defer server.Close()
+
+	o := New(zerolog.Nop())
+	loadVersion(t, o, "2.0.0", server.URL)
+	loadVersion(t, o, "1.0.0", server.URL)
+
+	ctx := WithIdempotencyKey(context.Background(), "invoice-1")
+	first, err := o.ExecuteWorkflow(WithVersion(ctx, "1.0.0"), "invoices", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("ExecuteWorkflow() error = %v", err)
+	}
+
+	_, err = o.ExecuteWorkflow(WithVersion(ctx, "2.0.0"), "invoices", map[string]interface{}{})
+	if !errors.Is(err, domain.ErrIdempotencyConflict) {
+		t.Errorf("same key pinned to another version: error = %v, want %v", err, domain.ErrIdempotencyConflict)
+	}
+
+	again, err := o.ExecuteWorkflow(ctx, "invoices", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("same key without a version: error = %v", err)
+	}
+	if again.WorkflowID != first.WorkflowID {
+		t.Errorf("same key without a version started %s, want the original %s", again.WorkflowID, first.WorkflowID)
+	}
+	if n := len(service.recorded()); n != 2 {
+		t.Errorf("got %d service events, want 2 (one call)", n)
+	}
+}
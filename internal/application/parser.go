@@ -2,120 +2,1416 @@ package application
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"net/url"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"text/template"
 
+	"github.com/maestro/maestro.go/internal/application/expression"
+	"github.com/maestro/maestro.go/internal/application/functions"
+	"github.com/maestro/maestro.go/internal/application/templating"
+	"github.com/maestro/maestro.go/internal/application/validation"
 	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/grpc"
+	"github.com/maestro/maestro.go/internal/infrastructure/metrics"
+	"github.com/maestro/maestro.go/internal/infrastructure/redis"
 	"gopkg.in/yaml.v3"
 )
 
 type Parser struct {
 	templateEngine *template.Template
+	serviceTypes   func(kind string) bool
 }
 
 func NewParser() *Parser {
 	return &Parser{
-		templateEngine: template.New("workflow").Option("missingkey=error"),
+		templateEngine: template.New("workflow").Funcs(functions.Template).Option("missingkey=error"),
 	}
 }
 
+func (p *Parser) AllowServiceTypes(allowed func(kind string) bool) {
+	p.serviceTypes = allowed
+}
+
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
 func (p *Parser) ParseFile(filename string) (*domain.Workflow, error) {
 	data, err := os.ReadFile(filename)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read workflow file: %w", err)
 	}
 
-	return p.Parse(data)
-}
+	format := DetectFormat(data)
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		format = FormatJSON
+	}
+
+	return p.parse(data, format, filepath.Dir(filename))
+}
+
+func (p *Parser) Parse(data []byte) (*domain.Workflow, error) {
+	return p.ParseFormat(data, DetectFormat(data))
+}
+
+func (p *Parser) ParseFormat(data []byte, format string) (*domain.Workflow, error) {
+	return p.parse(data, format, "")
+}
+
+func (p *Parser) parse(data []byte, format, baseDir string) (*domain.Workflow, error) {
+	var workflow domain.Workflow
+
+	switch format {
+	case FormatJSON:
+		if err := json.Unmarshal(data, &workflow); err != nil {
+			return nil, fmt.Errorf("failed to parse workflow JSON: %w", err)
+		}
+	case FormatYAML:
+		if err := yaml.Unmarshal(data, &workflow); err != nil {
+			return nil, fmt.Errorf("failed to parse workflow YAML: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported workflow format %s", format)
+	}
+
+	if baseDir != "" {
+		resolveServicePaths(workflow.Services, baseDir)
+	}
+
+	if err := importServices(&workflow, baseDir); err != nil {
+		return nil, fmt.Errorf("workflow validation failed: %w", err)
+	}
+
+	if err := mergeProfiles(&workflow); err != nil {
+		return nil, fmt.Errorf("workflow validation failed: %w", err)
+	}
+
+	if err := p.validateWorkflow(&workflow); err != nil {
+		return nil, fmt.Errorf("workflow validation failed: %w", err)
+	}
+
+	return &workflow, nil
+}
+
+func DetectFormat(data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		return FormatJSON
+	}
+	return FormatYAML
+}
+
+func (p *Parser) validateWorkflow(w *domain.Workflow) error {
+	if w.Name == "" {
+		return fmt.Errorf("workflow name is required")
+	}
+
+	if w.Version == "" {
+		return fmt.Errorf("workflow version is required")
+	}
+
+	if strings.ContainsAny(w.Namespace, "/ ") {
+		return fmt.Errorf("namespace %q cannot contain slashes or spaces", w.Namespace)
+	}
+
+	if w.CallbackURL != "" {
+		if u, err := url.Parse(w.CallbackURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid callback_url %q", w.CallbackURL)
+		}
+	}
+
+	if len(w.Steps) == 0 {
+		return fmt.Errorf("workflow must have at least one step")
+	}
+
+	for name, service := range w.Services {
+		if service.Type == "local" && service.Endpoint == "" {
+			service.Endpoint = name
+			w.Services[name] = service
+		}
+		if err := p.validateService(name, &service); err != nil {
+			return err
+		}
+	}
+
+	for i := range w.BeforeEach {
+		if err := p.validateHook(&w.BeforeEach[i], w.Services); err != nil {
+			return fmt.Errorf("before_each hook %d: %w", i, err)
+		}
+	}
+
+	for i := range w.AfterEach {
+		if err := p.validateHook(&w.AfterEach[i], w.Services); err != nil {
+			return fmt.Errorf("after_each hook %d: %w", i, err)
+		}
+	}
+
+	for i, step := range w.Steps {
+		if err := p.validateStep(&step, w.Services, i); err != nil {
+			return err
+		}
+	}
+
+	if err := validateStates(w); err != nil {
+		return err
+	}
+
+	ids := collectStepIDs(w.Steps, nil)
+	for i := range w.Finally {
+		step := &w.Finally[i]
+		if step.ID == "" {
+			step.ID = fmt.Sprintf("finally_%d", i)
+		}
+		if ids[step.ID] {
+			return fmt.Errorf("finally step %s: duplicate step ID", step.ID)
+		}
+		if len(step.DependsOn) > 0 || len(step.CompensateAfter) > 0 || step.Compensate != nil {
+			return fmt.Errorf("finally step %s: finally steps run after compensation and cannot declare depends_on or compensation", step.ID)
+		}
+		if err := p.validateStep(step, w.Services, i); err != nil {
+			return fmt.Errorf("finally: %w", err)
+		}
+	}
+
+	if c := w.Compensation; c != nil {
+		if c.Concurrency < 0 {
+			return fmt.Errorf("compensation concurrency must not be negative")
+		}
+		switch c.OnFailure {
+		case "", domain.CompensationContinue, domain.CompensationStop:
+		default:
+			return fmt.Errorf("invalid compensation on_failure %s (must be 'continue' or 'stop')", c.OnFailure)
+		}
+		if c.Retry != nil {
+			if err := p.validateRetry(c.Retry); err != nil {
+				return fmt.Errorf("compensation: %w", err)
+			}
+		}
+	}
+
+	if len(w.InputSchema) > 0 {
+		if err := validation.Check(w.InputSchema); err != nil {
+			return fmt.Errorf("input_schema: %w", err)
+		}
+	}
+
+	if t := w.Trigger; t != nil {
+		switch t.Type {
+		case "":
+			if t.Topic != "" || t.Group != "" {
+				return fmt.Errorf("trigger topic and group require a trigger type")
+			}
+		case domain.TriggerKafka:
+			if t.Topic == "" {
+				return fmt.Errorf("%s trigger requires a topic", t.Type)
+			}
+		default:
+			return fmt.Errorf("unknown trigger type %s", t.Type)
+		}
+		if t.Filter != "" {
+			if err := expression.Check(t.Filter); err != nil {
+				return fmt.Errorf("trigger filter: %w", err)
+			}
+		}
+		for field, expr := range t.Transform {
+			if err := expression.Check(expr); err != nil {
+				return fmt.Errorf("trigger transform %s: %w", field, err)
+			}
+		}
+		if t.IdempotencyKey != "" {
+			if err := expression.Check(t.IdempotencyKey); err != nil {
+				return fmt.Errorf("trigger idempotency_key: %w", err)
+			}
+		}
+	}
+
+	if q := w.Quarantine; q != nil {
+		if q.FailureRate <= 0 || q.FailureRate > 1 {
+			return fmt.Errorf("quarantine failure_rate must be between 0 and 1")
+		}
+		if q.Window < 0 || q.MinExecutions < 0 {
+			return fmt.Errorf("quarantine window and min_executions must not be negative")
+		}
+	}
+
+	if b := w.Breaker; b != nil {
+		if b.Failures < 1 {
+			return fmt.Errorf("breaker failures must be at least 1")
+		}
+		if b.Cooldown.Duration < 0 {
+			return fmt.Errorf("breaker cooldown must not be negative")
+		}
+	}
+
+	if rr := w.Rerun; rr != nil {
+		if rr.Attempts < 1 {
+			return fmt.Errorf("rerun attempts must be at least 1")
+		}
+		if rr.Delay.Duration < 0 || rr.PerMinute < 0 {
+			return fmt.Errorf("rerun delay and per_minute must not be negative")
+		}
+		switch rr.Backoff {
+		case "", domain.BackoffConstant, domain.BackoffExponential:
+		default:
+			return fmt.Errorf("invalid rerun backoff %s (must be 'constant' or 'exponential')", rr.Backoff)
+		}
+	}
+
+	if w.IdempotencyTTL.Duration < 0 {
+		return fmt.Errorf("idempotency_ttl must not be negative")
+	}
+
+	if c := w.Calendar; c != nil {
+		if _, err := c.Location(); err != nil {
+			return fmt.Errorf("calendar timezone %q: %w", c.Timezone, err)
+		}
+		for i, window := range c.Windows {
+			if err := window.Validate(); err != nil {
+				return fmt.Errorf("calendar window %d: %w", i, err)
+			}
+			if window.PerMinute != 0 {
+				return fmt.Errorf("calendar window %d: per_minute is only allowed in throttle", i)
+			}
+		}
+		for i, window := range c.Throttle {
+			if err := window.Validate(); err != nil {
+				return fmt.Errorf("calendar throttle %d: %w", i, err)
+			}
+			if window.PerMinute < 1 {
+				return fmt.Errorf("calendar throttle %d: per_minute must be at least 1", i)
+			}
+		}
+		if len(c.Windows) == 0 && len(c.Throttle) == 0 {
+			return fmt.Errorf("calendar needs at least one window or throttle")
+		}
+	}
+
+	if pf := w.Preflight; pf != nil && (pf.Timeout.Duration < 0 || pf.NegativeTTL.Duration < 0) {
+		return fmt.Errorf("preflight timeout and negative_ttl must not be negative")
+	}
+
+	if a := w.Alerting; a != nil {
+		if a.SLA.Duration < 0 {
+			return fmt.Errorf("alerting sla must not be negative")
+		}
+		for event, severity := range a.SeverityMap {
+			if !domain.IsAlertEvent(event) {
+				return fmt.Errorf("alerting severity_map: unknown event %s (must be failed, compensated, compensation_unfinished or sla_breached)", event)
+			}
+			if !domain.IsAlertSeverity(severity) {
+				return fmt.Errorf("alerting severity_map: invalid severity %s for %s (must be critical, error, warning or info)", severity, event)
+			}
+		}
+	}
+
+	outputs := collectOutputs(w.Finally, collectOutputs(w.Steps, nil))
+	for path, class := range w.Retention {
+		if _, err := domain.RetentionPeriod(class); err != nil {
+			return fmt.Errorf("retention of %s: %w", path, err)
+		}
+		root, field, _ := strings.Cut(path, ".")
+		if root == "input" && field == "" {
+			return fmt.Errorf("retention of %s: name a field of the input", path)
+		}
+		if root != "input" && !outputs[root] {
+			return fmt.Errorf("retention of %s: no step produces output %s", path, root)
+		}
+	}
+	for _, path := range w.Sensitive {
+		root, field, _ := strings.Cut(path, ".")
+		if root == "input" && field == "" {
+			return fmt.Errorf("sensitive %s: name a field of the input", path)
+		}
+		if root != "input" && !outputs[root] {
+			return fmt.Errorf("sensitive %s: no step produces output %s", path, root)
+		}
+		if strings.Contains(field, "..") || strings.HasSuffix(field, ".") {
+			return fmt.Errorf("sensitive %s: invalid field path", path)
+		}
+	}
+
+	for name, limit := range w.ConcurrencyGroups {
+		if limit <= 0 {
+			return fmt.Errorf("concurrency group %s: limit must be positive", name)
+		}
+	}
+
+	if err := p.validateConcurrencyGroups(w.Steps, w.ConcurrencyGroups); err != nil {
+		return err
+	}
+	if err := p.validateConcurrencyGroups(w.Finally, w.ConcurrencyGroups); err != nil {
+		return err
+	}
+
+	for name, env := range w.Environments {
+		if err := p.validateEnvironment(name, &env, w.Services); err != nil {
+			return err
+		}
+	}
+
+	if w.TemplateEngine != "" {
+		engine, err := templating.Lookup(w.TemplateEngine)
+		if err != nil {
+			return fmt.Errorf("template_engine: %w (available: %s)", err, strings.Join(templating.Names(), ", "))
+		}
+		if err := validateTemplates(w.Steps, engine); err != nil {
+			return err
+		}
+		if err := validateTemplates(w.Finally, engine); err != nil {
+			return err
+		}
+		for key, tmpl := range w.Output {
+			if err := engine.Check(tmpl); err != nil {
+				return fmt.Errorf("output %s: %w", key, err)
+			}
+		}
+	}
+
+	if err := NewValidator().ValidateDAG(w); err != nil {
+		return err
+	}
+
+	outputNames := collectOutputNames(w.Finally, collectOutputNames(w.Steps, nil))
+	if err := validateConditionReferences(w.Steps, w.TemplateEngine, outputs, outputNames); err != nil {
+		return err
+	}
+	if err := validateConditionReferences(w.Finally, w.TemplateEngine, outputs, outputNames); err != nil {
+		return err
+	}
+
+	return p.validateCompensationOrder(w.Steps, ids)
+}
+
+func validateTemplates(steps []domain.Step, engine templating.Engine) error {
+	for i := range steps {
+		step := &steps[i]
+		for _, tmpl := range stepTemplateFields(step) {
+			if !domain.ContainsTemplate(tmpl) {
+				continue
+			}
+			if err := engine.Check(tmpl); err != nil {
+				return fmt.Errorf("step %s: %w", step.ID, err)
+			}
+		}
+
+		if err := validateTemplates(step.Parallel, engine); err != nil {
+			return err
+		}
+		if step.Foreach != nil {
+			if err := validateTemplates(step.Foreach.Steps, engine); err != nil {
+				return err
+			}
+		}
+		if step.Fallback != nil {
+			if err := validateTemplates([]domain.Step{*step.Fallback}, engine); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func stepTemplateFields(step *domain.Step) []string {
+	fields := []string{step.Method, step.StreamInput, step.Key}
+	for _, value := range step.Input {
+		if s, ok := value.(string); ok {
+			fields = append(fields, s)
+		}
+	}
+	for _, value := range step.Headers {
+		fields = append(fields, value)
+	}
+	if step.Foreach != nil {
+		fields = append(fields, step.Foreach.Items)
+	}
+	if step.Compensate != nil {
+		fields = append(fields, step.Compensate.Key)
+		for _, value := range step.Compensate.Input {
+			if s, ok := value.(string); ok {
+				fields = append(fields, s)
+			}
+		}
+	}
+	return fields
+}
+
+func collectStepIDs(steps []domain.Step, ids map[string]bool) map[string]bool {
+	if ids == nil {
+		ids = make(map[string]bool)
+	}
+	for _, step := range steps {
+		if step.ID != "" {
+			ids[step.ID] = true
+		}
+		collectStepIDs(step.Parallel, ids)
+		if step.Foreach != nil {
+			collectStepIDs(step.Foreach.Steps, ids)
+		}
+		if step.Fallback != nil {
+			collectStepIDs([]domain.Step{*step.Fallback}, ids)
+		}
+	}
+	return ids
+}
+
+func validateStates(w *domain.Workflow) error {
+	for entity, machine := range w.States {
+		if len(machine.Transitions) == 0 {
+			return fmt.Errorf("states of %s must list at least one allowed transition", entity)
+		}
+	}
+
+	var check func(steps []domain.Step) error
+	check = func(steps []domain.Step) error {
+		for _, step := range steps {
+			if config := step.Transition; config != nil {
+				machine, ok := config.Machine(w.States)
+				if !ok {
+					return fmt.Errorf("step %s: no states declared for entity %s, add them under states or list transitions on the step", step.ID, config.Entity)
+				}
+				if !domain.ContainsTemplate(config.To) && !machine.Reaches(config.To) {
+					return fmt.Errorf("step %s: no transition of %s leads to %s", step.ID, config.Entity, config.To)
+				}
+			}
+			if err := check(step.Parallel); err != nil {
+				return err
+			}
+			if step.Foreach != nil {
+				if err := check(step.Foreach.Steps); err != nil {
+					return err
+				}
+			}
+			if step.Fallback != nil {
+				if err := check([]domain.Step{*step.Fallback}); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	if err := check(w.Steps); err != nil {
+		return err
+	}
+	return check(w.Finally)
+}
+
+func collectOutputs(steps []domain.Step, outputs map[string]bool) map[string]bool {
+	if outputs == nil {
+		outputs = make(map[string]bool)
+	}
+	for _, step := range steps {
+		if step.Output != "" {
+			outputs[step.Output] = true
+		}
+		collectOutputs(step.Parallel, outputs)
+		if step.Foreach != nil {
+			collectOutputs(step.Foreach.Steps, outputs)
+		}
+		if step.Fallback != nil {
+			collectOutputs([]domain.Step{*step.Fallback}, outputs)
+		}
+	}
+	return outputs
+}
+
+func collectOutputNames(steps []domain.Step, names map[string]string) map[string]string {
+	if names == nil {
+		names = make(map[string]string)
+	}
+	for _, step := range steps {
+		if step.ID != "" {
+			names[step.ID] = step.Output
+		}
+		collectOutputNames(step.Parallel, names)
+		if step.Foreach != nil {
+			collectOutputNames(step.Foreach.Steps, names)
+		}
+		if step.Fallback != nil {
+			collectOutputNames([]domain.Step{*step.Fallback}, names)
+		}
+	}
+	return names
+}
+
+func (p *Parser) validateCompensationOrder(steps []domain.Step, ids map[string]bool) error {
+	for _, step := range steps {
+		for _, after := range step.CompensateAfter {
+			if !ids[after] {
+				return fmt.Errorf("step %s: compensate_after references unknown step %s", step.ID, after)
+			}
+			if after == step.ID {
+				return fmt.Errorf("step %s: compensate_after cannot reference itself", step.ID)
+			}
+		}
+		if err := p.validateCompensationOrder(step.Parallel, ids); err != nil {
+			return err
+		}
+		if step.Foreach != nil {
+			if err := p.validateCompensationOrder(step.Foreach.Steps, ids); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Parser) validateConcurrencyGroups(steps []domain.Step, groups map[string]int) error {
+	for _, step := range steps {
+		if step.ConcurrencyGroup != "" {
+			if _, ok := groups[step.ConcurrencyGroup]; !ok {
+				return fmt.Errorf("step %s: unknown concurrency group %s", step.ID, step.ConcurrencyGroup)
+			}
+		}
+		if err := p.validateConcurrencyGroups(step.Parallel, groups); err != nil {
+			return err
+		}
+		if step.Foreach != nil {
+			if err := p.validateConcurrencyGroups(step.Foreach.Steps, groups); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateFailover(name string, s *domain.Service) error {
+	if len(s.Failover.Regions) == 0 {
+		return fmt.Errorf("service %s: failover needs at least one region", name)
+	}
+	for _, trigger := range s.Failover.On {
+		switch trigger {
+		case domain.FailoverUnavailable, domain.FailoverCircuitOpen, domain.FailoverDeadlineExceeded:
+		default:
+			return fmt.Errorf("service %s: invalid failover trigger %s (must be 'unavailable', 'circuit_open' or 'deadline_exceeded')", name, trigger)
+		}
+	}
+
+	seen := map[string]bool{s.Region: s.Region != ""}
+	for _, region := range s.Failover.Regions {
+		switch {
+		case region.Name == "":
+			return fmt.Errorf("service %s: failover region name is required", name)
+		case strings.ContainsAny(region.Name, "@#"):
+			return fmt.Errorf("service %s: failover region %s must not contain '@' or '#'", name, region.Name)
+		case region.Endpoint == "":
+			return fmt.Errorf("service %s: failover region %s: endpoint is required", name, region.Name)
+		case seen[region.Name]:
+			return fmt.Errorf("service %s: duplicate failover region %s", name, region.Name)
+		}
+		seen[region.Name] = true
+	}
+	return nil
+}
+
+func validateExec(name string, s *domain.Service) error {
+	if s.Type != "exec" {
+		return fmt.Errorf("service %s: exec settings require type 'exec'", name)
+	}
+	switch s.Exec.Input {
+	case "", domain.ExecInputStdin, domain.ExecInputEnv:
+	default:
+		return fmt.Errorf("service %s: invalid exec input %s (must be 'stdin' or 'env')", name, s.Exec.Input)
+	}
+	if s.Exec.Timeout.Duration < 0 {
+		return fmt.Errorf("service %s: exec.timeout must not be negative", name)
+	}
+	if len(s.Exec.Commands) == 0 {
+		return fmt.Errorf("service %s: exec services require at least one command under exec.commands", name)
+	}
+	for command, argv := range s.Exec.Commands {
+		if len(argv) == 0 || argv[0] == "" {
+			return fmt.Errorf("service %s: exec command %s has no program", name, command)
+		}
+	}
+	return nil
+}
+
+func (p *Parser) validateService(name string, s *domain.Service) error {
+	if s.Type == "" {
+		return fmt.Errorf("service %s: type is required", name)
+	}
+
+	if s.Endpoint == "" && grpc.RequiresEndpoint(s.Type) {
+		return fmt.Errorf("service %s: endpoint is required", name)
+	}
+
+	switch s.Type {
+	case "grpc", "http", "nats", "kafka", "amqp", "sql", "redis", "lambda", "exec", "local":
+	default:
+		if p.serviceTypes == nil || !p.serviceTypes(s.Type) {
+			return fmt.Errorf("service %s: invalid type %s (must be 'grpc', 'http', 'nats', 'kafka', 'amqp', 'sql', 'redis', 'lambda', 'exec', 'local' or a registered protocol)", name, s.Type)
+		}
+	}
+
+	if s.Exec != nil {
+		if err := validateExec(name, s); err != nil {
+			return err
+		}
+	} else if s.Type == "exec" {
+		return fmt.Errorf("service %s: exec services require at least one command under exec.commands", name)
+	}
+
+	if s.TLS != nil {
+		if s.Type != "grpc" && s.Type != "http" {
+			return fmt.Errorf("service %s: tls settings require type 'grpc' or 'http'", name)
+		}
+		if err := validateTLS(s.TLS); err != nil {
+			return fmt.Errorf("service %s: %w", name, err)
+		}
+	}
+
+	if s.Lambda != nil && s.Type != "lambda" {
+		return fmt.Errorf("service %s: lambda settings require type 'lambda'", name)
+	}
+
+	if s.AMQP != nil && s.Type != "amqp" {
+		return fmt.Errorf("service %s: amqp settings require type 'amqp'", name)
+	}
+
+	if s.Redis != nil && s.Type != "redis" {
+		return fmt.Errorf("service %s: redis settings require type 'redis'", name)
+	}
+	if s.Redis != nil && s.Redis.LockTTL.Duration < 0 {
+		return fmt.Errorf("service %s: redis.lock_ttl must not be negative", name)
+	}
+
+	if s.SQL != nil && s.Type != "sql" {
+		return fmt.Errorf("service %s: sql settings require type 'sql'", name)
+	}
+	if s.Type == "sql" {
+		switch s.SQL.DriverFor(s.Endpoint) {
+		case domain.SQLDriverPostgres, domain.SQLDriverMySQL:
+		case "":
+			return fmt.Errorf("service %s: sql.driver is required unless the endpoint is a postgres:// or mysql:// URL", name)
+		default:
+			return fmt.Errorf("service %s: invalid sql driver %s (must be 'postgres' or 'mysql')", name, s.SQL.Driver)
+		}
+		if s.SQL != nil && s.SQL.MaxConnections < 0 {
+			return fmt.Errorf("service %s: sql.max_connections must not be negative", name)
+		}
+	}
+
+	if s.Failover != nil {
+		if err := validateFailover(name, s); err != nil {
+			return err
+		}
+	}
+
+	switch s.Protocol {
+	case "", domain.ProtocolMaestro:
+	case domain.ProtocolGRPCReflection:
+		if s.Type != "grpc" {
+			return fmt.Errorf("service %s: protocol %s requires type 'grpc'", name, s.Protocol)
+		}
+	default:
+		return fmt.Errorf("service %s: invalid protocol %s (must be 'maestro' or 'grpc-reflection')", name, s.Protocol)
+	}
+
+	if s.Descriptor != "" {
+		if s.Type != "grpc" {
+			return fmt.Errorf("service %s: descriptor requires type 'grpc'", name)
+		}
+		if s.Protocol == domain.ProtocolGRPCReflection {
+			return fmt.Errorf("service %s: descriptor and protocol %s are mutually exclusive", name, s.Protocol)
+		}
+	}
+
+	if s.Retry != nil {
+		if err := p.validateRetry(s.Retry); err != nil {
+			return fmt.Errorf("service %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func resolveServicePaths(services map[string]domain.Service, baseDir string) {
+	for name, service := range services {
+		if service.OpenAPI != "" && !filepath.IsAbs(service.OpenAPI) {
+			service.OpenAPI = filepath.Join(baseDir, service.OpenAPI)
+		}
+		if service.Descriptor != "" && !filepath.IsAbs(service.Descriptor) {
+			service.Descriptor = filepath.Join(baseDir, service.Descriptor)
+		}
+		services[name] = service
+	}
+}
+
+func importServices(w *domain.Workflow, baseDir string) error {
+	if len(w.Import) == 0 {
+		return nil
+	}
+	if baseDir == "" {
+		return fmt.Errorf("import is only supported for workflows loaded from a file")
+	}
+
+	imported := make(map[string]domain.Service)
+	origin := make(map[string]string)
+	for _, file := range w.Import {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(baseDir, file)
+		}
+		catalog, err := loadServiceCatalog(file)
+		if err != nil {
+			return fmt.Errorf("import %s: %w", file, err)
+		}
+		for name, service := range catalog.Services {
+			if previous, ok := imported[name]; ok && !reflect.DeepEqual(previous, service) {
+				return fmt.Errorf("import %s: service %s is already defined differently in %s", file, name, origin[name])
+			}
+			imported[name] = service
+			origin[name] = file
+		}
+	}
+
+	if w.Services == nil {
+		w.Services = make(map[string]domain.Service, len(imported))
+	}
+	for name, service := range imported {
+		if _, ok := w.Services[name]; !ok {
+			w.Services[name] = service
+		}
+	}
+	return nil
+}
+
+func loadServiceCatalog(filename string) (*domain.ServiceCatalog, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service catalog: %w", err)
+	}
+
+	var catalog domain.ServiceCatalog
+	if DetectFormat(data) == FormatJSON || strings.EqualFold(filepath.Ext(filename), ".json") {
+		err = json.Unmarshal(data, &catalog)
+	} else {
+		err = yaml.Unmarshal(data, &catalog)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse service catalog: %w", err)
+	}
+	if len(catalog.Services) == 0 {
+		return nil, fmt.Errorf("service catalog defines no services")
+	}
+
+	resolveServicePaths(catalog.Services, filepath.Dir(filename))
+	return &catalog, nil
+}
+
+func mergeProfiles(w *domain.Workflow) error {
+	if len(w.Profiles) == 0 {
+		return nil
+	}
+	if w.Environments == nil {
+		w.Environments = make(map[string]domain.Environment, len(w.Profiles))
+	}
+	for name, profile := range w.Profiles {
+		if _, ok := w.Environments[name]; ok {
+			return fmt.Errorf("profile %s is also defined under environments", name)
+		}
+		w.Environments[name] = profile
+	}
+	w.Profiles = nil
+	return nil
+}
+
+func (p *Parser) validateEnvironment(name string, env *domain.Environment, services map[string]domain.Service) error {
+	if strings.Contains(name, "@") {
+		return fmt.Errorf("environment %s: name cannot contain '@'", name)
+	}
+
+	for serviceName, override := range env.Services {
+		service, ok := services[serviceName]
+		if !ok {
+			return fmt.Errorf("environment %s: unknown service %s", name, serviceName)
+		}
+		if override.Timeout.Duration < 0 {
+			return fmt.Errorf("environment %s: service %s: timeout cannot be negative", name, serviceName)
+		}
+		if override.Retry != nil {
+			if err := p.validateRetry(override.Retry); err != nil {
+				return fmt.Errorf("environment %s: service %s: %w", name, serviceName, err)
+			}
+		}
+		if override.TLS != nil {
+			if service.Type != "grpc" && service.Type != "http" {
+				return fmt.Errorf("environment %s: service %s: tls settings require type 'grpc' or 'http'", name, serviceName)
+			}
+			if err := validateTLS(override.TLS); err != nil {
+				return fmt.Errorf("environment %s: service %s: %w", name, serviceName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateTLS(t *domain.TLSConfig) error {
+	if (t.Cert == "") != (t.Key == "") {
+		return fmt.Errorf("tls.cert and tls.key must be set together")
+	}
+	return nil
+}
+
+func (p *Parser) validateRetry(r *domain.RetryConfig) error {
+	if r.Attempts < 0 {
+		return fmt.Errorf("retry attempts cannot be negative")
+	}
+
+	switch r.Backoff {
+	case "", domain.BackoffConstant, domain.BackoffExponential:
+	default:
+		return fmt.Errorf("invalid retry backoff %s (must be 'constant' or 'exponential')", r.Backoff)
+	}
+
+	if r.MaxDelay.Duration < 0 {
+		return fmt.Errorf("retry max_delay cannot be negative")
+	}
+
+	if r.Jitter < 0 || r.Jitter > 1 {
+		return fmt.Errorf("retry jitter must be between 0 and 1")
+	}
+
+	return nil
+}
+
+func (p *Parser) validateStep(s *domain.Step, services map[string]domain.Service, index int) error {
+	if len(s.Parallel) > 0 {
+		if s.OnError != "" || s.Fallback != nil {
+			return fmt.Errorf("parallel group: on_error is not supported here, set it on the steps inside the group")
+		}
+		for i, parallelStep := range s.Parallel {
+			if err := p.validateStep(&parallelStep, services, i); err != nil {
+				return fmt.Errorf("parallel step %d: %w", i, err)
+			}
+		}
+		return nil
+	}
+
+	if s.ID == "" {
+		s.ID = fmt.Sprintf("step_%d", index)
+	}
+
+	if err := validateCondition(s.When); err != nil {
+		return fmt.Errorf("step %s: when: %w", s.ID, err)
+	}
+
+	if err := p.validateOnError(s, services); err != nil {
+		return err
+	}
 
-func (p *Parser) Parse(data []byte) (*domain.Workflow, error) {
-	var workflow domain.Workflow
-	if err := yaml.Unmarshal(data, &workflow); err != nil {
-		return nil, fmt.Errorf("failed to parse workflow YAML: %w", err)
+	if len(s.Retention) > 0 && s.Output == "" {
+		return fmt.Errorf("step %s: retention requires an output", s.ID)
 	}
 
-	if err := p.validateWorkflow(&workflow); err != nil {
-		return nil, fmt.Errorf("workflow validation failed: %w", err)
+	if len(s.OutputSchema) > 0 {
+		if err := validation.Check(s.OutputSchema); err != nil {
+			return fmt.Errorf("step %s: output_schema: %w", s.ID, err)
+		}
+	}
+	for field, class := range s.Retention {
+		if _, err := domain.RetentionPeriod(class); err != nil {
+			return fmt.Errorf("step %s: retention of %s: %w", s.ID, field, err)
+		}
 	}
 
-	return &workflow, nil
-}
+	if s.Assert != nil {
+		return p.validateAssertStep(s)
+	}
 
-func (p *Parser) validateWorkflow(w *domain.Workflow) error {
-	if w.Name == "" {
-		return fmt.Errorf("workflow name is required")
+	if s.AcquireLock != nil || s.ReleaseLock != nil {
+		return p.validateLockStep(s)
 	}
 
-	if w.Version == "" {
-		return fmt.Errorf("workflow version is required")
+	if s.KV != nil {
+		return p.validateKVStep(s)
 	}
 
-	if len(w.Steps) == 0 {
-		return fmt.Errorf("workflow must have at least one step")
+	if s.Transition != nil {
+		return p.validateTransitionStep(s)
 	}
 
-	for name, service := range w.Services {
-		if err := p.validateService(name, &service); err != nil {
+	if s.Wait != nil {
+		return p.validateWaitStep(s)
+	}
+
+	if s.Foreach != nil {
+		return p.validateForeachStep(s, services)
+	}
+
+	if s.Workflow != "" {
+		return p.validateSubWorkflowStep(s)
+	}
+
+	if s.EmitMetric != nil {
+		if err := p.validateMetric(s.ID, s.EmitMetric); err != nil {
 			return err
 		}
+		if s.Service == "" {
+			return nil
+		}
 	}
 
-	for i, step := range w.Steps {
-		if err := p.validateStep(&step, w.Services, i); err != nil {
-			return err
+	if s.Service == "" {
+		return fmt.Errorf("step %s: service is required", s.ID)
+	}
+
+	if _, ok := services[s.Service]; !ok {
+		return fmt.Errorf("step %s: unknown service %s", s.ID, s.Service)
+	}
+
+	if s.Method == "" {
+		return fmt.Errorf("step %s: method is required", s.ID)
+	}
+
+	if services[s.Service].Typed() {
+		if err := validateReflectionMethods(s); err != nil {
+			return fmt.Errorf("step %s: %w", s.ID, err)
+		}
+	}
+
+	if services[s.Service].Type == "sql" && strings.Contains(s.Method, "{{") {
+		return fmt.Errorf("step %s: sql method cannot be a template, pass values as :name parameters in input", s.ID)
+	}
+
+	if services[s.Service].Type == "redis" {
+		if err := redis.ValidateCommand(s.Method); err != nil {
+			return fmt.Errorf("step %s: %w", s.ID, err)
+		}
+	}
+
+	if err := validateExecMethod(services[s.Service], s.Method); err != nil {
+		return fmt.Errorf("step %s: %w", s.ID, err)
+	}
+
+	if s.HTTPResponse && services[s.Service].Type != "http" {
+		return fmt.Errorf("step %s: http_response requires an http service", s.ID)
+	}
+
+	if s.Key != "" && services[s.Service].Type != "kafka" {
+		return fmt.Errorf("step %s: key requires a kafka service", s.ID)
+	}
+
+	if s.Stream || s.StreamInput != "" {
+		if err := validateStreaming(s, services[s.Service]); err != nil {
+			return fmt.Errorf("step %s: %w", s.ID, err)
+		}
+	}
+
+	if s.CachedFallback != nil {
+		if err := validateCachedFallback(s, services[s.Service]); err != nil {
+			return fmt.Errorf("step %s: %w", s.ID, err)
+		}
+	}
+
+	if s.Compensate != nil {
+		if s.Compensate.Method == "" {
+			return fmt.Errorf("step %s: compensation method is required", s.ID)
+		}
+		service := s.Service
+		if s.Compensate.Service != "" {
+			if _, ok := services[s.Compensate.Service]; !ok {
+				return fmt.Errorf("step %s: unknown compensation service %s", s.ID, s.Compensate.Service)
+			}
+			service = s.Compensate.Service
+		}
+		if services[service].Typed() {
+			if _, _, err := grpc.SplitReflectionMethod(s.Compensate.Method); err != nil {
+				return fmt.Errorf("step %s: compensation: %w", s.ID, err)
+			}
+		}
+		if services[service].Type == "redis" {
+			if err := redis.ValidateCommand(s.Compensate.Method); err != nil {
+				return fmt.Errorf("step %s: compensation: %w", s.ID, err)
+			}
+		}
+		if err := validateExecMethod(services[service], s.Compensate.Method); err != nil {
+			return fmt.Errorf("step %s: compensation: %w", s.ID, err)
+		}
+		if (s.Compensate.Tombstone || s.Compensate.Key != "") && services[service].Type != "kafka" {
+			return fmt.Errorf("step %s: compensation key and tombstone require a kafka service", s.ID)
+		}
+		if s.Compensate.Tombstone && len(s.Compensate.Input) > 0 {
+			return fmt.Errorf("step %s: a tombstone compensation cannot have input", s.ID)
+		}
+		if s.Compensate.Retry != nil {
+			if err := p.validateRetry(s.Compensate.Retry); err != nil {
+				return fmt.Errorf("step %s: compensation: %w", s.ID, err)
+			}
+		}
+	}
+
+	if s.Retry != nil {
+		if err := p.validateRetry(s.Retry); err != nil {
+			return fmt.Errorf("step %s: %w", s.ID, err)
+		}
+	}
+
+	if s.Resources != nil {
+		if err := p.validateResources(s.Resources); err != nil {
+			return fmt.Errorf("step %s: %w", s.ID, err)
 		}
 	}
 
 	return nil
 }
 
-func (p *Parser) validateService(name string, s *domain.Service) error {
-	if s.Type == "" {
-		return fmt.Errorf("service %s: type is required", name)
+func validateExecMethod(service domain.Service, method string) error {
+	if service.Type != "exec" || service.Exec == nil || strings.Contains(method, "{{") {
+		return nil
 	}
+	if _, ok := service.Exec.Commands[method]; !ok {
+		return fmt.Errorf("exec method %s is not a command under exec.commands", method)
+	}
+	return nil
+}
 
-	if s.Endpoint == "" {
-		return fmt.Errorf("service %s: endpoint is required", name)
+func validateReflectionMethods(s *domain.Step) error {
+	_, _, err := grpc.SplitReflectionMethod(s.Method)
+	return err
+}
+
+func validateStreaming(s *domain.Step, service domain.Service) error {
+	if s.Stream && s.StreamInput != "" {
+		return fmt.Errorf("stream and stream_input cannot be combined")
+	}
+	if service.Type != "grpc" || service.Typed() {
+		return fmt.Errorf("streaming requires a gRPC service with the maestro protocol, %s is not one", s.Service)
+	}
+	return nil
+}
+
+func validateCachedFallback(s *domain.Step, service domain.Service) error {
+	if !s.Idempotent {
+		return fmt.Errorf("cached_fallback requires idempotent: true")
+	}
+	if service.Type == "http" {
+		return fmt.Errorf("cached_fallback requires a gRPC service, %s has no circuit breaker", s.Service)
+	}
+	if s.Stream || s.StreamInput != "" {
+		return fmt.Errorf("cached_fallback cannot be used with streaming")
+	}
+	if s.CachedFallback.MaxAge.Duration < 0 {
+		return fmt.Errorf("cached_fallback max_age cannot be negative")
+	}
+	return nil
+}
+
+func (p *Parser) validateResources(r *domain.ResourceHints) error {
+	if r.Weight < 0 {
+		return fmt.Errorf("resources weight cannot be negative")
 	}
 
-	if s.Type != "grpc" && s.Type != "http" {
-		return fmt.Errorf("service %s: invalid type %s (must be 'grpc' or 'http')", name, s.Type)
+	switch r.Latency {
+	case "", domain.LatencyFast, domain.LatencyNormal, domain.LatencySlow:
+	default:
+		return fmt.Errorf("invalid resources latency %s (must be 'fast', 'normal' or 'slow')", r.Latency)
 	}
 
 	return nil
 }
 
-func (p *Parser) validateStep(s *domain.Step, services map[string]domain.Service, index int) error {
-	if len(s.Parallel) > 0 {
-		for i, parallelStep := range s.Parallel {
-			if err := p.validateStep(&parallelStep, services, i); err != nil {
-				return fmt.Errorf("parallel step %d: %w", i, err)
+func (p *Parser) validateHook(h *domain.Hook, services map[string]domain.Service) error {
+	if len(h.Command) > 0 {
+		if h.Service != "" {
+			return fmt.Errorf("command and service are mutually exclusive")
+		}
+		if h.Command[0] == "" {
+			return fmt.Errorf("command has no program")
+		}
+		return nil
+	}
+
+	if h.Service == "" {
+		return fmt.Errorf("service or command is required")
+	}
+
+	if _, ok := services[h.Service]; !ok {
+		return fmt.Errorf("unknown service %s", h.Service)
+	}
+
+	if h.Method == "" {
+		return fmt.Errorf("method is required")
+	}
+
+	return nil
+}
+
+func (p *Parser) validateLockStep(s *domain.Step) error {
+	if s.AcquireLock != nil && s.ReleaseLock != nil {
+		return fmt.Errorf("step %s: acquire_lock and release_lock are mutually exclusive", s.ID)
+	}
+
+	if s.Service != "" {
+		return fmt.Errorf("step %s: lock steps cannot call a service", s.ID)
+	}
+
+	lock := s.AcquireLock
+	if lock == nil {
+		lock = s.ReleaseLock
+	}
+
+	if lock.Key == "" {
+		return fmt.Errorf("step %s: lock key is required", s.ID)
+	}
+
+	return nil
+}
+
+func (p *Parser) validateKVStep(s *domain.Step) error {
+	if s.Service != "" {
+		return fmt.Errorf("step %s: kv steps cannot call a service", s.ID)
+	}
+
+	switch s.KV.Op {
+	case domain.KVOpGet, domain.KVOpSet, domain.KVOpDelete, domain.KVOpIncrement:
+	default:
+		return fmt.Errorf("step %s: invalid kv op %s (must be 'get', 'set', 'delete' or 'incr')", s.ID, s.KV.Op)
+	}
+
+	if s.KV.Namespace == "" {
+		return fmt.Errorf("step %s: kv namespace is required", s.ID)
+	}
+
+	if s.KV.Key == "" {
+		return fmt.Errorf("step %s: kv key is required", s.ID)
+	}
+
+	return nil
+}
+
+func (p *Parser) validateTransitionStep(s *domain.Step) error {
+	if s.Service != "" {
+		return fmt.Errorf("step %s: transition steps cannot call a service", s.ID)
+	}
+
+	if s.Compensate != nil {
+		return fmt.Errorf("step %s: transition steps revert on their own and cannot have a compensate", s.ID)
+	}
+
+	config := s.Transition
+	if config.Entity == "" {
+		return fmt.Errorf("step %s: transition entity is required", s.ID)
+	}
+	if config.ID == "" {
+		return fmt.Errorf("step %s: transition id is required", s.ID)
+	}
+	if config.To == "" {
+		return fmt.Errorf("step %s: transition to is required", s.ID)
+	}
+	return nil
+}
+
+func (p *Parser) validateWaitStep(s *domain.Step) error {
+	if s.Service != "" {
+		return fmt.Errorf("step %s: wait steps cannot call a service", s.ID)
+	}
+
+	if s.Wait.Signal == "" {
+		return fmt.Errorf("step %s: wait signal is required", s.ID)
+	}
+
+	if s.Wait.ExpireAfter.Duration < 0 {
+		return fmt.Errorf("step %s: expire_after cannot be negative", s.ID)
+	}
+
+	switch s.Wait.OnExpire {
+	case "":
+	case domain.WaitExpireFail, domain.WaitExpireSkip, domain.WaitExpireDefault, domain.WaitExpireCompensate:
+		if s.Wait.ExpireAfter.Duration == 0 {
+			return fmt.Errorf("step %s: on_expire requires expire_after", s.ID)
+		}
+	default:
+		return fmt.Errorf("step %s: invalid on_expire %s (must be 'fail', 'skip', 'default' or 'compensate')", s.ID, s.Wait.OnExpire)
+	}
+
+	if s.Wait.Default != nil && s.Wait.OnExpire != domain.WaitExpireDefault {
+		return fmt.Errorf("step %s: wait default is only used with on_expire 'default'", s.ID)
+	}
+
+	return nil
+}
+
+func (p *Parser) validateAssertStep(s *domain.Step) error {
+	if s.Service != "" || s.Method != "" {
+		return fmt.Errorf("step %s: assert steps cannot call a service", s.ID)
+	}
+
+	if s.Compensate != nil {
+		return fmt.Errorf("step %s: assert steps have nothing to compensate", s.ID)
+	}
+
+	if s.Assert.Condition == "" {
+		return fmt.Errorf("step %s: assert condition is required", s.ID)
+	}
+
+	if err := validateCondition(s.Assert.Condition); err != nil {
+		return fmt.Errorf("step %s: assert: %w", s.ID, err)
+	}
+
+	return nil
+}
+
+func validateCondition(condition string) error {
+	if condition == "" || domain.ContainsTemplate(condition) {
+		return nil
+	}
+	return expression.Check(condition)
+}
+
+var conditionScope = map[string]bool{"input": true, "vars": true, "namespace": true, "tags": true}
+
+func validateConditionReferences(steps []domain.Step, engine string, outputs map[string]bool, outputNames map[string]string) error {
+	for i := range steps {
+		step := &steps[i]
+		if err := checkConditionReferences(step, engine, outputs, outputNames); err != nil {
+			return err
+		}
+		if err := validateConditionReferences(step.Parallel, engine, outputs, outputNames); err != nil {
+			return err
+		}
+		if step.Foreach != nil {
+			iteration := maps.Clone(outputs)
+			iteration["item"], iteration["index"] = true, true
+			if err := validateConditionReferences(step.Foreach.Steps, engine, iteration, outputNames); err != nil {
+				return err
+			}
+		}
+		if step.Fallback != nil {
+			if err := checkConditionReferences(step.Fallback, engine, outputs, outputNames); err != nil {
+				return err
 			}
 		}
+	}
+	return nil
+}
+
+func checkConditionReferences(step *domain.Step, engine string, outputs map[string]bool, outputNames map[string]string) error {
+	if step.When == "" {
 		return nil
 	}
 
-	if s.ID == "" {
-		s.ID = fmt.Sprintf("step_%d", index)
+	var roots []string
+	if expr, ok := expression.Unwrap(step.When); ok {
+		roots, _ = expression.Roots(expr)
+	} else if refs, ok := templating.Roots(engine, step.When); ok {
+		roots = refs
 	}
 
-	if s.Service == "" {
-		return fmt.Errorf("step %s: service is required", s.ID)
+	for _, root := range roots {
+		if conditionScope[root] || outputs[root] {
+			continue
+		}
+		output, isStep := outputNames[root]
+		switch {
+		case templating.IsFunc(root):
+			return fmt.Errorf("step %s: when %q is evaluated as an expression, where %s is not a function; use !, &&, || and comparison operators instead", step.ID, step.When, root)
+		case isStep && output == "":
+			return fmt.Errorf("step %s: when references step %s, which has no output; set output on it to use its result", step.ID, root)
+		case isStep:
+			return fmt.Errorf("step %s: when references step %s by ID, use its output name %s", step.ID, root, output)
+		default:
+			return fmt.Errorf("step %s: when references %s, but no step produces that output", step.ID, root)
+		}
+	}
+	return nil
+}
+
+func (p *Parser) validateSubWorkflowStep(s *domain.Step) error {
+	if s.Service != "" || s.Method != "" {
+		return fmt.Errorf("step %s: sub-workflow steps cannot call a service", s.ID)
 	}
 
-	if _, ok := services[s.Service]; !ok {
-		return fmt.Errorf("step %s: unknown service %s", s.ID, s.Service)
+	if s.Compensate != nil {
+		return fmt.Errorf("step %s: sub-workflow steps are compensated by their own workflow", s.ID)
 	}
 
-	if s.Method == "" {
-		return fmt.Errorf("step %s: method is required", s.ID)
+	return nil
+}
+
+func (p *Parser) validateOnError(s *domain.Step, services map[string]domain.Service) error {
+	switch s.OnError {
+	case "", domain.OnErrorFail, domain.OnErrorContinue, domain.OnErrorHold:
+	case domain.OnErrorFallback:
+		if s.Fallback == nil {
+			return fmt.Errorf("step %s: on_error 'fallback' requires a fallback step", s.ID)
+		}
+	default:
+		return fmt.Errorf("step %s: invalid on_error %s (must be 'fail', 'continue', 'fallback' or 'hold')", s.ID, s.OnError)
+	}
+
+	if s.Fallback == nil {
+		return nil
+	}
+	if s.OnError != domain.OnErrorFallback {
+		return fmt.Errorf("step %s: fallback is only used with on_error 'fallback'", s.ID)
+	}
+	if s.Fallback.ID == "" {
+		s.Fallback.ID = s.ID + "_fallback"
+	}
+	if len(s.Fallback.DependsOn) > 0 {
+		return fmt.Errorf("step %s: fallback %s cannot declare depends_on", s.ID, s.Fallback.ID)
+	}
+	if err := p.validateStep(s.Fallback, services, 0); err != nil {
+		return fmt.Errorf("step %s: fallback: %w", s.ID, err)
+	}
+	return nil
+}
+
+func (p *Parser) validateForeachStep(s *domain.Step, services map[string]domain.Service) error {
+	if s.Service != "" {
+		return fmt.Errorf("step %s: foreach steps cannot call a service directly", s.ID)
 	}
 
 	if s.Compensate != nil {
-		if s.Compensate.Method == "" {
-			return fmt.Errorf("step %s: compensation method is required", s.ID)
+		return fmt.Errorf("step %s: foreach steps cannot be compensated, compensate the nested steps instead", s.ID)
+	}
+
+	if !domain.IsTemplate(strings.TrimSpace(s.Foreach.Items)) {
+		return fmt.Errorf("step %s: foreach items must be a template", s.ID)
+	}
+
+	if len(s.Foreach.Steps) == 0 {
+		return fmt.Errorf("step %s: foreach requires at least one nested step", s.ID)
+	}
+
+	if s.Foreach.Concurrency < 0 {
+		return fmt.Errorf("step %s: foreach concurrency cannot be negative", s.ID)
+	}
+
+	for i := range s.Foreach.Steps {
+		nested := &s.Foreach.Steps[i]
+		if nested.ID == "" {
+			nested.ID = fmt.Sprintf("%s_%d", s.ID, i)
 		}
+		if len(nested.DependsOn) > 0 {
+			return fmt.Errorf("step %s: nested step %s cannot declare depends_on", s.ID, nested.ID)
+		}
+		if err := p.validateStep(nested, services, i); err != nil {
+			return fmt.Errorf("foreach step %s: %w", s.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Parser) validateMetric(stepID string, m *domain.MetricConfig) error {
+	if m.Name == "" {
+		return fmt.Errorf("step %s: metric name is required", stepID)
+	}
+
+	switch m.Type {
+	case metrics.MetricTypeCounter, metrics.MetricTypeGauge, metrics.MetricTypeHistogram:
+	default:
+		return fmt.Errorf("step %s: invalid metric type %s (must be 'counter', 'gauge' or 'histogram')", stepID, m.Type)
 	}
 
 	return nil
@@ -135,6 +1431,18 @@ func (p *Parser) ResolveTemplate(tmpl string, data interface{}) (string, error)
 	return buf.String(), nil
 }
 
+func (p *Parser) RenderTemplate(engine, tmpl string, data map[string]interface{}) (string, error) {
+	if engine == "" || engine == templating.GoTemplate {
+		return p.ResolveTemplate(tmpl, data)
+	}
+
+	renderer, err := templating.Lookup(engine)
+	if err != nil {
+		return "", err
+	}
+	return renderer.Render(tmpl, data, nil)
+}
+
 func (p *Parser) ResolveStepInput(step *domain.Step, ctx *domain.ExecutionContext) (map[string]interface{}, error) {
 	resolvedInput := make(map[string]interface{})
 
@@ -165,4 +1473,3 @@ func (p *Parser) ResolveStepInput(step *domain.Step, ctx *domain.ExecutionContex
 
 	return resolvedInput, nil
 }
-
@@ -0,0 +1,113 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const conditionWorkflow = `
+name: fraud
+version: "1.0.0"
+services:
+  risk:
+    type: http
+    endpoint: http://localhost:8080
+steps:
+  - id: score
+    service: risk
+    method: score
+    output: risk
+  - id: audit
+    service: risk
+    method: audit
+  - id: review
+    service: risk
+    method: review
+    when: %q
+  - id: lines
+    foreach:
+      items: "{{ .input.lines }}"
+      steps:
+        - id: check
+          service: risk
+          method: check
+          output: checked
+        - id: hold
+          service: risk
+          method: hold
+          when: %q
+`
+
+func TestConditionReferences(t *testing.T) {
+	tests := []struct {
+		name     string
+		when     string
+		bodyWhen string
+		wantErr  string
+	}{
+		{
+			name: "an output name",
+			when: "{{ risk.score > 50 }}",
+		},
+		{
+			name: "input and vars",
+			when: "{{ input.amount > vars.limit }}",
+		},
+		{
+			name: "comprehension locals",
+			when: "{{ risk.flags.exists(f, f == 'stolen') }}",
+		},
+		{
+			name: "a go template reading an output",
+			when: "{{ if .risk.cleared }}false{{ else }}true{{ end }}",
+		},
+		{
+			name:     "item, index and outputs inside a foreach body",
+			bodyWhen: "{{ item.amount > 100 && index > 0 && checked.ok }}",
+		},
+		{
+			name:    "a step ID instead of its output name",
+			when:    "{{ score.value > 50 }}",
+			wantErr: "step review: when references step score by ID, use its output name risk",
+		},
+		{
+			name:    "a step without output",
+			when:    "{{ audit.ok }}",
+			wantErr: "step review: when references step audit, which has no output",
+		},
+		{
+			name:    "an unknown root",
+			when:    "{{ fraud.flagged }}",
+			wantErr: "step review: when references fraud, but no step produces that output",
+		},
+		{
+			name:    "a template function read as an expression",
+			when:    "{{ not .risk.cleared }}",
+			wantErr: "step review: when \"{{ not .risk.cleared }}\" is evaluated as an expression, where not is not a function",
+		},
+		{
+			name:    "item outside a foreach body",
+			when:    "{{ item.amount > 100 }}",
+			wantErr: "step review: when references item, but no step produces that output",
+		},
+		{
+			name:     "an unknown root inside a foreach body",
+			bodyWhen: "{{ line.amount > 100 }}",
+			wantErr:  "step hold: when references line, but no step produces that output",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			definition := fmt.Sprintf(conditionWorkflow, tt.when, tt.bodyWhen)
+			_, err := NewParser().Parse([]byte(definition))
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("Parse() error = %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("Parse() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
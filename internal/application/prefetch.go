@@ -0,0 +1,196 @@
+package application
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/maestro/maestro.go/internal/application/templating"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/rs/zerolog"
+)
+
+type prefetcher struct {
+	orchestrator *Orchestrator
+	ctx          context.Context
+	cancel       context.CancelFunc
+	wf           *workflow.Workflow
+	logger       zerolog.Logger
+	refs         map[int][]string
+	prereqs      map[int][]int
+	done         map[int]bool
+	launched     map[int]bool
+	pending      map[int]*prefetch
+}
+
+type prefetch struct {
+	done   chan struct{}
+	result *workflow.StepResult
+	err    error
+}
+
+func (o *Orchestrator) newPrefetcher(ctx context.Context, wf *workflow.Workflow, graph *StepGraph, logger zerolog.Logger) *prefetcher {
+	ctx, cancel := context.WithCancel(ctx)
+	p := &prefetcher{
+		orchestrator: o,
+		ctx:          ctx,
+		cancel:       cancel,
+		wf:           wf,
+		logger:       logger,
+		refs:         make(map[int][]string),
+		prereqs:      make(map[int][]int),
+		done:         make(map[int]bool),
+		launched:     make(map[int]bool),
+		pending:      make(map[int]*prefetch),
+	}
+
+	// Once a step declares depends_on, the graph only holds real
+	// prerequisites. Without any, it is the file order, which prefetching
+	// is meant to look past.
+	explicit := slices.ContainsFunc(graph.Steps, declaresDependencies)
+	for i := range wf.Steps {
+		if refs, ok := prefetchableRefs(&wf.Steps[i], wf.TemplateEngine); ok {
+			p.refs[i] = refs
+			if explicit {
+				p.prereqs[i] = graph.Deps[i]
+			}
+		}
+	}
+
+	return p
+}
+
+func (p *prefetcher) stop() {
+	p.cancel()
+}
+
+func (p *prefetcher) complete(index int) {
+	p.done[index] = true
+}
+
+func (p *prefetcher) schedule(position int, execCtx *workflow.ExecutionContext) {
+	var outputs map[string]any
+	for i, refs := range p.refs {
+		if i <= position || p.launched[i] {
+			continue
+		}
+		if outputs == nil {
+			outputs = execCtx.CopyStepOutputs()
+		}
+		if !p.resolvable(position, i, refs, outputs) {
+			continue
+		}
+
+		p.launched[i] = true
+		p.launch(i, execCtx, outputs)
+	}
+}
+
+func (p *prefetcher) resolvable(position, index int, refs []string, outputs map[string]any) bool {
+	for _, dep := range p.prereqs[index] {
+		if !p.done[dep] {
+			return false
+		}
+	}
+	for _, ref := range refs {
+		if ref == "input" || ref == "tags" || ref == "secrets" {
+			continue
+		}
+		if _, ok := outputs[ref]; !ok {
+			return false
+		}
+		for k := position; k < index; k++ {
+			if producesOutput(&p.wf.Steps[k], ref) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (p *prefetcher) launch(index int, execCtx *workflow.ExecutionContext, outputs map[string]any) {
+	step := &p.wf.Steps[index]
+	snapshot := &workflow.ExecutionContext{
+		WorkflowID:     execCtx.WorkflowID,
+		WorkflowName:   execCtx.WorkflowName,
+		Namespace:      execCtx.Namespace,
+		TemplateEngine: execCtx.TemplateEngine,
+		Input:          execCtx.Input,
+		Variables:      maps.Clone(execCtx.Variables),
+		StepOutputs:    maps.Clone(outputs),
+	}
+
+	p.logger.Debug().
+		Str("step_id", step.ID).
+		Msg("Prefetching idempotent step")
+
+	pf := &prefetch{done: make(chan struct{})}
+	p.pending[index] = pf
+
+	go func() {
+		defer close(pf.done)
+		pf.result, pf.err = p.orchestrator.executor.ExecuteStep(p.ctx, step, snapshot, p.wf)
+	}()
+}
+
+func (p *prefetcher) claim(index int) *prefetch {
+	p.launched[index] = true
+
+	pf, ok := p.pending[index]
+	if !ok {
+		return nil
+	}
+	delete(p.pending, index)
+	return pf
+}
+
+func (p *prefetcher) await(ctx context.Context, index int, pf *prefetch) (*workflow.StepResult, bool) {
+	select {
+	case <-pf.done:
+	case <-ctx.Done():
+		return nil, false
+	}
+
+	if pf.err != nil {
+		p.logger.Debug().
+			Err(pf.err).
+			Str("step_id", p.wf.Steps[index].ID).
+			Msg("Prefetch failed, executing step in place")
+		return nil, false
+	}
+
+	return pf.result, true
+}
+
+func prefetchableRefs(step *workflow.Step, engine string) ([]string, bool) {
+	if !step.Idempotent || step.Service == "" || step.When != "" || len(step.Parallel) > 0 ||
+		step.AcquireLock != nil || step.ReleaseLock != nil || step.KV != nil || step.Wait != nil {
+		return nil, false
+	}
+
+	var refs []string
+	for _, value := range step.Input {
+		s, ok := value.(string)
+		if !ok || !workflow.IsTemplate(s) {
+			continue
+		}
+		roots, ok := templating.Roots(engine, s)
+		if !ok {
+			return nil, false
+		}
+		refs = append(refs, roots...)
+	}
+	return refs, true
+}
+
+func producesOutput(step *workflow.Step, name string) bool {
+	if step.Output == name {
+		return true
+	}
+	for i := range step.Parallel {
+		if producesOutput(&step.Parallel[i], name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package application
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type recordingService struct {
+	mu     sync.Mutex
+	events []string
+	delay  map[string]time.Duration
+	fail   string
+}
+
+func (s *recordingService) record(event string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+}
+
+func (s *recordingService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method := strings.TrimPrefix(r.URL.Path, "/api/")
+	s.record("start " + method)
+	time.Sleep(s.delay[method])
+	s.record("end " + method)
+
+	if method == s.fail {
+		http.Error(w, method+" refused", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"id": method + "-1"})
+}
+
+func (s *recordingService) recorded() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.events)
+}
+
+const prefetchWorkflow = `
+name: ledger
+version: "1.0.0"
+services:
+  ledger:
+    type: http
+    endpoint: %s
+steps:
+  - id: write
+    service: ledger
+    method: write
+    output: written
+  - id: lookup
+    service: ledger
+    method: lookup
+    idempotent: %t
+    output: found
+    input:
+      id: "%s"
+    depends_on: %s
+`
+
+func TestPrefetch(t *testing.T) {
+	tests := []struct {
+		name       string
+		idempotent bool
+		input      string
+		dependsOn  string
+		wantAhead  bool
+	}{
+		{
+			name:       "an idempotent step reading only input starts ahead",
+			idempotent: true,
+			input:      "{{ .input.id }}",
+			wantAhead:  true,
+		},
+		{
+			name:       "an idempotent step waits for the output it reads",
+			idempotent: true,
+			input:      "{{ .written.id }}",
+		},
+		{
+			name:  "a step not marked idempotent keeps its turn",
+			input: "{{ .input.id }}",
+		},
+		{
+			name:       "an idempotent step waits for its depends_on",
+			idempotent: true,
+			input:      "{{ .input.id }}",
+			dependsOn:  "[write]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &recordingService{delay: map[string]time.Duration{"write": 50 * time.Millisecond}}
+			server := httptest.NewServer(service)
+			defer server.Close()
+
+			o := New(zerolog.Nop())
+			definition := fmt.Sprintf(prefetchWorkflow, server.URL, tt.idempotent, tt.input, cmp.Or(tt.dependsOn, "null"))
+			if _, err := o.LoadWorkflowData([]byte(definition), FormatYAML); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := o.ExecuteWorkflow(context.Background(), "ledger", map[string]interface{}{"id": "42"})
+			if err != nil {
+				t.Fatalf("ExecuteWorkflow() error = %v", err)
+			}
+			if result.Output["found"] == nil {
+				t.Errorf("lookup output missing from %v", result.Output)
+			}
+
+			events := service.recorded()
+			ahead := slices.Index(events, "start lookup") < slices.Index(events, "end write")
+			if ahead != tt.wantAhead {
+				t.Errorf("lookup started ahead of write = %v, want %v (events %v)", ahead, tt.wantAhead, events)
+			}
+			if n := len(events); n != 4 {
+				t.Errorf("got %d service events, want 4: %v", n, events)
+			}
+		})
+	}
+}
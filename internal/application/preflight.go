@@ -0,0 +1,150 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/grpc"
+)
+
+func (o *Orchestrator) preflight(ctx context.Context, wf *workflow.Workflow, environment string) error {
+	refs := wf.ServiceReferences()
+	services := make([]string, 0, len(refs))
+	for service := range refs {
+		services = append(services, service)
+	}
+	slices.Sort(services)
+
+	problems := make([]*workflow.PreflightProblem, len(services))
+	var wg sync.WaitGroup
+	for i, service := range services {
+		name := o.registeredServiceName(wf.Name, wf.Version, service, environment)
+		if err := o.registry.CheckService(name); err != nil {
+			problems[i] = &workflow.PreflightProblem{Service: service, Steps: refs[service], Problem: err.Error()}
+			continue
+		}
+		if wf.Preflight == nil || !wf.Preflight.Reachability {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := o.registry.ProbeService(ctx, name, wf.Preflight.ProbeTimeout(), wf.Preflight.FailureTTL()); err != nil {
+				problems[i] = &workflow.PreflightProblem{Service: service, Steps: refs[service], Problem: err.Error()}
+			}
+		}()
+	}
+	wg.Wait()
+
+	failed := &workflow.PreflightError{Workflow: wf.Name}
+	for _, problem := range problems {
+		if problem != nil {
+			failed.Problems = append(failed.Problems, *problem)
+		}
+	}
+	if len(failed.Problems) == 0 {
+		return nil
+	}
+	return failed
+}
+
+func (o *Orchestrator) registeredServiceName(wfName, version, service, environment string) string {
+	if o.serviceMerge == workflow.ServiceMergeNamespace {
+		service = workflow.WorkflowServiceName(wfName, service)
+	}
+	if environment != "" {
+		scoped := workflow.EnvironmentServiceName(service, environment)
+		if name, ok := o.versionedServiceName(wfName, version, scoped); ok {
+			return name
+		}
+	}
+	name, _ := o.versionedServiceName(wfName, version, service)
+	return name
+}
+
+func (o *Orchestrator) versionedServiceName(wfName, version, service string) (string, bool) {
+	versioned := workflow.VersionServiceName(wfName, version, service)
+	if _, err := o.registry.GetService(versioned); err == nil {
+		return versioned, true
+	}
+	_, err := o.registry.GetService(service)
+	return service, err == nil
+}
+
+func (o *Orchestrator) Preflight(ctx context.Context, timeout time.Duration) []workflow.PreflightCheck {
+	o.mu.RLock()
+	users := make(map[string][]string)
+	for name, wf := range o.workflows {
+		refs := wf.ServiceReferences()
+		for service := range o.serviceRegistrations(wf, o.overrides) {
+			base := strings.TrimPrefix(service, workflow.WorkflowServiceName(name, ""))
+			if i := strings.IndexAny(base, "@#"); i >= 0 {
+				base = base[:i]
+			}
+			if _, ok := refs[base]; ok {
+				users[service] = append(users[service], name)
+			}
+		}
+	}
+	o.mu.RUnlock()
+
+	services := make([]string, 0, len(users))
+	for service := range users {
+		services = append(services, service)
+		slices.Sort(users[service])
+	}
+	slices.Sort(services)
+
+	results := make([][]workflow.PreflightCheck, len(services))
+	var wg sync.WaitGroup
+	for i, service := range services {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = o.preflightService(ctx, service, users[service], timeout)
+		}()
+	}
+	wg.Wait()
+
+	return slices.Concat(results...)
+}
+
+func (o *Orchestrator) preflightService(ctx context.Context, service string, workflows []string, timeout time.Duration) []workflow.PreflightCheck {
+	var checks []workflow.PreflightCheck
+	run := func(name string, check func() error) bool {
+		start := time.Now()
+		err := check()
+		result := workflow.PreflightCheck{
+			Name:      name,
+			Target:    service,
+			Workflows: workflows,
+			Status:    workflow.PreflightPass,
+			Duration:  workflow.Duration{Duration: time.Since(start)},
+		}
+		switch {
+		case errors.Is(err, grpc.ErrHealthUnsupported):
+			result.Status = workflow.PreflightSkip
+			result.Detail = err.Error()
+		case err != nil:
+			result.Status = workflow.PreflightFail
+			result.Detail = err.Error()
+		}
+		checks = append(checks, result)
+		return err == nil
+	}
+
+	if !run("registration", func() error { return o.registry.CheckService(service) }) {
+		return checks
+	}
+	if !run("dial", func() error { return o.registry.ProbeService(ctx, service, timeout, 0) }) {
+		return checks
+	}
+	run("health", func() error { return o.registry.CheckHealth(ctx, service, timeout) })
+	return checks
+}
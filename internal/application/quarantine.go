@@ -0,0 +1,144 @@
+package application
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/metrics"
+)
+
+var (
+	quarantinedMetric = &workflow.MetricConfig{
+		Name: "maestro_workflow_quarantined",
+		Type: metrics.MetricTypeGauge,
+		Help: "1 while a workflow refuses new executions because of its failure rate",
+	}
+	quarantinesMetric = &workflow.MetricConfig{
+		Name: "maestro_workflow_quarantines_total",
+		Type: metrics.MetricTypeCounter,
+		Help: "Number of times a workflow was quarantined",
+	}
+)
+
+type quarantine struct {
+	mu          sync.Mutex
+	outcomes    map[string][]bool
+	quarantined map[string]*workflow.QuarantinedError
+}
+
+func newQuarantine() *quarantine {
+	return &quarantine{
+		outcomes:    make(map[string][]bool),
+		quarantined: make(map[string]*workflow.QuarantinedError),
+	}
+}
+
+func (o *Orchestrator) checkQuarantine(name string) error {
+	o.quarantine.mu.Lock()
+	defer o.quarantine.mu.Unlock()
+
+	if state, ok := o.quarantine.quarantined[name]; ok {
+		return state
+	}
+	return nil
+}
+
+func (o *Orchestrator) recordOutcome(wf *workflow.Workflow, result *workflow.WorkflowResult) {
+	policy := wf.Quarantine
+	if policy == nil {
+		return
+	}
+
+	var failed bool
+	switch result.Status {
+	case workflow.WorkflowStatusSuccess:
+	case workflow.WorkflowStatusFailed, workflow.WorkflowStatusCompensated:
+		failed = true
+	default:
+		return
+	}
+
+	q := o.quarantine
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.quarantined[wf.Name]; ok {
+		return
+	}
+
+	outcomes := append(q.outcomes[wf.Name], failed)
+	if len(outcomes) > policy.WindowSize() {
+		outcomes = outcomes[len(outcomes)-policy.WindowSize():]
+	}
+	q.outcomes[wf.Name] = outcomes
+
+	if len(outcomes) < policy.MinimumExecutions() {
+		return
+	}
+
+	failures := 0
+	for _, f := range outcomes {
+		if f {
+			failures++
+		}
+	}
+	if float64(failures)/float64(len(outcomes)) < policy.FailureRate {
+		return
+	}
+
+	state := &workflow.QuarantinedError{
+		Workflow:   wf.Name,
+		Since:      time.Now(),
+		Failures:   failures,
+		Executions: len(outcomes),
+	}
+	q.quarantined[wf.Name] = state
+	delete(q.outcomes, wf.Name)
+
+	labels := map[string]string{"workflow": wf.Name}
+	_ = o.metrics.Record(quarantinedMetric, 1, labels)
+	_ = o.metrics.Record(quarantinesMetric, 1, labels)
+
+	o.logger.Error().
+		Str("workflow", wf.Name).
+		Str("version", wf.Version).
+		Int("failures", failures).
+		Int("executions", len(outcomes)).
+		Float64("threshold", policy.FailureRate).
+		Msg("Workflow quarantined, refusing new executions until resumed")
+}
+
+func (o *Orchestrator) ResumeWorkflow(name string) error {
+	reset := o.resetBreaker(name)
+
+	q := o.quarantine
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.quarantined[name]; !ok {
+		if reset {
+			return nil
+		}
+		return fmt.Errorf("workflow %s is not quarantined or short-circuited", name)
+	}
+	delete(q.quarantined, name)
+	delete(q.outcomes, name)
+
+	_ = o.metrics.Record(quarantinedMetric, 0, map[string]string{"workflow": name})
+
+	o.logger.Info().
+		Str("workflow", name).
+		Msg("Workflow resumed from quarantine")
+
+	return nil
+}
+
+func (o *Orchestrator) Quarantined(name string) (*workflow.QuarantinedError, bool) {
+	o.quarantine.mu.Lock()
+	defer o.quarantine.mu.Unlock()
+
+	state, ok := o.quarantine.quarantined[name]
+	return state, ok
+}
@@ -0,0 +1,50 @@
+package application
+
+import (
+	"sync"
+	"time"
+)
+
+type rateLimiter struct {
+	mu         sync.Mutex
+	dispatched map[string][]time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{dispatched: make(map[string][]time.Time)}
+}
+
+func (l *rateLimiter) allow(name string, perMinute int, now time.Time) bool {
+	if perMinute <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	recent := l.dispatched[name][:0]
+	for _, at := range l.dispatched[name] {
+		if now.Sub(at) < time.Minute {
+			recent = append(recent, at)
+		}
+	}
+	if len(recent) >= perMinute {
+		l.dispatched[name] = recent
+		return false
+	}
+	l.dispatched[name] = append(recent, now)
+	return true
+}
+
+func (l *rateLimiter) wait(name string, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	oldest := now
+	for _, at := range l.dispatched[name] {
+		if at.Before(oldest) {
+			oldest = at
+		}
+	}
+	return max(oldest.Add(time.Minute).Sub(now), time.Second)
+}
@@ -0,0 +1,204 @@
+package application
+
+import (
+	"fmt"
+	"slices"
+
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/metrics"
+)
+
+var (
+	reclaimedPoolsMetric = &workflow.MetricConfig{
+		Name: "maestro_reclaimed_connection_pools_total",
+		Type: metrics.MetricTypeCounter,
+		Help: "Connection pools closed because no loaded workflow references their service anymore",
+	}
+	reclaimedBreakersMetric = &workflow.MetricConfig{
+		Name: "maestro_reclaimed_circuit_breakers_total",
+		Type: metrics.MetricTypeCounter,
+		Help: "Circuit breakers dropped because no loaded workflow references their service anymore",
+	}
+)
+
+func (o *Orchestrator) UnloadWorkflow(name string) error {
+	defer o.syncTriggers()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	wf, ok := o.workflows[name]
+	if !ok {
+		return fmt.Errorf("workflow %s not found", name)
+	}
+	for _, version := range o.versions[name] {
+		if version.Version == wf.Version {
+			continue
+		}
+		for service := range o.versionRegistrations(version, o.overrides) {
+			o.retiring[service] = struct{}{}
+		}
+	}
+	delete(o.workflows, name)
+	delete(o.sources, name)
+	delete(o.versions, name)
+
+	for service := range o.serviceRegistrations(wf, o.overrides) {
+		o.retiring[service] = struct{}{}
+	}
+	o.reclaimServices()
+
+	o.logger.Info().
+		Str("workflow", name).
+		Msg("Workflow unloaded")
+
+	return nil
+}
+
+type pendingRelease struct {
+	release    func()
+	executions []string
+}
+
+func (o *Orchestrator) releaseAfterInFlight(release func()) {
+	var executions []string
+	o.activeWorkflows.Range(func(key, _ any) bool {
+		executions = append(executions, key.(string))
+		return true
+	})
+	if len(executions) == 0 {
+		release()
+		return
+	}
+	o.releases = append(o.releases, pendingRelease{release: release, executions: executions})
+}
+
+func (o *Orchestrator) runReleases() {
+	o.releases = slices.DeleteFunc(o.releases, func(pending pendingRelease) bool {
+		for _, id := range pending.executions {
+			if _, ok := o.activeWorkflows.Load(id); ok {
+				return false
+			}
+		}
+		pending.release()
+		return true
+	})
+}
+
+func (o *Orchestrator) reclaimOrphanedServices(name string) {
+	o.mu.RLock()
+	pending := len(o.retiring) + len(o.releases)
+	versioned := len(o.versions[name]) > 1
+	o.mu.RUnlock()
+	if pending == 0 && !versioned {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.runReleases()
+	o.evictVersions(name)
+	o.reclaimServices()
+}
+
+// evictVersions drops the versions of a workflow that new executions no
+// longer get and that no running or suspended execution uses, and retires
+// the services registered for them.
+func (o *Orchestrator) evictVersions(name string) {
+	latest, ok := o.workflows[name]
+	if !ok || len(o.versions[name]) < 2 {
+		return
+	}
+
+	inUse := make(map[string]bool)
+	o.activeWorkflows.Range(func(_, value any) bool {
+		if wf := value.(*workflow.Workflow); wf.Name == name {
+			inUse[wf.Version] = true
+		}
+		return true
+	})
+	o.suspended.Range(func(_, value any) bool {
+		if execution := value.(*workflow.Execution); execution.WorkflowName == name {
+			inUse[execution.WorkflowVersion] = true
+		}
+		return true
+	})
+
+	for version, wf := range o.versions[name] {
+		if version == latest.Version || inUse[version] {
+			continue
+		}
+		for service := range o.versionRegistrations(wf, o.overrides) {
+			o.retiring[service] = struct{}{}
+		}
+		delete(o.versions[name], version)
+
+		o.logger.Info().
+			Str("workflow", name).
+			Str("version", version).
+			Str("latest", latest.Version).
+			Msg("Workflow version evicted, no execution uses it anymore")
+	}
+}
+
+func (o *Orchestrator) reclaimServices() {
+	if len(o.retiring) == 0 {
+		return
+	}
+
+	loaded := make(map[string]bool)
+	for name, wf := range o.workflows {
+		for service := range o.serviceRegistrations(wf, o.overrides) {
+			loaded[service] = true
+		}
+		for _, version := range o.versions[name] {
+			if version.Version == wf.Version {
+				continue
+			}
+			for service := range o.versionRegistrations(version, o.overrides) {
+				loaded[service] = true
+			}
+		}
+	}
+	inFlight := make(map[string]bool)
+	o.activeWorkflows.Range(func(_, value any) bool {
+		wf := value.(*workflow.Workflow)
+		for service := range o.serviceRegistrations(wf, o.overrides) {
+			inFlight[service] = true
+		}
+		for service := range o.versionRegistrations(wf, o.overrides) {
+			inFlight[service] = true
+		}
+		return true
+	})
+
+	var pools, breakers int
+	for service := range o.retiring {
+		if loaded[service] {
+			delete(o.retiring, service)
+			continue
+		}
+		if inFlight[service] {
+			continue
+		}
+		p, b := o.registry.RetireService(service)
+		pools += p
+		breakers += b
+		delete(o.retiring, service)
+	}
+
+	if pools == 0 && breakers == 0 {
+		return
+	}
+
+	if err := o.metrics.Record(reclaimedPoolsMetric, float64(pools), nil); err != nil {
+		o.logger.Warn().Err(err).Msg("Failed to record reclaimed connection pools")
+	}
+	if err := o.metrics.Record(reclaimedBreakersMetric, float64(breakers), nil); err != nil {
+		o.logger.Warn().Err(err).Msg("Failed to record reclaimed circuit breakers")
+	}
+
+	o.logger.Info().
+		Int("connection_pools", pools).
+		Int("circuit_breakers", breakers).
+		Msg("Reclaimed resources of unreferenced services")
+}
@@ -0,0 +1,171 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/metrics"
+	"github.com/maestro/maestro.go/internal/ports"
+)
+
+const sagaRecoveryInterval = time.Minute
+
+var recoveredSagasMetric = &workflow.MetricConfig{
+	Name: "maestro_recovered_sagas_total",
+	Type: metrics.MetricTypeCounter,
+	Help: "Sagas whose interrupted compensation was resumed by the recovery scanner",
+}
+
+func (o *Orchestrator) RunSagaRecovery(ctx context.Context) {
+	sagas, ok := o.store.(ports.SagaStore)
+	if !ok {
+		return
+	}
+
+	ticker := time.NewTicker(sagaRecoveryInterval)
+	defer ticker.Stop()
+
+	for {
+		o.recoverSagas(ctx, sagas)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (o *Orchestrator) recoverSagas(ctx context.Context, sagas ports.SagaStore) {
+	if o.draining.Load() {
+		return
+	}
+
+	stalled, err := sagas.ListStalledSagas(ctx, workflow.SagaStatusCompensating)
+	if err != nil {
+		o.logger.Error().
+			Err(err).
+			Msg("Failed to list stalled sagas")
+		return
+	}
+
+	for _, state := range stalled {
+		if ctx.Err() != nil {
+			return
+		}
+
+		err := o.recoverSaga(ctx, state)
+		if errors.Is(err, workflow.ErrFenced) {
+			continue
+		}
+		if err != nil {
+			o.logger.Error().
+				Err(err).
+				Str("workflow_id", state.WorkflowID).
+				Msg("Failed to recover saga")
+			continue
+		}
+
+		if err := o.metrics.Record(recoveredSagasMetric, 1, nil); err != nil {
+			o.logger.Warn().Err(err).Msg("Failed to record recovered saga")
+		}
+	}
+}
+
+func (o *Orchestrator) recoverSaga(ctx context.Context, state *workflow.SagaState) error {
+	workflowID := state.WorkflowID
+
+	execution, found, err := o.store.LoadExecution(ctx, workflowID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("execution %s not found", workflowID)
+	}
+
+	wf, ok := o.GetWorkflowVersion(execution.WorkflowName, execution.WorkflowVersion)
+	if !ok {
+		wf, ok = o.GetWorkflow(execution.WorkflowName)
+	}
+	if !ok {
+		return fmt.Errorf("workflow %s is not loaded", execution.WorkflowName)
+	}
+	execCtx := execution.Context
+	if execCtx.Environment != "" {
+		scoped, err := wf.ForEnvironment(execCtx.Environment)
+		if err != nil {
+			return err
+		}
+		wf = scoped
+	}
+
+	ctx, lease, err := o.claimExecution(ctx, workflowID)
+	if err != nil {
+		return err
+	}
+	ctx = context.WithValue(ctx, ctxkeys.WorkflowID, workflowID)
+	ctx = context.WithValue(ctx, ctxkeys.WorkflowName, execution.WorkflowName)
+	ctx = context.WithValue(ctx, ctxkeys.WorkflowVersion, wf.Version)
+	ctx = context.WithValue(ctx, ctxkeys.Namespace, execCtx.Namespace)
+	ctx = context.WithValue(ctx, ctxkeys.Environment, execCtx.Environment)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	logger := o.logger.With().
+		Str("workflow_id", workflowID).
+		Str("workflow_name", execution.WorkflowName).
+		Logger()
+	r := &run{
+		ctx:       ctx,
+		cancel:    cancel,
+		wf:        wf,
+		execCtx:   execCtx,
+		result:    execution.Result,
+		execution: execution,
+		lease:     lease,
+		logger:    logger,
+	}
+	defer o.keepLease(r)()
+
+	execCtx.ExecutedSteps = state.ExecutedSteps
+	execution.Result.SetStatus(workflow.WorkflowStatusCompensating)
+	o.executions.Store(workflowID, execution)
+	defer o.forgetAfterRetention(workflowID, execution)
+
+	logger.Warn().
+		Time("interrupted_at", state.UpdatedAt).
+		Msg("Resuming interrupted saga compensation")
+
+	compensationErr := o.sagaCoordinator.Compensate(ctx, execCtx, wf)
+	if lease.isFenced() {
+		return workflow.ErrFenced
+	}
+
+	status := workflow.WorkflowStatusCompensated
+	var unfinished *workflow.CompensationError
+	if errors.As(compensationErr, &unfinished) {
+		execution.Result.SetUnfinishedCompensations(unfinished.Unfinished)
+	} else {
+		execution.Result.SetUnfinishedCompensations(nil)
+	}
+	if compensationErr != nil {
+		status = workflow.WorkflowStatusFailed
+	}
+	execution.Result.Complete(status, execution.Result.Error)
+
+	if err := o.checkpoint(ctx, execution); err != nil {
+		return err
+	}
+	o.sagaCoordinator.SaveState(ctx, execCtx, workflow.SagaStatusOf(execution.Result.Status))
+
+	if compensationErr != nil {
+		return fmt.Errorf("resumed compensation failed: %w", compensationErr)
+	}
+
+	logger.Info().Msg("Interrupted saga compensation completed")
+	return nil
+}
@@ -0,0 +1,22 @@
+package application
+
+import (
+	"context"
+
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+)
+
+func (o *Orchestrator) redactorFor(ctx context.Context) *workflow.Redactor {
+	if redactor, ok := ctx.Value(ctxkeys.Redactor).(*workflow.Redactor); ok {
+		return redactor
+	}
+	return o.redactor
+}
+
+func (o *Orchestrator) RedactOutputs(workflowName string, outputs map[string]interface{}) map[string]interface{} {
+	if wf, ok := o.GetWorkflow(workflowName); ok {
+		return o.redactor.ForWorkflow(wf).Outputs(outputs)
+	}
+	return o.redactor.Outputs(outputs)
+}
@@ -0,0 +1,93 @@
+package application
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+
+	workflow "github.com/maestro/maestro.go/internal/domain"
+)
+
+func (o *Orchestrator) setSource(wf *workflow.Workflow, file string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.workflows[wf.Name] != wf {
+		return
+	}
+	if file == "" {
+		delete(o.sources, wf.Name)
+		return
+	}
+	o.sources[wf.Name] = file
+}
+
+func (o *Orchestrator) WorkflowFiles() []string {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	var files []string
+	for name, source := range o.sources {
+		files = append(files, absPath(source))
+		files = append(files, importedFiles(o.workflows[name], source)...)
+	}
+	slices.Sort(files)
+	return slices.Compact(files)
+}
+
+func (o *Orchestrator) ReloadWorkflowFile(file string) ([]*workflow.Workflow, error) {
+	importers := o.importers(file)
+	if len(importers) == 0 {
+		wf, err := o.LoadWorkflow(file)
+		if err != nil {
+			return nil, err
+		}
+		return []*workflow.Workflow{wf}, nil
+	}
+
+	var reloaded []*workflow.Workflow
+	var errs []error
+	for _, source := range importers {
+		wf, err := o.LoadWorkflow(source)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		reloaded = append(reloaded, wf)
+	}
+	return reloaded, errors.Join(errs...)
+}
+
+func (o *Orchestrator) importers(catalog string) []string {
+	catalog = absPath(catalog)
+
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	var sources []string
+	for name, source := range o.sources {
+		if slices.Contains(importedFiles(o.workflows[name], source), catalog) {
+			sources = append(sources, source)
+		}
+	}
+	slices.Sort(sources)
+	return sources
+}
+
+func importedFiles(wf *workflow.Workflow, source string) []string {
+	files := make([]string, len(wf.Import))
+	for i, file := range wf.Import {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(filepath.Dir(source), file)
+		}
+		files[i] = absPath(file)
+	}
+	return files
+}
+
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
@@ -0,0 +1,134 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+)
+
+type ReplayDiff struct {
+	WorkflowID      string         `json:"workflow_id"`
+	OriginalVersion string         `json:"original_version"`
+	ReplayVersion   string         `json:"replay_version"`
+	OriginalStatus  string         `json:"original_status"`
+	ReplayStatus    string         `json:"replay_status"`
+	ReplayError     string         `json:"replay_error,omitempty"`
+	MissingSteps    []string       `json:"missing_steps,omitempty"`
+	AddedSteps      []string       `json:"added_steps,omitempty"`
+	Unrecorded      []string       `json:"unrecorded_steps,omitempty"`
+	OutputChanges   []OutputChange `json:"output_changes,omitempty"`
+	SuppressedCalls int            `json:"suppressed_calls,omitempty"`
+}
+
+type OutputChange struct {
+	Key      string      `json:"key"`
+	Original interface{} `json:"original"`
+	Replayed interface{} `json:"replayed"`
+}
+
+func (d *ReplayDiff) Diverged() bool {
+	return d.OriginalStatus != d.ReplayStatus ||
+		len(d.MissingSteps) > 0 ||
+		len(d.AddedSteps) > 0 ||
+		len(d.OutputChanges) > 0
+}
+
+func (o *Orchestrator) ReplayExecution(
+	ctx context.Context,
+	workflowName string,
+	original *workflow.Execution,
+	recorded []*workflow.StepResult,
+) (*ReplayDiff, error) {
+	wf, ok := o.GetWorkflow(workflowName)
+	if !ok {
+		return nil, fmt.Errorf("workflow %s not found", workflowName)
+	}
+	if err := workflow.CheckNamespace(original.Context.Namespace, wf.Namespace); err != nil {
+		return nil, fmt.Errorf("cannot replay execution %s: %w", original.Context.WorkflowID, err)
+	}
+
+	shadow := workflow.NewShadowRun(recorded, original.Context.CopyStepOutputs())
+	ctx = context.WithValue(ctx, ctxkeys.Shadow, shadow)
+	if env := original.Context.Environment; env != "" {
+		ctx = WithEnvironment(ctx, env)
+	}
+
+	result, err := o.ExecuteWorkflow(ctx, workflowName, maps.Clone(original.Context.Input))
+	if result == nil {
+		return nil, fmt.Errorf("failed to replay execution %s: %w", original.Context.WorkflowID, err)
+	}
+
+	diff := &ReplayDiff{
+		WorkflowID:      original.Context.WorkflowID,
+		OriginalVersion: original.WorkflowVersion,
+		ReplayVersion:   wf.Version,
+		OriginalStatus:  original.Result.Status.String(),
+		ReplayStatus:    result.Status.String(),
+		Unrecorded:      shadow.Unrecorded(),
+		OutputChanges:   compareOutputs(original.Result.Output, result.Output),
+		SuppressedCalls: shadow.Suppressed(),
+	}
+	if err != nil {
+		diff.ReplayError = err.Error()
+	}
+
+	if len(recorded) > 0 {
+		var originalPath []string
+		for _, step := range recorded {
+			if !step.Skipped && step.StepID != "parallel" {
+				originalPath = append(originalPath, step.StepID)
+			}
+		}
+		replayPath := shadow.Executed()
+
+		for _, id := range originalPath {
+			if !slices.Contains(replayPath, id) {
+				diff.MissingSteps = append(diff.MissingSteps, id)
+			}
+		}
+		for _, id := range replayPath {
+			if !slices.Contains(originalPath, id) {
+				diff.AddedSteps = append(diff.AddedSteps, id)
+			}
+		}
+	}
+
+	return diff, nil
+}
+
+func compareOutputs(original, replayed map[string]interface{}) []OutputChange {
+	keys := slices.Collect(maps.Keys(original))
+	for key := range replayed {
+		if _, ok := original[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var changes []OutputChange
+	for _, key := range keys {
+		before, after := normalizeJSON(original[key]), normalizeJSON(replayed[key])
+		if !reflect.DeepEqual(before, after) {
+			changes = append(changes, OutputChange{Key: key, Original: before, Replayed: after})
+		}
+	}
+	return changes
+}
+
+func normalizeJSON(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
@@ -0,0 +1,235 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"time"
+
+	"github.com/google/uuid"
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/metrics"
+)
+
+const (
+	rerunPollInterval = 5 * time.Second
+	rerunRetryDelay   = time.Minute
+)
+
+var rerunNamespace = uuid.MustParse("0b5c3f7e-4d1a-4e8b-9a61-2f0c7d9e5a43")
+
+var rerunsMetric = &workflow.MetricConfig{
+	Name: "maestro_reruns_total",
+	Type: metrics.MetricTypeCounter,
+	Help: "Failed executions queued for a re-run, and what became of the queued re-runs",
+}
+
+func (o *Orchestrator) scheduleRerun(r *run) {
+	policy := r.wf.Rerun
+	if policy == nil || r.child || r.handedOff() || r.ctx.Value(ctxkeys.Shadow) != nil {
+		return
+	}
+	switch r.result.Status {
+	case workflow.WorkflowStatusFailed, workflow.WorkflowStatusCompensated:
+	default:
+		return
+	}
+
+	execCtx := r.execCtx
+	if len(r.result.UnfinishedCompensations) > 0 {
+		r.logger.Warn().
+			Int("unfinished_compensations", len(r.result.UnfinishedCompensations)).
+			Msg("Execution not queued for a re-run, its compensation did not finish")
+		return
+	}
+
+	attempt := execCtx.RerunAttempt + 1
+	if attempt > policy.Attempts {
+		r.logger.Warn().
+			Int("attempts", policy.Attempts).
+			Msg("Execution failed on its last re-run")
+		o.recordRerun(r.wf.Name, "exhausted")
+		return
+	}
+
+	originalID := execCtx.RerunOf
+	if originalID == "" {
+		originalID = execCtx.WorkflowID
+	}
+	var reason string
+	if r.result.Error != nil {
+		reason = r.result.Error.Error()
+	}
+
+	now := time.Now()
+	rerun := &workflow.Rerun{
+		ID:          uuid.NewSHA1(rerunNamespace, []byte(execCtx.WorkflowID)).String(),
+		Workflow:    r.wf.Name,
+		Namespace:   execCtx.Namespace,
+		Environment: execCtx.Environment,
+		Tags:        maps.Clone(execCtx.Tags),
+		Input:       maps.Clone(execCtx.Input),
+		OriginalID:  originalID,
+		PreviousID:  execCtx.WorkflowID,
+		Attempt:     attempt,
+		Reason:      reason,
+		DueAt:       now.Add(policy.DelayFor(attempt)),
+		CreatedAt:   now,
+	}
+	if err := o.reruns.EnqueueRerun(context.WithoutCancel(r.ctx), rerun); err != nil {
+		r.logger.Error().
+			Err(err).
+			Msg("Failed to queue execution for a re-run")
+		return
+	}
+	execCtx.RerunID = rerun.ID
+
+	o.recordRerun(r.wf.Name, "queued")
+	r.logger.Info().
+		Str("rerun_id", rerun.ID).
+		Str("original_id", originalID).
+		Int("attempt", attempt).
+		Time("due_at", rerun.DueAt).
+		Msg("Execution queued for a re-run")
+}
+
+func (o *Orchestrator) RunReruns(ctx context.Context) {
+	ticker := time.NewTicker(rerunPollInterval)
+	defer ticker.Stop()
+
+	for {
+		o.dispatchReruns(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (o *Orchestrator) dispatchReruns(ctx context.Context) {
+	if o.draining.Load() {
+		return
+	}
+
+	now := time.Now()
+	due, err := o.reruns.DueReruns(ctx, now)
+	if err != nil {
+		o.logger.Error().
+			Err(err).
+			Msg("Failed to list due re-runs")
+		return
+	}
+
+	for _, rerun := range due {
+		if ctx.Err() != nil || o.draining.Load() {
+			return
+		}
+
+		wf, ok := o.GetWorkflow(rerun.Workflow)
+		if !ok {
+			continue
+		}
+		if wf.Rerun == nil {
+			o.dropRerun(ctx, rerun, "workflow no longer has a rerun policy")
+			continue
+		}
+		if !o.rerunLimiter.allow(rerun.Workflow, wf.Rerun.PerMinute, now) {
+			continue
+		}
+
+		err := o.reruns.DeleteRerun(ctx, rerun.ID)
+		if errors.Is(err, workflow.ErrRerunNotFound) {
+			continue
+		}
+		if err != nil {
+			o.logger.Error().
+				Err(err).
+				Str("rerun_id", rerun.ID).
+				Msg("Failed to take re-run off the queue")
+			continue
+		}
+
+		o.startRerun(ctx, rerun)
+	}
+}
+
+func (o *Orchestrator) startRerun(ctx context.Context, rerun *workflow.Rerun) {
+	runCtx := WithWorkflowID(context.WithoutCancel(ctx), rerun.ID)
+	runCtx = WithTags(runCtx, rerun.Tags)
+	runCtx = context.WithValue(runCtx, ctxkeys.Rerun, rerun)
+	if rerun.Environment != "" {
+		runCtx = WithEnvironment(runCtx, rerun.Environment)
+	}
+
+	r, err := o.prepareRun(runCtx, rerun.Workflow, rerun.Input)
+	if errors.Is(err, workflow.ErrFenced) {
+		return
+	}
+	if err != nil {
+		rerun.Reason = err.Error()
+		rerun.DueAt = time.Now().Add(rerunRetryDelay)
+		var deferred *workflow.CalendarError
+		if errors.As(err, &deferred) {
+			rerun.DueAt = deferred.RetryAt
+		}
+		if queueErr := o.reruns.EnqueueRerun(context.WithoutCancel(ctx), rerun); queueErr != nil {
+			o.logger.Error().
+				Err(queueErr).
+				Str("rerun_id", rerun.ID).
+				Msg("Failed to put re-run back on the queue")
+		}
+		o.logger.Warn().
+			Err(err).
+			Str("rerun_id", rerun.ID).
+			Str("workflow", rerun.Workflow).
+			Time("due_at", rerun.DueAt).
+			Msg("Re-run could not start, postponed")
+		o.recordRerun(rerun.Workflow, "postponed")
+		return
+	}
+
+	go func() {
+		_, _ = o.execute(r)
+	}()
+
+	o.recordRerun(rerun.Workflow, "started")
+	r.logger.Info().
+		Str("original_id", rerun.OriginalID).
+		Str("previous_id", rerun.PreviousID).
+		Int("attempt", rerun.Attempt).
+		Msg("Re-run of failed execution started")
+}
+
+func (o *Orchestrator) dropRerun(ctx context.Context, rerun *workflow.Rerun, reason string) {
+	if err := o.reruns.DeleteRerun(ctx, rerun.ID); err != nil && !errors.Is(err, workflow.ErrRerunNotFound) {
+		o.logger.Error().
+			Err(err).
+			Str("rerun_id", rerun.ID).
+			Msg("Failed to drop re-run")
+		return
+	}
+
+	o.logger.Warn().
+		Str("rerun_id", rerun.ID).
+		Str("workflow", rerun.Workflow).
+		Str("reason", reason).
+		Msg("Re-run dropped")
+	o.recordRerun(rerun.Workflow, "dropped")
+}
+
+func (o *Orchestrator) recordRerun(name, outcome string) {
+	if err := o.metrics.Record(rerunsMetric, 1, map[string]string{"workflow": name, "outcome": outcome}); err != nil {
+		o.logger.Warn().Err(err).Msg("Failed to record re-run")
+	}
+}
+
+func (o *Orchestrator) ListReruns(ctx context.Context, name string) ([]*workflow.Rerun, error) {
+	return o.reruns.ListReruns(ctx, name)
+}
+
+func (o *Orchestrator) CancelRerun(ctx context.Context, id string) error {
+	return o.reruns.DeleteRerun(ctx, id)
+}
@@ -0,0 +1,86 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/metrics"
+	"github.com/maestro/maestro.go/internal/ports"
+)
+
+const (
+	retentionInterval         = time.Hour
+	defaultExecutionRetention = time.Hour
+)
+
+var purgedFieldsMetric = &workflow.MetricConfig{
+	Name: "maestro_retention_purged_fields_total",
+	Type: metrics.MetricTypeCounter,
+	Help: "Execution fields purged from the store because their retention period ended",
+}
+
+func (o *Orchestrator) RunRetention(ctx context.Context) {
+	purger, _ := o.store.(ports.RetentionPurger)
+
+	ticker := time.NewTicker(retentionInterval)
+	defer ticker.Stop()
+
+	for {
+		if purger != nil {
+			o.purgeExpiredFields(ctx, purger)
+		}
+		o.purgeIdempotencyKeys(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (o *Orchestrator) evictExecution(r *run) {
+	if r.result.Status == workflow.WorkflowStatusSuspended {
+		return
+	}
+	o.forgetAfterRetention(r.execCtx.WorkflowID, r.execution)
+}
+
+func (o *Orchestrator) forgetAfterRetention(workflowID string, execution *workflow.Execution) {
+	time.AfterFunc(o.executionRetention, func() {
+		o.executions.CompareAndDelete(workflowID, execution)
+	})
+}
+
+func (o *Orchestrator) purgeExpiredFields(ctx context.Context, purger ports.RetentionPurger) {
+	purged, err := purger.PurgeExpiredFields(ctx, time.Now())
+	if purged > 0 {
+		if err := o.metrics.Record(purgedFieldsMetric, float64(purged), nil); err != nil {
+			o.logger.Warn().Err(err).Msg("Failed to record purged fields")
+		}
+		o.logger.Info().
+			Int("fields", purged).
+			Msg("Purged execution fields past their retention")
+	}
+	if err != nil {
+		o.logger.Error().
+			Err(err).
+			Msg("Failed to purge expired execution fields")
+	}
+}
+
+func (o *Orchestrator) purgeIdempotencyKeys(ctx context.Context) {
+	purged, err := o.idempotency.PurgeIdempotencyKeys(ctx, time.Now())
+	if err != nil {
+		o.logger.Error().
+			Err(err).
+			Msg("Failed to purge expired idempotency keys")
+		return
+	}
+	if purged > 0 {
+		o.logger.Info().
+			Int("keys", purged).
+			Msg("Purged expired idempotency keys")
+	}
+}
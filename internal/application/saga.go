@@ -3,21 +3,29 @@ package application
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/maestro/maestro.go/internal/application/executor"
 	ctxkeys "github.com/maestro/maestro.go/internal/context"
 	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/tracing"
+	"github.com/maestro/maestro.go/internal/ports"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type SagaCoordinator struct {
 	executor *executor.Executor
+	store    ports.SagaStore
 	logger   zerolog.Logger
 }
 
-func NewSagaCoordinator(executor *executor.Executor, logger zerolog.Logger) *SagaCoordinator {
+func NewSagaCoordinator(executor *executor.Executor, store ports.SagaStore, logger zerolog.Logger) *SagaCoordinator {
 	return &SagaCoordinator{
 		executor: executor,
+		store:    store,
 		logger:   logger,
 	}
 }
@@ -42,13 +50,31 @@ func (s *SagaCoordinator) Compensate(
 		Logger()
 
 	logger.Info().Msg("Starting saga compensation")
+	s.executor.Emit(ctx, domain.Event{Type: domain.EventCompensationStarted})
 
-	var compensationErrors []error
+	ctx, span := tracing.Tracer().Start(ctx, "compensation", trace.WithAttributes(
+		attribute.String("maestro.workflow_id", execCtx.WorkflowID),
+		attribute.Int("maestro.executed_steps", len(execCtx.ExecutedSteps)),
+	))
 
+	concurrency := 1
+	if wf.Compensation != nil && wf.Compensation.Concurrency > 1 {
+		concurrency = wf.Compensation.Concurrency
+	}
+
+	state := &domain.SagaState{
+		WorkflowID:    execCtx.WorkflowID,
+		Namespace:     execCtx.Namespace,
+		ExecutedSteps: execCtx.CopyExecutedSteps(),
+		Status:        domain.SagaStatusCompensating,
+	}
+	s.saveState(ctx, state)
+
+	var pending []int
 	for i := len(execCtx.ExecutedSteps) - 1; i >= 0; i-- {
 		step := &execCtx.ExecutedSteps[i]
 
-		if step.Compensation == nil {
+		if step.Compensation == nil && step.SubWorkflowID == "" && step.Transition == nil {
 			logger.Debug().
 				Str("step_id", step.StepID).
 				Msg("Step has no compensation, skipping")
@@ -62,30 +88,149 @@ func (s *SagaCoordinator) Compensate(
 			continue
 		}
 
-		err := s.executor.CompensateStep(ctx, step, execCtx, wf)
-		if err != nil {
+		pending = append(pending, i)
+	}
+
+	if err := s.runCompensations(ctx, execCtx, wf, state, pending, concurrency, logger); err != nil {
+		tracing.End(span, err)
+		s.executor.Emit(ctx, domain.Event{Type: domain.EventCompensationFailed, Error: err.Error()})
+		return err
+	}
+	span.End()
+	s.executor.Emit(ctx, domain.Event{Type: domain.EventCompensationCompleted})
+
+	logger.Info().Msg("Saga compensation completed successfully")
+	return nil
+}
+
+func (s *SagaCoordinator) runCompensations(
+	ctx context.Context,
+	execCtx *domain.ExecutionContext,
+	wf *domain.Workflow,
+	state *domain.SagaState,
+	pending []int,
+	concurrency int,
+	logger zerolog.Logger,
+) *domain.CompensationError {
+	waitingOn := make(map[int]int, len(pending))
+	dependents := make(map[int][]int, len(pending))
+	for _, i := range pending {
+		for _, after := range execCtx.ExecutedSteps[i].CompensateAfter {
+			for _, j := range pending {
+				if j != i && execCtx.ExecutedSteps[j].StepID == after {
+					waitingOn[i]++
+					dependents[j] = append(dependents[j], i)
+				}
+			}
+		}
+	}
+
+	type outcome struct {
+		index int
+		err   error
+	}
+
+	var ready []int
+	for _, i := range pending {
+		if waitingOn[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	done := make(chan outcome)
+	remaining := len(pending)
+	running := 0
+	started := make(map[int]bool, len(pending))
+	stopOnFailure := wf.Compensation.StopOnFailure()
+	var failure *domain.CompensationError
+
+	for remaining > 0 {
+		if failure != nil && stopOnFailure {
+			if running == 0 {
+				break
+			}
+			ready = nil
+		}
+
+		if len(ready) == 0 && running == 0 {
+			logger.Warn().Msg("Compensation ordering contains a cycle, falling back to reverse order")
+			for _, i := range pending {
+				if waitingOn[i] > 0 {
+					waitingOn[i] = 0
+					ready = append(ready, i)
+				}
+			}
+		}
+
+		for len(ready) > 0 && running < concurrency {
+			slices.Sort(ready)
+			i := ready[len(ready)-1]
+			ready = ready[:len(ready)-1]
+			running++
+			started[i] = true
+
+			go func(i int) {
+				done <- outcome{index: i, err: s.executor.CompensateStep(ctx, &execCtx.ExecutedSteps[i], execCtx, wf)}
+			}(i)
+		}
+
+		result := <-done
+		running--
+		remaining--
+
+		step := &execCtx.ExecutedSteps[result.index]
+		if result.err != nil {
 			logger.Error().
-				Err(err).
+				Err(result.err).
 				Str("step_id", step.StepID).
+				Int("attempts", step.CompensationAttempts).
 				Msg("Failed to compensate step")
-			compensationErrors = append(compensationErrors, fmt.Errorf(
-				"failed to compensate step %s: %w", step.StepID, err,
+			if failure == nil {
+				failure = &domain.CompensationError{}
+			}
+			failure.Errors = append(failure.Errors, fmt.Errorf(
+				"failed to compensate step %s: %w", step.StepID, result.err,
 			))
-			continue
+			failure.Unfinished = append(failure.Unfinished, domain.UnfinishedCompensation{
+				StepID:   step.StepID,
+				Attempts: step.CompensationAttempts,
+				Error:    result.err.Error(),
+			})
+		} else {
+			logger.Info().
+				Str("step_id", step.StepID).
+				Msg("Step compensated successfully")
+			state.ExecutedSteps[result.index].Compensated = true
+			s.saveState(ctx, state)
 		}
 
-		logger.Info().
-			Str("step_id", step.StepID).
-			Msg("Step compensated successfully")
+		for _, dependent := range dependents[result.index] {
+			if waitingOn[dependent] > 0 {
+				waitingOn[dependent]--
+				if waitingOn[dependent] == 0 {
+					ready = append(ready, dependent)
+				}
+			}
+		}
 	}
 
-	if len(compensationErrors) > 0 {
-		return fmt.Errorf("compensation completed with %d errors: %v",
-			len(compensationErrors), compensationErrors)
+	if failure == nil {
+		return nil
 	}
 
-	logger.Info().Msg("Saga compensation completed successfully")
-	return nil
+	for _, i := range pending {
+		if !started[i] {
+			step := &execCtx.ExecutedSteps[i]
+			logger.Warn().
+				Str("step_id", step.StepID).
+				Msg("Compensation not attempted after an earlier failure")
+			failure.Unfinished = append(failure.Unfinished, domain.UnfinishedCompensation{
+				StepID:   step.StepID,
+				Attempts: step.CompensationAttempts,
+			})
+		}
+	}
+	return failure
 }
 
 func (s *SagaCoordinator) RecordStep(
@@ -93,49 +238,35 @@ func (s *SagaCoordinator) RecordStep(
 	step *domain.Step,
 	result *domain.StepResult,
 ) {
-	if step.Compensate != nil {
-		execCtx.ExecutedSteps = append(execCtx.ExecutedSteps, domain.ExecutedStep{
-			StepID:       step.ID,
-			Output:       result.Output,
-			Compensation: step.Compensate,
-			Compensated:  false,
-		})
+	if step.Compensate != nil && !result.Recovered() {
+		execCtx.AppendExecutedStep(domain.NewExecutedStep(step, result.Output))
 	}
 
 	if step.Output != "" && result != nil {
-		execCtx.StepOutputs[step.Output] = result.Output
+		execCtx.SetStepOutput(step.Output, result.Output)
 	}
 }
 
-type SagaState struct {
-	WorkflowID    string
-	ExecutedSteps []domain.ExecutedStep
-	Status        SagaStatus
+func (s *SagaCoordinator) SaveState(ctx context.Context, execCtx *domain.ExecutionContext, status domain.SagaStatus) {
+	s.saveState(ctx, &domain.SagaState{
+		WorkflowID:    execCtx.WorkflowID,
+		Namespace:     execCtx.Namespace,
+		ExecutedSteps: execCtx.CopyExecutedSteps(),
+		Status:        status,
+	})
 }
 
-type SagaStatus int
-
-const (
-	SagaStatusRunning SagaStatus = iota
-	SagaStatusCompensating
-	SagaStatusCompleted
-	SagaStatusFailed
-	SagaStatusCompensated
-)
+func (s *SagaCoordinator) saveState(ctx context.Context, state *domain.SagaState) {
+	if s.store == nil || isDryRun(ctx) {
+		return
+	}
 
-func (s SagaStatus) String() string {
-	switch s {
-	case SagaStatusRunning:
-		return "running"
-	case SagaStatusCompensating:
-		return "compensating"
-	case SagaStatusCompleted:
-		return "completed"
-	case SagaStatusFailed:
-		return "failed"
-	case SagaStatusCompensated:
-		return "compensated"
-	default:
-		return "unknown"
+	state.UpdatedAt = time.Now()
+	if err := s.store.SaveSagaState(context.WithoutCancel(ctx), state); err != nil {
+		s.logger.Error().
+			Err(err).
+			Str("workflow_id", state.WorkflowID).
+			Str("saga_status", state.Status.String()).
+			Msg("Failed to persist saga state")
 	}
 }
@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/rs/zerolog"
+)
+
+const compensatedWorkflow = `
+name: orders
+version: "1.0.0"
+services:
+  inventory:
+    type: http
+    endpoint: %s
+steps:
+  - id: reserve
+    service: inventory
+    method: reserve
+    compensate:
+      method: release
+  - id: charge
+    service: inventory
+    method: charge
+`
+
+type callLog struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (l *callLog) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method := strings.TrimPrefix(r.URL.Path, "/api/")
+	l.mu.Lock()
+	l.calls = append(l.calls, method)
+	l.mu.Unlock()
+
+	if method == "charge" {
+		http.Error(w, "card declined", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"id": method + "-1"})
+}
+
+func TestFailedStepCompensatesWithDefaultPoolSize(t *testing.T) {
+	service := &callLog{}
+	server := httptest.NewServer(service)
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "orders.yaml")
+	if err := os.WriteFile(file, []byte(fmt.Sprintf(compensatedWorkflow, server.URL)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	o := New(zerolog.Nop())
+	if _, err := o.LoadWorkflow(file); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := o.ExecuteWorkflow(ctx, "orders", map[string]interface{}{})
+	if err == nil {
+		t.Fatal("ExecuteWorkflow() succeeded, want the charge failure")
+	}
+	if result.Status != domain.WorkflowStatusCompensated {
+		t.Errorf("status = %s, want compensated", result.Status)
+	}
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	if !slices.Contains(service.calls, "release") {
+		t.Errorf("calls = %v, want the reservation released", service.calls)
+	}
+}
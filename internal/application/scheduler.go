@@ -0,0 +1,186 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+)
+
+type stepOutcome struct {
+	index  int
+	result *workflow.StepResult
+	err    error
+}
+
+func (o *Orchestrator) runSteps(r *run, graph *StepGraph) (stepErr error, err error) {
+	ctx, execCtx := r.ctx, r.execCtx
+
+	prefetcher := o.newPrefetcher(ctx, r.wf, graph, r.logger)
+	defer prefetcher.stop()
+
+	remaining := make([]int, len(graph.Steps))
+	var ready []int
+	for i, deps := range graph.Deps {
+		remaining[i] = len(deps)
+		if remaining[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	release := func(index int) {
+		prefetcher.complete(index)
+		for _, dependent := range graph.Dependents[index] {
+			remaining[dependent]--
+			if remaining[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	outcomes := make(chan stepOutcome, len(graph.Steps))
+	running := 0
+	suspended := false
+
+	for len(ready) > 0 || running > 0 {
+		if stepErr == nil && !suspended {
+			if err := ctx.Err(); err != nil {
+				for running > 0 {
+					<-outcomes
+					running--
+				}
+				return nil, err
+			}
+
+			suspended = len(ready) > 0 && o.suspends(r)
+			for i := 0; i < len(ready) && !suspended; i++ {
+				index := ready[i]
+				if r.completed[graph.Steps[index].ID] {
+					release(index)
+					continue
+				}
+				o.dispatchStep(ctx, r, graph, prefetcher, index, outcomes)
+				running++
+			}
+		}
+		ready = ready[:0]
+
+		if running == 0 {
+			break
+		}
+
+		outcome := <-outcomes
+		running--
+		step := graph.Steps[outcome.index]
+
+		if outcome.err != nil {
+			r.logger.Error().
+				Err(outcome.err).
+				Str("step_id", step.ID).
+				Msg("Step execution failed")
+
+			failure := outcome.err
+			if err := o.checkpointStep(ctx, r.execution, &workflow.StepResult{StepID: step.ID, Error: outcome.err}); err != nil {
+				failure = errors.Join(failure, err)
+			}
+			if stepErr == nil {
+				stepErr = failure
+				r.failedStep = step.ID
+			}
+			continue
+		}
+
+		if outcome.result != nil {
+			o.sagaCoordinator.RecordStep(execCtx, step, outcome.result)
+			if err := o.checkpointStep(ctx, r.execution, outcome.result); err != nil {
+				r.logger.Error().
+					Err(err).
+					Str("step_id", step.ID).
+					Msg("Failed to checkpoint step, failing the execution")
+				if stepErr == nil {
+					stepErr = err
+					r.failedStep = step.ID
+				}
+				continue
+			}
+		}
+		execCtx.MarkCompleted(step.ID)
+
+		release(outcome.index)
+	}
+
+	if suspended && stepErr == nil {
+		return nil, errDrained
+	}
+	return stepErr, nil
+}
+
+func (o *Orchestrator) dispatchStep(
+	ctx context.Context,
+	r *run,
+	graph *StepGraph,
+	prefetcher *prefetcher,
+	index int,
+	outcomes chan<- stepOutcome,
+) {
+	prefetcher.schedule(index, r.execCtx)
+	pf := prefetcher.claim(index)
+	step := graph.Steps[index]
+
+	go func() {
+		readyAt := time.Now()
+		clock := &workflow.StepClock{}
+
+		if pf != nil {
+			if result, ok := prefetcher.await(ctx, index, pf); ok {
+				recordTiming(r.execCtx, graph, index, readyAt, clock, result, nil)
+				outcomes <- stepOutcome{index: index, result: result}
+				return
+			}
+		}
+
+		stepCtx := context.WithValue(ctx, ctxkeys.StepClock, clock)
+		result, err := o.executor.ExecuteStep(stepCtx, step, r.execCtx, r.wf)
+		recordTiming(r.execCtx, graph, index, readyAt, clock, result, err)
+		outcomes <- stepOutcome{index: index, result: result, err: err}
+	}()
+}
+
+func recordTiming(
+	execCtx *workflow.ExecutionContext,
+	graph *StepGraph,
+	index int,
+	readyAt time.Time,
+	clock *workflow.StepClock,
+	result *workflow.StepResult,
+	err error,
+) {
+	step := graph.Steps[index]
+	finishedAt := time.Now()
+	slotWait, serviceTime := clock.Totals()
+
+	timing := workflow.StepTiming{
+		StepID:      step.ID,
+		Service:     step.Service,
+		Status:      workflow.StepTimingSucceeded,
+		ReadyAt:     readyAt,
+		FinishedAt:  finishedAt,
+		Duration:    workflow.Duration{Duration: finishedAt.Sub(readyAt)},
+		SlotWait:    workflow.Duration{Duration: slotWait},
+		ServiceTime: workflow.Duration{Duration: serviceTime},
+	}
+	for _, dep := range graph.Deps[index] {
+		timing.DependsOn = append(timing.DependsOn, graph.Steps[dep].ID)
+	}
+
+	switch {
+	case err != nil || result != nil && result.Error != nil:
+		timing.Status = workflow.StepTimingFailed
+	case result != nil && result.Skipped:
+		timing.Status = workflow.StepTimingSkipped
+	}
+
+	execCtx.RecordTiming(timing)
+}
@@ -0,0 +1,180 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/rs/zerolog"
+)
+
+const runStepsWorkflow = `
+name: orders
+version: "1.0.0"
+services:
+  billing:
+    type: http
+    endpoint: %s
+steps:
+  - id: reserve
+    service: billing
+    method: reserve
+    output: reservation
+  - id: charge
+    service: billing
+    method: charge
+    output: charge
+    depends_on: [reserve]
+  - id: notify
+    service: billing
+    method: notify
+    depends_on: [reserve]
+  - id: ship
+    service: billing
+    method: ship
+    input:
+      charge_id: "{{ .charge.id }}"
+    depends_on: [notify]
+`
+
+type failingStore struct {
+	failStep string
+}
+
+func (s *failingStore) SaveExecution(context.Context, *domain.Execution) error {
+	return nil
+}
+
+func (s *failingStore) SaveStepResult(_ context.Context, _ string, result *domain.StepResult) error {
+	if result.StepID == s.failStep {
+		return errors.New("disk full")
+	}
+	return nil
+}
+
+func (s *failingStore) LoadExecution(context.Context, string) (*domain.Execution, bool, error) {
+	return nil, false, nil
+}
+
+func (s *failingStore) ListExecutions(context.Context, domain.ExecutionFilter) ([]*domain.Execution, error) {
+	return nil, nil
+}
+
+func TestRunSteps(t *testing.T) {
+	tests := []struct {
+		name        string
+		fail        string
+		failStore   string
+		delay       map[string]time.Duration
+		completed   []string
+		cancel      bool
+		wantCalls   []string
+		wantStepErr string
+		wantErr     error
+	}{
+		{
+			name:      "runs every step once its dependencies finished",
+			wantCalls: []string{"reserve", "charge", "notify", "ship"},
+		},
+		{
+			name:      "a slow branch does not hold up an independent one",
+			delay:     map[string]time.Duration{"charge": 50 * time.Millisecond},
+			wantCalls: []string{"reserve", "charge", "notify", "ship"},
+		},
+		{
+			name:      "completed steps are not run again",
+			completed: []string{"reserve", "charge"},
+			wantCalls: []string{"notify", "ship"},
+		},
+		{
+			name:        "a failed step stops its dependents",
+			fail:        "notify",
+			wantCalls:   []string{"reserve", "charge", "notify"},
+			wantStepErr: "notify refused",
+		},
+		{
+			name:        "a step whose checkpoint fails fails the run",
+			failStore:   "reserve",
+			wantCalls:   []string{"reserve"},
+			wantStepErr: "disk full",
+		},
+		{
+			name:    "a cancelled run dispatches nothing",
+			cancel:  true,
+			wantErr: context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &recordingService{delay: tt.delay, fail: tt.fail}
+			server := httptest.NewServer(service)
+			defer server.Close()
+
+			var opts []Option
+			if tt.failStore != "" {
+				opts = append(opts, WithExecutionStore(&failingStore{failStep: tt.failStore}))
+			}
+			o := New(zerolog.Nop(), opts...)
+			definition := fmt.Sprintf(runStepsWorkflow, server.URL)
+			if _, err := o.LoadWorkflowData([]byte(definition), FormatYAML); err != nil {
+				t.Fatal(err)
+			}
+
+			r, err := o.prepareRun(context.Background(), "orders", map[string]interface{}{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.cancel()
+			r.completed = make(map[string]bool)
+			for _, id := range tt.completed {
+				r.completed[id] = true
+			}
+			if tt.cancel {
+				r.cancel()
+			}
+
+			graph, err := NewValidator().BuildGraph(r.wf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			stepErr, err := o.runSteps(r, graph)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("runSteps() error = %v, want %v", err, tt.wantErr)
+			}
+			switch {
+			case tt.wantStepErr == "" && stepErr != nil:
+				t.Fatalf("runSteps() step error = %v", stepErr)
+			case tt.wantStepErr != "" && (stepErr == nil || !strings.Contains(stepErr.Error(), tt.wantStepErr)):
+				t.Fatalf("runSteps() step error = %v, want %q", stepErr, tt.wantStepErr)
+			}
+
+			events := service.recorded()
+			if ship := slices.Index(events, "start ship"); ship >= 0 && ship < slices.Index(events, "end notify") {
+				t.Errorf("ship started before notify finished: %v", events)
+			}
+			if tt.delay["charge"] > 0 && slices.Index(events, "end notify") > slices.Index(events, "end charge") {
+				t.Errorf("notify waited for the slow charge: %v", events)
+			}
+
+			var calls []string
+			for _, event := range events {
+				if method, ok := strings.CutPrefix(event, "start "); ok {
+					calls = append(calls, method)
+				}
+			}
+			slices.Sort(calls)
+			want := slices.Sorted(slices.Values(tt.wantCalls))
+			if !slices.Equal(calls, want) {
+				t.Errorf("calls = %v, want %v", calls, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,363 @@
+package application
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/metrics"
+	"github.com/robfig/cron/v3"
+	"github.com/rs/zerolog"
+)
+
+const maxQueuedRuns = 10
+
+var (
+	scheduleNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("maestro/schedules"))
+
+	scheduledRunsMetric = &workflow.MetricConfig{
+		Name: "maestro_scheduled_runs_total",
+		Type: metrics.MetricTypeCounter,
+		Help: "Scheduled workflow runs by schedule and outcome",
+	}
+)
+
+type scheduler struct {
+	mu      sync.Mutex
+	ctx     context.Context
+	runners map[string]*scheduleRunner
+}
+
+type scheduleRunner struct {
+	schedule workflow.Schedule
+	cron     cron.Schedule
+	location *time.Location
+	logger   zerolog.Logger
+	stop     context.CancelFunc
+
+	mu     sync.Mutex
+	status workflow.ScheduleStatus
+}
+
+func newScheduler() *scheduler {
+	return &scheduler{runners: make(map[string]*scheduleRunner)}
+}
+
+func (o *Orchestrator) SetSchedules(schedules []workflow.Schedule) error {
+	runners := make(map[string]*scheduleRunner, len(schedules))
+	for _, schedule := range schedules {
+		if _, exists := runners[schedule.Name]; exists {
+			return fmt.Errorf("schedule %s is defined twice", schedule.Name)
+		}
+		runner, err := o.compileSchedule(schedule)
+		if err != nil {
+			return fmt.Errorf("schedule %s: %w", schedule.Name, err)
+		}
+		runners[schedule.Name] = runner
+	}
+
+	s := o.schedules
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name, current := range s.runners {
+		if next, ok := runners[name]; ok && reflect.DeepEqual(next.schedule, current.schedule) {
+			runners[name] = current
+			continue
+		}
+		if current.stop != nil {
+			current.stop()
+		}
+	}
+	s.runners = runners
+
+	if s.ctx != nil {
+		for _, runner := range runners {
+			if runner.stop == nil {
+				o.startSchedule(s.ctx, runner)
+			}
+		}
+	}
+	return nil
+}
+
+func (o *Orchestrator) RunSchedules(ctx context.Context) {
+	s := o.schedules
+	s.mu.Lock()
+	s.ctx = ctx
+	for _, runner := range s.runners {
+		o.startSchedule(ctx, runner)
+	}
+	s.mu.Unlock()
+
+	<-ctx.Done()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ctx = nil
+	for _, runner := range s.runners {
+		runner.stop = nil
+	}
+}
+
+func (o *Orchestrator) Schedules() []workflow.ScheduleStatus {
+	s := o.schedules
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]workflow.ScheduleStatus, 0, len(s.runners))
+	for _, runner := range s.runners {
+		runner.mu.Lock()
+		statuses = append(statuses, runner.status)
+		runner.mu.Unlock()
+	}
+	slices.SortFunc(statuses, func(a, b workflow.ScheduleStatus) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return statuses
+}
+
+func (o *Orchestrator) compileSchedule(schedule workflow.Schedule) (*scheduleRunner, error) {
+	if schedule.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+
+	wf, ok := o.GetWorkflow(schedule.Workflow)
+	if !ok {
+		return nil, fmt.Errorf("workflow %s is not loaded", schedule.Workflow)
+	}
+	if _, ok := wf.Environments[schedule.Environment]; schedule.Environment != "" && !ok {
+		return nil, fmt.Errorf("workflow %s has no environment %s", schedule.Workflow, schedule.Environment)
+	}
+
+	spec, err := cron.ParseStandard(schedule.Cron)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", schedule.Cron, err)
+	}
+
+	location := time.Local
+	if schedule.Timezone != "" {
+		location, err = time.LoadLocation(schedule.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", schedule.Timezone, err)
+		}
+	}
+
+	switch schedule.OverlapPolicy() {
+	case workflow.OverlapSkip, workflow.OverlapQueue, workflow.OverlapReplace:
+	default:
+		return nil, fmt.Errorf("unknown overlap policy %q", schedule.Overlap)
+	}
+
+	return &scheduleRunner{
+		schedule: schedule,
+		cron:     spec,
+		location: location,
+		logger: o.logger.With().
+			Str("schedule", schedule.Name).
+			Str("workflow_name", schedule.Workflow).
+			Logger(),
+		status: workflow.ScheduleStatus{
+			Schedule: schedule,
+			NextRun:  spec.Next(time.Now().In(location)),
+		},
+	}, nil
+}
+
+func (o *Orchestrator) startSchedule(ctx context.Context, runner *scheduleRunner) {
+	ctx, runner.stop = context.WithCancel(ctx)
+	go o.runSchedule(ctx, runner)
+}
+
+func (o *Orchestrator) runSchedule(ctx context.Context, runner *scheduleRunner) {
+	var (
+		done    <-chan struct{}
+		current string
+		queued  []time.Time
+		held    *time.Time
+		retryAt time.Time
+	)
+
+	fire := func(at time.Time) {
+		var deferred *workflow.CalendarError
+		done, current, deferred = o.fireSchedule(ctx, runner, at)
+		held = nil
+		if deferred != nil {
+			held, retryAt = &at, deferred.RetryAt
+		}
+	}
+
+	runner.logger.Info().
+		Str("cron", runner.schedule.Cron).
+		Str("overlap", runner.schedule.OverlapPolicy()).
+		Msg("Schedule started")
+
+	for {
+		next := runner.cron.Next(time.Now().In(runner.location))
+		runner.update(func(status *workflow.ScheduleStatus) {
+			status.NextRun = next
+			status.Running = done != nil
+			status.Queued = len(queued)
+			status.DeferredUntil = nil
+			if held != nil {
+				status.DeferredUntil = &retryAt
+			}
+		})
+
+		var wake <-chan time.Time
+		if held != nil {
+			wake = time.After(time.Until(retryAt))
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+
+		case <-wake:
+			timer.Stop()
+			fire(*held)
+
+		case <-done:
+			timer.Stop()
+			done, current = nil, ""
+			if len(queued) > 0 {
+				at := queued[0]
+				queued = queued[1:]
+				fire(at)
+			}
+
+		case <-timer.C:
+			if done == nil && held == nil {
+				fire(next)
+				continue
+			}
+
+			switch runner.schedule.OverlapPolicy() {
+			case workflow.OverlapQueue:
+				if len(queued) < maxQueuedRuns {
+					queued = append(queued, next)
+					runner.logger.Info().
+						Str("workflow_id", current).
+						Int("queued", len(queued)).
+						Msg("Previous scheduled run still in progress, run queued")
+					continue
+				}
+				o.recordScheduledRun(runner, "skipped")
+				runner.logger.Warn().
+					Str("workflow_id", current).
+					Msg("Scheduled run queue is full, run skipped")
+
+			case workflow.OverlapReplace:
+				o.recordScheduledRun(runner, "replaced")
+				if done == nil {
+					runner.logger.Warn().
+						Time("scheduled_at", *held).
+						Msg("Deferred scheduled run replaced by a newer one")
+					fire(next)
+					continue
+				}
+
+				runner.logger.Warn().
+					Str("workflow_id", current).
+					Msg("Previous scheduled run still in progress, cancelling it")
+				_ = o.CancelWorkflow(current)
+				select {
+				case <-done:
+				case <-ctx.Done():
+					return
+				}
+				fire(next)
+
+			default:
+				o.recordScheduledRun(runner, "skipped")
+				runner.logger.Warn().
+					Str("workflow_id", current).
+					Msg("Previous scheduled run still in progress, run skipped")
+			}
+		}
+	}
+}
+
+func (o *Orchestrator) fireSchedule(ctx context.Context, runner *scheduleRunner, at time.Time) (<-chan struct{}, string, *workflow.CalendarError) {
+	schedule := runner.schedule
+	workflowID := uuid.NewSHA1(scheduleNamespace, []byte(schedule.Name+"@"+at.UTC().Format(time.RFC3339))).String()
+
+	runCtx := WithWorkflowID(context.WithoutCancel(ctx), workflowID)
+	if schedule.Environment != "" {
+		runCtx = WithEnvironment(runCtx, schedule.Environment)
+	}
+
+	input := maps.Clone(schedule.Input)
+	if input == nil {
+		input = make(map[string]interface{})
+	}
+
+	r, err := o.prepareRun(runCtx, schedule.Workflow, input)
+	if errors.Is(err, workflow.ErrFenced) {
+		runner.logger.Debug().
+			Str("workflow_id", workflowID).
+			Msg("Scheduled run already started by another node")
+		return nil, "", nil
+	}
+	var deferred *workflow.CalendarError
+	if errors.As(err, &deferred) {
+		o.recordScheduledRun(runner, "deferred")
+		runner.logger.Info().
+			Str("workflow_id", workflowID).
+			Time("scheduled_at", at).
+			Time("retry_at", deferred.RetryAt).
+			Msg("Scheduled run deferred by the workflow calendar")
+		return nil, "", deferred
+	}
+	if err != nil {
+		o.recordScheduledRun(runner, "failed")
+		runner.logger.Error().
+			Err(err).
+			Str("workflow_id", workflowID).
+			Time("scheduled_at", at).
+			Msg("Failed to start scheduled run")
+		return nil, "", nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = o.execute(r)
+	}()
+
+	o.recordScheduledRun(runner, "started")
+	runner.update(func(status *workflow.ScheduleStatus) {
+		status.LastRun = &at
+		status.LastWorkflowID = workflowID
+		status.Running = true
+	})
+	runner.logger.Info().
+		Str("workflow_id", workflowID).
+		Time("scheduled_at", at).
+		Msg("Scheduled run started")
+
+	return done, workflowID, nil
+}
+
+func (o *Orchestrator) recordScheduledRun(runner *scheduleRunner, outcome string) {
+	labels := map[string]string{"schedule": runner.schedule.Name, "outcome": outcome}
+	if err := o.metrics.Record(scheduledRunsMetric, 1, labels); err != nil {
+		runner.logger.Warn().Err(err).Msg("Failed to record scheduled run")
+	}
+}
+
+func (r *scheduleRunner) update(apply func(*workflow.ScheduleStatus)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	apply(&r.status)
+}
@@ -0,0 +1,171 @@
+package application
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/grpc"
+)
+
+const WorkflowSchemaID = "https://maestro.go/schemas/workflow.json"
+
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	If                   *Schema            `json:"if,omitempty"`
+	Then                 *Schema            `json:"then,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+var (
+	durationType = reflect.TypeOf(domain.Duration{})
+	importsType  = reflect.TypeOf(domain.Imports{})
+	serviceType  = reflect.TypeOf(domain.Service{})
+
+	schemaRequired = map[reflect.Type][]string{
+		reflect.TypeOf(domain.Workflow{}):         {"name", "version", "steps"},
+		reflect.TypeOf(domain.Service{}):          {"type"},
+		reflect.TypeOf(domain.CompensateConfig{}): {"method"},
+		reflect.TypeOf(domain.MetricConfig{}):     {"name", "type"},
+		reflect.TypeOf(domain.TraceEvent{}):       {"name"},
+		reflect.TypeOf(domain.LockConfig{}):       {"key"},
+		reflect.TypeOf(domain.KVConfig{}):         {"op", "namespace", "key"},
+		reflect.TypeOf(domain.TransitionConfig{}): {"entity", "id", "to"},
+		reflect.TypeOf(domain.StateMachine{}):     {"transitions"},
+		reflect.TypeOf(domain.CalendarWindow{}):   {"from", "to"},
+		reflect.TypeOf(domain.WaitConfig{}):       {"signal"},
+		reflect.TypeOf(domain.ForeachConfig{}):    {"items", "steps"},
+		reflect.TypeOf(domain.AssertConfig{}):     {"condition"},
+		reflect.TypeOf(domain.FailoverPolicy{}):   {"regions"},
+		reflect.TypeOf(domain.FailoverRegion{}):   {"name", "endpoint"},
+	}
+
+	schemaEnums = map[reflect.Type]map[string][]string{
+		reflect.TypeOf(domain.Service{}):            {"protocol": {"maestro", "grpc-reflection"}},
+		reflect.TypeOf(domain.ExecConfig{}):         {"input": {"stdin", "env"}},
+		reflect.TypeOf(domain.MetricConfig{}):       {"type": {"counter", "gauge", "histogram"}},
+		reflect.TypeOf(domain.KVConfig{}):           {"op": {"get", "set", "delete", "incr"}},
+		reflect.TypeOf(domain.WaitConfig{}):         {"on_expire": {"fail", "skip", "default", "compensate"}},
+		reflect.TypeOf(domain.RetryConfig{}):        {"backoff": {"constant", "exponential"}},
+		reflect.TypeOf(domain.ResourceHints{}):      {"latency": {"fast", "normal", "slow"}},
+		reflect.TypeOf(domain.Step{}):               {"on_error": {"fail", "continue", "fallback", "hold"}},
+		reflect.TypeOf(domain.CompensationPolicy{}): {"on_failure": {"continue", "stop"}},
+	}
+)
+
+func WorkflowSchema(serviceTypes []string) *Schema {
+	g := &schemaGenerator{defs: make(map[string]*Schema), serviceTypes: serviceTypes}
+	root := g.structSchema(reflect.TypeOf(domain.Workflow{}))
+	root.Schema = "https://json-schema.org/draft/2020-12/schema"
+	root.ID = WorkflowSchemaID
+	root.Title = "Maestro workflow"
+	root.Defs = g.defs
+	return root
+}
+
+func WorkflowSchemaJSON(serviceTypes []string) ([]byte, error) {
+	return json.MarshalIndent(WorkflowSchema(serviceTypes), "", "  ")
+}
+
+func (o *Orchestrator) WorkflowSchemaJSON() ([]byte, error) {
+	return WorkflowSchemaJSON(o.registry.ServiceTypes())
+}
+
+func (o *Orchestrator) ValidateSchema(data []byte) ([]SchemaError, error) {
+	return ValidateSchema(data, o.registry.ServiceTypes())
+}
+
+type schemaGenerator struct {
+	defs         map[string]*Schema
+	serviceTypes []string
+}
+
+func endpointTypes(serviceTypes []string) []string {
+	return slices.DeleteFunc(slices.Clone(serviceTypes), func(kind string) bool {
+		return !grpc.RequiresEndpoint(kind)
+	})
+}
+
+func (g *schemaGenerator) typeSchema(t reflect.Type) *Schema {
+	if t == durationType {
+		return &Schema{Type: "string", Pattern: `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`}
+	}
+	if t == importsType {
+		return &Schema{OneOf: []*Schema{{Type: "string"}, {Type: "array", Items: &Schema{Type: "string"}}}}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.typeSchema(t.Elem())
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		if _, ok := g.defs[t.Name()]; !ok {
+			g.defs[t.Name()] = nil
+			g.defs[t.Name()] = g.structSchema(t)
+		}
+		return &Schema{Ref: "#/$defs/" + t.Name()}
+	default:
+		return &Schema{}
+	}
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{
+		Type:                 "object",
+		Properties:           make(map[string]*Schema),
+		Required:             schemaRequired[t],
+		AdditionalProperties: false,
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("yaml")
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		prop := g.typeSchema(field.Type)
+		if enum, ok := schemaEnums[t][name]; ok {
+			prop.Enum = enum
+		}
+		s.Properties[name] = prop
+	}
+
+	if t == serviceType {
+		s.Properties["type"].Enum = g.serviceTypes
+		s.If = &Schema{
+			Type:       "object",
+			Properties: map[string]*Schema{"type": {Enum: endpointTypes(g.serviceTypes)}},
+			Required:   []string{"type"},
+		}
+		s.Then = &Schema{Type: "object", Required: []string{"endpoint"}}
+	}
+
+	return s
+}
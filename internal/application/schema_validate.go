@@ -0,0 +1,222 @@
+package application
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type SchemaError struct {
+	Line    int
+	Column  int
+	Path    string
+	Message string
+}
+
+func (e SchemaError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("line %d, column %d: %s: %s", e.Line, e.Column, e.Path, e.Message)
+}
+
+func ValidateSchema(data []byte, serviceTypes []string) ([]SchemaError, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow document: %w", err)
+	}
+
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return []SchemaError{{Line: 1, Column: 1, Message: "document is empty"}}, nil
+	}
+
+	root := WorkflowSchema(serviceTypes)
+	v := &schemaValidator{defs: root.Defs}
+	v.validate(doc.Content[0], root, "")
+
+	return v.errors, nil
+}
+
+type schemaValidator struct {
+	defs   map[string]*Schema
+	errors []SchemaError
+}
+
+func (v *schemaValidator) fail(node *yaml.Node, path, format string, args ...any) {
+	v.errors = append(v.errors, SchemaError{
+		Line:    node.Line,
+		Column:  node.Column,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *schemaValidator) validate(node *yaml.Node, schema *Schema, path string) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	if schema.Ref != "" {
+		def, ok := v.defs[strings.TrimPrefix(schema.Ref, "#/$defs/")]
+		if !ok {
+			v.fail(node, path, "unresolvable schema reference %s", schema.Ref)
+			return
+		}
+		schema = def
+	}
+
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+
+	if len(schema.OneOf) > 0 {
+		v.validateOneOf(node, schema.OneOf, path)
+		return
+	}
+
+	switch schema.Type {
+	case "":
+		if node.Kind == yaml.ScalarNode {
+			v.validateScalar(node, schema, path)
+		}
+	case "object":
+		v.validateObject(node, schema, path)
+	case "array":
+		if node.Kind != yaml.SequenceNode {
+			v.fail(node, path, "expected a list, got %s", describeNode(node))
+			return
+		}
+		for i, item := range node.Content {
+			v.validate(item, schema.Items, fmt.Sprintf("%s[%d]", path, i))
+		}
+	case "string":
+		if node.Kind != yaml.ScalarNode {
+			v.fail(node, path, "expected a string, got %s", describeNode(node))
+			return
+		}
+		v.validateScalar(node, schema, path)
+	case "integer":
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!int" {
+			v.fail(node, path, "expected an integer, got %s", describeNode(node))
+		}
+	case "number":
+		if node.Kind != yaml.ScalarNode || (node.Tag != "!!int" && node.Tag != "!!float") {
+			v.fail(node, path, "expected a number, got %s", describeNode(node))
+		}
+	case "boolean":
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			v.fail(node, path, "expected a boolean, got %s", describeNode(node))
+		}
+	}
+}
+
+func (v *schemaValidator) validateObject(node *yaml.Node, schema *Schema, path string) {
+	if node.Kind != yaml.MappingNode {
+		v.fail(node, path, "expected a mapping, got %s", describeNode(node))
+		return
+	}
+
+	seen := make(map[string]bool, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		seen[key.Value] = true
+		childPath := joinPath(path, key.Value)
+
+		if prop, ok := schema.Properties[key.Value]; ok {
+			v.validate(value, prop, childPath)
+			continue
+		}
+
+		switch extra := schema.AdditionalProperties.(type) {
+		case bool:
+			if !extra {
+				v.fail(key, path, "unknown field %q", key.Value)
+			}
+		case *Schema:
+			v.validate(value, extra, childPath)
+		}
+	}
+
+	for _, name := range schema.Required {
+		if !seen[name] {
+			v.fail(node, path, "missing required field %q", name)
+		}
+	}
+
+	if schema.If != nil && schema.Then != nil && v.matches(node, schema.If, path) {
+		v.validate(node, schema.Then, path)
+	}
+}
+
+func (v *schemaValidator) matches(node *yaml.Node, schema *Schema, path string) bool {
+	probe := &schemaValidator{defs: v.defs}
+	probe.validate(node, schema, path)
+	return len(probe.errors) == 0
+}
+
+func (v *schemaValidator) validateOneOf(node *yaml.Node, schemas []*Schema, path string) {
+	var kinds []string
+	for _, schema := range schemas {
+		if v.matches(node, schema, path) {
+			return
+		}
+		kinds = append(kinds, describeType(schema))
+	}
+	v.fail(node, path, "expected %s, got %s", strings.Join(kinds, " or "), describeNode(node))
+}
+
+func describeType(schema *Schema) string {
+	switch schema.Type {
+	case "array":
+		return "a list"
+	case "object":
+		return "a mapping"
+	case "integer":
+		return "an integer"
+	default:
+		return "a " + schema.Type
+	}
+}
+
+func (v *schemaValidator) validateScalar(node *yaml.Node, schema *Schema, path string) {
+	if len(schema.Enum) > 0 {
+		valid := false
+		for _, allowed := range schema.Enum {
+			if node.Value == allowed {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			v.fail(node, path, "invalid value %q (must be one of %s)", node.Value, strings.Join(schema.Enum, ", "))
+		}
+	}
+
+	if schema.Pattern != "" {
+		if re, err := regexp.Compile(schema.Pattern); err == nil && !re.MatchString(node.Value) {
+			v.fail(node, path, "invalid value %q (must match %s)", node.Value, schema.Pattern)
+		}
+	}
+}
+
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	case yaml.ScalarNode:
+		return fmt.Sprintf("%q", node.Value)
+	default:
+		return "an unexpected node"
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,86 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	workflow "github.com/maestro/maestro.go/internal/domain"
+)
+
+func (o *Orchestrator) ExportExecution(workflowID string) (*workflow.ExecutionSnapshot, error) {
+	execution, ok, err := o.GetExecution(workflowID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", workflow.ErrExecutionNotFound, workflowID)
+	}
+
+	return workflow.NewExecutionSnapshot(execution), nil
+}
+
+func (o *Orchestrator) ImportExecution(ctx context.Context, snapshot *workflow.ExecutionSnapshot) error {
+	execution, err := snapshot.Execution()
+	if err != nil {
+		return err
+	}
+	if wf, ok := o.GetWorkflow(snapshot.WorkflowName); ok {
+		if err := workflow.CheckNamespace(snapshot.Namespace, wf.Namespace); err != nil {
+			return fmt.Errorf("cannot import execution %s: %w", snapshot.WorkflowID, err)
+		}
+	}
+
+	switch execution.Result.Status {
+	case workflow.WorkflowStatusSuspended, workflow.WorkflowStatusRunning:
+		return o.ResumeExecution(ctx, snapshot)
+	}
+	if !execution.Result.Status.IsTerminal() {
+		return fmt.Errorf("execution %s is %s, only finished, running or suspended executions can be imported", snapshot.WorkflowID, execution.Result.Status)
+	}
+
+	if _, loaded := o.executions.LoadOrStore(snapshot.WorkflowID, execution); loaded {
+		return fmt.Errorf("execution %s already exists", snapshot.WorkflowID)
+	}
+	if err := o.checkpoint(ctx, execution); err != nil {
+		o.executions.Delete(snapshot.WorkflowID)
+		return err
+	}
+	o.forgetAfterRetention(snapshot.WorkflowID, execution)
+
+	o.logger.Info().
+		Str("workflow_id", snapshot.WorkflowID).
+		Str("workflow_name", snapshot.WorkflowName).
+		Str("status", snapshot.Status).
+		Msg("Execution imported from snapshot")
+
+	return nil
+}
+
+func WriteSnapshot(filename string, snapshot *workflow.ExecutionSnapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	if err := os.WriteFile(filename, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+
+	return nil
+}
+
+func ReadSnapshot(filename string) (*workflow.ExecutionSnapshot, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot file: %w", err)
+	}
+
+	var snapshot workflow.ExecutionSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
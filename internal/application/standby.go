@@ -0,0 +1,259 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/metrics"
+	"github.com/maestro/maestro.go/internal/ports"
+)
+
+const (
+	primaryLeaseTTL     = 6 * time.Second
+	standbySyncInterval = time.Second
+	standbyTailInterval = 5 * time.Second
+)
+
+var takenOverExecutionsMetric = &workflow.MetricConfig{
+	Name: "maestro_taken_over_executions_total",
+	Type: metrics.MetricTypeCounter,
+	Help: "Executions of a lost primary resumed from their last checkpoint by this node",
+}
+
+type standby struct {
+	mu     sync.Mutex
+	status workflow.StandbyStatus
+}
+
+func (o *Orchestrator) StandbyStatus() (*workflow.StandbyStatus, bool) {
+	if o.standby == nil {
+		return nil, false
+	}
+
+	o.standby.mu.Lock()
+	defer o.standby.mu.Unlock()
+
+	status := o.standby.status
+	status.Node = o.nodeID
+	status.Role = workflow.RoleStandby
+	if !o.passive.Load() {
+		status.Role = workflow.RolePrimary
+	}
+	return &status, true
+}
+
+func (o *Orchestrator) RunStandby(ctx context.Context, activate func(context.Context)) {
+	leaser, ok := o.store.(ports.PrimaryLeaser)
+	if !ok || o.standby == nil {
+		if o.standby != nil {
+			o.logger.Error().Msg("Standby mode needs a PostgreSQL execution store, running as primary")
+		}
+		o.passive.Store(false)
+		activate(ctx)
+		return
+	}
+
+	ticker := time.NewTicker(standbySyncInterval)
+	defer ticker.Stop()
+
+	var (
+		token   int64
+		renewed time.Time
+		stop    context.CancelFunc = func() {}
+	)
+	defer func() {
+		stop()
+		if token != 0 {
+			if err := leaser.ReleasePrimary(context.WithoutCancel(ctx), o.nodeID, token); err != nil {
+				o.logger.Warn().Err(err).Msg("Failed to release primary lease")
+			}
+		}
+	}()
+
+	o.logger.Info().Str("node_id", o.nodeID).Msg("Starting as standby, waiting for the primary lease")
+
+	for {
+		switch {
+		case token == 0:
+			if token = o.tryPromote(ctx, leaser); token != 0 {
+				renewed = time.Now()
+				stop = startActive(ctx, activate)
+			}
+		default:
+			err := leaser.RenewPrimary(ctx, o.nodeID, token, primaryLeaseTTL)
+			if err == nil {
+				renewed = time.Now()
+				o.standby.mu.Lock()
+				o.standby.status.Primary.ExpiresAt = renewed.Add(primaryLeaseTTL)
+				o.standby.mu.Unlock()
+				break
+			}
+			if ctx.Err() != nil {
+				break
+			}
+			if !errors.Is(err, workflow.ErrFenced) && time.Since(renewed) < primaryLeaseTTL {
+				o.logger.Warn().Err(err).Msg("Failed to renew primary lease")
+				break
+			}
+
+			o.passive.Store(true)
+			stop()
+			token = 0
+			o.logger.Error().Err(err).Msg("Lost primary lease, stepping down to standby")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func startActive(ctx context.Context, activate func(context.Context)) context.CancelFunc {
+	active, cancel := context.WithCancel(ctx)
+	activate(active)
+	return cancel
+}
+
+func (o *Orchestrator) tryPromote(ctx context.Context, leaser ports.PrimaryLeaser) int64 {
+	primary, held, err := leaser.CurrentPrimary(ctx)
+	if err != nil {
+		o.logger.Warn().Err(err).Msg("Failed to read primary lease")
+		return 0
+	}
+
+	o.standby.mu.Lock()
+	previous := o.standby.status.Primary
+	o.standby.mu.Unlock()
+
+	if held && primary.Owner != o.nodeID {
+		o.tailPrimary(ctx, primary)
+		return 0
+	}
+
+	token, err := leaser.ClaimPrimary(ctx, o.nodeID, primaryLeaseTTL)
+	if errors.Is(err, workflow.ErrFenced) {
+		return 0
+	}
+	if err != nil {
+		o.logger.Warn().Err(err).Msg("Failed to claim primary lease")
+		return 0
+	}
+
+	var from string
+	if previous != nil && previous.Owner != o.nodeID {
+		from = previous.Owner
+	}
+
+	now := time.Now()
+	o.standby.mu.Lock()
+	o.standby.status.Primary = &workflow.PrimaryLease{Owner: o.nodeID, Token: token, ExpiresAt: now.Add(primaryLeaseTTL)}
+	o.standby.status.PromotedAt = &now
+	o.standby.mu.Unlock()
+	o.passive.Store(false)
+
+	o.logger.Warn().
+		Str("previous_primary", from).
+		Int64("fencing_token", token).
+		Msg("Acquired primary lease, taking over")
+
+	o.takeOver(ctx, from)
+	return token
+}
+
+func (o *Orchestrator) tailPrimary(ctx context.Context, primary *workflow.PrimaryLease) {
+	o.standby.mu.Lock()
+	changed := o.standby.status.Primary == nil || o.standby.status.Primary.Owner != primary.Owner
+	o.standby.status.Primary = primary
+	due := time.Since(o.standby.status.SyncedAt) >= standbyTailInterval
+	o.standby.mu.Unlock()
+
+	if changed {
+		o.logger.Info().Str("primary", primary.Owner).Msg("Following primary")
+	}
+	if !due && !changed {
+		return
+	}
+
+	running, err := o.store.ListExecutions(ctx, workflow.ExecutionFilter{
+		Status: workflow.WorkflowStatusRunning.String(),
+	})
+	if err != nil {
+		o.logger.Warn().Err(err).Msg("Failed to list in-flight executions of the primary")
+		return
+	}
+
+	o.standby.mu.Lock()
+	o.standby.status.InFlight = len(running)
+	o.standby.status.SyncedAt = time.Now()
+	o.standby.mu.Unlock()
+}
+
+func (o *Orchestrator) takeOver(ctx context.Context, from string) {
+	executions, err := o.store.ListExecutions(ctx, workflow.ExecutionFilter{
+		Status: workflow.WorkflowStatusRunning.String(),
+	})
+	if err != nil {
+		o.logger.Error().
+			Err(err).
+			Msg("Failed to list in-flight executions to take over")
+		return
+	}
+
+	for _, execution := range executions {
+		if ctx.Err() != nil {
+			return
+		}
+		if execution.Context.ParentID != "" {
+			continue
+		}
+
+		r, err := o.continueRun(context.WithoutCancel(ctx), execution, func(ctx context.Context, workflowID string) (context.Context, *lease, error) {
+			return o.seizeExecution(ctx, workflowID, from)
+		})
+		if errors.Is(err, workflow.ErrFenced) {
+			continue
+		}
+		if err != nil {
+			o.logger.Error().
+				Err(err).
+				Str("workflow_id", execution.Context.WorkflowID).
+				Msg("Failed to take over execution")
+			continue
+		}
+
+		r.logger.Info().
+			Str("previous_primary", from).
+			Int("completed_steps", len(r.completed)).
+			Msg("Taking over execution from its last checkpoint")
+
+		if err := o.metrics.Record(takenOverExecutionsMetric, 1, nil); err != nil {
+			o.logger.Warn().Err(err).Msg("Failed to record taken over execution")
+		}
+
+		go func() {
+			_, _ = o.execute(r)
+		}()
+	}
+}
+
+func (o *Orchestrator) seizeExecution(ctx context.Context, workflowID, from string) (context.Context, *lease, error) {
+	leaser := o.store.(ports.PrimaryLeaser)
+
+	token, err := leaser.SeizeExecution(ctx, workflowID, o.nodeID, from, executionLeaseTTL)
+	if err != nil {
+		return ctx, nil, fmt.Errorf("failed to take over execution %s: %w", workflowID, err)
+	}
+
+	return context.WithValue(ctx, ctxkeys.FencingToken, token), &lease{
+		leaser: leaser,
+		owner:  o.nodeID,
+		token:  token,
+	}, nil
+}
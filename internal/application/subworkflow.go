@@ -0,0 +1,86 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+)
+
+type callStackKey struct{}
+
+func withCallStack(ctx context.Context, workflowName string) context.Context {
+	stack, _ := ctx.Value(callStackKey{}).([]string)
+	return context.WithValue(ctx, callStackKey{}, append(slices.Clip(stack), workflowName))
+}
+
+func (o *Orchestrator) RunWorkflow(
+	ctx context.Context,
+	name string,
+	input map[string]interface{},
+) (string, map[string]interface{}, error) {
+	stack, _ := ctx.Value(callStackKey{}).([]string)
+	if slices.Contains(stack, name) {
+		return "", nil, fmt.Errorf("sub-workflow cycle: %s -> %s", strings.Join(stack, " -> "), name)
+	}
+
+	if env, _ := ctx.Value(ctxkeys.Environment).(string); env != "" {
+		if wf, ok := o.GetWorkflow(name); ok {
+			if _, defined := wf.Environments[env]; !defined {
+				ctx = WithEnvironment(ctx, "")
+			}
+		}
+	}
+
+	result, err := o.ExecuteWorkflow(ctx, name, input)
+	if result == nil {
+		return "", nil, err
+	}
+	return result.WorkflowID, result.Output, err
+}
+
+func (o *Orchestrator) CompensateWorkflow(ctx context.Context, workflowID string) error {
+	execution, ok, err := o.execution(workflowID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("execution %s not found", workflowID)
+	}
+
+	wf, ok := o.GetWorkflow(execution.WorkflowName)
+	if !ok {
+		return fmt.Errorf("workflow %s not found", execution.WorkflowName)
+	}
+	if execution.Context.Environment != "" {
+		scoped, err := wf.ForEnvironment(execution.Context.Environment)
+		if err != nil {
+			return err
+		}
+		wf = scoped
+	}
+
+	if err := o.sagaCoordinator.Compensate(ctx, execution.Context, wf); err != nil {
+		var unfinished *workflow.CompensationError
+		if errors.As(err, &unfinished) {
+			execution.Result.SetUnfinishedCompensations(unfinished.Unfinished)
+			if checkpointErr := o.checkpoint(ctx, execution); checkpointErr != nil {
+				err = errors.Join(err, checkpointErr)
+			}
+		}
+		o.sagaCoordinator.SaveState(ctx, execution.Context, workflow.SagaStatusFailed)
+		return err
+	}
+
+	execution.Result.SetUnfinishedCompensations(nil)
+	execution.Result.Complete(workflow.WorkflowStatusCompensated, execution.Result.Error)
+	if err := o.checkpoint(ctx, execution); err != nil {
+		return err
+	}
+	o.sagaCoordinator.SaveState(ctx, execution.Context, workflow.SagaStatusCompensated)
+	return nil
+}
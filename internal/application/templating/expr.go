@@ -0,0 +1,84 @@
+package templating
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/maestro/maestro.go/internal/application/expression"
+)
+
+type exprEngine struct{}
+
+func (exprEngine) Check(tmpl string) error {
+	_, err := exprSegments(tmpl, func(expr string) (string, error) {
+		return "", expression.Check(expr)
+	})
+	return err
+}
+
+func (exprEngine) Render(tmpl string, data map[string]any, _ Funcs) (string, error) {
+	return exprSegments(tmpl, func(expr string) (string, error) {
+		value, err := expression.EvaluateJSON(expr, data)
+		if err != nil {
+			return "", err
+		}
+		switch v := value.(type) {
+		case nil:
+			return "", nil
+		case string:
+			return v, nil
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode %q: %w", expr, err)
+		}
+		return string(encoded), nil
+	})
+}
+
+func (exprEngine) Roots(tmpl string) ([]string, bool) {
+	var roots []string
+	_, err := exprSegments(tmpl, func(expr string) (string, error) {
+		refs, err := expression.Roots(expr)
+		roots = append(roots, refs...)
+		return "", err
+	})
+	return roots, err == nil
+}
+
+func (exprEngine) Value(tmpl string, data map[string]any) (any, bool) {
+	inner := strings.TrimSpace(tmpl)
+	if !strings.HasPrefix(inner, "{{") || !strings.HasSuffix(inner, "}}") || strings.Count(inner, "{{") != 1 {
+		return nil, false
+	}
+	value, err := expression.EvaluateJSON(strings.TrimSpace(inner[2:len(inner)-2]), data)
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+func exprSegments(tmpl string, each func(expr string) (string, error)) (string, error) {
+	var out strings.Builder
+	rest := tmpl
+	for {
+		start := strings.Index(rest, "{{")
+		if start < 0 {
+			out.WriteString(rest)
+			return out.String(), nil
+		}
+		end := strings.Index(rest[start:], "}}")
+		if end < 0 {
+			return "", fmt.Errorf("unclosed {{ in template %q", tmpl)
+		}
+		out.WriteString(rest[:start])
+
+		rendered, err := each(strings.TrimSpace(rest[start+2 : start+end]))
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(rendered)
+		rest = rest[start+end+2:]
+	}
+}
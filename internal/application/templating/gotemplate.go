@@ -0,0 +1,151 @@
+package templating
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/maestro/maestro.go/internal/application/functions"
+)
+
+var stubFuncs = template.FuncMap{
+	"kv":      func(...any) any { return nil },
+	"counter": func(...any) any { return nil },
+	"secrets": func(...any) any { return nil },
+}
+
+type goTemplate struct{}
+
+func (goTemplate) Check(tmpl string) error {
+	if _, err := template.New("check").Funcs(functions.Template).Funcs(stubFuncs).Parse(tmpl); err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+	return nil
+}
+
+func (goTemplate) Render(tmpl string, data map[string]any, funcs Funcs) (string, error) {
+	return renderGoTemplate("executor", tmpl, data, funcs)
+}
+
+func (goTemplate) Roots(tmpl string) ([]string, bool) {
+	return goTemplateRoots(tmpl)
+}
+
+func (goTemplate) Value(tmpl string, data map[string]any) (any, bool) {
+	return goTemplateValue(tmpl, data)
+}
+
+func renderGoTemplate(name, tmpl string, data map[string]any, funcs Funcs) (string, error) {
+	t, err := template.New(name).Funcs(functions.Template).Funcs(template.FuncMap(funcs)).Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+func goTemplateRoots(tmpl string) ([]string, bool) {
+	t, err := template.New("roots").Funcs(functions.Template).Funcs(stubFuncs).Funcs(helperStubs).Parse(tmpl)
+	if err != nil {
+		return nil, false
+	}
+
+	var roots []string
+	ok := walkTemplate(t.Root, &roots)
+	return roots, ok
+}
+
+// IsFunc reports whether name is a function Go templates can call, either
+// a text/template builtin or one the executor provides.
+func IsFunc(name string) bool {
+	_, err := template.New("func").Funcs(functions.Template).Funcs(stubFuncs).Parse("{{ " + name + " }}")
+	return err == nil
+}
+
+func walkTemplate(node parse.Node, roots *[]string) bool {
+	switch n := node.(type) {
+	case nil:
+		return true
+	case *parse.ListNode:
+		if n == nil {
+			return true
+		}
+		for _, child := range n.Nodes {
+			if !walkTemplate(child, roots) {
+				return false
+			}
+		}
+		return true
+	case *parse.ActionNode:
+		return walkTemplate(n.Pipe, roots)
+	case *parse.PipeNode:
+		if n == nil {
+			return true
+		}
+		for _, cmd := range n.Cmds {
+			if !walkTemplate(cmd, roots) {
+				return false
+			}
+		}
+		return true
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if !walkTemplate(arg, roots) {
+				return false
+			}
+		}
+		return true
+	case *parse.IfNode:
+		return walkTemplate(n.Pipe, roots) && walkTemplate(n.List, roots) && walkTemplate(n.ElseList, roots)
+	case *parse.FieldNode:
+		*roots = append(*roots, n.Ident[0])
+		return true
+	case *parse.ChainNode:
+		return walkTemplate(n.Node, roots)
+	case *parse.IdentifierNode:
+		_, stateful := stubFuncs[n.Ident]
+		return !stateful
+	case *parse.TextNode, *parse.StringNode, *parse.NumberNode, *parse.BoolNode, *parse.NilNode:
+		return true
+	default:
+		return false
+	}
+}
+
+func goTemplateValue(tmpl string, data map[string]any) (any, bool) {
+	tree, err := parse.Parse("items", tmpl, "{{", "}}")
+	if err != nil {
+		return nil, false
+	}
+
+	root := tree["items"].Root
+	if len(root.Nodes) != 1 {
+		return nil, false
+	}
+	action, ok := root.Nodes[0].(*parse.ActionNode)
+	if !ok || len(action.Pipe.Decl) > 0 || len(action.Pipe.Cmds) != 1 || len(action.Pipe.Cmds[0].Args) != 1 {
+		return nil, false
+	}
+	field, ok := action.Pipe.Cmds[0].Args[0].(*parse.FieldNode)
+	if !ok {
+		return nil, false
+	}
+
+	var value any = data
+	for _, ident := range field.Ident {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[ident]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
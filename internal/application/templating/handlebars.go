@@ -0,0 +1,270 @@
+package templating
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/maestro/maestro.go/internal/application/functions"
+)
+
+var helperStubs = template.FuncMap{
+	"_value": func(...any) any { return nil },
+}
+
+type handlebars struct{}
+
+func (handlebars) Check(tmpl string) error {
+	translated, err := translateHandlebars(tmpl)
+	if err != nil {
+		return err
+	}
+	if _, err := template.New("check").Funcs(functions.Template).Funcs(stubFuncs).Funcs(helperStubs).Parse(translated); err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+	return nil
+}
+
+func (handlebars) Render(tmpl string, data map[string]any, funcs Funcs) (string, error) {
+	translated, err := translateHandlebars(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	all := Funcs{"_value": handlebarsValue}
+	for name, fn := range funcs {
+		all[name] = fn
+	}
+	return renderGoTemplate("handlebars", translated, data, all)
+}
+
+func (handlebars) Roots(tmpl string) ([]string, bool) {
+	translated, err := translateHandlebars(tmpl)
+	if err != nil {
+		return nil, false
+	}
+	return goTemplateRoots(translated)
+}
+
+func (handlebars) Value(tmpl string, data map[string]any) (any, bool) {
+	inner := strings.TrimSpace(tmpl)
+	if !strings.HasPrefix(inner, "{{") || !strings.HasSuffix(inner, "}}") {
+		return nil, false
+	}
+	inner = strings.TrimSpace(inner[2 : len(inner)-2])
+	if inner == "" || strings.ContainsAny(inner, "{} \t#/") {
+		return nil, false
+	}
+
+	path, err := handlebarsArg(inner)
+	if err != nil || !strings.HasPrefix(path, ".") {
+		return nil, false
+	}
+	return goTemplateValue("{{"+path+"}}", data)
+}
+
+func handlebarsValue(value any) any {
+	if value == nil {
+		return ""
+	}
+	return value
+}
+
+func translateHandlebars(tmpl string) (string, error) {
+	var out strings.Builder
+	var blocks []string
+
+	rest := tmpl
+	for {
+		start := strings.Index(rest, "{{")
+		if start < 0 {
+			out.WriteString(rest)
+			break
+		}
+		out.WriteString(rest[:start])
+		rest = rest[start:]
+
+		open, close := "{{", "}}"
+		if strings.HasPrefix(rest, "{{{") {
+			open, close = "{{{", "}}}"
+		}
+		end := strings.Index(rest[len(open):], close)
+		if end < 0 {
+			return "", fmt.Errorf("unclosed %s in template %q", open, tmpl)
+		}
+		body := strings.TrimSpace(rest[len(open) : len(open)+end])
+		rest = rest[len(open)+end+len(close):]
+
+		action, err := translateHandlebarsTag(body, &blocks)
+		if err != nil {
+			return "", fmt.Errorf("template %q: %w", tmpl, err)
+		}
+		out.WriteString(action)
+	}
+
+	if len(blocks) > 0 {
+		return "", fmt.Errorf("template %q: {{#%s}} is never closed", tmpl, blocks[len(blocks)-1])
+	}
+	return out.String(), nil
+}
+
+func translateHandlebarsTag(body string, blocks *[]string) (string, error) {
+	switch {
+	case body == "":
+		return "", fmt.Errorf("empty {{}}")
+	case strings.HasPrefix(body, "!"):
+		return "", nil
+	case body == "else":
+		if len(*blocks) == 0 {
+			return "", fmt.Errorf("{{else}} outside a block")
+		}
+		return "{{else}}", nil
+	case strings.HasPrefix(body, "/"):
+		name := strings.TrimSpace(body[1:])
+		if len(*blocks) == 0 || (*blocks)[len(*blocks)-1] != name {
+			return "", fmt.Errorf("unexpected {{/%s}}", name)
+		}
+		*blocks = (*blocks)[:len(*blocks)-1]
+		return "{{end}}", nil
+	case strings.HasPrefix(body, "#"):
+		fields, err := handlebarsFields(body[1:])
+		if err != nil {
+			return "", err
+		}
+		if len(fields) != 2 {
+			return "", fmt.Errorf("{{#%s}} takes exactly one argument", body[1:])
+		}
+		arg, err := handlebarsArg(fields[1])
+		if err != nil {
+			return "", err
+		}
+
+		*blocks = append(*blocks, fields[0])
+		switch fields[0] {
+		case "if":
+			return "{{if " + arg + "}}", nil
+		case "unless":
+			return "{{if not " + arg + "}}", nil
+		case "each":
+			return "{{range $index, $item := " + arg + "}}", nil
+		case "with":
+			return "{{with " + arg + "}}", nil
+		}
+		return "", fmt.Errorf("unknown block helper #%s (must be 'if', 'unless', 'each' or 'with')", fields[0])
+	}
+
+	fields, err := handlebarsFields(body)
+	if err != nil {
+		return "", err
+	}
+	if len(fields) == 1 {
+		arg, err := handlebarsArg(fields[0])
+		if err != nil {
+			return "", err
+		}
+		return "{{_value " + arg + "}}", nil
+	}
+
+	args := []string{fields[0]}
+	for _, field := range fields[1:] {
+		arg, err := handlebarsArg(field)
+		if err != nil {
+			return "", err
+		}
+		args = append(args, arg)
+	}
+	return "{{" + strings.Join(args, " ") + "}}", nil
+}
+
+func handlebarsFields(body string) ([]string, error) {
+	var fields []string
+	for body = strings.TrimSpace(body); body != ""; body = strings.TrimSpace(body) {
+		if body[0] == '"' || body[0] == '\'' {
+			end := strings.IndexByte(body[1:], body[0])
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string in {{%s}}", body)
+			}
+			fields = append(fields, body[:end+2])
+			body = body[end+2:]
+			continue
+		}
+		if body[0] == '(' {
+			end := closingParen(body)
+			if end < 0 {
+				return nil, fmt.Errorf("unbalanced ( in {{%s}}", body)
+			}
+			fields = append(fields, body[:end+1])
+			body = body[end+1:]
+			continue
+		}
+		end := strings.IndexAny(body, " \t\n")
+		if end < 0 {
+			end = len(body)
+		}
+		fields = append(fields, body[:end])
+		body = body[end:]
+	}
+	return fields, nil
+}
+
+func handlebarsArg(arg string) (string, error) {
+	switch {
+	case arg == "this":
+		return ".", nil
+	case arg == "@index" || arg == "@key":
+		return "$index", nil
+	case arg == "true" || arg == "false":
+		return arg, nil
+	case arg == "null" || arg == "undefined":
+		return "nil", nil
+	case arg[0] == '"' || arg[0] == '\'':
+		return strconv.Quote(arg[1 : len(arg)-1]), nil
+	case arg[0] == '(':
+		fields, err := handlebarsFields(arg[1 : len(arg)-1])
+		if err != nil {
+			return "", err
+		}
+		if len(fields) == 0 {
+			return "", fmt.Errorf("empty subexpression ()")
+		}
+		args := []string{fields[0]}
+		for _, field := range fields[1:] {
+			translated, err := handlebarsArg(field)
+			if err != nil {
+				return "", err
+			}
+			args = append(args, translated)
+		}
+		return "(" + strings.Join(args, " ") + ")", nil
+	case strings.HasPrefix(arg, "../"):
+		return "", fmt.Errorf("%s is not supported", arg)
+	}
+	if _, err := strconv.ParseFloat(arg, 64); err == nil {
+		return arg, nil
+	}
+	return "." + strings.TrimPrefix(arg, "this."), nil
+}
+
+func closingParen(body string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(body); i++ {
+		switch c := body[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,104 @@
+package templating
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+const (
+	GoTemplate = "go-template"
+	Handlebars = "handlebars"
+	Expr       = "expr"
+)
+
+type Funcs map[string]any
+
+type Engine interface {
+	Check(tmpl string) error
+	Render(tmpl string, data map[string]any, funcs Funcs) (string, error)
+}
+
+type RootFinder interface {
+	Roots(tmpl string) ([]string, bool)
+}
+
+type ValueFinder interface {
+	Value(tmpl string, data map[string]any) (any, bool)
+}
+
+var (
+	mu      sync.RWMutex
+	engines = map[string]Engine{
+		GoTemplate: goTemplate{},
+		Handlebars: handlebars{},
+		Expr:       exprEngine{},
+	}
+)
+
+func Register(name string, engine Engine) error {
+	if name == "" {
+		return fmt.Errorf("template engine name is required")
+	}
+	if engine == nil {
+		return fmt.Errorf("template engine %s is nil", name)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if _, exists := engines[name]; exists {
+		return fmt.Errorf("template engine %s is already registered", name)
+	}
+	engines[name] = engine
+	return nil
+}
+
+func Lookup(name string) (Engine, error) {
+	if name == "" {
+		name = GoTemplate
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+	engine, ok := engines[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown template engine %q", name)
+	}
+	return engine, nil
+}
+
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(engines))
+	for name := range engines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func Roots(name, tmpl string) ([]string, bool) {
+	engine, err := Lookup(name)
+	if err != nil {
+		return nil, false
+	}
+	finder, ok := engine.(RootFinder)
+	if !ok {
+		return nil, false
+	}
+	return finder.Roots(tmpl)
+}
+
+func Value(name, tmpl string, data map[string]any) (any, bool) {
+	engine, err := Lookup(name)
+	if err != nil {
+		return nil, false
+	}
+	finder, ok := engine.(ValueFinder)
+	if !ok {
+		return nil, false
+	}
+	return finder.Value(tmpl, data)
+}
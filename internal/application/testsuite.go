@@ -0,0 +1,390 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/ports"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	TestSuiteSuffix    = "_test.yaml"
+	DefaultTestTimeout = 30 * time.Second
+
+	mockProtocol = "mock"
+)
+
+type TestSuite struct {
+	Workflow     string     `yaml:"workflow,omitempty"`
+	SubWorkflows []string   `yaml:"sub_workflows,omitempty"`
+	Tests        []TestCase `yaml:"tests"`
+
+	path string
+}
+
+type TestCase struct {
+	Name        string                 `yaml:"name"`
+	Input       map[string]interface{} `yaml:"input,omitempty"`
+	Environment string                 `yaml:"environment,omitempty"`
+	Timeout     workflow.Duration      `yaml:"timeout,omitempty"`
+	Mocks       map[string]TestMocks   `yaml:"mocks,omitempty"`
+	Expect      TestExpectation        `yaml:"expect"`
+}
+
+type TestMock struct {
+	Response  interface{}       `yaml:"response,omitempty"`
+	Error     string            `yaml:"error,omitempty"`
+	Retryable bool              `yaml:"retryable,omitempty"`
+	Delay     workflow.Duration `yaml:"delay,omitempty"`
+}
+
+type TestMocks []TestMock
+
+func (m *TestMocks) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.SequenceNode {
+		var mocks []TestMock
+		if err := node.Decode(&mocks); err != nil {
+			return err
+		}
+		*m = mocks
+		return nil
+	}
+
+	var mock TestMock
+	if err := node.Decode(&mock); err != nil {
+		return err
+	}
+	*m = TestMocks{mock}
+	return nil
+}
+
+type TestExpectation struct {
+	Status string                 `yaml:"status,omitempty"`
+	Error  string                 `yaml:"error,omitempty"`
+	Output map[string]interface{} `yaml:"output,omitempty"`
+	Calls  []string               `yaml:"calls,omitempty"`
+}
+
+type TestOutcome struct {
+	Name     string
+	Status   string
+	Duration time.Duration
+	Failures []string
+}
+
+func (o *TestOutcome) Passed() bool {
+	return len(o.Failures) == 0
+}
+
+func FindTestSuites(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find tests: %w", err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), TestSuiteSuffix) {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find tests: %w", err)
+		}
+	}
+	return files, nil
+}
+
+func LoadTestSuite(path string) (*TestSuite, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read test file: %w", err)
+	}
+
+	var suite TestSuite
+	if err := yaml.Unmarshal(data, &suite); err != nil {
+		return nil, fmt.Errorf("failed to parse test file: %w", err)
+	}
+	suite.path = path
+
+	if suite.Workflow == "" {
+		suite.Workflow = strings.TrimSuffix(filepath.Base(path), TestSuiteSuffix) + ".yaml"
+	}
+	if len(suite.Tests) == 0 {
+		return nil, fmt.Errorf("test file %s has no tests", path)
+	}
+
+	names := make(map[string]bool, len(suite.Tests))
+	for i, tc := range suite.Tests {
+		if tc.Name == "" {
+			return nil, fmt.Errorf("test %d: name is required", i+1)
+		}
+		if names[tc.Name] {
+			return nil, fmt.Errorf("test %s: duplicate name", tc.Name)
+		}
+		names[tc.Name] = true
+
+		for key, mocks := range tc.Mocks {
+			if service, method, ok := strings.Cut(key, "."); !ok || service == "" || method == "" {
+				return nil, fmt.Errorf("test %s: mock %q: key must be service.method", tc.Name, key)
+			}
+			if len(mocks) == 0 {
+				return nil, fmt.Errorf("test %s: mock %q has no responses", tc.Name, key)
+			}
+		}
+		if tc.Expect.Status != "" && !slices.Contains(testStatuses, tc.Expect.Status) {
+			return nil, fmt.Errorf("test %s: invalid expected status %s (must be one of %s)", tc.Name, tc.Expect.Status, strings.Join(testStatuses, ", "))
+		}
+	}
+
+	return &suite, nil
+}
+
+var testStatuses = []string{
+	workflow.WorkflowStatusSuccess.String(),
+	workflow.WorkflowStatusFailed.String(),
+	workflow.WorkflowStatusCompensated.String(),
+	workflow.WorkflowStatusCancelled.String(),
+}
+
+func (s *TestSuite) Path() string {
+	return s.path
+}
+
+func (s *TestSuite) workflowFiles() []string {
+	dir := filepath.Dir(s.path)
+	files := make([]string, 0, 1+len(s.SubWorkflows))
+	for _, file := range append([]string{s.Workflow}, s.SubWorkflows...) {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(dir, file)
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+func RunTestSuite(ctx context.Context, suite *TestSuite, logger zerolog.Logger) ([]*TestOutcome, error) {
+	outcomes := make([]*TestOutcome, 0, len(suite.Tests))
+	for _, tc := range suite.Tests {
+		outcome, err := runTestCase(ctx, suite, tc, logger)
+		if err != nil {
+			return nil, fmt.Errorf("test %s: %w", tc.Name, err)
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes, nil
+}
+
+func runTestCase(ctx context.Context, suite *TestSuite, tc TestCase, logger zerolog.Logger) (*TestOutcome, error) {
+	o := New(logger)
+
+	var workflows []*workflow.Workflow
+	services := make(map[string]bool)
+	for _, file := range suite.workflowFiles() {
+		wf, err := o.parser.ParseFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load workflow: %w", err)
+		}
+		for name := range wf.Services {
+			services[name] = true
+		}
+		workflows = append(workflows, wf)
+	}
+	for key := range tc.Mocks {
+		if service, _, _ := strings.Cut(key, "."); !services[service] {
+			return nil, fmt.Errorf("mock %q: no workflow declares service %s", key, service)
+		}
+	}
+
+	mocks := newMockServices(tc.Mocks)
+	if err := o.RegisterProtocol(mockProtocol, mocks.adapter); err != nil {
+		return nil, err
+	}
+	for _, wf := range workflows {
+		wf.Calendar = nil
+		for name, service := range wf.Services {
+			wf.Services[name] = workflow.Service{
+				Type:     mockProtocol,
+				Endpoint: name,
+				Timeout:  service.Timeout,
+				Retry:    service.Retry,
+				Metadata: service.Metadata,
+			}
+		}
+		if err := o.registerWorkflow(wf); err != nil {
+			return nil, err
+		}
+	}
+
+	timeout := tc.Timeout.Duration
+	if timeout <= 0 {
+		timeout = DefaultTestTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if tc.Environment != "" {
+		ctx = WithEnvironment(ctx, tc.Environment)
+	}
+
+	started := time.Now()
+	result, err := o.ExecuteWorkflow(ctx, workflows[0].Name, tc.Input)
+	outcome := &TestOutcome{Name: tc.Name, Duration: time.Since(started)}
+	if result == nil {
+		outcome.Failures = append(outcome.Failures, fmt.Sprintf("workflow did not run: %v", err))
+		return outcome, nil
+	}
+	outcome.Status = result.Status.String()
+
+	if result.Error != nil {
+		err = result.Error
+	}
+	outcome.Failures = checkExpectation(tc.Expect, result, err, mocks.calls())
+	return outcome, nil
+}
+
+func checkExpectation(expect TestExpectation, result *workflow.WorkflowResult, runErr error, calls []string) []string {
+	var failures []string
+
+	actual := result.Status.String()
+	succeeded := result.Status == workflow.WorkflowStatusSuccess
+	switch {
+	case expect.Status != "":
+		if actual != expect.Status {
+			failures = append(failures, fmt.Sprintf("status is %s, want %s", actual, expect.Status))
+		}
+	case expect.Error == "":
+		if !succeeded {
+			failures = append(failures, fmt.Sprintf("status is %s, want success: %v", actual, runErr))
+		}
+	default:
+		if succeeded {
+			failures = append(failures, "workflow succeeded, want an error")
+		}
+	}
+
+	if expect.Error != "" {
+		var message string
+		if runErr != nil {
+			message = runErr.Error()
+		}
+		if !strings.Contains(message, expect.Error) {
+			failures = append(failures, fmt.Sprintf("error is %q, want it to contain %q", message, expect.Error))
+		}
+	}
+
+	keys := make([]string, 0, len(expect.Output))
+	for key := range expect.Output {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		actual, ok := result.Output[key]
+		if !ok {
+			failures = append(failures, fmt.Sprintf("output %s is missing", key))
+			continue
+		}
+		want, got := normalizeJSON(expect.Output[key]), normalizeJSON(actual)
+		if !reflect.DeepEqual(want, got) {
+			wantJSON, _ := json.Marshal(want)
+			gotJSON, _ := json.Marshal(got)
+			failures = append(failures, fmt.Sprintf("output %s is %s, want %s", key, gotJSON, wantJSON))
+		}
+	}
+
+	if expect.Calls != nil && !slices.Equal(calls, expect.Calls) {
+		failures = append(failures, fmt.Sprintf("calls were [%s], want [%s]", strings.Join(calls, ", "), strings.Join(expect.Calls, ", ")))
+	}
+
+	return failures
+}
+
+type mockServices struct {
+	mu       sync.Mutex
+	mocks    map[string]TestMocks
+	served   map[string]int
+	recorded []string
+}
+
+func newMockServices(mocks map[string]TestMocks) *mockServices {
+	return &mockServices{mocks: mocks, served: make(map[string]int)}
+}
+
+func (m *mockServices) adapter(string, workflow.Service) (ports.ProtocolAdapter, error) {
+	return m, nil
+}
+
+func (m *mockServices) Invoke(ctx context.Context, call ports.ProtocolCall) (interface{}, error) {
+	service := call.Service
+	if i := strings.IndexAny(service, "@#"); i >= 0 {
+		service = service[:i]
+	}
+	key := service + "." + call.Method
+
+	m.mu.Lock()
+	m.recorded = append(m.recorded, key)
+	responses, ok := m.mocks[key]
+	var mock TestMock
+	if ok {
+		n := m.served[key]
+		mock = responses[min(n, len(responses)-1)]
+		m.served[key] = n + 1
+	}
+	m.mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no mock for %s", key)
+	}
+
+	if mock.Delay.Duration > 0 {
+		select {
+		case <-time.After(mock.Delay.Duration):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if mock.Error != "" {
+		if mock.Retryable {
+			return nil, status.Error(codes.Unavailable, mock.Error)
+		}
+		return nil, errors.New(mock.Error)
+	}
+	return mock.Response, nil
+}
+
+func (m *mockServices) HealthCheck(context.Context) error {
+	return nil
+}
+
+func (m *mockServices) Close() error {
+	return nil
+}
+
+func (m *mockServices) calls() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.recorded)
+}
@@ -0,0 +1,32 @@
+package application
+
+import (
+	"context"
+
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func startWorkflowSpan(ctx context.Context, wf *workflow.Workflow, workflowID, environment string, tags map[string]string) (context.Context, trace.Span) {
+	attributes := []attribute.KeyValue{
+		attribute.String("maestro.workflow_id", workflowID),
+		attribute.String("maestro.workflow_name", wf.Name),
+		attribute.String("maestro.workflow_version", wf.Version),
+		attribute.String("maestro.namespace", wf.Namespace),
+		attribute.String("maestro.environment", environment),
+	}
+	for key, value := range tags {
+		attributes = append(attributes, attribute.String("maestro.tag."+key, value))
+	}
+	return tracing.Tracer().Start(ctx, "workflow "+wf.Name, trace.WithAttributes(attributes...))
+}
+
+func (r *run) endSpan() {
+	if r.span == nil {
+		return
+	}
+	r.span.SetAttributes(attribute.String("maestro.status", r.result.Status.String()))
+	tracing.End(r.span, r.result.Error)
+}
@@ -0,0 +1,50 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	workflow "github.com/maestro/maestro.go/internal/domain"
+)
+
+const maxTransactionExecutions = 500
+
+func (o *Orchestrator) Transaction(ctx context.Context, key map[string]string) (*workflow.Transaction, error) {
+	if len(key) == 0 {
+		return nil, fmt.Errorf("a transaction key is required")
+	}
+
+	executions, err := o.ListExecutions(ctx, workflow.ExecutionFilter{
+		Tags:  key,
+		Limit: maxTransactionExecutions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(executions) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[string]bool, len(executions))
+	for _, execution := range executions {
+		seen[execution.Context.WorkflowID] = true
+	}
+	for i := 0; i < len(executions) && len(executions) < maxTransactionExecutions; i++ {
+		for _, executed := range executions[i].Context.CopyExecutedSteps() {
+			childID := executed.SubWorkflowID
+			if childID == "" || seen[childID] {
+				continue
+			}
+			seen[childID] = true
+			child, ok, err := o.GetExecution(childID)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				executions = append(executions, child)
+			}
+		}
+	}
+
+	return workflow.NewTransaction(key, executions), nil
+}
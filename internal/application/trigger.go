@@ -0,0 +1,246 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/application/expression"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/metrics"
+	"github.com/maestro/maestro.go/internal/ports"
+)
+
+const triggerRestartDelay = 5 * time.Second
+
+type triggerSet struct {
+	mu        sync.Mutex
+	ctx       context.Context
+	sources   map[string]ports.TriggerSource
+	consumers map[string]*triggerConsumer
+}
+
+type triggerConsumer struct {
+	kind  string
+	topic string
+	group string
+	stop  context.CancelFunc
+}
+
+var filteredTriggersMetric = &workflow.MetricConfig{
+	Name: "maestro_trigger_messages_filtered_total",
+	Type: metrics.MetricTypeCounter,
+	Help: "Trigger messages dropped by their workflow's trigger filter",
+}
+
+func triggerVars(msg *workflow.TriggerMessage) (map[string]any, error) {
+	var payload interface{}
+	if len(bytes.TrimSpace(msg.Payload)) > 0 {
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("invalid trigger payload: %w", err)
+		}
+	}
+
+	headers := msg.Headers
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	return map[string]any{
+		"payload": payload,
+		"headers": headers,
+		"source":  msg.Source,
+	}, nil
+}
+
+func triggerIdempotencyKey(trigger *workflow.TriggerConfig, msg *workflow.TriggerMessage) (string, error) {
+	if trigger == nil || trigger.IdempotencyKey == "" {
+		return "", nil
+	}
+
+	vars, err := triggerVars(msg)
+	if err != nil {
+		return "", err
+	}
+	key, err := expression.EvaluateJSON(trigger.IdempotencyKey, vars)
+	if err != nil {
+		return "", fmt.Errorf("trigger idempotency key failed: %w", err)
+	}
+	switch key := key.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return key, nil
+	case float64:
+		return strconv.FormatFloat(key, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("trigger idempotency key must be a string or a number, got %T", key)
+	}
+}
+
+func triggerInput(trigger *workflow.TriggerConfig, msg *workflow.TriggerMessage) (map[string]interface{}, error) {
+	vars, err := triggerVars(msg)
+	if err != nil {
+		return nil, err
+	}
+	payload := vars["payload"]
+
+	if trigger != nil && trigger.Filter != "" {
+		keep, err := expression.EvaluateBool(trigger.Filter, vars)
+		if err != nil {
+			return nil, fmt.Errorf("trigger filter failed: %w", err)
+		}
+		if !keep {
+			return nil, workflow.ErrTriggerFiltered
+		}
+	}
+
+	if trigger == nil || len(trigger.Transform) == 0 {
+		if payload == nil {
+			return make(map[string]interface{}), nil
+		}
+		input, ok := payload.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid trigger payload: expected a JSON object, got %T", payload)
+		}
+		return input, nil
+	}
+
+	input := make(map[string]interface{}, len(trigger.Transform))
+	for field, expr := range trigger.Transform {
+		value, err := expression.EvaluateJSON(expr, vars)
+		if err != nil {
+			return nil, fmt.Errorf("trigger transform of %s failed: %w", field, err)
+		}
+		input[field] = value
+	}
+	return input, nil
+}
+
+func newTriggerSet(sources map[string]ports.TriggerSource) *triggerSet {
+	return &triggerSet{
+		sources:   sources,
+		consumers: make(map[string]*triggerConsumer),
+	}
+}
+
+func (o *Orchestrator) RunTriggers(ctx context.Context) {
+	t := o.triggers
+	t.mu.Lock()
+	t.ctx = ctx
+	t.mu.Unlock()
+
+	o.syncTriggers()
+	<-ctx.Done()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.ctx = nil
+	clear(t.consumers)
+}
+
+func (o *Orchestrator) syncTriggers() {
+	o.mu.RLock()
+	wanted := make(map[string]*triggerConsumer)
+	for name, wf := range o.workflows {
+		if wf.Trigger == nil || wf.Trigger.Type == "" {
+			continue
+		}
+		wanted[name] = &triggerConsumer{
+			kind:  wf.Trigger.Type,
+			topic: wf.Trigger.Topic,
+			group: wf.Trigger.ConsumerGroup(name),
+		}
+	}
+	o.mu.RUnlock()
+
+	t := o.triggers
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.ctx == nil {
+		return
+	}
+
+	for name, current := range t.consumers {
+		next, ok := wanted[name]
+		if ok && next.kind == current.kind && next.topic == current.topic && next.group == current.group {
+			continue
+		}
+		current.stop()
+		delete(t.consumers, name)
+	}
+
+	for name, consumer := range wanted {
+		if _, running := t.consumers[name]; running {
+			continue
+		}
+		source, ok := t.sources[consumer.kind]
+		if !ok {
+			o.logger.Error().
+				Str("workflow", name).
+				Str("trigger", consumer.kind).
+				Msg("No source configured for workflow trigger, messages will not be consumed")
+			continue
+		}
+
+		var ctx context.Context
+		ctx, consumer.stop = context.WithCancel(t.ctx)
+		t.consumers[name] = consumer
+		go o.consumeTrigger(ctx, source, name, consumer)
+	}
+}
+
+func (o *Orchestrator) consumeTrigger(ctx context.Context, source ports.TriggerSource, name string, consumer *triggerConsumer) {
+	logger := o.logger.With().
+		Str("workflow", name).
+		Str("trigger", consumer.kind).
+		Str("topic", consumer.topic).
+		Str("group", consumer.group).
+		Logger()
+
+	handle := func(ctx context.Context, msg *workflow.TriggerMessage) error {
+		msg.Workflow = name
+		for {
+			_, err := o.Trigger(context.WithoutCancel(ctx), msg)
+			if errors.Is(err, workflow.ErrTriggerFiltered) || errors.Is(err, workflow.ErrDeadLettered) {
+				return nil
+			}
+			var deferred *workflow.CalendarError
+			if !errors.As(err, &deferred) {
+				return err
+			}
+
+			logger.Debug().
+				Time("retry_at", deferred.RetryAt).
+				Msg("Trigger message held back by the workflow calendar")
+			select {
+			case <-time.After(time.Until(deferred.RetryAt)):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+
+	logger.Info().Msg("Consuming workflow trigger")
+	for {
+		err := source.Consume(ctx, consumer.topic, consumer.group, handle)
+		if ctx.Err() != nil {
+			logger.Info().Msg("Stopped consuming workflow trigger")
+			return
+		}
+
+		logger.Error().
+			Err(err).
+			Dur("retry_in", triggerRestartDelay).
+			Msg("Trigger consumer failed, restarting")
+		select {
+		case <-time.After(triggerRestartDelay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
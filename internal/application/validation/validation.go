@@ -0,0 +1,95 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+const schemaURL = "maestro://schema.json"
+
+var (
+	schemas sync.Map
+	printer = message.NewPrinter(language.English)
+)
+
+func Check(schema map[string]any) error {
+	_, err := compile(schema)
+	return err
+}
+
+func Validate(schema map[string]any, value any, root string) ([]string, error) {
+	compiled, err := compile(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := normalize(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", root, err)
+	}
+
+	err = compiled.Validate(doc)
+	var invalid *jsonschema.ValidationError
+	if errors.As(err, &invalid) {
+		return problems(invalid, root, nil), nil
+	}
+	return nil, err
+}
+
+func compile(schema map[string]any) (*jsonschema.Schema, error) {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	if cached, ok := schemas.Load(string(data)); ok {
+		return cached.(*jsonschema.Schema), nil
+	}
+
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(schemaURL, doc); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	compiled, err := compiler.Compile(schemaURL)
+	var metaschema *jsonschema.SchemaValidationError
+	var invalid *jsonschema.ValidationError
+	if errors.As(err, &metaschema) && errors.As(metaschema.Err, &invalid) {
+		return nil, fmt.Errorf("invalid schema: %s", strings.Join(problems(invalid, "schema", nil), "; "))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+
+	schemas.Store(string(data), compiled)
+	return compiled, nil
+}
+
+func normalize(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return jsonschema.UnmarshalJSON(bytes.NewReader(data))
+}
+
+func problems(err *jsonschema.ValidationError, root string, found []string) []string {
+	if len(err.Causes) == 0 {
+		path := strings.Join(append([]string{root}, err.InstanceLocation...), ".")
+		return append(found, fmt.Sprintf("%s: %s", path, err.ErrorKind.LocalizedString(printer)))
+	}
+	for _, cause := range err.Causes {
+		found = problems(cause, root, found)
+	}
+	return found
+}
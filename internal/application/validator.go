@@ -2,7 +2,10 @@ package application
 
 import (
 	"fmt"
+	"slices"
 
+	"github.com/maestro/maestro.go/internal/application/expression"
+	"github.com/maestro/maestro.go/internal/application/templating"
 	"github.com/maestro/maestro.go/internal/domain"
 )
 
@@ -12,115 +15,232 @@ func NewValidator() *Validator {
 	return &Validator{}
 }
 
+type StepGraph struct {
+	Steps      []*domain.Step
+	Deps       [][]int
+	Dependents [][]int
+}
+
 func (v *Validator) ValidateDAG(workflow *domain.Workflow) error {
-	stepMap := make(map[string]*domain.Step)
-	dependencies := make(map[string][]string)
+	_, err := v.BuildGraph(workflow)
+	return err
+}
+
+func (v *Validator) BuildGraph(workflow *domain.Workflow) (*StepGraph, error) {
+	graph := &StepGraph{
+		Steps:      make([]*domain.Step, len(workflow.Steps)),
+		Deps:       make([][]int, len(workflow.Steps)),
+		Dependents: make([][]int, len(workflow.Steps)),
+	}
+
+	owners := make(map[string]int)
+	producers := make(map[string]int)
+	explicit := false
 
 	for i := range workflow.Steps {
-		if err := v.buildStepMap(&workflow.Steps[i], stepMap, dependencies); err != nil {
-			return err
+		graph.Steps[i] = &workflow.Steps[i]
+		if err := v.buildStepMap(&workflow.Steps[i], i, owners, producers); err != nil {
+			return nil, err
 		}
+		explicit = explicit || declaresDependencies(&workflow.Steps[i])
 	}
 
-	if err := v.detectCycles(dependencies); err != nil {
-		return fmt.Errorf("workflow contains cycles: %w", err)
-	}
+	for i, step := range graph.Steps {
+		deps := make(map[int]bool)
 
-	return nil
-}
+		if (!explicit || !declaresDependencies(step)) && i > 0 {
+			deps[i-1] = true
+		}
 
-func (v *Validator) buildStepMap(step *domain.Step, stepMap map[string]*domain.Step, deps map[string][]string) error {
-	if len(step.Parallel) > 0 {
-		for i := range step.Parallel {
-			if err := v.buildStepMap(&step.Parallel[i], stepMap, deps); err != nil {
-				return err
+		if explicit {
+			if err := v.collectDependencies(step, i, workflow.TemplateEngine, owners, producers, deps); err != nil {
+				return nil, err
 			}
 		}
-		return nil
+
+		for dep := range deps {
+			graph.Deps[i] = append(graph.Deps[i], dep)
+			graph.Dependents[dep] = append(graph.Dependents[dep], i)
+		}
+		slices.Sort(graph.Deps[i])
 	}
 
-	if step.ID == "" {
-		return fmt.Errorf("step must have an ID")
+	for i := range graph.Dependents {
+		slices.Sort(graph.Dependents[i])
 	}
 
-	if _, exists := stepMap[step.ID]; exists {
-		return fmt.Errorf("duplicate step ID: %s", step.ID)
+	if err := v.detectCycles(graph); err != nil {
+		return nil, fmt.Errorf("workflow contains cycles: %w", err)
 	}
 
-	stepMap[step.ID] = step
+	return graph, nil
+}
+
+func (v *Validator) buildStepMap(step *domain.Step, unit int, owners, producers map[string]int) error {
+	if step.ID != "" {
+		if _, exists := owners[step.ID]; exists {
+			return fmt.Errorf("duplicate step ID: %s", step.ID)
+		}
+		owners[step.ID] = unit
+	}
 
 	if step.Output != "" {
-		for key, value := range step.Input {
-			if strVal, ok := value.(string); ok && domain.IsTemplate(strVal) {
-				referencedSteps := v.extractStepReferences(strVal)
-				for _, ref := range referencedSteps {
-					if ref != "input" {
-						deps[step.ID] = append(deps[step.ID], ref)
-					}
-				}
-				_ = key
+		producers[step.Output] = unit
+	}
+
+	for i := range step.Parallel {
+		if err := v.buildStepMap(&step.Parallel[i], unit, owners, producers); err != nil {
+			return err
+		}
+	}
+
+	if step.Foreach != nil {
+		for i := range step.Foreach.Steps {
+			nested := &step.Foreach.Steps[i]
+			if nested.ID == "" {
+				continue
 			}
+			if _, exists := owners[nested.ID]; exists {
+				return fmt.Errorf("duplicate step ID: %s", nested.ID)
+			}
+			owners[nested.ID] = unit
 		}
 	}
 
+	if step.Fallback != nil {
+		return v.buildStepMap(step.Fallback, unit, owners, producers)
+	}
+
 	return nil
 }
 
-func (v *Validator) extractStepReferences(template string) []string {
-	var refs []string
-	i := 0
-	for i < len(template) {
-		if i+3 < len(template) && template[i:i+3] == "{{ " {
-			j := i + 3
-			for j < len(template)-2 && template[j:j+2] != " }" {
-				j++
+func (v *Validator) collectDependencies(step *domain.Step, unit int, engine string, owners, producers map[string]int, deps map[int]bool) error {
+	for _, id := range step.DependsOn {
+		dep, ok := owners[id]
+		if !ok {
+			return fmt.Errorf("step %s: depends_on references unknown step %s", step.ID, id)
+		}
+		if dep == unit {
+			return fmt.Errorf("step %s: depends_on cannot reference itself or a step in the same parallel group", step.ID)
+		}
+		deps[dep] = true
+	}
+
+	templates := stepTemplates(step)
+
+	for _, tmpl := range templates {
+		for _, ref := range v.extractStepReferences(engine, tmpl) {
+			if dep, ok := producers[ref]; ok && dep != unit {
+				deps[dep] = true
 			}
-			if j < len(template)-2 {
-				expr := template[i+3 : j]
-				if len(expr) > 1 && expr[0] == '.' {
-					parts := splitExpression(expr[1:])
-					if len(parts) > 0 {
-						refs = append(refs, parts[0])
-					}
-				}
-				i = j + 2
-			} else {
-				i++
+		}
+	}
+
+	for _, expr := range stepConditions(step) {
+		for _, ref := range v.extractExpressionReferences(expr) {
+			if dep, ok := producers[ref]; ok && dep != unit {
+				deps[dep] = true
 			}
-		} else {
-			i++
 		}
 	}
-	return refs
+
+	for i := range step.Parallel {
+		if err := v.collectDependencies(&step.Parallel[i], unit, engine, owners, producers, deps); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func splitExpression(expr string) []string {
-	var parts []string
-	var current string
-	for _, ch := range expr {
-		if ch == '.' {
-			if current != "" {
-				parts = append(parts, current)
-				current = ""
-			}
-		} else {
-			current += string(ch)
+func stepTemplates(step *domain.Step) []string {
+	templates := []string{step.When, step.Method, step.StreamInput}
+	for _, value := range step.Input {
+		if s, ok := value.(string); ok {
+			templates = append(templates, s)
+		}
+	}
+	for _, value := range step.Headers {
+		templates = append(templates, value)
+	}
+
+	if step.Foreach != nil {
+		templates = append(templates, step.Foreach.Items)
+		for i := range step.Foreach.Steps {
+			templates = append(templates, stepTemplates(&step.Foreach.Steps[i])...)
+		}
+	}
+	if step.Fallback != nil {
+		templates = append(templates, stepTemplates(step.Fallback)...)
+	}
+
+	return templates
+}
+
+func stepConditions(step *domain.Step) []string {
+	var conditions []string
+	if step.When != "" {
+		conditions = append(conditions, step.When)
+	}
+	if step.Assert != nil {
+		conditions = append(conditions, step.Assert.Condition)
+	}
+
+	if step.Foreach != nil {
+		for i := range step.Foreach.Steps {
+			conditions = append(conditions, stepConditions(&step.Foreach.Steps[i])...)
 		}
 	}
-	if current != "" {
-		parts = append(parts, current)
+	if step.Fallback != nil {
+		conditions = append(conditions, stepConditions(step.Fallback)...)
 	}
-	return parts
+
+	return conditions
+}
+
+func (v *Validator) extractExpressionReferences(condition string) []string {
+	expr, ok := expression.Unwrap(condition)
+	if !ok {
+		return nil
+	}
+
+	refs, _ := expression.Roots(expr)
+	return slices.DeleteFunc(refs, func(ref string) bool {
+		return ref == "input" || ref == "vars"
+	})
+}
+
+func (v *Validator) extractStepReferences(engine, template string) []string {
+	if !domain.ContainsTemplate(template) {
+		return nil
+	}
+
+	refs, _ := templating.Roots(engine, template)
+	return slices.DeleteFunc(refs, func(ref string) bool {
+		return ref == "input"
+	})
+}
+
+func declaresDependencies(step *domain.Step) bool {
+	if step.DependsOn != nil {
+		return true
+	}
+	for i := range step.Parallel {
+		if declaresDependencies(&step.Parallel[i]) {
+			return true
+		}
+	}
+	return false
 }
 
-func (v *Validator) detectCycles(dependencies map[string][]string) error {
-	visited := make(map[string]bool)
-	recStack := make(map[string]bool)
+func (v *Validator) detectCycles(graph *StepGraph) error {
+	visited := make(map[int]bool)
+	recStack := make(map[int]bool)
 
-	for node := range dependencies {
+	for node := range graph.Steps {
 		if !visited[node] {
-			if v.hasCycleDFS(node, dependencies, visited, recStack) {
-				return fmt.Errorf("cycle detected involving step: %s", node)
+			if v.hasCycleDFS(node, graph.Deps, visited, recStack) {
+				return fmt.Errorf("cycle detected involving step: %s", graph.Steps[node].ID)
 			}
 		}
 	}
@@ -128,7 +248,7 @@ func (v *Validator) detectCycles(dependencies map[string][]string) error {
 	return nil
 }
 
-func (v *Validator) hasCycleDFS(node string, deps map[string][]string, visited, recStack map[string]bool) bool {
+func (v *Validator) hasCycleDFS(node int, deps [][]int, visited, recStack map[int]bool) bool {
 	visited[node] = true
 	recStack[node] = true
 
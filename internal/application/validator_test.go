@@ -0,0 +1,127 @@
+package application
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+func TestBuildGraph(t *testing.T) {
+	tests := []struct {
+		name    string
+		steps   []domain.Step
+		deps    [][]int
+		wantErr string
+	}{
+		{
+			name: "steps without dependencies run in order",
+			steps: []domain.Step{
+				{ID: "a"},
+				{ID: "b"},
+				{ID: "c"},
+			},
+			deps: [][]int{nil, {0}, {1}},
+		},
+		{
+			name: "depends_on replaces the implicit order of its step",
+			steps: []domain.Step{
+				{ID: "a"},
+				{ID: "b"},
+				{ID: "c", DependsOn: []string{"a"}},
+			},
+			deps: [][]int{nil, {0}, {0}},
+		},
+		{
+			name: "template references add the producing step",
+			steps: []domain.Step{
+				{ID: "charge", Output: "charge"},
+				{ID: "reserve", Output: "reservation"},
+				{ID: "ship", DependsOn: []string{"reserve"}, Input: map[string]any{"charge_id": "{{ .charge.id }}"}},
+			},
+			deps: [][]int{nil, {0}, {0, 1}},
+		},
+		{
+			name: "conditions add the producing step",
+			steps: []domain.Step{
+				{ID: "quote", Output: "quote"},
+				{ID: "audit"},
+				{ID: "approve", DependsOn: []string{"audit"}, When: "{{ quote.total > 100 }}"},
+			},
+			deps: [][]int{nil, {0}, {0, 1}},
+		},
+		{
+			name: "parallel branches depend on the group",
+			steps: []domain.Step{
+				{ID: "fanout", Parallel: []domain.Step{{ID: "left"}, {ID: "right"}}},
+				{ID: "join", DependsOn: []string{"left"}},
+			},
+			deps: [][]int{nil, {0}},
+		},
+		{
+			name: "duplicate step IDs",
+			steps: []domain.Step{
+				{ID: "a"},
+				{ID: "a"},
+			},
+			wantErr: "duplicate step ID: a",
+		},
+		{
+			name: "duplicate ID inside a parallel group",
+			steps: []domain.Step{
+				{ID: "a"},
+				{ID: "group", Parallel: []domain.Step{{ID: "a"}}},
+			},
+			wantErr: "duplicate step ID: a",
+		},
+		{
+			name: "unknown dependency",
+			steps: []domain.Step{
+				{ID: "a", DependsOn: []string{"missing"}},
+			},
+			wantErr: "depends_on references unknown step missing",
+		},
+		{
+			name: "dependency on a step of the same parallel group",
+			steps: []domain.Step{
+				{ID: "group", Parallel: []domain.Step{{ID: "left"}, {ID: "right", DependsOn: []string{"left"}}}},
+			},
+			wantErr: "cannot reference itself or a step in the same parallel group",
+		},
+		{
+			name: "cycle",
+			steps: []domain.Step{
+				{ID: "a", DependsOn: []string{"b"}},
+				{ID: "b", DependsOn: []string{"a"}},
+			},
+			wantErr: "workflow contains cycles",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph, err := NewValidator().BuildGraph(&domain.Workflow{Name: "test", Steps: tt.steps})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("BuildGraph() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BuildGraph() error = %v", err)
+			}
+
+			for i, want := range tt.deps {
+				if !slices.Equal(graph.Deps[i], want) {
+					t.Errorf("Deps[%d] = %v, want %v", i, graph.Deps[i], want)
+				}
+				for _, dep := range want {
+					if !slices.Contains(graph.Dependents[dep], i) {
+						t.Errorf("Dependents[%d] = %v, missing %d", dep, graph.Dependents[dep], i)
+					}
+				}
+			}
+		})
+	}
+}
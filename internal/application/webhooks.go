@@ -0,0 +1,70 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	workflow "github.com/maestro/maestro.go/internal/domain"
+)
+
+var ErrWebhooksDisabled = errors.New("no webhooks are configured")
+
+func (o *Orchestrator) publishCompletion(r *run) {
+	if o.webhooks == nil || r.handedOff() || r.ctx.Value(ctxkeys.Shadow) != nil {
+		return
+	}
+	event := workflow.NewCompletionEvent(r.wf.Name, r.execCtx.Namespace, r.result)
+	redactor := o.redactorFor(r.ctx)
+	event.Output = redactor.Outputs(event.Output)
+	event.Error = redactor.String(event.Error)
+	o.webhooks.Dispatch(event, r.callbacks...)
+}
+
+func (o *Orchestrator) completionCallbacks(ctx context.Context, wf *workflow.Workflow) ([]string, error) {
+	if ctx.Value(ctxkeys.Shadow) != nil {
+		return nil, nil
+	}
+
+	var callbacks []string
+	if wf.CallbackURL != "" {
+		callbacks = append(callbacks, wf.CallbackURL)
+	}
+	if url, _ := ctx.Value(ctxkeys.Callback).(string); url != "" && url != wf.CallbackURL {
+		callbacks = append(callbacks, url)
+	}
+	if len(callbacks) == 0 {
+		return nil, nil
+	}
+
+	if o.webhooks == nil {
+		return nil, fmt.Errorf("%w: callbacks are not enabled", workflow.ErrCallbackRejected)
+	}
+	for _, url := range callbacks {
+		if err := o.webhooks.AcceptsCallback(url); err != nil {
+			return nil, err
+		}
+	}
+	return callbacks, nil
+}
+
+func (o *Orchestrator) WebhookDeliveries(workflowID string) ([]workflow.WebhookDelivery, error) {
+	if o.webhooks == nil {
+		return nil, ErrWebhooksDisabled
+	}
+	return o.webhooks.Deliveries(workflowID), nil
+}
+
+func (o *Orchestrator) RedeliverWebhook(id string) (workflow.WebhookDelivery, error) {
+	if o.webhooks == nil {
+		return workflow.WebhookDelivery{}, ErrWebhooksDisabled
+	}
+	return o.webhooks.Redeliver(id)
+}
+
+func (o *Orchestrator) FlushWebhooks(ctx context.Context) {
+	if o.webhooks != nil {
+		o.webhooks.Close(ctx)
+	}
+}
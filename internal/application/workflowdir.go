@@ -0,0 +1,102 @@
+package application
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	workflow "github.com/maestro/maestro.go/internal/domain"
+)
+
+var workflowExtensions = []string{".yaml", ".yml", ".json"}
+
+func (o *Orchestrator) LoadWorkflowDir(path string) ([]*workflow.Workflow, error) {
+	files, err := FindWorkflowFiles(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var loaded []*workflow.Workflow
+	var failures []workflow.WorkflowLoadFailure
+	imported := make(map[string]bool)
+	for _, file := range files {
+		wf, err := o.LoadWorkflow(file)
+		if err != nil {
+			failures = append(failures, workflow.WorkflowLoadFailure{File: file, Err: err})
+			continue
+		}
+		loaded = append(loaded, wf)
+		for _, catalog := range importedFiles(wf, file) {
+			imported[catalog] = true
+		}
+	}
+
+	failures = slices.DeleteFunc(failures, func(f workflow.WorkflowLoadFailure) bool {
+		return imported[absPath(f.File)]
+	})
+
+	o.logger.Info().
+		Str("path", path).
+		Int("workflows", len(loaded)).
+		Int("failed", len(failures)).
+		Msg("Workflow directory loaded")
+
+	if len(failures) > 0 {
+		return loaded, &workflow.WorkflowDirError{Failures: failures}
+	}
+	return loaded, nil
+}
+
+func FindWorkflowFiles(path string) ([]string, error) {
+	roots := []string{path}
+	if strings.ContainsAny(path, "*?[") {
+		matches, err := filepath.Glob(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid workflow pattern %s: %w", path, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no workflow files match %s", path)
+		}
+		roots = matches
+	}
+
+	var files []string
+	for _, root := range roots {
+		info, err := os.Stat(root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find workflows: %w", err)
+		}
+		if !info.IsDir() {
+			if IsWorkflowFile(root) {
+				files = append(files, root)
+			}
+			continue
+		}
+
+		err = filepath.WalkDir(root, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !entry.IsDir() && IsWorkflowFile(file) {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find workflows: %w", err)
+		}
+	}
+
+	slices.Sort(files)
+	return slices.Compact(files), nil
+}
+
+func IsWorkflowFile(file string) bool {
+	if strings.HasSuffix(file, TestSuiteSuffix) {
+		return false
+	}
+	return slices.Contains(workflowExtensions, strings.ToLower(filepath.Ext(file)))
+}
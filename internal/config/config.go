@@ -0,0 +1,263 @@
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/rs/zerolog"
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	LogLevel            string                            `yaml:"log_level,omitempty"`
+	Workers             int                               `yaml:"workers,omitempty"`
+	CompensationWorkers int                               `yaml:"compensation_workers,omitempty"`
+	APIKeys             []string                          `yaml:"api_keys,omitempty"`
+	Services            map[string]domain.ServiceOverride `yaml:"services,omitempty"`
+	NamespaceKeys       map[string]string                 `yaml:"namespace_keys,omitempty"`
+	Alerting            *AlertingConfig                   `yaml:"alerting,omitempty"`
+	Webhooks            []WebhookConfig                   `yaml:"webhooks,omitempty"`
+	Callbacks           *CallbackConfig                   `yaml:"callbacks,omitempty"`
+	Schedules           []domain.Schedule                 `yaml:"schedules,omitempty"`
+	Kafka               *KafkaConfig                      `yaml:"kafka,omitempty"`
+	SecretProviders     *SecretsConfig                    `yaml:"secrets,omitempty"`
+	Redaction           *RedactionConfig                  `yaml:"redaction,omitempty"`
+}
+
+type AlertingConfig struct {
+	Webhook           string            `yaml:"webhook,omitempty"`
+	PagerDutyURL      string            `yaml:"pagerduty_url,omitempty"`
+	PagerDutyServices map[string]string `yaml:"pagerduty_services,omitempty"`
+}
+
+type WebhookConfig struct {
+	URL    string `yaml:"url"`
+	Secret string `yaml:"secret"`
+}
+
+type CallbackConfig struct {
+	Secret       string   `yaml:"secret"`
+	AllowedHosts []string `yaml:"allowed_hosts,omitempty"`
+}
+
+type KafkaConfig struct {
+	Brokers []string `yaml:"brokers"`
+}
+
+type SecretsConfig struct {
+	CacheTTL  *domain.Duration       `yaml:"cache_ttl,omitempty"`
+	Providers []SecretProviderConfig `yaml:"providers"`
+}
+
+type SecretProviderConfig struct {
+	Type    string `yaml:"type"`
+	Prefix  string `yaml:"prefix,omitempty"`
+	Dir     string `yaml:"dir,omitempty"`
+	Address string `yaml:"address,omitempty"`
+	Token   string `yaml:"token,omitempty"`
+	Mount   string `yaml:"mount,omitempty"`
+	Path    string `yaml:"path,omitempty"`
+	Region  string `yaml:"region,omitempty"`
+}
+
+type RedactionConfig struct {
+	Fields []string `yaml:"fields"`
+}
+
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+func (c *Config) Level() (zerolog.Level, bool) {
+	if c.LogLevel == "" {
+		return zerolog.NoLevel, false
+	}
+	level, err := zerolog.ParseLevel(c.LogLevel)
+	if err != nil {
+		return zerolog.NoLevel, false
+	}
+	return level, true
+}
+
+func (c *Config) EncryptionKeys() (map[string][]byte, error) {
+	keys := make(map[string][]byte, len(c.NamespaceKeys))
+	for namespace, encoded := range c.NamespaceKeys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("namespace_keys.%s is not valid base64: %w", namespace, err)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("namespace_keys.%s must decode to 32 bytes, got %d", namespace, len(key))
+		}
+		keys[namespace] = key
+	}
+	return keys, nil
+}
+
+func (c *Config) SensitiveFields() []*regexp.Regexp {
+	if c.Redaction == nil {
+		return nil
+	}
+	fields := make([]*regexp.Regexp, 0, len(c.Redaction.Fields))
+	for _, field := range c.Redaction.Fields {
+		fields = append(fields, regexp.MustCompile(field))
+	}
+	return fields
+}
+
+func (c *Config) Secrets() map[string]string {
+	secrets := make(map[string]string)
+	for i, key := range c.APIKeys {
+		secrets[fmt.Sprintf("api_keys[%d]", i)] = key
+	}
+	for namespace, key := range c.NamespaceKeys {
+		secrets["namespace_keys."+namespace] = key
+	}
+	for i, hook := range c.Webhooks {
+		secrets[fmt.Sprintf("webhooks[%d].secret", i)] = hook.Secret
+	}
+	if c.Callbacks != nil {
+		secrets["callbacks.secret"] = c.Callbacks.Secret
+	}
+	if c.Alerting != nil {
+		for service, routingKey := range c.Alerting.PagerDutyServices {
+			secrets["alerting.pagerduty_services."+service] = routingKey
+		}
+	}
+	return secrets
+}
+
+func (c *Config) validate() error {
+	if c.LogLevel != "" {
+		if _, err := zerolog.ParseLevel(c.LogLevel); err != nil {
+			return fmt.Errorf("invalid log_level %s", c.LogLevel)
+		}
+	}
+
+	if c.Workers < 0 {
+		return fmt.Errorf("workers cannot be negative")
+	}
+
+	if c.CompensationWorkers < 0 {
+		return fmt.Errorf("compensation_workers cannot be negative")
+	}
+
+	for i, key := range c.APIKeys {
+		if key == "" {
+			return fmt.Errorf("api_keys[%d] is empty", i)
+		}
+	}
+
+	for name, override := range c.Services {
+		if override.Timeout.Duration < 0 {
+			return fmt.Errorf("service %s: timeout cannot be negative", name)
+		}
+	}
+
+	if _, err := c.EncryptionKeys(); err != nil {
+		return err
+	}
+
+	if c.Alerting != nil {
+		for service, routingKey := range c.Alerting.PagerDutyServices {
+			if routingKey == "" {
+				return fmt.Errorf("alerting.pagerduty_services.%s has no routing key", service)
+			}
+		}
+	}
+
+	for i, hook := range c.Webhooks {
+		if u, err := url.Parse(hook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("webhooks[%d]: invalid url %q", i, hook.URL)
+		}
+		if hook.Secret == "" {
+			return fmt.Errorf("webhooks[%d]: secret is required to sign deliveries", i)
+		}
+	}
+
+	if c.Callbacks != nil {
+		if c.Callbacks.Secret == "" {
+			return fmt.Errorf("callbacks: secret is required to sign deliveries")
+		}
+		for i, host := range c.Callbacks.AllowedHosts {
+			if host == "" || strings.ContainsAny(host, "/:") {
+				return fmt.Errorf("callbacks.allowed_hosts[%d]: invalid host %q", i, host)
+			}
+		}
+	}
+
+	if c.Kafka != nil && len(c.Kafka.Brokers) == 0 {
+		return fmt.Errorf("kafka: at least one broker is required")
+	}
+
+	if c.SecretProviders != nil {
+		if c.SecretProviders.CacheTTL != nil && c.SecretProviders.CacheTTL.Duration < 0 {
+			return fmt.Errorf("secrets: cache_ttl cannot be negative")
+		}
+		if len(c.SecretProviders.Providers) == 0 {
+			return fmt.Errorf("secrets: at least one provider is required")
+		}
+		for i, provider := range c.SecretProviders.Providers {
+			switch provider.Type {
+			case "env", "aws":
+			case "file":
+				if provider.Dir == "" {
+					return fmt.Errorf("secrets.providers[%d]: dir is required", i)
+				}
+			case "vault":
+				if provider.Path == "" {
+					return fmt.Errorf("secrets.providers[%d]: path is required", i)
+				}
+				if provider.Address == "" && os.Getenv("VAULT_ADDR") == "" {
+					return fmt.Errorf("secrets.providers[%d]: address or VAULT_ADDR is required", i)
+				}
+			default:
+				return fmt.Errorf("secrets.providers[%d]: unknown type %q", i, provider.Type)
+			}
+		}
+	}
+
+	if c.Redaction != nil {
+		if len(c.Redaction.Fields) == 0 {
+			return fmt.Errorf("redaction: at least one field pattern is required")
+		}
+		for i, field := range c.Redaction.Fields {
+			if _, err := regexp.Compile(field); err != nil {
+				return fmt.Errorf("redaction.fields[%d]: invalid pattern %q: %w", i, field, err)
+			}
+		}
+	}
+
+	for i, schedule := range c.Schedules {
+		if schedule.Name == "" {
+			return fmt.Errorf("schedules[%d]: name is required", i)
+		}
+		if schedule.Workflow == "" {
+			return fmt.Errorf("schedule %s: workflow is required", schedule.Name)
+		}
+		if schedule.Cron == "" {
+			return fmt.Errorf("schedule %s: cron is required", schedule.Name)
+		}
+	}
+
+	return nil
+}
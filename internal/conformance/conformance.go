@@ -0,0 +1,337 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	pb "github.com/maestro/maestro.go/pkg/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const unknownMethod = "__maestro_conformance_unknown__"
+
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusFail Status = "fail"
+	StatusSkip Status = "skip"
+)
+
+type Options struct {
+	Endpoint          string
+	Method            string
+	CompensateMethod  string
+	Input             map[string]interface{}
+	Timeout           time.Duration
+	DeadlineTolerance time.Duration
+}
+
+type CheckResult struct {
+	Name     string        `json:"name"`
+	Status   Status        `json:"status"`
+	Detail   string        `json:"detail,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+type Report struct {
+	Endpoint string        `json:"endpoint"`
+	Checks   []CheckResult `json:"checks"`
+}
+
+func (r *Report) Passed() bool {
+	for _, check := range r.Checks {
+		if check.Status == StatusFail {
+			return false
+		}
+	}
+	return true
+}
+
+type verifier struct {
+	opts   Options
+	client pb.MaestroServiceClient
+}
+
+type check struct {
+	name string
+	run  func(ctx context.Context) (Status, string)
+}
+
+func Verify(ctx context.Context, opts Options) (*Report, error) {
+	if opts.Endpoint == "" {
+		return nil, fmt.Errorf("endpoint is required")
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	if opts.DeadlineTolerance <= 0 {
+		opts.DeadlineTolerance = 500 * time.Millisecond
+	}
+	if opts.Input == nil {
+		opts.Input = map[string]interface{}{}
+	}
+
+	conn, err := grpc.NewClient(opts.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", opts.Endpoint, err)
+	}
+	defer conn.Close()
+
+	v := &verifier{opts: opts, client: pb.NewMaestroServiceClient(conn)}
+
+	checks := []check{
+		{"health_check", v.checkHealth},
+		{"unknown_method", v.checkUnknownMethod},
+		{"invalid_payload", v.checkInvalidPayload},
+		{"execute", v.checkExecute},
+		{"execute_idempotency", v.checkExecuteIdempotency},
+		{"deadline", v.checkDeadline},
+		{"compensate", v.checkCompensate},
+		{"compensate_idempotency", v.checkCompensateIdempotency},
+	}
+
+	report := &Report{Endpoint: opts.Endpoint}
+	for _, c := range checks {
+		start := time.Now()
+		status, detail := c.run(ctx)
+		report.Checks = append(report.Checks, CheckResult{
+			Name:     c.name,
+			Status:   status,
+			Detail:   detail,
+			Duration: time.Since(start),
+		})
+	}
+
+	return report, nil
+}
+
+func (v *verifier) checkHealth(ctx context.Context) (Status, string) {
+	ctx, cancel := context.WithTimeout(ctx, v.opts.Timeout)
+	defer cancel()
+
+	resp, err := v.client.HealthCheck(ctx, &pb.Empty{})
+	if err != nil {
+		return StatusFail, fmt.Sprintf("HealthCheck failed: %v", err)
+	}
+	if !resp.Healthy {
+		return StatusFail, fmt.Sprintf("service reported unhealthy: %s", resp.Message)
+	}
+	if resp.CheckedAt == nil {
+		return StatusPass, "healthy (checked_at not set)"
+	}
+	return StatusPass, "healthy"
+}
+
+func (v *verifier) checkUnknownMethod(ctx context.Context) (Status, string) {
+	req, err := v.request(unknownMethod, v.opts.Input, uuid.New().String())
+	if err != nil {
+		return StatusFail, err.Error()
+	}
+
+	resp, err := v.execute(ctx, req)
+	return expectRejection("unknown method", resp, err)
+}
+
+func (v *verifier) checkInvalidPayload(ctx context.Context) (Status, string) {
+	if v.opts.Method == "" {
+		return StatusSkip, "no --method given"
+	}
+
+	req, err := v.request(v.opts.Method, v.opts.Input, uuid.New().String())
+	if err != nil {
+		return StatusFail, err.Error()
+	}
+	req.Payload, err = anypb.New(&emptypb.Empty{})
+	if err != nil {
+		return StatusFail, err.Error()
+	}
+
+	resp, err := v.execute(ctx, req)
+	return expectRejection("payload that is not a google.protobuf.Struct", resp, err)
+}
+
+func (v *verifier) checkExecute(ctx context.Context) (Status, string) {
+	if v.opts.Method == "" {
+		return StatusSkip, "no --method given"
+	}
+
+	req, err := v.request(v.opts.Method, v.opts.Input, uuid.New().String())
+	if err != nil {
+		return StatusFail, err.Error()
+	}
+
+	resp, err := v.execute(ctx, req)
+	if err != nil {
+		return StatusFail, fmt.Sprintf("Execute failed: %v", err)
+	}
+	if !resp.Success {
+		return StatusFail, fmt.Sprintf("Execute returned success=false: %s", resp.Error)
+	}
+	if resp.Data != nil && !resp.Data.MessageIs(&structpb.Struct{}) {
+		return StatusFail, fmt.Sprintf("data must be a google.protobuf.Struct, got %s", resp.Data.TypeUrl)
+	}
+	return StatusPass, "returned success"
+}
+
+func (v *verifier) checkExecuteIdempotency(ctx context.Context) (Status, string) {
+	if v.opts.Method == "" {
+		return StatusSkip, "no --method given"
+	}
+	return v.checkRepeated(ctx, v.opts.Method, "Execute", v.execute)
+}
+
+func (v *verifier) checkCompensate(ctx context.Context) (Status, string) {
+	if v.opts.CompensateMethod == "" {
+		return StatusSkip, "no --compensate-method given"
+	}
+
+	req, err := v.request(v.opts.CompensateMethod, v.opts.Input, uuid.New().String())
+	if err != nil {
+		return StatusFail, err.Error()
+	}
+
+	resp, err := v.compensate(ctx, req)
+	if err != nil {
+		return StatusFail, fmt.Sprintf("Compensate failed: %v", err)
+	}
+	if !resp.Success {
+		return StatusFail, fmt.Sprintf("Compensate returned success=false: %s", resp.Error)
+	}
+	return StatusPass, "returned success"
+}
+
+func (v *verifier) checkCompensateIdempotency(ctx context.Context) (Status, string) {
+	if v.opts.CompensateMethod == "" {
+		return StatusSkip, "no --compensate-method given"
+	}
+	return v.checkRepeated(ctx, v.opts.CompensateMethod, "Compensate", v.compensate)
+}
+
+func (v *verifier) checkRepeated(
+	ctx context.Context,
+	method, rpc string,
+	call func(context.Context, *pb.ServiceRequest) (*pb.ServiceResponse, error),
+) (Status, string) {
+	req, err := v.request(method, v.opts.Input, uuid.New().String())
+	if err != nil {
+		return StatusFail, err.Error()
+	}
+
+	first, err := call(ctx, req)
+	if err != nil {
+		return StatusFail, fmt.Sprintf("first %s failed: %v", rpc, err)
+	}
+	second, err := call(ctx, req)
+	if err != nil {
+		return StatusFail, fmt.Sprintf("repeated %s with the same correlation ID failed: %v", rpc, err)
+	}
+
+	if first.Success != second.Success {
+		return StatusFail, fmt.Sprintf("repeated %s with the same correlation ID returned success=%t, then success=%t",
+			rpc, first.Success, second.Success)
+	}
+	if !proto.Equal(first.Data, second.Data) {
+		return StatusFail, fmt.Sprintf("repeated %s with the same correlation ID returned different data", rpc)
+	}
+	return StatusPass, "same response for a repeated correlation ID"
+}
+
+func (v *verifier) checkDeadline(ctx context.Context) (Status, string) {
+	if v.opts.Method == "" {
+		return StatusSkip, "no --method given"
+	}
+
+	req, err := v.request(v.opts.Method, v.opts.Input, uuid.New().String())
+	if err != nil {
+		return StatusFail, err.Error()
+	}
+
+	deadline := time.Millisecond
+	ctx, cancel := context.WithTimeout(ctx, deadline)
+	defer cancel()
+
+	start := time.Now()
+	_, err = v.client.Execute(v.outgoing(ctx, req), req)
+	elapsed := time.Since(start)
+
+	if elapsed > deadline+v.opts.DeadlineTolerance {
+		return StatusFail, fmt.Sprintf("call with a %s deadline took %s", deadline, elapsed.Round(time.Millisecond))
+	}
+
+	switch code := status.Code(err); code {
+	case codes.OK, codes.DeadlineExceeded, codes.Canceled:
+		return StatusPass, fmt.Sprintf("deadline honoured (%s)", code)
+	default:
+		return StatusFail, fmt.Sprintf("expected DEADLINE_EXCEEDED, got %s: %v", code, err)
+	}
+}
+
+func expectRejection(what string, resp *pb.ServiceResponse, err error) (Status, string) {
+	if err != nil {
+		switch code := status.Code(err); code {
+		case codes.InvalidArgument, codes.NotFound, codes.Unimplemented, codes.FailedPrecondition:
+			return StatusPass, fmt.Sprintf("rejected with %s", code)
+		default:
+			return StatusFail, fmt.Sprintf("%s should be rejected with success=false or INVALID_ARGUMENT, NOT_FOUND or UNIMPLEMENTED, got %s", what, code)
+		}
+	}
+	if resp.Success {
+		return StatusFail, fmt.Sprintf("%s returned success=true", what)
+	}
+	if resp.Error == "" {
+		return StatusFail, fmt.Sprintf("%s returned success=false without an error message", what)
+	}
+	return StatusPass, "rejected with success=false"
+}
+
+func (v *verifier) request(method string, input map[string]interface{}, correlationID string) (*pb.ServiceRequest, error) {
+	payload, err := structpb.NewStruct(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create struct payload: %w", err)
+	}
+
+	payloadAny, err := anypb.New(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create any payload: %w", err)
+	}
+
+	return &pb.ServiceRequest{
+		Method:        method,
+		Payload:       payloadAny,
+		Headers:       make(map[string]string),
+		CorrelationId: correlationID,
+		WorkflowId:    "conformance",
+		StepId:        "conformance",
+	}, nil
+}
+
+func (v *verifier) outgoing(ctx context.Context, req *pb.ServiceRequest) context.Context {
+	return metadata.NewOutgoingContext(ctx, metadata.New(map[string]string{
+		"workflow-id":    req.WorkflowId,
+		"step-id":        req.StepId,
+		"correlation-id": req.CorrelationId,
+	}))
+}
+
+func (v *verifier) execute(ctx context.Context, req *pb.ServiceRequest) (*pb.ServiceResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, v.opts.Timeout)
+	defer cancel()
+	return v.client.Execute(v.outgoing(ctx, req), req)
+}
+
+func (v *verifier) compensate(ctx context.Context, req *pb.ServiceRequest) (*pb.ServiceResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, v.opts.Timeout)
+	defer cancel()
+	return v.client.Compensate(v.outgoing(ctx, req), req)
+}
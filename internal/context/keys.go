@@ -3,7 +3,23 @@ package context
 type Key string
 
 const (
-	WorkflowID   Key = "workflow_id"
-	WorkflowName Key = "workflow_name"
-	StepID       Key = "step_id"
+	WorkflowID       Key = "workflow_id"
+	WorkflowName     Key = "workflow_name"
+	Namespace        Key = "namespace"
+	StepID           Key = "step_id"
+	Environment      Key = "environment"
+	Capture          Key = "capture"
+	AssignedID       Key = "assigned_workflow_id"
+	FencingToken     Key = "fencing_token"
+	Shadow           Key = "shadow_run"
+	Callback         Key = "callback_url"
+	StepClock        Key = "step_clock"
+	Tags             Key = "tags"
+	Rerun            Key = "rerun"
+	DryRun           Key = "dry_run"
+	Idempotency      Key = "idempotency_key"
+	Iteration        Key = "iteration"
+	Redactor         Key = "redactor"
+	RequestedVersion Key = "requested_version"
+	WorkflowVersion  Key = "workflow_version"
 )
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	AlertWorkflowFailed         = "failed"
+	AlertWorkflowCompensated    = "compensated"
+	AlertCompensationUnfinished = "compensation_unfinished"
+	AlertSLABreached            = "sla_breached"
+)
+
+const (
+	SeverityCritical = "critical"
+	SeverityError    = "error"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+var defaultSeverities = map[string]string{
+	AlertWorkflowFailed:         SeverityError,
+	AlertWorkflowCompensated:    SeverityWarning,
+	AlertCompensationUnfinished: SeverityCritical,
+	AlertSLABreached:            SeverityWarning,
+}
+
+type AlertingConfig struct {
+	PagerDutyService string            `yaml:"pagerduty_service,omitempty" json:"pagerduty_service,omitempty"`
+	SLA              Duration          `yaml:"sla,omitempty" json:"sla,omitempty"`
+	SeverityMap      map[string]string `yaml:"severity_map,omitempty" json:"severity_map,omitempty"`
+}
+
+type Alert struct {
+	Event            string        `json:"event"`
+	Severity         string        `json:"severity"`
+	Summary          string        `json:"summary"`
+	WorkflowID       string        `json:"workflow_id"`
+	WorkflowName     string        `json:"workflow_name"`
+	Namespace        string        `json:"namespace,omitempty"`
+	PagerDutyService string        `json:"pagerduty_service,omitempty"`
+	Error            string        `json:"error,omitempty"`
+	Duration         time.Duration `json:"duration"`
+	OccurredAt       time.Time     `json:"occurred_at"`
+}
+
+func IsAlertEvent(event string) bool {
+	_, ok := defaultSeverities[event]
+	return ok
+}
+
+func IsAlertSeverity(severity string) bool {
+	switch severity {
+	case SeverityCritical, SeverityError, SeverityWarning, SeverityInfo:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *AlertingConfig) Severity(event string) string {
+	if c != nil {
+		if severity, ok := c.SeverityMap[event]; ok {
+			return severity
+		}
+	}
+	return defaultSeverities[event]
+}
+
+func (w *Workflow) Alerts(workflowID, namespace string, result *WorkflowResult) []*Alert {
+	duration := result.CompletedAt.Sub(result.StartedAt)
+
+	var events []string
+	switch result.Status {
+	case WorkflowStatusFailed:
+		if len(result.UnfinishedCompensations) > 0 {
+			events = append(events, AlertCompensationUnfinished)
+		} else {
+			events = append(events, AlertWorkflowFailed)
+		}
+	case WorkflowStatusCompensated:
+		events = append(events, AlertWorkflowCompensated)
+	}
+	if w.Alerting != nil && w.Alerting.SLA.Duration > 0 && duration > w.Alerting.SLA.Duration {
+		events = append(events, AlertSLABreached)
+	}
+
+	alerts := make([]*Alert, 0, len(events))
+	for _, event := range events {
+		alert := &Alert{
+			Event:        event,
+			Severity:     w.Alerting.Severity(event),
+			WorkflowID:   workflowID,
+			WorkflowName: w.Name,
+			Namespace:    namespace,
+			Duration:     duration,
+			OccurredAt:   result.CompletedAt,
+		}
+		if w.Alerting != nil {
+			alert.PagerDutyService = w.Alerting.PagerDutyService
+		}
+		if result.Error != nil {
+			alert.Error = result.Error.Error()
+		}
+
+		switch event {
+		case AlertSLABreached:
+			alert.Summary = fmt.Sprintf("Workflow %s took %s, over its %s SLA", w.Name, duration.Round(time.Millisecond), w.Alerting.SLA.Duration)
+		case AlertCompensationUnfinished:
+			alert.Summary = fmt.Sprintf("Workflow %s failed and %d compensations did not finish", w.Name, len(result.UnfinishedCompensations))
+		default:
+			alert.Summary = fmt.Sprintf("Workflow %s %s", w.Name, event)
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts
+}
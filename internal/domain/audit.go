@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	AuditStepOverridden = "step_overridden"
+	AuditStepFailed     = "step_failed"
+)
+
+var ErrStepNotActive = errors.New("step is not running or held")
+
+type AuditEntry struct {
+	Time     time.Time   `json:"time"`
+	Action   string      `json:"action"`
+	StepID   string      `json:"step_id"`
+	Operator string      `json:"operator,omitempty"`
+	Reason   string      `json:"reason,omitempty"`
+	Output   interface{} `json:"output,omitempty"`
+}
+
+type StepOverride struct {
+	Fail     bool
+	Output   interface{}
+	Operator string
+	Reason   string
+}
+
+type StepFailedByOperatorError struct {
+	StepID   string
+	Operator string
+	Reason   string
+}
+
+func (e *StepFailedByOperatorError) Error() string {
+	msg := fmt.Sprintf("step %s failed by operator", e.StepID)
+	if e.Operator != "" {
+		msg = fmt.Sprintf("step %s failed by %s", e.StepID, e.Operator)
+	}
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+func (c *ExecutionContext) AppendAudit(entry AuditEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Audit = append(c.Audit, entry)
+}
+
+func (c *ExecutionContext) CopyAudit() []AuditEntry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Clone(c.Audit)
+}
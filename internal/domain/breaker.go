@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type BreakerPolicy struct {
+	Failures int      `yaml:"failures" json:"failures"`
+	Cooldown Duration `yaml:"cooldown,omitempty" json:"cooldown,omitempty"`
+}
+
+const DefaultBreakerCooldown = time.Minute
+
+func (p *BreakerPolicy) CooldownPeriod() time.Duration {
+	if p.Cooldown.Duration <= 0 {
+		return DefaultBreakerCooldown
+	}
+	return p.Cooldown.Duration
+}
+
+type BreakerOpenError struct {
+	Workflow  string    `json:"workflow"`
+	Step      string    `json:"step"`
+	Failures  int       `json:"failures"`
+	LastError string    `json:"last_error"`
+	Since     time.Time `json:"since"`
+	Until     time.Time `json:"until"`
+}
+
+func (e *BreakerOpenError) Error() string {
+	return fmt.Sprintf("workflow %s is short-circuited since %s after step %s failed in %d executions in a row (last error: %s); new executions are refused until %s",
+		e.Workflow, e.Since.Format(time.RFC3339), e.Step, e.Failures, e.LastError, e.Until.Format(time.RFC3339))
+}
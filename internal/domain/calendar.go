@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const calendarHorizon = 8 * 24 * time.Hour
+
+var (
+	ErrOutsideWindow = errors.New("outside the workflow's execution windows")
+	ErrThrottled     = errors.New("workflow execution rate exceeded")
+)
+
+var weekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+type Calendar struct {
+	Timezone string           `yaml:"timezone,omitempty" json:"timezone,omitempty"`
+	Windows  []CalendarWindow `yaml:"windows,omitempty" json:"windows,omitempty"`
+	Throttle []CalendarWindow `yaml:"throttle,omitempty" json:"throttle,omitempty"`
+}
+
+type CalendarWindow struct {
+	Days      []string `yaml:"days,omitempty" json:"days,omitempty"`
+	From      string   `yaml:"from" json:"from"`
+	To        string   `yaml:"to" json:"to"`
+	PerMinute int      `yaml:"per_minute,omitempty" json:"per_minute,omitempty"`
+}
+
+func (c *Calendar) Location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(c.Timezone)
+}
+
+func (c *Calendar) Open(t time.Time) bool {
+	if len(c.Windows) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(c.Windows, func(w CalendarWindow) bool { return w.Contains(t) })
+}
+
+func (c *Calendar) NextOpen(t time.Time) time.Time {
+	next := t.Truncate(time.Minute)
+	for end := t.Add(calendarHorizon); next.Before(end); next = next.Add(time.Minute) {
+		if next.After(t) && c.Open(next) {
+			return next
+		}
+	}
+	return next
+}
+
+func (c *Calendar) Limit(t time.Time) int {
+	limit := 0
+	for _, w := range c.Throttle {
+		if w.Contains(t) && (limit == 0 || w.PerMinute < limit) {
+			limit = w.PerMinute
+		}
+	}
+	return limit
+}
+
+func (w CalendarWindow) Validate() error {
+	from, err := parseClock(w.From)
+	if err != nil {
+		return fmt.Errorf("from: %w", err)
+	}
+	to, err := parseClock(w.To)
+	if err != nil {
+		return fmt.Errorf("to: %w", err)
+	}
+	if from == to {
+		return fmt.Errorf("from and to are both %s", w.From)
+	}
+	for _, day := range w.Days {
+		if !slices.Contains(weekdays, strings.ToLower(day)) {
+			return fmt.Errorf("unknown day %q, expected one of %s", day, strings.Join(weekdays, ", "))
+		}
+	}
+	if w.PerMinute < 0 {
+		return fmt.Errorf("per_minute must not be negative")
+	}
+	return nil
+}
+
+func (w CalendarWindow) Contains(t time.Time) bool {
+	from, err := parseClock(w.From)
+	if err != nil {
+		return false
+	}
+	to, err := parseClock(w.To)
+	if err != nil {
+		return false
+	}
+
+	minute := t.Hour()*60 + t.Minute()
+	if from < to {
+		return minute >= from && minute < to && w.on(t.Weekday())
+	}
+	if minute >= from {
+		return w.on(t.Weekday())
+	}
+	return minute < to && w.on((t.Weekday()+6)%7)
+}
+
+func (w CalendarWindow) on(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(w.Days, func(d string) bool { return strings.EqualFold(d, weekdays[day]) })
+}
+
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+type CalendarError struct {
+	Workflow string
+	RetryAt  time.Time
+	err      error
+}
+
+func NewCalendarError(workflow string, retryAt time.Time, err error) *CalendarError {
+	return &CalendarError{Workflow: workflow, RetryAt: retryAt, err: err}
+}
+
+func (e *CalendarError) Error() string {
+	return fmt.Sprintf("workflow %s: %v, next start at %s", e.Workflow, e.err, e.RetryAt.Format(time.RFC3339))
+}
+
+func (e *CalendarError) Unwrap() error {
+	return e.err
+}
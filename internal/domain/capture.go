@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+type Capture struct {
+	WorkflowID   string             `json:"workflow_id"`
+	WorkflowName string             `json:"workflow_name"`
+	Exchanges    []CapturedExchange `json:"exchanges"`
+
+	mu sync.Mutex
+}
+
+type CapturedExchange struct {
+	WorkflowID string            `json:"workflow_id"`
+	StepID     string            `json:"step_id"`
+	Service    string            `json:"service"`
+	Type       string            `json:"type"`
+	Endpoint   string            `json:"endpoint"`
+	Method     string            `json:"method"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Request    any               `json:"request"`
+	Response   any               `json:"response,omitempty"`
+	Error      string            `json:"error,omitempty"`
+	StartedAt  time.Time         `json:"started_at"`
+	Duration   Duration          `json:"duration"`
+}
+
+func (c *Capture) Record(exchange CapturedExchange) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Exchanges = append(c.Exchanges, exchange)
+}
+
+func (c *Capture) CopyExchanges() []CapturedExchange {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.Exchanges)
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type ServiceCatalog struct {
+	Services map[string]Service `yaml:"services" json:"services"`
+}
+
+type Imports []string
+
+func (i *Imports) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var single string
+	if err := unmarshal(&single); err == nil {
+		*i = Imports{single}
+		return nil
+	}
+	var list []string
+	if err := unmarshal(&list); err != nil {
+		return fmt.Errorf("import must be a file or a list of files")
+	}
+	*i = list
+	return nil
+}
+
+func (i *Imports) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*i = Imports{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("import must be a file or a list of files")
+	}
+	*i = list
+	return nil
+}
+
+const (
+	ServiceMergeReuse     = "reuse"
+	ServiceMergeError     = "error"
+	ServiceMergeNamespace = "namespace"
+)
+
+var ServiceMergeModes = []string{ServiceMergeReuse, ServiceMergeError, ServiceMergeNamespace}
+
+func WorkflowServiceName(workflow, service string) string {
+	return workflow + "/" + service
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	DeadLetterTrigger = "trigger"
+	DeadLetterWebhook = "webhook"
+)
+
+var (
+	ErrDeadLettered       = errors.New("message parked in the dead-letter queue")
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+	ErrUnknownDeadLetter  = errors.New("dead letter kind cannot be replayed")
+)
+
+type TriggerMessage struct {
+	Source   string
+	Workflow string
+	Payload  []byte
+	Headers  map[string]string
+}
+
+type DeadLetter struct {
+	ID            string            `json:"id"`
+	Kind          string            `json:"kind"`
+	Source        string            `json:"source"`
+	Workflow      string            `json:"workflow,omitempty"`
+	WorkflowID    string            `json:"workflow_id,omitempty"`
+	Namespace     string            `json:"namespace,omitempty"`
+	Payload       []byte            `json:"payload"`
+	Headers       map[string]string `json:"headers,omitempty"`
+	Reason        string            `json:"reason"`
+	Attempts      int               `json:"attempts"`
+	CreatedAt     time.Time         `json:"created_at"`
+	LastAttemptAt time.Time         `json:"last_attempt_at"`
+}
+
+type DeadLetterFilter struct {
+	Kind   string
+	Source string
+	Limit  int
+}
+
+func (l *DeadLetter) Trigger() *TriggerMessage {
+	return &TriggerMessage{
+		Source:   l.Source,
+		Workflow: l.Workflow,
+		Payload:  l.Payload,
+		Headers:  l.Headers,
+	}
+}
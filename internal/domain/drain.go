@@ -0,0 +1,5 @@
+package domain
+
+import "errors"
+
+var ErrDraining = errors.New("orchestrator is draining and accepts no new executions")
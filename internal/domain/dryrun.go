@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"slices"
+	"sync"
+)
+
+const (
+	PlannedCall     = "call"
+	PlannedWorkflow = "workflow"
+	PlannedLock     = "lock"
+	PlannedKV       = "kv"
+	PlannedState    = "transition"
+	PlannedWait     = "wait"
+	PlannedAssert   = "assert"
+	PlannedMetric   = "metric"
+)
+
+type PlannedStep struct {
+	StepID    string            `json:"step_id"`
+	Kind      string            `json:"kind,omitempty"`
+	Service   string            `json:"service,omitempty"`
+	Type      string            `json:"type,omitempty"`
+	Endpoint  string            `json:"endpoint,omitempty"`
+	Method    string            `json:"method,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Input     map[string]any    `json:"input,omitempty"`
+	Skipped   bool              `json:"skipped,omitempty"`
+	Condition string            `json:"condition,omitempty"`
+	Error     string            `json:"error,omitempty"`
+}
+
+type DryRun struct {
+	mu    sync.Mutex
+	steps []PlannedStep
+}
+
+func (d *DryRun) Record(step PlannedStep) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.steps = append(d.steps, step)
+}
+
+func (d *DryRun) Steps() []PlannedStep {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return slices.Clone(d.steps)
+}
+
+func (d *DryRun) Failed() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return slices.ContainsFunc(d.steps, func(step PlannedStep) bool {
+		return step.Error != ""
+	})
+}
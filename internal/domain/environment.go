@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"fmt"
+	"maps"
+)
+
+type Environment struct {
+	Services map[string]ServiceOverride `yaml:"services,omitempty" json:"services,omitempty"`
+}
+
+type ServiceOverride struct {
+	Endpoint string            `yaml:"endpoint,omitempty" json:"endpoint,omitempty"`
+	Timeout  Duration          `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	Retry    *RetryConfig      `yaml:"retry,omitempty" json:"retry,omitempty"`
+	Metadata map[string]string `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+	TLS      *TLSConfig        `yaml:"tls,omitempty" json:"tls,omitempty"`
+}
+
+func EnvironmentServiceName(service, environment string) string {
+	return service + "@" + environment
+}
+
+func (s Service) WithOverride(o ServiceOverride) Service {
+	if o.Endpoint != "" {
+		s.Endpoint = o.Endpoint
+	}
+	if o.Timeout.Duration > 0 {
+		s.Timeout = o.Timeout
+	}
+	if o.Retry != nil {
+		s.Retry = o.Retry
+	}
+	if o.TLS != nil {
+		s.TLS = o.TLS
+	}
+	if len(o.Metadata) > 0 {
+		metadata := maps.Clone(s.Metadata)
+		if metadata == nil {
+			metadata = make(map[string]string, len(o.Metadata))
+		}
+		maps.Copy(metadata, o.Metadata)
+		s.Metadata = metadata
+	}
+	return s
+}
+
+func (w *Workflow) ForEnvironment(name string) (*Workflow, error) {
+	env, ok := w.Environments[name]
+	if !ok {
+		return nil, fmt.Errorf("workflow %s has no environment %s", w.Name, name)
+	}
+	return w.WithServiceOverrides(env.Services), nil
+}
+
+func (w *Workflow) WithServiceOverrides(overrides map[string]ServiceOverride) *Workflow {
+	services := maps.Clone(w.Services)
+	for name, override := range overrides {
+		if service, ok := services[name]; ok {
+			services[name] = service.WithOverride(override)
+		}
+	}
+
+	scoped := *w
+	scoped.Services = services
+	return &scoped
+}
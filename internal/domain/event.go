@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+type EventType string
+
+const (
+	EventWorkflowStarted        EventType = "WorkflowStarted"
+	EventWorkflowSucceeded      EventType = "WorkflowSucceeded"
+	EventWorkflowFailed         EventType = "WorkflowFailed"
+	EventWorkflowCancelled      EventType = "WorkflowCancelled"
+	EventStepStarted            EventType = "StepStarted"
+	EventStepSucceeded          EventType = "StepSucceeded"
+	EventStepFailed             EventType = "StepFailed"
+	EventStepSkipped            EventType = "StepSkipped"
+	EventStepHeld               EventType = "StepHeld"
+	EventStepOverridden         EventType = "StepOverridden"
+	EventCompensationStarted    EventType = "CompensationStarted"
+	EventCompensationCompleted  EventType = "CompensationCompleted"
+	EventCompensationFailed     EventType = "CompensationFailed"
+	EventStepCompensated        EventType = "StepCompensated"
+	EventStepCompensationFailed EventType = "StepCompensationFailed"
+)
+
+type Event struct {
+	Type         EventType `json:"type"`
+	WorkflowID   string    `json:"workflow_id"`
+	WorkflowName string    `json:"workflow_name,omitempty"`
+	Namespace    string    `json:"namespace,omitempty"`
+	StepID       string    `json:"step_id,omitempty"`
+	Service      string    `json:"service,omitempty"`
+	Status       string    `json:"status,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	Fallback     string    `json:"fallback,omitempty"`
+	Duration     *Duration `json:"duration,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func WorkflowFinishedEvent(status WorkflowStatus) EventType {
+	switch status {
+	case WorkflowStatusSuccess:
+		return EventWorkflowSucceeded
+	case WorkflowStatusCancelled:
+		return EventWorkflowCancelled
+	default:
+		return EventWorkflowFailed
+	}
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "slices"
+
+const (
+	FailoverUnavailable      = "unavailable"
+	FailoverCircuitOpen      = "circuit_open"
+	FailoverDeadlineExceeded = "deadline_exceeded"
+)
+
+var defaultFailoverTriggers = []string{FailoverUnavailable, FailoverCircuitOpen}
+
+type FailoverPolicy struct {
+	On      []string         `yaml:"on,omitempty" json:"on,omitempty"`
+	Regions []FailoverRegion `yaml:"regions" json:"regions"`
+}
+
+type FailoverRegion struct {
+	Name     string `yaml:"name" json:"name"`
+	Endpoint string `yaml:"endpoint" json:"endpoint"`
+}
+
+func (p *FailoverPolicy) Triggers(reason string) bool {
+	if p == nil || reason == "" {
+		return false
+	}
+	if len(p.On) == 0 {
+		return slices.Contains(defaultFailoverTriggers, reason)
+	}
+	return slices.Contains(p.On, reason)
+}
+
+func RegionServiceName(service, region string) string {
+	return service + "#" + region
+}
+
+func (s Service) RegionServices(name string) map[string]Service {
+	if s.Failover == nil {
+		return nil
+	}
+	services := make(map[string]Service, len(s.Failover.Regions))
+	for _, region := range s.Failover.Regions {
+		secondary := s
+		secondary.Endpoint = region.Endpoint
+		secondary.Region = region.Name
+		secondary.Failover = nil
+		services[RegionServiceName(name, region.Name)] = secondary
+	}
+	return services
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+var ErrIdempotencyConflict = errors.New("idempotency key was already used with a different input or workflow version")
+
+type IdempotencyRecord struct {
+	Workflow   string    `json:"workflow"`
+	Version    string    `json:"version"`
+	Key        string    `json:"key"`
+	WorkflowID string    `json:"workflow_id"`
+	InputHash  string    `json:"input_hash"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type DuplicateExecutionError struct {
+	Key        string
+	WorkflowID string
+}
+
+func (e *DuplicateExecutionError) Error() string {
+	return fmt.Sprintf("idempotency key %s already started execution %s", e.Key, e.WorkflowID)
+}
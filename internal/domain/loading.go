@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+type WorkflowLoadFailure struct {
+	File string
+	Err  error
+}
+
+type WorkflowDirError struct {
+	Failures []WorkflowLoadFailure
+}
+
+func (e *WorkflowDirError) Error() string {
+	failures := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		failures[i] = fmt.Sprintf("%s: %v", f.File, f.Err)
+	}
+	return fmt.Sprintf("%d workflow file(s) failed to load: %s", len(e.Failures), strings.Join(failures, "; "))
+}
+
+func (e *WorkflowDirError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, f := range e.Failures {
+		errs[i] = f.Err
+	}
+	return errs
+}
@@ -0,0 +1,5 @@
+package domain
+
+import "errors"
+
+var ErrLockLost = errors.New("lock is no longer held by this owner")
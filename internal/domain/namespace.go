@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrNamespaceIsolation = errors.New("cross-namespace access denied")
+
+func CheckNamespace(from, to string) error {
+	if from == to {
+		return nil
+	}
+	return fmt.Errorf("%w: namespace %s cannot access namespace %s", ErrNamespaceIsolation, namespaceName(from), namespaceName(to))
+}
+
+func KVScope(tenant, workflowName string) string {
+	if tenant == "" {
+		return "/" + workflowName
+	}
+	return tenant
+}
+
+func ScopedKVNamespace(scope, namespace string) string {
+	return scope + "/" + namespace
+}
+
+func namespaceName(namespace string) string {
+	if namespace == "" {
+		return "(default)"
+	}
+	return namespace
+}
@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	StepTimingSucceeded = "succeeded"
+	StepTimingFailed    = "failed"
+	StepTimingSkipped   = "skipped"
+)
+
+type StepTiming struct {
+	StepID      string    `json:"step_id"`
+	Service     string    `json:"service,omitempty"`
+	DependsOn   []string  `json:"depends_on,omitempty"`
+	Status      string    `json:"status"`
+	ReadyAt     time.Time `json:"ready_at"`
+	FinishedAt  time.Time `json:"finished_at"`
+	Duration    Duration  `json:"duration"`
+	SlotWait    Duration  `json:"slot_wait"`
+	ServiceTime Duration  `json:"service_time"`
+}
+
+type StepClock struct {
+	mu          sync.Mutex
+	slotWait    time.Duration
+	serviceTime time.Duration
+}
+
+func (c *StepClock) AddSlotWait(d time.Duration) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.slotWait += d
+}
+
+func (c *StepClock) AddServiceTime(d time.Duration) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.serviceTime += d
+}
+
+func (c *StepClock) Totals() (slotWait, serviceTime time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.slotWait, c.serviceTime
+}
+
+type ExecutionPlan struct {
+	Steps                []StepTiming `json:"steps"`
+	Concurrent           [][]string   `json:"concurrent"`
+	MaxConcurrency       int          `json:"max_concurrency"`
+	SlotWait             Duration     `json:"slot_wait"`
+	ServiceTime          Duration     `json:"service_time"`
+	CriticalPath         []string     `json:"critical_path"`
+	CriticalPathDuration Duration     `json:"critical_path_duration"`
+}
+
+func NewExecutionPlan(timings []StepTiming) *ExecutionPlan {
+	if len(timings) == 0 {
+		return nil
+	}
+
+	steps := slices.Clone(timings)
+	slices.SortStableFunc(steps, func(a, b StepTiming) int {
+		return a.ReadyAt.Compare(b.ReadyAt)
+	})
+
+	plan := &ExecutionPlan{Steps: steps}
+
+	var groupEnd time.Time
+	for _, step := range steps {
+		plan.SlotWait.Duration += step.SlotWait.Duration
+		plan.ServiceTime.Duration += step.ServiceTime.Duration
+
+		if len(plan.Concurrent) == 0 || !step.ReadyAt.Before(groupEnd) {
+			plan.Concurrent = append(plan.Concurrent, nil)
+			groupEnd = step.FinishedAt
+		}
+		last := len(plan.Concurrent) - 1
+		plan.Concurrent[last] = append(plan.Concurrent[last], step.StepID)
+		if step.FinishedAt.After(groupEnd) {
+			groupEnd = step.FinishedAt
+		}
+	}
+
+	plan.MaxConcurrency = maxOverlap(steps)
+	plan.CriticalPath, plan.CriticalPathDuration = criticalPath(steps)
+	return plan
+}
+
+func maxOverlap(steps []StepTiming) int {
+	type edge struct {
+		at    time.Time
+		delta int
+	}
+
+	edges := make([]edge, 0, 2*len(steps))
+	for _, step := range steps {
+		edges = append(edges, edge{step.ReadyAt, 1}, edge{step.FinishedAt, -1})
+	}
+	slices.SortFunc(edges, func(a, b edge) int {
+		if c := a.at.Compare(b.at); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.delta, b.delta)
+	})
+
+	running, peak := 0, 0
+	for _, e := range edges {
+		running += e.delta
+		peak = max(peak, running)
+	}
+	return peak
+}
+
+func criticalPath(steps []StepTiming) ([]string, Duration) {
+	byID := make(map[string]*StepTiming, len(steps))
+	var current *StepTiming
+	for i := range steps {
+		byID[steps[i].StepID] = &steps[i]
+		if current == nil || steps[i].FinishedAt.After(current.FinishedAt) {
+			current = &steps[i]
+		}
+	}
+
+	var (
+		path  []string
+		total Duration
+		seen  = make(map[string]bool)
+	)
+	for current != nil && !seen[current.StepID] {
+		seen[current.StepID] = true
+		path = append(path, current.StepID)
+		total.Duration += current.Duration.Duration
+
+		var gate *StepTiming
+		for _, dep := range current.DependsOn {
+			if t, ok := byID[dep]; ok && (gate == nil || t.FinishedAt.After(gate.FinishedAt)) {
+				gate = t
+			}
+		}
+		current = gate
+	}
+
+	slices.Reverse(path)
+	return path, total
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPreflightTimeout     = 2 * time.Second
+	DefaultPreflightNegativeTTL = 30 * time.Second
+)
+
+type PreflightPolicy struct {
+	Reachability bool     `yaml:"reachability,omitempty" json:"reachability,omitempty"`
+	Timeout      Duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	NegativeTTL  Duration `yaml:"negative_ttl,omitempty" json:"negative_ttl,omitempty"`
+}
+
+func (p *PreflightPolicy) ProbeTimeout() time.Duration {
+	if p == nil || p.Timeout.Duration <= 0 {
+		return DefaultPreflightTimeout
+	}
+	return p.Timeout.Duration
+}
+
+func (p *PreflightPolicy) FailureTTL() time.Duration {
+	if p == nil || p.NegativeTTL.Duration <= 0 {
+		return DefaultPreflightNegativeTTL
+	}
+	return p.NegativeTTL.Duration
+}
+
+type PreflightProblem struct {
+	Service string   `json:"service"`
+	Steps   []string `json:"steps,omitempty"`
+	Problem string   `json:"problem"`
+}
+
+type PreflightError struct {
+	Workflow string             `json:"workflow"`
+	Problems []PreflightProblem `json:"problems"`
+}
+
+func (e *PreflightError) Error() string {
+	problems := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		problems[i] = fmt.Sprintf("%s (%s): %s", p.Service, strings.Join(p.Steps, ", "), p.Problem)
+	}
+	return fmt.Sprintf("workflow %s cannot start, %d service(s) failed preflight: %s",
+		e.Workflow, len(e.Problems), strings.Join(problems, "; "))
+}
+
+type PreflightStatus string
+
+const (
+	PreflightPass PreflightStatus = "pass"
+	PreflightFail PreflightStatus = "fail"
+	PreflightSkip PreflightStatus = "skip"
+)
+
+type PreflightCheck struct {
+	Name      string          `json:"name"`
+	Target    string          `json:"target"`
+	Workflows []string        `json:"workflows,omitempty"`
+	Status    PreflightStatus `json:"status"`
+	Detail    string          `json:"detail,omitempty"`
+	Duration  Duration        `json:"duration"`
+}
+
+type PreflightReport struct {
+	Workflows []string         `json:"workflows"`
+	Checks    []PreflightCheck `json:"checks"`
+}
+
+func (r *PreflightReport) Passed() bool {
+	for _, check := range r.Checks {
+		if check.Status == PreflightFail {
+			return false
+		}
+	}
+	return true
+}
+
+func (w *Workflow) ServiceReferences() map[string][]string {
+	refs := make(map[string][]string)
+	add := func(service, user string) {
+		if service != "" && !slices.Contains(refs[service], user) {
+			refs[service] = append(refs[service], user)
+		}
+	}
+
+	var walk func(steps []Step)
+	walk = func(steps []Step) {
+		for _, step := range steps {
+			add(step.Service, step.ID)
+			if c := step.Compensate; c != nil {
+				service := step.Service
+				if c.Service != "" {
+					service = c.Service
+				}
+				add(service, step.ID)
+			}
+			walk(step.Parallel)
+			if step.Foreach != nil {
+				walk(step.Foreach.Steps)
+			}
+			if step.Fallback != nil {
+				walk([]Step{*step.Fallback})
+			}
+		}
+	}
+	walk(w.Steps)
+	walk(w.Finally)
+
+	for _, hook := range w.BeforeEach {
+		add(hook.Service, "before_each")
+	}
+	for _, hook := range w.AfterEach {
+		add(hook.Service, "after_each")
+	}
+	return refs
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type QuarantinePolicy struct {
+	FailureRate   float64 `yaml:"failure_rate" json:"failure_rate"`
+	Window        int     `yaml:"window,omitempty" json:"window,omitempty"`
+	MinExecutions int     `yaml:"min_executions,omitempty" json:"min_executions,omitempty"`
+}
+
+const DefaultQuarantineWindow = 20
+
+func (p *QuarantinePolicy) WindowSize() int {
+	if p.Window <= 0 {
+		return DefaultQuarantineWindow
+	}
+	return p.Window
+}
+
+func (p *QuarantinePolicy) MinimumExecutions() int {
+	if p.MinExecutions <= 0 {
+		return p.WindowSize()
+	}
+	return min(p.MinExecutions, p.WindowSize())
+}
+
+type QuarantinedError struct {
+	Workflow   string    `json:"workflow"`
+	Since      time.Time `json:"since"`
+	Failures   int       `json:"failures"`
+	Executions int       `json:"executions"`
+}
+
+func (e *QuarantinedError) Error() string {
+	return fmt.Sprintf("workflow %s is quarantined since %s after %d of its last %d executions failed; new executions are refused until an operator resumes it",
+		e.Workflow, e.Since.Format(time.RFC3339), e.Failures, e.Executions)
+}
@@ -0,0 +1,272 @@
+package domain
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	Redacted = "[REDACTED]"
+
+	minRedactedSecretLength = 4
+)
+
+var (
+	dsnPassword  = regexp.MustCompile(`(?i)(password=)('[^']*'|\S+)`)
+	userPassword = regexp.MustCompile(`^([^:@/]+):[^@]*@`)
+)
+
+type Redactor struct {
+	fields  []*regexp.Regexp
+	paths   map[string][][]string
+	outputs map[string]string
+	secrets *secretValues
+}
+
+type secretValues struct {
+	mu     sync.RWMutex
+	values map[string]struct{}
+}
+
+func NewRedactor(fields []*regexp.Regexp) *Redactor {
+	return &Redactor{
+		fields:  fields,
+		secrets: &secretValues{values: make(map[string]struct{})},
+	}
+}
+
+func (r *Redactor) ForWorkflow(w *Workflow) *Redactor {
+	if r == nil {
+		return nil
+	}
+
+	scoped := &Redactor{
+		fields:  r.fields,
+		paths:   make(map[string][][]string),
+		outputs: make(map[string]string),
+		secrets: r.secrets,
+	}
+
+	var walk func(steps []Step)
+	walk = func(steps []Step) {
+		for i := range steps {
+			step := &steps[i]
+			if step.Output != "" {
+				scoped.outputs[step.ID] = step.Output
+			}
+			walk(step.Parallel)
+			if step.Foreach != nil {
+				walk(step.Foreach.Steps)
+			}
+			if step.Fallback != nil {
+				walk([]Step{*step.Fallback})
+			}
+		}
+	}
+	walk(w.Steps)
+	walk(w.Finally)
+
+	for _, path := range w.Sensitive {
+		root, field, _ := strings.Cut(path, ".")
+		var parts []string
+		if field != "" {
+			parts = strings.Split(field, ".")
+		}
+		scoped.paths[root] = append(scoped.paths[root], parts)
+	}
+	return scoped
+}
+
+func (r *Redactor) AddSecret(value string) {
+	if r == nil || len(value) < minRedactedSecretLength {
+		return
+	}
+	r.secrets.mu.Lock()
+	r.secrets.values[value] = struct{}{}
+	r.secrets.mu.Unlock()
+}
+
+func (r *Redactor) Input(input map[string]interface{}) map[string]interface{} {
+	if r == nil || input == nil {
+		return input
+	}
+	redacted, _ := r.redact(input, r.paths["input"]).(map[string]interface{})
+	return redacted
+}
+
+func (r *Redactor) Output(name string, value interface{}) interface{} {
+	if r == nil {
+		return value
+	}
+	return r.redact(value, r.paths[name])
+}
+
+func (r *Redactor) StepOutput(stepID string, value interface{}) interface{} {
+	if r == nil {
+		return value
+	}
+	return r.Output(r.outputs[stepID], value)
+}
+
+func (r *Redactor) Outputs(outputs map[string]interface{}) map[string]interface{} {
+	if r == nil || outputs == nil {
+		return outputs
+	}
+	redacted := make(map[string]interface{}, len(outputs))
+	for name, value := range outputs {
+		if r.sensitiveField(name) {
+			redacted[name] = Redacted
+			continue
+		}
+		redacted[name] = r.Output(name, value)
+	}
+	return redacted
+}
+
+func (r *Redactor) Value(value interface{}) interface{} {
+	if r == nil {
+		return value
+	}
+	return r.redact(value, nil)
+}
+
+func (r *Redactor) String(s string) string {
+	if r == nil || s == "" {
+		return s
+	}
+	r.secrets.mu.RLock()
+	defer r.secrets.mu.RUnlock()
+	for secret := range r.secrets.values {
+		s = strings.ReplaceAll(s, secret, Redacted)
+	}
+	return s
+}
+
+func (r *Redactor) StepResult(result *StepResult) *StepResult {
+	if r == nil || result == nil {
+		return result
+	}
+	redacted := *result
+	redacted.Output = r.StepOutput(result.StepID, result.Output)
+	if result.Error != nil {
+		if message := r.String(result.Error.Error()); message != result.Error.Error() {
+			redacted.Error = errors.New(message)
+		}
+	}
+	return &redacted
+}
+
+// PlannedStep hides secrets, sensitive fields and endpoint passwords from a
+// dry-run step before it is shown.
+func (r *Redactor) PlannedStep(step PlannedStep) PlannedStep {
+	if r == nil {
+		return step
+	}
+
+	step.Endpoint = r.String(redactEndpoint(step.Endpoint))
+	step.Method = r.String(step.Method)
+	step.Error = r.String(step.Error)
+	step.Headers = r.Headers(step.Headers)
+	if step.Input != nil {
+		step.Input, _ = r.Value(step.Input).(map[string]any)
+	}
+	return step
+}
+
+// Headers hides secrets and sensitive header values.
+func (r *Redactor) Headers(headers map[string]string) map[string]string {
+	if r == nil || headers == nil {
+		return headers
+	}
+	redacted := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if r.sensitiveField(name) {
+			redacted[name] = Redacted
+			continue
+		}
+		redacted[name] = r.String(value)
+	}
+	return redacted
+}
+
+func redactEndpoint(endpoint string) string {
+	if u, err := url.Parse(endpoint); err == nil && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			return u.Redacted()
+		}
+		return endpoint
+	}
+	endpoint = dsnPassword.ReplaceAllString(endpoint, "${1}"+Redacted)
+	return userPassword.ReplaceAllString(endpoint, "${1}:"+Redacted+"@")
+}
+
+func (r *Redactor) Execution(execution *Execution) (*Execution, error) {
+	if r == nil {
+		return execution, nil
+	}
+
+	snapshot := NewExecutionSnapshot(execution)
+	snapshot.Error = r.String(snapshot.Error)
+	snapshot.Input = r.Input(snapshot.Input)
+	snapshot.Variables, _ = r.Value(snapshot.Variables).(map[string]interface{})
+	snapshot.StepOutputs = r.Outputs(snapshot.StepOutputs)
+	snapshot.Output = r.Outputs(snapshot.Output)
+	for i := range snapshot.ExecutedSteps {
+		step := &snapshot.ExecutedSteps[i]
+		step.Output = r.StepOutput(step.StepID, step.Output)
+	}
+	return snapshot.Execution()
+}
+
+func (r *Redactor) redact(value interface{}, paths [][]string) interface{} {
+	for _, path := range paths {
+		if len(path) == 0 {
+			return Redacted
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if r.sensitiveField(key) {
+				redacted[key] = Redacted
+				continue
+			}
+			redacted[key] = r.redact(item, descend(paths, key))
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = r.redact(item, paths)
+		}
+		return redacted
+	case string:
+		return r.String(v)
+	default:
+		return value
+	}
+}
+
+func (r *Redactor) sensitiveField(name string) bool {
+	for _, field := range r.fields {
+		if field.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func descend(paths [][]string, key string) [][]string {
+	var next [][]string
+	for _, path := range paths {
+		if path[0] == key {
+			next = append(next, path[1:])
+		}
+	}
+	return next
+}
@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func testRedactor() *Redactor {
+	r := NewRedactor([]*regexp.Regexp{regexp.MustCompile(`(?i)password`)})
+	r.AddSecret("s3cr3t-token")
+	r.AddSecret("abc")
+	return r.ForWorkflow(&Workflow{
+		Sensitive: []string{"input.card.number", "charge", "profile.ssn"},
+		Steps: []Step{
+			{ID: "pay", Output: "charge"},
+			{ID: "lookup", Output: "profile"},
+		},
+	})
+}
+
+func TestRedactorInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input map[string]any
+		want  map[string]any
+	}{
+		{
+			name:  "sensitive path",
+			input: map[string]any{"card": map[string]any{"number": "4242", "brand": "visa"}},
+			want:  map[string]any{"card": map[string]any{"number": Redacted, "brand": "visa"}},
+		},
+		{
+			name:  "sensitive field name at any depth",
+			input: map[string]any{"user": map[string]any{"Password": "hunter2", "name": "ada"}},
+			want:  map[string]any{"user": map[string]any{"Password": Redacted, "name": "ada"}},
+		},
+		{
+			name:  "secret inside a string",
+			input: map[string]any{"auth": "Bearer s3cr3t-token"},
+			want:  map[string]any{"auth": "Bearer " + Redacted},
+		},
+		{
+			name:  "secrets shorter than the minimum are kept",
+			input: map[string]any{"code": "abc"},
+			want:  map[string]any{"code": "abc"},
+		},
+		{
+			name:  "lists are walked",
+			input: map[string]any{"tokens": []any{"s3cr3t-token", 7}},
+			want:  map[string]any{"tokens": []any{Redacted, 7}},
+		},
+	}
+
+	r := testRedactor()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Input(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Input() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactorStepOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		stepID string
+		value  any
+		want   any
+	}{
+		{
+			name:   "whole output",
+			stepID: "pay",
+			value:  map[string]any{"id": "ch_1"},
+			want:   Redacted,
+		},
+		{
+			name:   "field of an output",
+			stepID: "lookup",
+			value:  map[string]any{"ssn": "123-45-6789", "name": "ada"},
+			want:   map[string]any{"ssn": Redacted, "name": "ada"},
+		},
+		{
+			name:   "step without a named output",
+			stepID: "notify",
+			value:  map[string]any{"ssn": "123-45-6789"},
+			want:   map[string]any{"ssn": "123-45-6789"},
+		},
+	}
+
+	r := testRedactor()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.StepOutput(tt.stepID, tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StepOutput() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactorHeaders(t *testing.T) {
+	got := testRedactor().Headers(map[string]string{
+		"X-Password":    "hunter2",
+		"Authorization": "Bearer s3cr3t-token",
+		"Accept":        "application/json",
+	})
+	want := map[string]string{
+		"X-Password":    Redacted,
+		"Authorization": "Bearer " + Redacted,
+		"Accept":        "application/json",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Headers() = %#v, want %#v", got, want)
+	}
+}
+
+func TestRedactEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		want     string
+	}{
+		{
+			name:     "url with password",
+			endpoint: "postgres://app:pw@db:5432/orders",
+			want:     "postgres://app:xxxxx@db:5432/orders",
+		},
+		{
+			name:     "url without password",
+			endpoint: "amqp://guest@rabbit:5672/",
+			want:     "amqp://guest@rabbit:5672/",
+		},
+		{
+			name:     "dsn keyword",
+			endpoint: "host=db user=app password='p w' dbname=orders",
+			want:     "host=db user=app password=" + Redacted + " dbname=orders",
+		},
+		{
+			name:     "user and password without a scheme",
+			endpoint: "app:pw@db:5432",
+			want:     "app:" + Redacted + "@db:5432",
+		},
+		{
+			name:     "plain host",
+			endpoint: "localhost:50051",
+			want:     "localhost:50051",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactEndpoint(tt.endpoint); got != tt.want {
+				t.Errorf("redactEndpoint(%q) = %q, want %q", tt.endpoint, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNilRedactorKeepsValues(t *testing.T) {
+	var r *Redactor
+	input := map[string]any{"password": "hunter2"}
+	if got := r.Input(input); !reflect.DeepEqual(got, input) {
+		t.Errorf("Input() = %#v, want %#v", got, input)
+	}
+	if got := r.String("s3cr3t-token"); got != "s3cr3t-token" {
+		t.Errorf("String() = %q, want it unchanged", got)
+	}
+	r.AddSecret("s3cr3t-token")
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const DefaultRerunDelay = 5 * time.Minute
+
+var ErrRerunNotFound = errors.New("rerun not found")
+
+type RerunPolicy struct {
+	Attempts  int      `yaml:"attempts" json:"attempts"`
+	Delay     Duration `yaml:"delay,omitempty" json:"delay,omitempty"`
+	Backoff   string   `yaml:"backoff,omitempty" json:"backoff,omitempty"`
+	PerMinute int      `yaml:"per_minute,omitempty" json:"per_minute,omitempty"`
+}
+
+func (p *RerunPolicy) DelayFor(attempt int) time.Duration {
+	delay := p.Delay.Duration
+	if delay <= 0 {
+		delay = DefaultRerunDelay
+	}
+	if p.Backoff == BackoffExponential {
+		for i := 1; i < attempt; i++ {
+			delay *= 2
+		}
+	}
+	return delay
+}
+
+type Rerun struct {
+	ID          string                 `json:"id"`
+	Workflow    string                 `json:"workflow"`
+	Namespace   string                 `json:"namespace,omitempty"`
+	Environment string                 `json:"environment,omitempty"`
+	Tags        map[string]string      `json:"tags,omitempty"`
+	Input       map[string]interface{} `json:"input"`
+	OriginalID  string                 `json:"original_id"`
+	PreviousID  string                 `json:"previous_id"`
+	Attempt     int                    `json:"attempt"`
+	Reason      string                 `json:"reason"`
+	DueAt       time.Time              `json:"due_at"`
+	CreatedAt   time.Time              `json:"created_at"`
+}
@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const RetentionEphemeral = "ephemeral"
+
+type FieldRetention struct {
+	Path   string `json:"path"`
+	StepID string `json:"step_id,omitempty"`
+	Class  string `json:"class"`
+}
+
+func RetentionPeriod(class string) (time.Duration, error) {
+	if class == RetentionEphemeral {
+		return 0, nil
+	}
+
+	day := 24 * time.Hour
+	for suffix, unit := range map[string]time.Duration{"d": day, "w": 7 * day, "y": 365 * day} {
+		if count, ok := strings.CutSuffix(class, suffix); ok {
+			n, err := strconv.Atoi(count)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid retention class %q", class)
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+
+	period, err := time.ParseDuration(class)
+	if err != nil || period <= 0 {
+		return 0, fmt.Errorf("invalid retention class %q (must be 'ephemeral' or a period like 30d or 1y)", class)
+	}
+	return period, nil
+}
+
+func (r FieldRetention) Ephemeral() bool {
+	return r.Class == RetentionEphemeral
+}
+
+func (r FieldRetention) Root() string {
+	root, _, _ := strings.Cut(r.Path, ".")
+	return root
+}
+
+func (r FieldRetention) Field() []string {
+	_, field, ok := strings.Cut(r.Path, ".")
+	if !ok {
+		return nil
+	}
+	return strings.Split(field, ".")
+}
+
+func (w *Workflow) RetentionPolicy() []FieldRetention {
+	producers := make(map[string]string)
+	var policy []FieldRetention
+
+	var walk func(steps []Step)
+	walk = func(steps []Step) {
+		for i := range steps {
+			step := &steps[i]
+			if step.Output != "" {
+				producers[step.Output] = step.ID
+			}
+			for _, field := range sortedKeys(step.Retention) {
+				path := step.Output
+				if field != "" {
+					path += "." + field
+				}
+				policy = append(policy, FieldRetention{Path: path, StepID: step.ID, Class: step.Retention[field]})
+			}
+			walk(step.Parallel)
+			if step.Foreach != nil {
+				walk(step.Foreach.Steps)
+			}
+			if step.Fallback != nil {
+				walk([]Step{*step.Fallback})
+			}
+		}
+	}
+	walk(w.Steps)
+	walk(w.Finally)
+
+	for _, path := range sortedKeys(w.Retention) {
+		rule := FieldRetention{Path: path, Class: w.Retention[path]}
+		rule.StepID = producers[rule.Root()]
+		policy = append(policy, rule)
+	}
+
+	return policy
+}
+
+func (s *ExecutionSnapshot) Scrub(rule FieldRetention) {
+	root, field := rule.Root(), rule.Field()
+
+	if root == "input" {
+		if len(field) > 0 {
+			s.Input, _ = scrubField(s.Input, field).(map[string]interface{})
+		}
+		return
+	}
+
+	for _, outputs := range []map[string]interface{}{s.StepOutputs, s.Output} {
+		if value, ok := outputs[root]; ok {
+			outputs[root] = scrubField(value, field)
+		}
+	}
+	for i := range s.ExecutedSteps {
+		if rule.StepID != "" && s.ExecutedSteps[i].StepID == rule.StepID {
+			s.ExecutedSteps[i].Output = scrubField(s.ExecutedSteps[i].Output, field)
+		}
+	}
+}
+
+func ScrubStepOutput(output interface{}, rule FieldRetention) interface{} {
+	return scrubField(output, rule.Field())
+}
+
+func scrubField(value interface{}, field []string) interface{} {
+	if len(field) == 0 {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(field) == 1 {
+			delete(v, field[0])
+			return v
+		}
+		if nested, ok := v[field[0]]; ok {
+			v[field[0]] = scrubField(nested, field[1:])
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = scrubField(v[i], field)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+type SagaState struct {
+	WorkflowID    string
+	Namespace     string
+	ExecutedSteps []ExecutedStep
+	Status        SagaStatus
+	UpdatedAt     time.Time
+}
+
+type SagaStatus int
+
+const (
+	SagaStatusRunning SagaStatus = iota
+	SagaStatusCompensating
+	SagaStatusCompleted
+	SagaStatusFailed
+	SagaStatusCompensated
+)
+
+func (s SagaStatus) String() string {
+	switch s {
+	case SagaStatusRunning:
+		return "running"
+	case SagaStatusCompensating:
+		return "compensating"
+	case SagaStatusCompleted:
+		return "completed"
+	case SagaStatusFailed:
+		return "failed"
+	case SagaStatusCompensated:
+		return "compensated"
+	default:
+		return "unknown"
+	}
+}
+
+func ParseSagaStatus(s string) (SagaStatus, bool) {
+	for status := SagaStatusRunning; status <= SagaStatusCompensated; status++ {
+		if status.String() == s {
+			return status, true
+		}
+	}
+	return SagaStatusRunning, false
+}
+
+func SagaStatusOf(status WorkflowStatus) SagaStatus {
+	switch status {
+	case WorkflowStatusPending, WorkflowStatusRunning, WorkflowStatusSuspended:
+		return SagaStatusRunning
+	case WorkflowStatusCompensating:
+		return SagaStatusCompensating
+	case WorkflowStatusSuccess:
+		return SagaStatusCompleted
+	case WorkflowStatusCompensated:
+		return SagaStatusCompensated
+	default:
+		return SagaStatusFailed
+	}
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+const (
+	OverlapSkip    = "skip"
+	OverlapQueue   = "queue"
+	OverlapReplace = "replace"
+)
+
+type Schedule struct {
+	Name        string                 `yaml:"name" json:"name"`
+	Workflow    string                 `yaml:"workflow" json:"workflow"`
+	Cron        string                 `yaml:"cron" json:"cron"`
+	Timezone    string                 `yaml:"timezone,omitempty" json:"timezone,omitempty"`
+	Environment string                 `yaml:"environment,omitempty" json:"environment,omitempty"`
+	Input       map[string]interface{} `yaml:"input,omitempty" json:"input,omitempty"`
+	Overlap     string                 `yaml:"overlap,omitempty" json:"overlap,omitempty"`
+}
+
+func (s *Schedule) OverlapPolicy() string {
+	if s.Overlap == "" {
+		return OverlapSkip
+	}
+	return s.Overlap
+}
+
+type ScheduleStatus struct {
+	Schedule
+	NextRun        time.Time  `json:"next_run"`
+	LastRun        *time.Time `json:"last_run,omitempty"`
+	LastWorkflowID string     `json:"last_workflow_id,omitempty"`
+	Running        bool       `json:"running"`
+	Queued         int        `json:"queued,omitempty"`
+	DeferredUntil  *time.Time `json:"deferred_until,omitempty"`
+}
@@ -0,0 +1,5 @@
+package domain
+
+import "errors"
+
+var ErrSecretNotFound = errors.New("secret not found")
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"slices"
+	"sync"
+)
+
+type ShadowRun struct {
+	mu         sync.Mutex
+	results    map[string]*StepResult
+	outputs    map[string]interface{}
+	executed   []string
+	unrecorded []string
+	suppressed int
+}
+
+func NewShadowRun(results []*StepResult, outputs map[string]interface{}) *ShadowRun {
+	s := &ShadowRun{
+		results: make(map[string]*StepResult, len(results)),
+		outputs: outputs,
+	}
+	for _, result := range results {
+		s.results[result.StepID] = result
+	}
+	return s
+}
+
+func (s *ShadowRun) Replay(step *Step) (*StepResult, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.executed = append(s.executed, step.ID)
+
+	if recorded, ok := s.results[step.ID]; ok && !recorded.Skipped {
+		return &StepResult{StepID: step.ID, Output: recorded.Output, Error: recorded.Error}, true
+	}
+	if step.Output != "" {
+		if output, ok := s.outputs[step.Output]; ok {
+			return &StepResult{StepID: step.ID, Output: output}, true
+		}
+	}
+	if step.Foreach != nil {
+		return nil, false
+	}
+
+	s.unrecorded = append(s.unrecorded, step.ID)
+	return &StepResult{StepID: step.ID}, true
+}
+
+func (s *ShadowRun) Suppress() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.suppressed++
+}
+
+func (s *ShadowRun) Executed() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return uniqueSteps(s.executed)
+}
+
+func (s *ShadowRun) Unrecorded() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return uniqueSteps(s.unrecorded)
+}
+
+func (s *ShadowRun) Suppressed() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.suppressed
+}
+
+func uniqueSteps(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return slices.Clip(unique)
+}
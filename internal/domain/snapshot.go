@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+const SnapshotFormatVersion = 1
+
+var (
+	ErrFenced            = errors.New("execution is owned by a newer lease holder")
+	ErrExecutionNotFound = errors.New("execution not found")
+)
+
+type Execution struct {
+	WorkflowName    string
+	WorkflowVersion string
+	Context         *ExecutionContext
+	Result          *WorkflowResult
+	Retention       []FieldRetention
+}
+
+func (e *Execution) Copy() *Execution {
+	return &Execution{
+		WorkflowName:    e.WorkflowName,
+		WorkflowVersion: e.WorkflowVersion,
+		Context:         e.Context,
+		Result:          e.Result.Copy(),
+		Retention:       e.Retention,
+	}
+}
+
+type ExecutionFilter struct {
+	WorkflowName string
+	Namespace    string
+	Status       string
+	Tags         map[string]string
+	RerunOf      string
+	Limit        int
+}
+
+type ExecutionSnapshot struct {
+	FormatVersion   int                      `json:"format_version"`
+	WorkflowID      string                   `json:"workflow_id"`
+	ParentID        string                   `json:"parent_id,omitempty"`
+	WorkflowName    string                   `json:"workflow_name"`
+	WorkflowVersion string                   `json:"workflow_version"`
+	Namespace       string                   `json:"namespace,omitempty"`
+	Environment     string                   `json:"environment,omitempty"`
+	TemplateEngine  string                   `json:"template_engine,omitempty"`
+	Tags            map[string]string        `json:"tags,omitempty"`
+	RerunOf         string                   `json:"rerun_of,omitempty"`
+	RerunAttempt    int                      `json:"rerun_attempt,omitempty"`
+	RerunID         string                   `json:"rerun_id,omitempty"`
+	Status          string                   `json:"status"`
+	Error           string                   `json:"error,omitempty"`
+	Input           map[string]interface{}   `json:"input"`
+	Variables       map[string]interface{}   `json:"variables"`
+	StepOutputs     map[string]interface{}   `json:"step_outputs"`
+	ExecutedSteps   []ExecutedStep           `json:"executed_steps"`
+	CompletedSteps  []string                 `json:"completed_steps"`
+	Timers          []PendingTimer           `json:"pending_timers,omitempty"`
+	HeldLocks       []string                 `json:"held_locks,omitempty"`
+	Timings         []StepTiming             `json:"timings,omitempty"`
+	Audit           []AuditEntry             `json:"audit,omitempty"`
+	Output          map[string]interface{}   `json:"output,omitempty"`
+	Unfinished      []UnfinishedCompensation `json:"unfinished_compensations,omitempty"`
+	Retention       []FieldRetention         `json:"retention,omitempty"`
+	StartedAt       time.Time                `json:"started_at"`
+	CompletedAt     time.Time                `json:"completed_at,omitempty"`
+	ExportedAt      time.Time                `json:"exported_at"`
+}
+
+// PendingTimer is a wait step's expire_after deadline, kept as the time left
+// when the snapshot was taken so it carries over between clocks.
+type PendingTimer struct {
+	Key       string   `json:"key"`
+	Remaining Duration `json:"remaining"`
+}
+
+func ParseWorkflowStatus(s string) (WorkflowStatus, bool) {
+	for status := WorkflowStatusPending; status <= WorkflowStatusSuspended; status++ {
+		if status.String() == s {
+			return status, true
+		}
+	}
+	return WorkflowStatusPending, false
+}
+
+func NewExecutionSnapshot(execution *Execution) *ExecutionSnapshot {
+	result := execution.Result.Copy()
+	snapshot := &ExecutionSnapshot{
+		FormatVersion:   SnapshotFormatVersion,
+		WorkflowID:      execution.Context.WorkflowID,
+		ParentID:        execution.Context.ParentID,
+		WorkflowName:    execution.WorkflowName,
+		WorkflowVersion: execution.WorkflowVersion,
+		Namespace:       execution.Context.Namespace,
+		Environment:     execution.Context.Environment,
+		TemplateEngine:  execution.Context.TemplateEngine,
+		Tags:            maps.Clone(execution.Context.Tags),
+		RerunOf:         execution.Context.RerunOf,
+		RerunAttempt:    execution.Context.RerunAttempt,
+		RerunID:         execution.Context.RerunID,
+		Status:          result.Status.String(),
+		Input:           maps.Clone(execution.Context.Input),
+		Variables:       maps.Clone(execution.Context.Variables),
+		StepOutputs:     execution.Context.CopyStepOutputs(),
+		ExecutedSteps:   execution.Context.CopyExecutedSteps(),
+		CompletedSteps:  execution.Context.CopyCompleted(),
+		HeldLocks:       slices.Clone(execution.Context.HeldLocks),
+		Timings:         execution.Context.CopyTimings(),
+		Audit:           execution.Context.CopyAudit(),
+		Output:          result.Output,
+		Unfinished:      result.UnfinishedCompensations,
+		Retention:       execution.Retention,
+		StartedAt:       result.StartedAt,
+		CompletedAt:     result.CompletedAt,
+		ExportedAt:      time.Now(),
+	}
+	if result.Error != nil {
+		snapshot.Error = result.Error.Error()
+	}
+	deadlines := execution.Context.CopyWaitDeadlines()
+	for _, key := range slices.Sorted(maps.Keys(deadlines)) {
+		deadline := deadlines[key]
+		snapshot.Timers = append(snapshot.Timers, PendingTimer{
+			Key:       key,
+			Remaining: Duration{Duration: max(time.Until(deadline), 0)},
+		})
+	}
+	return snapshot
+}
+
+func (s *ExecutionSnapshot) Execution() (*Execution, error) {
+	if s.FormatVersion != SnapshotFormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot format version %d", s.FormatVersion)
+	}
+
+	if s.WorkflowID == "" {
+		return nil, fmt.Errorf("snapshot has no workflow ID")
+	}
+
+	status, ok := ParseWorkflowStatus(s.Status)
+	if !ok {
+		return nil, fmt.Errorf("snapshot has invalid status %s", s.Status)
+	}
+
+	execCtx := &ExecutionContext{
+		WorkflowID:     s.WorkflowID,
+		WorkflowName:   s.WorkflowName,
+		ParentID:       s.ParentID,
+		Namespace:      s.Namespace,
+		Environment:    s.Environment,
+		TemplateEngine: s.TemplateEngine,
+		Tags:           s.Tags,
+		RerunOf:        s.RerunOf,
+		RerunAttempt:   s.RerunAttempt,
+		RerunID:        s.RerunID,
+		Input:          s.Input,
+		Variables:      s.Variables,
+		StepOutputs:    s.StepOutputs,
+		ExecutedSteps:  s.ExecutedSteps,
+		Completed:      s.CompletedSteps,
+		HeldLocks:      s.HeldLocks,
+		Timings:        s.Timings,
+		Audit:          s.Audit,
+	}
+	if execCtx.Variables == nil {
+		execCtx.Variables = make(map[string]interface{})
+	}
+	if execCtx.StepOutputs == nil {
+		execCtx.StepOutputs = make(map[string]interface{})
+	}
+	for _, timer := range s.Timers {
+		execCtx.WaitDeadline(timer.Key, timer.Remaining.Duration)
+	}
+
+	result := &WorkflowResult{
+		WorkflowID:              s.WorkflowID,
+		Status:                  status,
+		Output:                  s.Output,
+		UnfinishedCompensations: s.Unfinished,
+		StartedAt:               s.StartedAt,
+		CompletedAt:             s.CompletedAt,
+	}
+	if s.Error != "" {
+		result.Error = errors.New(s.Error)
+	}
+
+	return &Execution{
+		WorkflowName:    s.WorkflowName,
+		WorkflowVersion: s.WorkflowVersion,
+		Context:         execCtx,
+		Result:          result,
+		Retention:       s.Retention,
+	}, nil
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrStandby = errors.New("node is a passive standby and accepts no executions")
+
+const (
+	RolePrimary = "primary"
+	RoleStandby = "standby"
+)
+
+type PrimaryLease struct {
+	Owner     string    `json:"owner"`
+	Token     int64     `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type StandbyStatus struct {
+	Role       string        `json:"role"`
+	Node       string        `json:"node"`
+	Primary    *PrimaryLease `json:"primary,omitempty"`
+	InFlight   int           `json:"in_flight"`
+	SyncedAt   time.Time     `json:"synced_at"`
+	PromotedAt *time.Time    `json:"promoted_at,omitempty"`
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	MaxTags        = 32
+	MaxTagValueLen = 256
+)
+
+var tagKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]{0,62}$`)
+
+func ParseTags(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	tags := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("tag %q must be key=value", pair)
+		}
+		tags[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return tags, ValidateTags(tags)
+}
+
+func ValidateTags(tags map[string]string) error {
+	if len(tags) > MaxTags {
+		return fmt.Errorf("too many tags: %d (max %d)", len(tags), MaxTags)
+	}
+	for key, value := range tags {
+		if !tagKeyPattern.MatchString(key) {
+			return fmt.Errorf("invalid tag key %q (letters, digits, '_', '.' and '-', starting with a letter or '_')", key)
+		}
+		if len(value) > MaxTagValueLen {
+			return fmt.Errorf("tag %s: value longer than %d characters", key, MaxTagValueLen)
+		}
+	}
+	return nil
+}
+
+func MatchTags(tags, filter map[string]string) bool {
+	for key, value := range filter {
+		if actual, ok := tags[key]; !ok || actual != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+type TemplateError struct {
+	StepID     string   `json:"step_id,omitempty"`
+	Key        string   `json:"key,omitempty"`
+	Expression string   `json:"expression"`
+	Cause      string   `json:"cause"`
+	Available  []string `json:"available"`
+
+	err error
+}
+
+func NewTemplateError(expression string, available []string, err error) *TemplateError {
+	return &TemplateError{
+		Expression: expression,
+		Cause:      err.Error(),
+		Available:  available,
+		err:        err,
+	}
+}
+
+func (e *TemplateError) Error() string {
+	available := "nothing"
+	if len(e.Available) > 0 {
+		available = strings.Join(e.Available, ", ")
+	}
+	return fmt.Sprintf("template %s: %s (available: %s)", e.Expression, e.Cause, available)
+}
+
+func (e *TemplateError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+const (
+	TransactionRoleRoot  = "root"
+	TransactionRoleChild = "child"
+	TransactionRoleRetry = "retry"
+)
+
+type Transaction struct {
+	Key         map[string]string        `json:"key"`
+	Status      string                   `json:"status"`
+	StartedAt   time.Time                `json:"started_at"`
+	CompletedAt *time.Time               `json:"completed_at,omitempty"`
+	Executions  []TransactionExecution   `json:"executions"`
+	Timeline    []TransactionEvent       `json:"timeline"`
+	Unfinished  []UnfinishedCompensation `json:"unfinished_compensations,omitempty"`
+}
+
+type TransactionExecution struct {
+	WorkflowID   string     `json:"workflow_id"`
+	WorkflowName string     `json:"workflow_name"`
+	Role         string     `json:"role"`
+	ParentID     string     `json:"parent_id,omitempty"`
+	RetryOf      string     `json:"retry_of,omitempty"`
+	Status       string     `json:"status"`
+	Error        string     `json:"error,omitempty"`
+	StartedAt    time.Time  `json:"started_at"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+}
+
+type TransactionEvent struct {
+	Time         time.Time `json:"time"`
+	WorkflowID   string    `json:"workflow_id"`
+	WorkflowName string    `json:"workflow_name"`
+	Event        string    `json:"event"`
+	StepID       string    `json:"step_id,omitempty"`
+	Status       string    `json:"status,omitempty"`
+	Detail       string    `json:"detail,omitempty"`
+}
+
+var transactionSeverity = map[WorkflowStatus]int{
+	WorkflowStatusSuccess:     0,
+	WorkflowStatusCancelled:   1,
+	WorkflowStatusCompensated: 2,
+	WorkflowStatusFailed:      3,
+}
+
+func NewTransaction(key map[string]string, executions []*Execution) *Transaction {
+	executions = slices.Clone(executions)
+	slices.SortStableFunc(executions, func(a, b *Execution) int {
+		return a.Result.StartedAt.Compare(b.Result.StartedAt)
+	})
+
+	ids := make(map[string]bool, len(executions))
+	for _, execution := range executions {
+		ids[execution.Context.WorkflowID] = true
+	}
+
+	tx := &Transaction{
+		Key:        key,
+		Executions: []TransactionExecution{},
+		Timeline:   []TransactionEvent{},
+	}
+
+	latest := make(map[string]*Execution)
+	var order []string
+	running := false
+	var completedAt time.Time
+
+	for _, execution := range executions {
+		execCtx, result := execution.Context, execution.Result
+		entry := TransactionExecution{
+			WorkflowID:   execCtx.WorkflowID,
+			WorkflowName: execution.WorkflowName,
+			Role:         TransactionRoleRoot,
+			ParentID:     execCtx.ParentID,
+			Status:       result.Status.String(),
+			StartedAt:    result.StartedAt,
+		}
+		if result.Error != nil {
+			entry.Error = result.Error.Error()
+		}
+		if !result.CompletedAt.IsZero() {
+			finished := result.CompletedAt
+			entry.CompletedAt = &finished
+			if finished.After(completedAt) {
+				completedAt = finished
+			}
+		}
+
+		switch previous, retried := latest[execution.WorkflowName]; {
+		case execCtx.ParentID != "" && ids[execCtx.ParentID]:
+			entry.Role = TransactionRoleChild
+		case retried:
+			entry.Role = TransactionRoleRetry
+			entry.RetryOf = previous.Context.WorkflowID
+			latest[execution.WorkflowName] = execution
+		default:
+			latest[execution.WorkflowName] = execution
+			order = append(order, execution.WorkflowName)
+		}
+
+		if !result.Status.IsTerminal() {
+			running = true
+		}
+		if tx.StartedAt.IsZero() || result.StartedAt.Before(tx.StartedAt) {
+			tx.StartedAt = result.StartedAt
+		}
+
+		tx.Executions = append(tx.Executions, entry)
+		tx.Timeline = append(tx.Timeline, executionEvents(execution)...)
+	}
+
+	worst := WorkflowStatusSuccess
+	for _, name := range order {
+		effective := latest[name]
+		status := effective.Result.Status
+		if transactionSeverity[status] > transactionSeverity[worst] {
+			worst = status
+		}
+		tx.Unfinished = append(tx.Unfinished, effective.Result.UnfinishedCompensations...)
+	}
+	tx.Status = worst.String()
+
+	switch {
+	case running:
+		tx.Status = WorkflowStatusRunning.String()
+	case len(executions) > 0:
+		tx.CompletedAt = &completedAt
+	}
+
+	slices.SortStableFunc(tx.Timeline, func(a, b TransactionEvent) int {
+		return cmp.Compare(a.Time.UnixNano(), b.Time.UnixNano())
+	})
+	return tx
+}
+
+func executionEvents(execution *Execution) []TransactionEvent {
+	execCtx, result := execution.Context, execution.Result
+	event := func(at time.Time, name string) TransactionEvent {
+		return TransactionEvent{
+			Time:         at,
+			WorkflowID:   execCtx.WorkflowID,
+			WorkflowName: execution.WorkflowName,
+			Event:        name,
+		}
+	}
+
+	events := []TransactionEvent{event(result.StartedAt, "started")}
+
+	for _, timing := range execCtx.CopyTimings() {
+		step := event(timing.FinishedAt, "step")
+		step.StepID = timing.StepID
+		step.Status = timing.Status
+		events = append(events, step)
+	}
+
+	for _, audit := range execCtx.CopyAudit() {
+		override := event(audit.Time, audit.Action)
+		override.StepID = audit.StepID
+		override.Detail = audit.Reason
+		events = append(events, override)
+	}
+
+	if !result.CompletedAt.IsZero() {
+		finished := event(result.CompletedAt, "finished")
+		finished.Status = result.Status.String()
+		if result.Error != nil {
+			finished.Detail = result.Error.Error()
+		}
+		events = append(events, finished)
+	}
+	return events
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+)
+
+var ErrIllegalTransition = errors.New("illegal state transition")
+
+type StateMachine struct {
+	Initial     string              `yaml:"initial,omitempty" json:"initial,omitempty"`
+	Transitions map[string][]string `yaml:"transitions" json:"transitions"`
+}
+
+func (m StateMachine) Allows(from, to string) bool {
+	return slices.Contains(m.Transitions[from], to)
+}
+
+func (m StateMachine) Reaches(state string) bool {
+	for _, targets := range m.Transitions {
+		if slices.Contains(targets, state) {
+			return true
+		}
+	}
+	return false
+}
+
+type TransitionConfig struct {
+	Entity      string              `yaml:"entity" json:"entity"`
+	ID          string              `yaml:"id" json:"id"`
+	To          string              `yaml:"to" json:"to"`
+	Initial     string              `yaml:"initial,omitempty" json:"initial,omitempty"`
+	Transitions map[string][]string `yaml:"transitions,omitempty" json:"transitions,omitempty"`
+}
+
+func (c *TransitionConfig) Machine(states map[string]StateMachine) (StateMachine, bool) {
+	if len(c.Transitions) > 0 {
+		return StateMachine{Initial: c.Initial, Transitions: c.Transitions}, true
+	}
+	machine, ok := states[c.Entity]
+	return machine, ok
+}
+
+func StateNamespace(tenant, entity string) string {
+	if tenant == "" {
+		return "state/" + entity
+	}
+	return ScopedKVNamespace(tenant, "state/"+entity)
+}
+
+type StateChange struct {
+	Entity  string `json:"entity"`
+	ID      string `json:"id"`
+	From    string `json:"from,omitempty"`
+	To      string `json:"to"`
+	Created bool   `json:"created,omitempty"`
+}
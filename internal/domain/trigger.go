@@ -0,0 +1,23 @@
+package domain
+
+import "errors"
+
+const TriggerKafka = "kafka"
+
+var ErrTriggerFiltered = errors.New("trigger message filtered out")
+
+type TriggerConfig struct {
+	Type           string            `yaml:"type,omitempty" json:"type,omitempty"`
+	Topic          string            `yaml:"topic,omitempty" json:"topic,omitempty"`
+	Group          string            `yaml:"group,omitempty" json:"group,omitempty"`
+	Filter         string            `yaml:"filter,omitempty" json:"filter,omitempty"`
+	Transform      map[string]string `yaml:"transform,omitempty" json:"transform,omitempty"`
+	IdempotencyKey string            `yaml:"idempotency_key,omitempty" json:"idempotency_key,omitempty"`
+}
+
+func (t *TriggerConfig) ConsumerGroup(workflowName string) string {
+	if t.Group != "" {
+		return t.Group
+	}
+	return "maestro-" + workflowName
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+type InputError struct {
+	Workflow string   `json:"workflow"`
+	Problems []string `json:"problems"`
+}
+
+func (e *InputError) Error() string {
+	return fmt.Sprintf("input of workflow %s does not match its input_schema: %s", e.Workflow, strings.Join(e.Problems, "; "))
+}
+
+type ContractError struct {
+	StepID   string   `json:"step_id"`
+	Problems []string `json:"problems"`
+}
+
+func (e *ContractError) Error() string {
+	return fmt.Sprintf("step %s returned an output that violates its output_schema: %s", e.StepID, strings.Join(e.Problems, "; "))
+}
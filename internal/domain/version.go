@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func CompareVersions(a, b string) int {
+	left := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	right := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(left) || i < len(right); i++ {
+		var l, r string
+		if i < len(left) {
+			l = left[i]
+		}
+		if i < len(right) {
+			r = right[i]
+		}
+		ln, lerr := strconv.Atoi(cmp.Or(l, "0"))
+		rn, rerr := strconv.Atoi(cmp.Or(r, "0"))
+		if lerr == nil && rerr == nil {
+			if c := cmp.Compare(ln, rn); c != 0 {
+				return c
+			}
+			continue
+		}
+		if c := strings.Compare(l, r); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func VersionServiceName(workflow, version, service string) string {
+	if !strings.HasPrefix(service, WorkflowServiceName(workflow, "")) {
+		service = WorkflowServiceName(workflow, service)
+	}
+	if base, region, ok := strings.Cut(service, "#"); ok {
+		return RegionServiceName(base+"~"+version, region)
+	}
+	return service + "~" + version
+}
+
+type VersionNotFoundError struct {
+	Workflow string
+	Version  string
+}
+
+func (e *VersionNotFoundError) Error() string {
+	return fmt.Sprintf("workflow %s has no version %s loaded", e.Workflow, e.Version)
+}
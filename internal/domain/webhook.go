@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+var (
+	ErrDeliveryNotFound   = errors.New("webhook delivery not found")
+	ErrDeliveryInProgress = errors.New("webhook delivery is still in progress")
+	ErrCallbackRejected   = errors.New("callback url rejected")
+)
+
+type CompletionEvent struct {
+	Event        string                   `json:"event"`
+	WorkflowID   string                   `json:"workflow_id"`
+	WorkflowName string                   `json:"workflow_name"`
+	Namespace    string                   `json:"namespace,omitempty"`
+	Status       string                   `json:"status"`
+	Output       map[string]interface{}   `json:"output,omitempty"`
+	Error        string                   `json:"error,omitempty"`
+	Unfinished   []UnfinishedCompensation `json:"unfinished_compensations,omitempty"`
+	StartedAt    time.Time                `json:"started_at"`
+	CompletedAt  time.Time                `json:"completed_at"`
+}
+
+func NewCompletionEvent(workflowName, namespace string, result *WorkflowResult) *CompletionEvent {
+	event := &CompletionEvent{
+		Event:        "workflow." + result.Status.String(),
+		WorkflowID:   result.WorkflowID,
+		WorkflowName: workflowName,
+		Namespace:    namespace,
+		Status:       result.Status.String(),
+		Output:       result.Output,
+		Unfinished:   result.UnfinishedCompensations,
+		StartedAt:    result.StartedAt,
+		CompletedAt:  result.CompletedAt,
+	}
+	if result.Error != nil {
+		event.Error = result.Error.Error()
+	}
+	return event
+}
+
+type WebhookDelivery struct {
+	ID          string     `json:"id"`
+	Endpoint    string     `json:"endpoint"`
+	Event       string     `json:"event"`
+	WorkflowID  string     `json:"workflow_id"`
+	Status      string     `json:"status"`
+	Attempts    int        `json:"attempts"`
+	LastError   string     `json:"last_error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+}
@@ -1,45 +1,377 @@
 package domain
 
 import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 )
 
 type Workflow struct {
-	Name     string            `yaml:"name"`
-	Version  string            `yaml:"version"`
-	Timeout  Duration          `yaml:"timeout"`
-	Services map[string]Service `yaml:"services"`
-	Steps    []Step            `yaml:"steps"`
-	Output   map[string]string `yaml:"output"`
+	Name              string                  `yaml:"name" json:"name"`
+	Version           string                  `yaml:"version" json:"version"`
+	Namespace         string                  `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	TemplateEngine    string                  `yaml:"template_engine,omitempty" json:"template_engine,omitempty"`
+	Timeout           Duration                `yaml:"timeout" json:"timeout"`
+	InputSchema       map[string]interface{}  `yaml:"input_schema,omitempty" json:"input_schema,omitempty"`
+	Import            Imports                 `yaml:"import,omitempty" json:"import,omitempty"`
+	Services          map[string]Service      `yaml:"services" json:"services"`
+	Steps             []Step                  `yaml:"steps" json:"steps"`
+	Finally           []Step                  `yaml:"finally,omitempty" json:"finally,omitempty"`
+	Output            map[string]string       `yaml:"output" json:"output"`
+	BeforeEach        []Hook                  `yaml:"before_each,omitempty" json:"before_each,omitempty"`
+	AfterEach         []Hook                  `yaml:"after_each,omitempty" json:"after_each,omitempty"`
+	Compensation      *CompensationPolicy     `yaml:"compensation,omitempty" json:"compensation,omitempty"`
+	ConcurrencyGroups map[string]int          `yaml:"concurrency_groups,omitempty" json:"concurrency_groups,omitempty"`
+	States            map[string]StateMachine `yaml:"states,omitempty" json:"states,omitempty"`
+	Environments      map[string]Environment  `yaml:"environments,omitempty" json:"environments,omitempty"`
+	Profiles          map[string]Environment  `yaml:"profiles,omitempty" json:"profiles,omitempty"`
+	Quarantine        *QuarantinePolicy       `yaml:"quarantine,omitempty" json:"quarantine,omitempty"`
+	Breaker           *BreakerPolicy          `yaml:"breaker,omitempty" json:"breaker,omitempty"`
+	Rerun             *RerunPolicy            `yaml:"rerun,omitempty" json:"rerun,omitempty"`
+	Calendar          *Calendar               `yaml:"calendar,omitempty" json:"calendar,omitempty"`
+	IdempotencyTTL    Duration                `yaml:"idempotency_ttl,omitempty" json:"idempotency_ttl,omitempty"`
+	Preflight         *PreflightPolicy        `yaml:"preflight,omitempty" json:"preflight,omitempty"`
+	Alerting          *AlertingConfig         `yaml:"alerting,omitempty" json:"alerting,omitempty"`
+	Retention         map[string]string       `yaml:"retention,omitempty" json:"retention,omitempty"`
+	Sensitive         []string                `yaml:"sensitive,omitempty" json:"sensitive,omitempty"`
+	CallbackURL       string                  `yaml:"callback_url,omitempty" json:"callback_url,omitempty"`
+	Trigger           *TriggerConfig          `yaml:"trigger,omitempty" json:"trigger,omitempty"`
+}
+
+const (
+	CompensationContinue = "continue"
+	CompensationStop     = "stop"
+)
+
+type CompensationPolicy struct {
+	Concurrency int          `yaml:"concurrency,omitempty" json:"concurrency,omitempty"`
+	Retry       *RetryConfig `yaml:"retry,omitempty" json:"retry,omitempty"`
+	OnFailure   string       `yaml:"on_failure,omitempty" json:"on_failure,omitempty"`
+}
+
+func (p *CompensationPolicy) StopOnFailure() bool {
+	return p != nil && p.OnFailure == CompensationStop
+}
+
+type Hook struct {
+	Service string                 `yaml:"service,omitempty" json:"service,omitempty"`
+	Method  string                 `yaml:"method,omitempty" json:"method,omitempty"`
+	Input   map[string]interface{} `yaml:"input,omitempty" json:"input,omitempty"`
+	Command []string               `yaml:"command,omitempty" json:"command,omitempty"`
+}
+
+func (w *Workflow) HasCommandHooks() bool {
+	for _, hook := range slices.Concat(w.BeforeEach, w.AfterEach) {
+		if len(hook.Command) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *Workflow) HasExecServices() bool {
+	for _, service := range w.Services {
+		if service.Type == "exec" {
+			return true
+		}
+	}
+	return false
 }
 
 type Service struct {
-	Type     string      `yaml:"type"`
-	Endpoint string      `yaml:"endpoint"`
-	Timeout  Duration    `yaml:"timeout"`
-	Retry    *RetryConfig `yaml:"retry,omitempty"`
-	Metadata map[string]string `yaml:"metadata,omitempty"`
+	Type       string            `yaml:"type" json:"type"`
+	Endpoint   string            `yaml:"endpoint" json:"endpoint"`
+	Timeout    Duration          `yaml:"timeout" json:"timeout"`
+	Retry      *RetryConfig      `yaml:"retry,omitempty" json:"retry,omitempty"`
+	OpenAPI    string            `yaml:"openapi,omitempty" json:"openapi,omitempty"`
+	Metadata   map[string]string `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+	Protocol   string            `yaml:"protocol,omitempty" json:"protocol,omitempty"`
+	Descriptor string            `yaml:"descriptor,omitempty" json:"descriptor,omitempty"`
+	AMQP       *AMQPConfig       `yaml:"amqp,omitempty" json:"amqp,omitempty"`
+	SQL        *SQLConfig        `yaml:"sql,omitempty" json:"sql,omitempty"`
+	Redis      *RedisConfig      `yaml:"redis,omitempty" json:"redis,omitempty"`
+	Lambda     *LambdaConfig     `yaml:"lambda,omitempty" json:"lambda,omitempty"`
+	Exec       *ExecConfig       `yaml:"exec,omitempty" json:"exec,omitempty"`
+	Region     string            `yaml:"region,omitempty" json:"region,omitempty"`
+	Failover   *FailoverPolicy   `yaml:"failover,omitempty" json:"failover,omitempty"`
+	TLS        *TLSConfig        `yaml:"tls,omitempty" json:"tls,omitempty"`
+}
+
+type TLSConfig struct {
+	CA                 string `yaml:"ca,omitempty" json:"ca,omitempty"`
+	Cert               string `yaml:"cert,omitempty" json:"cert,omitempty"`
+	Key                string `yaml:"key,omitempty" json:"key,omitempty"`
+	ServerName         string `yaml:"server_name,omitempty" json:"server_name,omitempty"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty" json:"insecure_skip_verify,omitempty"`
+}
+
+type AMQPConfig struct {
+	Exchange string `yaml:"exchange,omitempty" json:"exchange,omitempty"`
+	Confirm  bool   `yaml:"confirm,omitempty" json:"confirm,omitempty"`
+	RPC      bool   `yaml:"rpc,omitempty" json:"rpc,omitempty"`
+}
+
+type SQLConfig struct {
+	Driver         string            `yaml:"driver,omitempty" json:"driver,omitempty"`
+	MaxConnections int               `yaml:"max_connections,omitempty" json:"max_connections,omitempty"`
+	Queries        map[string]string `yaml:"queries,omitempty" json:"queries,omitempty"`
+}
+
+type LambdaConfig struct {
+	Region string `yaml:"region,omitempty" json:"region,omitempty"`
+	Async  bool   `yaml:"async,omitempty" json:"async,omitempty"`
 }
 
+const (
+	ExecInputStdin = "stdin"
+	ExecInputEnv   = "env"
+)
+
+type ExecConfig struct {
+	Commands map[string][]string `yaml:"commands,omitempty" json:"commands,omitempty"`
+	Input    string              `yaml:"input,omitempty" json:"input,omitempty"`
+	Env      map[string]string   `yaml:"env,omitempty" json:"env,omitempty"`
+	Timeout  Duration            `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+type RedisConfig struct {
+	KeyPrefix string   `yaml:"key_prefix,omitempty" json:"key_prefix,omitempty"`
+	LockTTL   Duration `yaml:"lock_ttl,omitempty" json:"lock_ttl,omitempty"`
+}
+
+const (
+	SQLDriverPostgres = "postgres"
+	SQLDriverMySQL    = "mysql"
+)
+
+func (c *SQLConfig) DriverFor(endpoint string) string {
+	if c != nil && c.Driver != "" {
+		return c.Driver
+	}
+	switch {
+	case strings.HasPrefix(endpoint, "postgres://"), strings.HasPrefix(endpoint, "postgresql://"):
+		return SQLDriverPostgres
+	case strings.HasPrefix(endpoint, "mysql://"):
+		return SQLDriverMySQL
+	}
+	return ""
+}
+
+func (s Service) Typed() bool {
+	return s.Type == "grpc" && (s.Protocol == ProtocolGRPCReflection || s.Descriptor != "")
+}
+
+const (
+	ProtocolMaestro        = "maestro"
+	ProtocolGRPCReflection = "grpc-reflection"
+)
+
+const (
+	BackoffConstant    = "constant"
+	BackoffExponential = "exponential"
+)
+
 type RetryConfig struct {
-	Attempts int    `yaml:"attempts"`
-	Backoff  string `yaml:"backoff"`
+	Attempts int      `yaml:"attempts" json:"attempts"`
+	Backoff  string   `yaml:"backoff" json:"backoff"`
+	MaxDelay Duration `yaml:"max_delay,omitempty" json:"max_delay,omitempty"`
+	Jitter   float64  `yaml:"jitter,omitempty" json:"jitter,omitempty"`
 }
 
 type Step struct {
-	ID         string            `yaml:"id,omitempty"`
-	Service    string            `yaml:"service,omitempty"`
-	Method     string            `yaml:"method,omitempty"`
-	Input      map[string]interface{} `yaml:"input,omitempty"`
-	Output     string            `yaml:"output,omitempty"`
-	When       string            `yaml:"when,omitempty"`
-	Compensate *CompensateConfig `yaml:"compensate,omitempty"`
-	Parallel   []Step            `yaml:"parallel,omitempty"`
+	ID               string                 `yaml:"id,omitempty" json:"id,omitempty"`
+	Service          string                 `yaml:"service,omitempty" json:"service,omitempty"`
+	Method           string                 `yaml:"method,omitempty" json:"method,omitempty"`
+	Input            map[string]interface{} `yaml:"input,omitempty" json:"input,omitempty"`
+	Output           string                 `yaml:"output,omitempty" json:"output,omitempty"`
+	OutputSchema     map[string]interface{} `yaml:"output_schema,omitempty" json:"output_schema,omitempty"`
+	When             string                 `yaml:"when,omitempty" json:"when,omitempty"`
+	Compensate       *CompensateConfig      `yaml:"compensate,omitempty" json:"compensate,omitempty"`
+	CompensateAfter  []string               `yaml:"compensate_after,omitempty" json:"compensate_after,omitempty"`
+	Parallel         []Step                 `yaml:"parallel,omitempty" json:"parallel,omitempty"`
+	EmitMetric       *MetricConfig          `yaml:"emit_metric,omitempty" json:"emit_metric,omitempty"`
+	Trace            *TraceConfig           `yaml:"trace,omitempty" json:"trace,omitempty"`
+	AcquireLock      *LockConfig            `yaml:"acquire_lock,omitempty" json:"acquire_lock,omitempty"`
+	ReleaseLock      *LockConfig            `yaml:"release_lock,omitempty" json:"release_lock,omitempty"`
+	KV               *KVConfig              `yaml:"kv,omitempty" json:"kv,omitempty"`
+	Transition       *TransitionConfig      `yaml:"transition,omitempty" json:"transition,omitempty"`
+	Wait             *WaitConfig            `yaml:"wait,omitempty" json:"wait,omitempty"`
+	ConcurrencyGroup string                 `yaml:"concurrency_group,omitempty" json:"concurrency_group,omitempty"`
+	Idempotent       bool                   `yaml:"idempotent,omitempty" json:"idempotent,omitempty"`
+	DependsOn        []string               `yaml:"depends_on,omitempty" json:"depends_on,omitempty"`
+	Retry            *RetryConfig           `yaml:"retry,omitempty" json:"retry,omitempty"`
+	Foreach          *ForeachConfig         `yaml:"foreach,omitempty" json:"foreach,omitempty"`
+	Workflow         string                 `yaml:"workflow,omitempty" json:"workflow,omitempty"`
+	Assert           *AssertConfig          `yaml:"assert,omitempty" json:"assert,omitempty"`
+	Resources        *ResourceHints         `yaml:"resources,omitempty" json:"resources,omitempty"`
+	Stream           bool                   `yaml:"stream,omitempty" json:"stream,omitempty"`
+	StreamInput      string                 `yaml:"stream_input,omitempty" json:"stream_input,omitempty"`
+	Headers          map[string]string      `yaml:"headers,omitempty" json:"headers,omitempty"`
+	Key              string                 `yaml:"key,omitempty" json:"key,omitempty"`
+	HTTPResponse     bool                   `yaml:"http_response,omitempty" json:"http_response,omitempty"`
+	OnError          string                 `yaml:"on_error,omitempty" json:"on_error,omitempty"`
+	Fallback         *Step                  `yaml:"fallback,omitempty" json:"fallback,omitempty"`
+	CachedFallback   *CachedFallbackConfig  `yaml:"cached_fallback,omitempty" json:"cached_fallback,omitempty"`
+	Retention        map[string]string      `yaml:"retention,omitempty" json:"retention,omitempty"`
+}
+
+const (
+	OnErrorFail     = "fail"
+	OnErrorContinue = "continue"
+	OnErrorFallback = "fallback"
+	OnErrorHold     = "hold"
+)
+
+type CachedFallbackConfig struct {
+	MaxAge Duration `yaml:"max_age,omitempty" json:"max_age,omitempty"`
+}
+
+const (
+	LatencyFast   = "fast"
+	LatencyNormal = "normal"
+	LatencySlow   = "slow"
+)
+
+type ResourceHints struct {
+	Weight  int    `yaml:"weight,omitempty" json:"weight,omitempty"`
+	Latency string `yaml:"latency,omitempty" json:"latency,omitempty"`
+}
+
+func (h *ResourceHints) StepWeight() int {
+	if h == nil || h.Weight <= 0 {
+		return 1
+	}
+	return h.Weight
+}
+
+func (h *ResourceHints) IsSlow() bool {
+	return h != nil && h.Latency == LatencySlow
+}
+
+type AssertConfig struct {
+	Condition string `yaml:"condition" json:"condition"`
+	Message   string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+type AssertionError struct {
+	StepID    string
+	Condition string
+	Message   string
+}
+
+func (e *AssertionError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("step %s: assertion failed: %s", e.StepID, e.Message)
+	}
+	return fmt.Sprintf("step %s: assertion failed: %s", e.StepID, e.Condition)
+}
+
+type ForeachConfig struct {
+	Items       string `yaml:"items" json:"items"`
+	Steps       []Step `yaml:"steps" json:"steps"`
+	Concurrency int    `yaml:"concurrency,omitempty" json:"concurrency,omitempty"`
+}
+
+const (
+	WaitExpireFail       = "fail"
+	WaitExpireSkip       = "skip"
+	WaitExpireDefault    = "default"
+	WaitExpireCompensate = "compensate"
+)
+
+type WaitConfig struct {
+	Signal      string      `yaml:"signal" json:"signal"`
+	ExpireAfter Duration    `yaml:"expire_after,omitempty" json:"expire_after,omitempty"`
+	OnExpire    string      `yaml:"on_expire,omitempty" json:"on_expire,omitempty"`
+	Default     interface{} `yaml:"default,omitempty" json:"default,omitempty"`
+}
+
+type WaitExpiredError struct {
+	StepID     string
+	Signal     string
+	After      time.Duration
+	Compensate bool
+}
+
+func (e *WaitExpiredError) Error() string {
+	return fmt.Sprintf("step %s: no %s signal received within %s", e.StepID, e.Signal, e.After)
+}
+
+const (
+	KVOpGet       = "get"
+	KVOpSet       = "set"
+	KVOpDelete    = "delete"
+	KVOpIncrement = "incr"
+)
+
+type KVConfig struct {
+	Op        string      `yaml:"op" json:"op"`
+	Namespace string      `yaml:"namespace" json:"namespace"`
+	Key       string      `yaml:"key" json:"key"`
+	Value     interface{} `yaml:"value,omitempty" json:"value,omitempty"`
+	Delta     int64       `yaml:"delta,omitempty" json:"delta,omitempty"`
+	TTL       Duration    `yaml:"ttl,omitempty" json:"ttl,omitempty"`
+}
+
+type LockConfig struct {
+	Key  string   `yaml:"key" json:"key"`
+	TTL  Duration `yaml:"ttl,omitempty" json:"ttl,omitempty"`
+	Wait Duration `yaml:"wait,omitempty" json:"wait,omitempty"`
+}
+
+type TraceConfig struct {
+	Attributes map[string]string `yaml:"attributes,omitempty" json:"attributes,omitempty"`
+	Events     []TraceEvent      `yaml:"events,omitempty" json:"events,omitempty"`
+}
+
+type TraceEvent struct {
+	Name       string            `yaml:"name" json:"name"`
+	Attributes map[string]string `yaml:"attributes,omitempty" json:"attributes,omitempty"`
+}
+
+type MetricConfig struct {
+	Name    string            `yaml:"name" json:"name"`
+	Type    string            `yaml:"type" json:"type"`
+	Help    string            `yaml:"help,omitempty" json:"help,omitempty"`
+	Value   string            `yaml:"value,omitempty" json:"value,omitempty"`
+	Labels  map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Buckets []float64         `yaml:"buckets,omitempty" json:"buckets,omitempty"`
 }
 
 type CompensateConfig struct {
-	Method string                 `yaml:"method"`
-	Input  map[string]interface{} `yaml:"input"`
+	Service   string                 `yaml:"service,omitempty" json:"service,omitempty"`
+	Method    string                 `yaml:"method" json:"method"`
+	Input     map[string]interface{} `yaml:"input" json:"input"`
+	Retry     *RetryConfig           `yaml:"retry,omitempty" json:"retry,omitempty"`
+	Key       string                 `yaml:"key,omitempty" json:"key,omitempty"`
+	Tombstone bool                   `yaml:"tombstone,omitempty" json:"tombstone,omitempty"`
+}
+
+type UnfinishedCompensation struct {
+	StepID   string `json:"step_id"`
+	Attempts int    `json:"attempts"`
+	Error    string `json:"error,omitempty"`
+}
+
+type CompensationError struct {
+	Errors     []error
+	Unfinished []UnfinishedCompensation
+}
+
+func (e *CompensationError) Error() string {
+	if skipped := len(e.Unfinished) - len(e.Errors); skipped > 0 {
+		return fmt.Sprintf("compensation stopped after %d errors, %d compensations not attempted: %v",
+			len(e.Errors), skipped, e.Errors)
+	}
+	return fmt.Sprintf("compensation completed with %d errors: %v", len(e.Errors), e.Errors)
+}
+
+func (e *CompensationError) Unwrap() []error {
+	return e.Errors
 }
 
 type Duration struct {
@@ -63,34 +395,250 @@ func (d Duration) MarshalYAML() (interface{}, error) {
 	return d.Duration.String(), nil
 }
 
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = duration
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Duration.String())
+}
+
 type ExecutionContext struct {
-	WorkflowID string
-	Input      map[string]interface{}
-	Variables  map[string]interface{}
-	StepOutputs map[string]interface{}
-	ExecutedSteps []ExecutedStep
+	WorkflowID     string
+	WorkflowName   string
+	ParentID       string
+	Namespace      string
+	Environment    string
+	TemplateEngine string
+	Tags           map[string]string
+	RerunOf        string
+	RerunAttempt   int
+	RerunID        string
+	Input          map[string]interface{}
+	Variables      map[string]interface{}
+	StepOutputs    map[string]interface{}
+	ExecutedSteps  []ExecutedStep
+	HeldLocks      []string
+	Completed      []string
+	WaitDeadlines  map[string]time.Time
+	Timings        []StepTiming
+	Audit          []AuditEntry
+
+	mu sync.RWMutex
+}
+
+// CompletedSteps returns the IDs of the steps that already ran, which a
+// resumed execution skips.
+func (c *ExecutionContext) CompletedSteps() map[string]bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	completed := make(map[string]bool, len(c.Completed))
+	for _, id := range c.Completed {
+		completed[id] = true
+	}
+	return completed
+}
+
+func (c *ExecutionContext) MarkCompleted(stepID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Completed = append(c.Completed, stepID)
+}
+
+func (c *ExecutionContext) CopyCompleted() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Clone(c.Completed)
+}
+
+// WaitDeadline returns when the wait under key expires, starting its timer
+// the first time so a resumed wait keeps the deadline it had.
+func (c *ExecutionContext) WaitDeadline(key string, after time.Duration) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if deadline, ok := c.WaitDeadlines[key]; ok {
+		return deadline
+	}
+	if c.WaitDeadlines == nil {
+		c.WaitDeadlines = make(map[string]time.Time)
+	}
+	deadline := time.Now().Add(after)
+	c.WaitDeadlines[key] = deadline
+	return deadline
+}
+
+func (c *ExecutionContext) ClearWaitDeadline(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.WaitDeadlines, key)
+}
+
+func (c *ExecutionContext) CopyWaitDeadlines() map[string]time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return maps.Clone(c.WaitDeadlines)
+}
+
+func (c *ExecutionContext) SetStepOutput(name string, output interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.StepOutputs[name] = output
+}
+
+func (c *ExecutionContext) CopyStepOutputs() map[string]interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return maps.Clone(c.StepOutputs)
+}
+
+func (c *ExecutionContext) AppendExecutedStep(step ExecutedStep) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ExecutedSteps = append(c.ExecutedSteps, step)
+}
+
+// MarkCompensated flags one of the context's executed steps as compensated
+// while readers may be copying them.
+func (c *ExecutionContext) MarkCompensated(step *ExecutedStep) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	step.Compensated = true
+}
+
+func (c *ExecutionContext) CopyExecutedSteps() []ExecutedStep {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Clone(c.ExecutedSteps)
+}
+
+func (c *ExecutionContext) RecordTiming(timing StepTiming) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Timings = append(c.Timings, timing)
+}
+
+func (c *ExecutionContext) CopyTimings() []StepTiming {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Clone(c.Timings)
 }
 
 type ExecutedStep struct {
-	StepID       string
-	Output       interface{}
-	Compensation *CompensateConfig
-	Compensated  bool
+	StepID               string            `json:"step_id"`
+	Service              string            `json:"service,omitempty"`
+	Output               interface{}       `json:"output"`
+	Compensation         *CompensateConfig `json:"compensation,omitempty"`
+	CompensateAfter      []string          `json:"compensate_after,omitempty"`
+	Compensated          bool              `json:"compensated"`
+	CompensationAttempts int               `json:"compensation_attempts,omitempty"`
+	Scope                map[string]any    `json:"scope,omitempty"`
+	Iteration            string            `json:"iteration,omitempty"`
+	SubWorkflowID        string            `json:"sub_workflow_id,omitempty"`
+	Transition           *StateChange      `json:"transition,omitempty"`
+}
+
+func (s *ExecutedStep) CompensationService() string {
+	switch {
+	case s.Compensation != nil && s.Compensation.Service != "":
+		return s.Compensation.Service
+	case s.Service != "":
+		return s.Service
+	default:
+		return s.StepID
+	}
+}
+
+func NewExecutedStep(step *Step, output interface{}) ExecutedStep {
+	compensation := step.Compensate
+	if compensation != nil && compensation.Key == "" && step.Key != "" {
+		withKey := *compensation
+		withKey.Key = step.Key
+		compensation = &withKey
+	}
+
+	return ExecutedStep{
+		StepID:          step.ID,
+		Service:         step.Service,
+		Output:          output,
+		Compensation:    compensation,
+		CompensateAfter: step.CompensateAfter,
+	}
 }
 
 type StepResult struct {
-	StepID string
-	Output interface{}
-	Error  error
+	StepID   string
+	Output   interface{}
+	Error    error
+	Skipped  bool
+	Fallback string
+}
+
+func (r *StepResult) Recovered() bool {
+	return r != nil && (r.Error != nil || r.Fallback != "")
 }
 
 type WorkflowResult struct {
-	WorkflowID string
-	Status     WorkflowStatus
-	Output     map[string]interface{}
-	Error      error
-	StartedAt  time.Time
-	CompletedAt time.Time
+	WorkflowID              string
+	Status                  WorkflowStatus
+	Output                  map[string]interface{}
+	Error                   error
+	UnfinishedCompensations []UnfinishedCompensation
+	StartedAt               time.Time
+	CompletedAt             time.Time
+
+	mu sync.RWMutex
+}
+
+func (r *WorkflowResult) SetStatus(status WorkflowStatus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Status = status
+}
+
+func (r *WorkflowResult) Complete(status WorkflowStatus, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Status = status
+	r.Error = err
+	r.CompletedAt = time.Now()
+}
+
+func (r *WorkflowResult) Succeed(output map[string]interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Status = WorkflowStatusSuccess
+	r.Output = output
+	r.CompletedAt = time.Now()
+}
+
+func (r *WorkflowResult) SetUnfinishedCompensations(unfinished []UnfinishedCompensation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.UnfinishedCompensations = unfinished
+}
+
+func (r *WorkflowResult) Copy() *WorkflowResult {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return &WorkflowResult{
+		WorkflowID:              r.WorkflowID,
+		Status:                  r.Status,
+		Output:                  maps.Clone(r.Output),
+		Error:                   r.Error,
+		UnfinishedCompensations: slices.Clone(r.UnfinishedCompensations),
+		StartedAt:               r.StartedAt,
+		CompletedAt:             r.CompletedAt,
+	}
 }
 
 type WorkflowStatus int
@@ -103,6 +651,7 @@ const (
 	WorkflowStatusCancelled
 	WorkflowStatusCompensating
 	WorkflowStatusCompensated
+	WorkflowStatusSuspended
 )
 
 func (s WorkflowStatus) String() string {
@@ -121,11 +670,33 @@ func (s WorkflowStatus) String() string {
 		return "compensating"
 	case WorkflowStatusCompensated:
 		return "compensated"
+	case WorkflowStatusSuspended:
+		return "suspended"
 	default:
 		return "unknown"
 	}
 }
 
+func (s WorkflowStatus) IsTerminal() bool {
+	switch s {
+	case WorkflowStatusSuccess, WorkflowStatusFailed, WorkflowStatusCancelled, WorkflowStatusCompensated:
+		return true
+	default:
+		return false
+	}
+}
+
 func IsTemplate(s string) bool {
 	return len(s) >= 4 && s[:2] == "{{" && s[len(s)-2:] == "}}"
-}
\ No newline at end of file
+}
+
+func ContainsTemplate(s string) bool {
+	return strings.Contains(s, "{{") && strings.Contains(s, "}}")
+}
+
+type FieldSchema struct {
+	Type       string
+	Enum       []string
+	Properties map[string]FieldSchema
+	Items      *FieldSchema
+}
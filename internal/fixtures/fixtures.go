@@ -0,0 +1,330 @@
+package fixtures
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	grpcadapter "github.com/maestro/maestro.go/internal/infrastructure/grpc"
+	httpadapter "github.com/maestro/maestro.go/internal/infrastructure/http"
+	pb "github.com/maestro/maestro.go/pkg/proto"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/yaml.v3"
+)
+
+type Fixture struct {
+	Response interface{}     `yaml:"response,omitempty"`
+	Error    string          `yaml:"error,omitempty"`
+	Status   int             `yaml:"status,omitempty"`
+	Delay    domain.Duration `yaml:"delay,omitempty"`
+}
+
+type File struct {
+	Fixtures map[string]Fixture `yaml:"fixtures"`
+}
+
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures file: %w", err)
+	}
+
+	var file File
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse fixtures file: %w", err)
+	}
+
+	for key, fixture := range file.Fixtures {
+		if service, method, ok := strings.Cut(key, "."); !ok || service == "" || method == "" {
+			return nil, fmt.Errorf("fixture %q: key must be service.method", key)
+		}
+		if fixture.Status != 0 && (fixture.Status < 100 || fixture.Status > 599) {
+			return nil, fmt.Errorf("fixture %q: invalid status %d", key, fixture.Status)
+		}
+	}
+
+	return &file, nil
+}
+
+func (f *File) forService(name string) map[string]Fixture {
+	fixtures := make(map[string]Fixture)
+	for key, fixture := range f.Fixtures {
+		if service, method, _ := strings.Cut(key, "."); service == name {
+			fixtures[method] = fixture
+		}
+	}
+	return fixtures
+}
+
+type Fake struct {
+	Service  string
+	Type     string
+	Endpoint string
+}
+
+type Servers struct {
+	fakes   []Fake
+	grpc    []*grpc.Server
+	http    []*http.Server
+	logger  zerolog.Logger
+	skipped []string
+}
+
+func Start(file *File, services map[string]domain.Service, logger zerolog.Logger) (*Servers, error) {
+	s := &Servers{logger: logger}
+
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		service := services[name]
+		fixtures := file.forService(name)
+		if len(fixtures) == 0 {
+			s.skipped = append(s.skipped, name)
+			continue
+		}
+		if service.Typed() {
+			s.Close()
+			return nil, fmt.Errorf("service %s: fixtures are not supported for typed gRPC services", name)
+		}
+		if service.Type != "http" && service.Type != "grpc" {
+			s.Close()
+			return nil, fmt.Errorf("service %s: fixtures are not supported for %s services", name, service.Type)
+		}
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			s.Close()
+			return nil, fmt.Errorf("service %s: failed to listen: %w", name, err)
+		}
+
+		fake := Fake{Service: name, Type: service.Type}
+		switch service.Type {
+		case "grpc":
+			handler, err := newGRPCFake(name, fixtures, logger)
+			if err != nil {
+				listener.Close()
+				s.Close()
+				return nil, err
+			}
+			server := grpc.NewServer()
+			pb.RegisterMaestroServiceServer(server, handler)
+			go func() { _ = server.Serve(listener) }()
+			s.grpc = append(s.grpc, server)
+			fake.Endpoint = listener.Addr().String()
+		default:
+			server := &http.Server{Handler: newHTTPFake(name, fixtures, logger)}
+			go func() { _ = server.Serve(listener) }()
+			s.http = append(s.http, server)
+			fake.Endpoint = "http://" + listener.Addr().String()
+		}
+		s.fakes = append(s.fakes, fake)
+	}
+
+	return s, nil
+}
+
+func (s *Servers) Fakes() []Fake {
+	return s.fakes
+}
+
+func (s *Servers) Skipped() []string {
+	return s.skipped
+}
+
+func (s *Servers) Overrides(base map[string]domain.ServiceOverride) map[string]domain.ServiceOverride {
+	overrides := make(map[string]domain.ServiceOverride, len(base)+len(s.fakes))
+	for name, override := range base {
+		overrides[name] = override
+	}
+	for _, fake := range s.fakes {
+		override := overrides[fake.Service]
+		override.Endpoint = fake.Endpoint
+		overrides[fake.Service] = override
+	}
+	return overrides
+}
+
+func (s *Servers) Close() {
+	for _, server := range s.grpc {
+		server.Stop()
+	}
+	for _, server := range s.http {
+		_ = server.Close()
+	}
+}
+
+type grpcFake struct {
+	pb.UnimplementedMaestroServiceServer
+	service   string
+	responses map[string]*pb.ServiceResponse
+	delays    map[string]time.Duration
+	logger    zerolog.Logger
+}
+
+func newGRPCFake(service string, fixtures map[string]Fixture, logger zerolog.Logger) (*grpcFake, error) {
+	fake := &grpcFake{
+		service:   service,
+		responses: make(map[string]*pb.ServiceResponse, len(fixtures)),
+		delays:    make(map[string]time.Duration, len(fixtures)),
+		logger:    logger,
+	}
+
+	for method, fixture := range fixtures {
+		if fixture.Status != 0 {
+			return nil, fmt.Errorf("fixture %s.%s: status only applies to http services", service, method)
+		}
+		resp := &pb.ServiceResponse{Success: fixture.Error == "", Error: fixture.Error}
+		if fixture.Response != nil {
+			fields, ok := fixture.Response.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("fixture %s.%s: response of a grpc service must be an object", service, method)
+			}
+			data, err := structpb.NewStruct(fields)
+			if err != nil {
+				return nil, fmt.Errorf("fixture %s.%s: %w", service, method, err)
+			}
+			if resp.Data, err = anypb.New(data); err != nil {
+				return nil, fmt.Errorf("fixture %s.%s: %w", service, method, err)
+			}
+		}
+		fake.responses[method] = resp
+		fake.delays[method] = fixture.Delay.Duration
+	}
+
+	return fake, nil
+}
+
+func (f *grpcFake) Execute(ctx context.Context, req *pb.ServiceRequest) (*pb.ServiceResponse, error) {
+	return f.respond(ctx, req)
+}
+
+func (f *grpcFake) Compensate(ctx context.Context, req *pb.ServiceRequest) (*pb.ServiceResponse, error) {
+	return f.respond(ctx, req)
+}
+
+func (f *grpcFake) Cancel(_ context.Context, req *pb.CancelStepRequest) (*pb.ServiceResponse, error) {
+	logCancel(f.logger, f.service, req.Method, req.StepId, req.Reason)
+	return &pb.ServiceResponse{Success: true}, nil
+}
+
+func (f *grpcFake) HealthCheck(context.Context, *pb.Empty) (*pb.HealthStatus, error) {
+	return &pb.HealthStatus{Healthy: true, Message: "fixture", CheckedAt: timestamppb.Now()}, nil
+}
+
+func (f *grpcFake) respond(ctx context.Context, req *pb.ServiceRequest) (*pb.ServiceResponse, error) {
+	resp, ok := f.responses[req.Method]
+	if !ok {
+		f.logger.Warn().
+			Str("service", f.service).
+			Str("method", req.Method).
+			Msg("No fixture for call")
+		return &pb.ServiceResponse{Error: fmt.Sprintf("no fixture for %s.%s", f.service, req.Method)}, nil
+	}
+
+	if err := wait(ctx, f.delays[req.Method]); err != nil {
+		return nil, err
+	}
+	f.logger.Debug().
+		Str("service", f.service).
+		Str("method", req.Method).
+		Str("step_id", req.StepId).
+		Msg("Served fixture")
+	return resp, nil
+}
+
+type route struct {
+	method  string
+	path    string
+	fixture Fixture
+}
+
+func newHTTPFake(service string, fixtures map[string]Fixture, logger zerolog.Logger) http.Handler {
+	routes := make([]route, 0, len(fixtures))
+	for method, fixture := range fixtures {
+		httpMethod, path := httpadapter.ResolveRoute(method)
+		routes = append(routes, route{method: httpMethod, path: path, fixture: fixture})
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == grpcadapter.HTTPCancelPath {
+			var notice struct {
+				Method string `json:"method"`
+				StepID string `json:"step_id"`
+				Reason string `json:"reason"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&notice)
+			logCancel(logger, service, notice.Method, notice.StepID, notice.Reason)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		i := slices.IndexFunc(routes, func(rt route) bool {
+			return rt.method == r.Method && rt.path == r.URL.Path
+		})
+		if i < 0 {
+			logger.Warn().
+				Str("service", service).
+				Str("method", r.Method+" "+r.URL.Path).
+				Msg("No fixture for call")
+			http.Error(w, fmt.Sprintf("no fixture for %s.%s %s", service, r.Method, r.URL.Path), http.StatusNotFound)
+			return
+		}
+
+		fixture := routes[i].fixture
+		if err := wait(r.Context(), fixture.Delay.Duration); err != nil {
+			return
+		}
+
+		status := fixture.Status
+		if status == 0 {
+			status = http.StatusOK
+			if fixture.Error != "" {
+				status = http.StatusInternalServerError
+			}
+		}
+		if fixture.Error != "" {
+			http.Error(w, fixture.Error, status)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(fixture.Response)
+	})
+}
+
+func logCancel(logger zerolog.Logger, service, method, stepID, reason string) {
+	logger.Info().
+		Str("service", service).
+		Str("method", method).
+		Str("step_id", stepID).
+		Str("reason", reason).
+		Msg("Fixture call cancelled")
+}
+
+func wait(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
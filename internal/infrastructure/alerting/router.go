@@ -0,0 +1,101 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/rs/zerolog"
+)
+
+const DefaultPagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+
+type Router struct {
+	webhook      string
+	pagerDutyURL string
+	routingKeys  map[string]string
+	client       *http.Client
+	logger       zerolog.Logger
+}
+
+func NewRouter(webhook, pagerDutyURL string, routingKeys map[string]string, logger zerolog.Logger) *Router {
+	if pagerDutyURL == "" {
+		pagerDutyURL = DefaultPagerDutyURL
+	}
+	return &Router{
+		webhook:      webhook,
+		pagerDutyURL: pagerDutyURL,
+		routingKeys:  routingKeys,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		logger:       logger,
+	}
+}
+
+func (r *Router) Notify(ctx context.Context, alert *domain.Alert) error {
+	if alert.PagerDutyService != "" {
+		if routingKey, ok := r.routingKeys[alert.PagerDutyService]; ok {
+			return r.post(ctx, r.pagerDutyURL, pagerDutyEvent(routingKey, alert))
+		}
+		r.logger.Warn().
+			Str("workflow_name", alert.WorkflowName).
+			Str("pagerduty_service", alert.PagerDutyService).
+			Msg("No routing key configured for PagerDuty service, falling back to webhook")
+	}
+
+	if r.webhook == "" {
+		return nil
+	}
+	return r.post(ctx, r.webhook, alert)
+}
+
+func pagerDutyEvent(routingKey string, alert *domain.Alert) map[string]any {
+	return map[string]any{
+		"routing_key":  routingKey,
+		"event_action": "trigger",
+		"dedup_key":    alert.WorkflowID + ":" + alert.Event,
+		"payload": map[string]any{
+			"summary":   alert.Summary,
+			"source":    "maestro",
+			"severity":  alert.Severity,
+			"timestamp": alert.OccurredAt.Format(time.RFC3339),
+			"component": alert.WorkflowName,
+			"group":     alert.Namespace,
+			"class":     alert.Event,
+			"custom_details": map[string]any{
+				"workflow_id": alert.WorkflowID,
+				"error":       alert.Error,
+				"duration":    alert.Duration.String(),
+			},
+		},
+	}
+}
+
+func (r *Router) post(ctx context.Context, url string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create alert request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("alert endpoint returned %d: %s", resp.StatusCode, bytes.TrimSpace(message))
+	}
+	return nil
+}
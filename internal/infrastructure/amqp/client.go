@@ -0,0 +1,287 @@
+package amqp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maestro/maestro.go/internal/domain"
+	amqpgo "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	ServiceErrorHeader = "x-service-error"
+
+	defaultTimeout = 30 * time.Second
+)
+
+var (
+	ErrUnavailable = errors.New("AMQP broker unavailable")
+	ErrTimeout     = errors.New("request timed out")
+	ErrReturned    = errors.New("message was not routed")
+	ErrNacked      = errors.New("broker rejected message")
+)
+
+type Client struct {
+	url    string
+	name   string
+	config domain.AMQPConfig
+
+	mu         sync.Mutex
+	conn       *amqpgo.Connection
+	channel    *amqpgo.Channel
+	replyQueue string
+	pending    map[string]chan amqpgo.Delivery
+	returns    map[string]chan amqpgo.Return
+}
+
+func NewClient(url, name string, config *domain.AMQPConfig) *Client {
+	c := &Client{url: url, name: name}
+	if config != nil {
+		c.config = *config
+	}
+	return c
+}
+
+func (c *Client) Publish(ctx context.Context, routingKey string, input map[string]interface{}, headers map[string]string) (interface{}, error) {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+
+	id := uuid.New().String()
+	msg := amqpgo.Publishing{
+		ContentType:   "application/json",
+		DeliveryMode:  amqpgo.Persistent,
+		MessageId:     id,
+		CorrelationId: id,
+		Timestamp:     time.Now(),
+		AppId:         "maestro",
+		Headers:       amqpgo.Table{},
+		Body:          body,
+	}
+	for key, value := range headers {
+		msg.Headers[key] = value
+	}
+
+	channel, replyQueue, replies, returned, err := c.prepare(id)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(id)
+	if c.config.RPC {
+		msg.ReplyTo = replyQueue
+	}
+
+	confirm, err := channel.PublishWithDeferredConfirmWithContext(ctx, c.config.Exchange, routingKey, true, false, msg)
+	if err != nil {
+		c.reset(channel)
+		return nil, fmt.Errorf("%w: publish to %s failed: %w", ErrUnavailable, c.destination(routingKey), err)
+	}
+
+	confirmed := false
+	if confirm != nil {
+		acked, err := confirm.WaitContext(ctx)
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, fmt.Errorf("%w waiting for a publisher confirm on %s", ErrTimeout, c.destination(routingKey))
+		case err != nil:
+			return nil, err
+		case !acked:
+			return nil, fmt.Errorf("%w on %s", ErrNacked, c.destination(routingKey))
+		}
+		confirmed = true
+
+		select {
+		case ret := <-returned:
+			return nil, fmt.Errorf("%w to %s: %s", ErrReturned, c.destination(routingKey), ret.ReplyText)
+		default:
+		}
+	}
+
+	if !c.config.RPC {
+		return map[string]interface{}{
+			"exchange":    c.config.Exchange,
+			"routing_key": routingKey,
+			"message_id":  id,
+			"confirmed":   confirmed,
+		}, nil
+	}
+
+	select {
+	case reply, ok := <-replies:
+		if !ok {
+			return nil, fmt.Errorf("%w: connection closed waiting for a reply on %s", ErrUnavailable, c.destination(routingKey))
+		}
+		return decodeReply(reply)
+	case ret := <-returned:
+		return nil, fmt.Errorf("%w to %s: %s", ErrReturned, c.destination(routingKey), ret.ReplyText)
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w waiting for a reply on %s", ErrTimeout, c.destination(routingKey))
+		}
+		return nil, ctx.Err()
+	}
+}
+
+func decodeReply(reply amqpgo.Delivery) (interface{}, error) {
+	if message, ok := reply.Headers[ServiceErrorHeader]; ok {
+		return nil, fmt.Errorf("service returned error: %v", message)
+	}
+
+	if len(reply.Body) == 0 {
+		return nil, nil
+	}
+
+	var result interface{}
+	if err := json.Unmarshal(reply.Body, &result); err != nil {
+		return string(reply.Body), nil
+	}
+	return result, nil
+}
+
+func (c *Client) destination(routingKey string) string {
+	if c.config.Exchange == "" {
+		return routingKey
+	}
+	return c.config.Exchange + "/" + routingKey
+}
+
+func (c *Client) prepare(id string) (*amqpgo.Channel, string, chan amqpgo.Delivery, chan amqpgo.Return, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.channel == nil || c.channel.IsClosed() {
+		if err := c.connect(); err != nil {
+			return nil, "", nil, nil, err
+		}
+	}
+
+	var replies chan amqpgo.Delivery
+	if c.config.RPC {
+		replies = make(chan amqpgo.Delivery, 1)
+		c.pending[id] = replies
+	}
+	returned := make(chan amqpgo.Return, 1)
+	c.returns[id] = returned
+	return c.channel, c.replyQueue, replies, returned, nil
+}
+
+func (c *Client) release(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.pending, id)
+	delete(c.returns, id)
+}
+
+func (c *Client) connect() error {
+	if c.conn != nil {
+		c.conn.Close()
+	}
+
+	config := amqpgo.Config{
+		Properties: amqpgo.NewConnectionProperties(),
+		Heartbeat:  10 * time.Second,
+	}
+	config.Properties.SetClientConnectionName("maestro/" + c.name)
+
+	conn, err := amqpgo.DialConfig(c.url, config)
+	if err != nil {
+		return fmt.Errorf("%w: failed to connect to %s: %w", ErrUnavailable, c.name, err)
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("%w: failed to open channel: %w", ErrUnavailable, err)
+	}
+
+	if c.config.Confirm {
+		if err := channel.Confirm(false); err != nil {
+			conn.Close()
+			return fmt.Errorf("failed to enable publisher confirms: %w", err)
+		}
+	}
+
+	pending := make(map[string]chan amqpgo.Delivery)
+	returns := make(map[string]chan amqpgo.Return)
+
+	var replyQueue string
+	if c.config.RPC {
+		queue, err := channel.QueueDeclare("", false, true, true, false, nil)
+		if err != nil {
+			conn.Close()
+			return fmt.Errorf("failed to declare reply queue: %w", err)
+		}
+		deliveries, err := channel.Consume(queue.Name, "", true, true, false, false, nil)
+		if err != nil {
+			conn.Close()
+			return fmt.Errorf("failed to consume reply queue: %w", err)
+		}
+		replyQueue = queue.Name
+		go c.dispatchReplies(deliveries, pending)
+	}
+	go c.dispatchReturns(channel.NotifyReturn(make(chan amqpgo.Return, 16)), returns)
+
+	c.conn, c.channel, c.replyQueue = conn, channel, replyQueue
+	c.pending, c.returns = pending, returns
+	return nil
+}
+
+func (c *Client) dispatchReplies(deliveries <-chan amqpgo.Delivery, pending map[string]chan amqpgo.Delivery) {
+	for reply := range deliveries {
+		c.mu.Lock()
+		if ch, ok := pending[reply.CorrelationId]; ok {
+			delete(pending, reply.CorrelationId)
+			ch <- reply
+		}
+		c.mu.Unlock()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, ch := range pending {
+		delete(pending, id)
+		close(ch)
+	}
+}
+
+func (c *Client) dispatchReturns(returns <-chan amqpgo.Return, waiting map[string]chan amqpgo.Return) {
+	for ret := range returns {
+		c.mu.Lock()
+		if ch, ok := waiting[ret.MessageId]; ok {
+			select {
+			case ch <- ret:
+			default:
+			}
+		}
+		c.mu.Unlock()
+	}
+}
+
+func (c *Client) reset(channel *amqpgo.Channel) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.channel == channel {
+		c.channel = nil
+	}
+}
+
+func (c *Client) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn != nil {
+		c.conn.Close()
+		c.conn, c.channel = nil, nil
+	}
+}
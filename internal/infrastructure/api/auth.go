@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/maestro/maestro.go/internal/infrastructure/cluster"
+	pb "github.com/maestro/maestro.go/pkg/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var privilegedMethods = map[string]bool{
+	pb.Orchestrator_RegisterWorkflow_FullMethodName: true,
+}
+
+type keySet struct {
+	keys atomic.Pointer[[]string]
+}
+
+func (k *keySet) set(keys []string) {
+	keys = append([]string(nil), keys...)
+	k.keys.Store(&keys)
+}
+
+func (k *keySet) configured() bool {
+	keys := k.keys.Load()
+	return keys != nil && len(*keys) > 0
+}
+
+func (k *keySet) allowed(key string) bool {
+	keys := k.keys.Load()
+	if keys == nil || len(*keys) == 0 {
+		return true
+	}
+
+	match := 0
+	for _, candidate := range *keys {
+		match |= subtle.ConstantTimeCompare([]byte(candidate), []byte(key))
+	}
+	return match == 1
+}
+
+func bearerToken(authorization string) string {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+func (s *Server) SetAPIKeys(keys []string) {
+	s.keys.set(keys)
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/schemas/workflow.json" || r.URL.Path == cluster.HealthPath {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := r.Header.Get("X-API-Key")
+		if key == "" {
+			key = bearerToken(r.Header.Get("Authorization"))
+		}
+
+		if !s.keys.allowed(key) {
+			writeError(w, http.StatusUnauthorized, "missing or invalid API key")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) HasAPIKeys() bool {
+	return s.keys.configured()
+}
+
+func (s *Server) privileged(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.keys.configured() {
+			writeError(w, http.StatusForbidden, "%s %s is disabled until api_keys are configured", r.Method, r.URL.Path)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *GRPCServer) SetAPIKeys(keys []string) {
+	s.keys.set(keys)
+}
+
+func (s *GRPCServer) checkAPIKey(ctx context.Context) error {
+	var key string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-api-key"); len(values) > 0 {
+			key = values[0]
+		} else if values := md.Get("authorization"); len(values) > 0 {
+			key = bearerToken(values[0])
+		}
+	}
+
+	if !s.keys.allowed(key) {
+		return status.Error(codes.Unauthenticated, "missing or invalid API key")
+	}
+	return nil
+}
+
+func (s *GRPCServer) unaryAuth(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if err := s.checkAPIKey(ctx); err != nil {
+		return nil, err
+	}
+	if privilegedMethods[info.FullMethod] && !s.keys.configured() {
+		return nil, status.Errorf(codes.PermissionDenied, "%s is disabled until api_keys are configured", info.FullMethod)
+	}
+	return handler(ctx, req)
+}
+
+func (s *GRPCServer) streamAuth(
+	srv any,
+	stream grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if err := s.checkAPIKey(stream.Context()); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/cluster"
+)
+
+func (s *Server) SetCluster(c *cluster.Cluster) {
+	s.cluster = c
+}
+
+func (s *Server) handleClusterHealth(w http.ResponseWriter, _ *http.Request) {
+	body := map[string]string{"status": "ok"}
+	if s.cluster != nil {
+		body["node_id"] = s.cluster.Self().ID
+	}
+	if s.orchestrator.Draining() {
+		body["status"] = "draining"
+		writeJSON(w, http.StatusServiceUnavailable, body)
+		return
+	}
+	if status, ok := s.orchestrator.StandbyStatus(); ok && status.Role == domain.RoleStandby {
+		body["status"] = "standby"
+		writeJSON(w, http.StatusServiceUnavailable, body)
+		return
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+func (s *Server) forwardExecution(w http.ResponseWriter, r *http.Request, workflowID string) bool {
+	_, ok, err := s.orchestrator.GetExecution(workflowID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return true
+	}
+	if ok {
+		return false
+	}
+	return s.forward(w, r, workflowID)
+}
+
+func (s *Server) forward(w http.ResponseWriter, r *http.Request, workflowID string) bool {
+	if s.cluster == nil || r.Header.Get(cluster.ForwardedHeader) != "" {
+		return false
+	}
+
+	owner := s.cluster.Owner(workflowID)
+	self := s.cluster.Self()
+	if owner.ID == self.ID {
+		return false
+	}
+
+	target, err := url.Parse(owner.URL)
+	if err != nil {
+		s.logger.Error().Err(err).Str("peer", owner.ID).Msg("Invalid peer URL")
+		return false
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWorkflowSize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "failed to read request body: %v", err)
+		return true
+	}
+
+	var failed error
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(target)
+			s.cluster.Sign(pr.Out.Header)
+			pr.Out.Header.Set(cluster.WorkflowIDHeader, workflowID)
+		},
+		ErrorHandler: func(_ http.ResponseWriter, _ *http.Request, err error) {
+			failed = err
+		},
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	proxy.ServeHTTP(w, r)
+	if failed == nil {
+		return true
+	}
+
+	s.logger.Warn().
+		Err(failed).
+		Str("workflow_id", workflowID).
+		Str("peer", owner.ID).
+		Msg("Owner unreachable, handling execution locally")
+	s.cluster.MarkDown(owner.ID)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return false
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/application"
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type deadLetterResponse struct {
+	ID            string            `json:"id"`
+	Kind          string            `json:"kind"`
+	Source        string            `json:"source"`
+	Workflow      string            `json:"workflow,omitempty"`
+	WorkflowID    string            `json:"workflow_id,omitempty"`
+	Namespace     string            `json:"namespace,omitempty"`
+	Payload       string            `json:"payload"`
+	Headers       map[string]string `json:"headers,omitempty"`
+	Reason        string            `json:"reason"`
+	Attempts      int               `json:"attempts"`
+	CreatedAt     time.Time         `json:"created_at"`
+	LastAttemptAt time.Time         `json:"last_attempt_at"`
+}
+
+func newDeadLetterResponse(letter *domain.DeadLetter) deadLetterResponse {
+	return deadLetterResponse{
+		ID:            letter.ID,
+		Kind:          letter.Kind,
+		Source:        letter.Source,
+		Workflow:      letter.Workflow,
+		WorkflowID:    letter.WorkflowID,
+		Namespace:     letter.Namespace,
+		Payload:       string(letter.Payload),
+		Headers:       letter.Headers,
+		Reason:        letter.Reason,
+		Attempts:      letter.Attempts,
+		CreatedAt:     letter.CreatedAt,
+		LastAttemptAt: letter.LastAttemptAt,
+	}
+}
+
+func deadLetterErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrDeadLetterNotFound), errors.Is(err, application.ErrWebhooksDisabled):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrUnknownDeadLetter):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (s *Server) handleListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.DeadLetterFilter{
+		Kind:   query.Get("kind"),
+		Source: query.Get("source"),
+		Limit:  100,
+	}
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit %s", limit)
+			return
+		}
+		filter.Limit = n
+	}
+
+	letters, err := s.orchestrator.ListDeadLetters(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	resp := make([]deadLetterResponse, 0, len(letters))
+	for _, letter := range letters {
+		resp = append(resp, newDeadLetterResponse(letter))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"dead_letters": resp})
+}
+
+func (s *Server) handleGetDeadLetter(w http.ResponseWriter, r *http.Request) {
+	letter, err := s.orchestrator.GetDeadLetter(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, deadLetterErrorStatus(err), "%v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newDeadLetterResponse(letter))
+}
+
+func (s *Server) handleReplayDeadLetter(w http.ResponseWriter, r *http.Request) {
+	replay, err := s.orchestrator.ReplayDeadLetter(r.Context(), r.PathValue("id"))
+	if err != nil {
+		status := deadLetterErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			status = http.StatusUnprocessableEntity
+		}
+		writeError(w, status, "%v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, replay)
+}
+
+func (s *Server) handleDeleteDeadLetter(w http.ResponseWriter, r *http.Request) {
+	if err := s.orchestrator.DeleteDeadLetter(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, deadLetterErrorStatus(err), "%v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
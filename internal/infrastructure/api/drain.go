@@ -0,0 +1,113 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+const defaultDrainTimeout = 5 * time.Minute
+
+type handoffResponse struct {
+	WorkflowID string `json:"workflow_id"`
+	NodeID     string `json:"node_id"`
+}
+
+type drainResponse struct {
+	Status    string                      `json:"status"`
+	HandedOff []handoffResponse           `json:"handed_off"`
+	Suspended []*domain.ExecutionSnapshot `json:"suspended"`
+	Running   []string                    `json:"running"`
+}
+
+func (s *Server) handleDrain(w http.ResponseWriter, r *http.Request) {
+	timeout := defaultDrainTimeout
+	if raw := r.URL.Query().Get("timeout"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid timeout %q", raw)
+			return
+		}
+		timeout = d
+	}
+
+	if s.cluster != nil {
+		s.cluster.SetDraining()
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+	report := s.orchestrator.Drain(ctx)
+
+	resp := drainResponse{
+		Status:    "drained",
+		HandedOff: []handoffResponse{},
+		Suspended: []*domain.ExecutionSnapshot{},
+		Running:   report.Running,
+	}
+	if len(resp.Running) > 0 {
+		resp.Status = "draining"
+	} else {
+		resp.Running = []string{}
+	}
+
+	for _, snapshot := range report.Suspended {
+		if s.cluster != nil {
+			body, err := json.Marshal(snapshot)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, "failed to encode snapshot: %v", err)
+				return
+			}
+
+			node, err := s.cluster.HandOff(r.Context(), snapshot.WorkflowID, body, r.Header)
+			if err == nil {
+				s.orchestrator.HandedOff(snapshot.WorkflowID)
+				resp.HandedOff = append(resp.HandedOff, handoffResponse{WorkflowID: snapshot.WorkflowID, NodeID: node.ID})
+				s.logger.Info().
+					Str("workflow_id", snapshot.WorkflowID).
+					Str("peer", node.ID).
+					Msg("Execution handed off")
+				continue
+			}
+			s.logger.Warn().
+				Err(err).
+				Str("workflow_id", snapshot.WorkflowID).
+				Msg("Handoff failed, execution left suspended for pickup")
+		}
+		resp.Suspended = append(resp.Suspended, snapshot)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleHandoff(w http.ResponseWriter, r *http.Request) {
+	var snapshot domain.ExecutionSnapshot
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWorkflowSize)).Decode(&snapshot); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid snapshot: %v", err)
+		return
+	}
+
+	err := s.orchestrator.ResumeExecution(r.Context(), &snapshot)
+	switch {
+	case errors.Is(err, domain.ErrDraining), errors.Is(err, domain.ErrStandby):
+		writeError(w, http.StatusServiceUnavailable, "%v", err)
+		return
+	case errors.Is(err, domain.ErrFenced):
+		writeError(w, http.StatusConflict, "%v", err)
+		return
+	case err != nil:
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+
+	nodeID := ""
+	if s.cluster != nil {
+		nodeID = s.cluster.Self().ID
+	}
+	w.Header().Set("Location", "/executions/"+snapshot.WorkflowID)
+	writeJSON(w, http.StatusAccepted, handoffResponse{WorkflowID: snapshot.WorkflowID, NodeID: nodeID})
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+const (
+	eventStreamBuffer    = 256
+	eventStreamKeepAlive = 15 * time.Second
+)
+
+func (s *Server) handleExecutionEvents(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if s.forwardExecution(w, r, id) {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+
+	events, unsubscribe := s.orchestrator.SubscribeEvents(eventStreamBuffer)
+	defer unsubscribe()
+
+	execution, ok := s.lookupExecution(w, id)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	if result := execution.Result; result.Status.IsTerminal() {
+		event := domain.Event{
+			Type:         domain.WorkflowFinishedEvent(result.Status),
+			WorkflowID:   id,
+			WorkflowName: execution.WorkflowName,
+			Status:       result.Status.String(),
+			Timestamp:    result.CompletedAt,
+		}
+		if result.Error != nil {
+			event.Error = result.Error.Error()
+		}
+		_ = writeEvent(w, event)
+		flusher.Flush()
+		return
+	}
+
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.streams:
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if event.WorkflowID != id {
+				continue
+			}
+			if err := writeEvent(w, event); err != nil {
+				return
+			}
+			flusher.Flush()
+			if finishesWorkflow(event.Type) {
+				return
+			}
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event domain.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
+
+func finishesWorkflow(eventType domain.EventType) bool {
+	switch eventType {
+	case domain.EventWorkflowSucceeded, domain.EventWorkflowFailed, domain.EventWorkflowCancelled:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,297 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+
+	"github.com/maestro/maestro.go/internal/application"
+	"github.com/maestro/maestro.go/internal/domain"
+	pb "github.com/maestro/maestro.go/pkg/proto"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type GRPCServer struct {
+	pb.UnimplementedOrchestratorServer
+
+	orchestrator *application.Orchestrator
+	logger       zerolog.Logger
+	addr         string
+	server       *grpc.Server
+	keys         keySet
+}
+
+func NewGRPCServer(orchestrator *application.Orchestrator, port int, logger zerolog.Logger) *GRPCServer {
+	s := &GRPCServer{
+		orchestrator: orchestrator,
+		logger:       logger,
+		addr:         fmt.Sprintf(":%d", port),
+	}
+	s.server = grpc.NewServer(
+		grpc.UnaryInterceptor(s.unaryAuth),
+		grpc.StreamInterceptor(s.streamAuth),
+	)
+	pb.RegisterOrchestratorServer(s.server, s)
+	return s
+}
+
+func (s *GRPCServer) Start() error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.addr, err)
+	}
+
+	s.logger.Info().Str("addr", s.addr).Msg("gRPC API listening")
+	if err := s.server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("gRPC server failed: %w", err)
+	}
+	return nil
+}
+
+func (s *GRPCServer) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
+
+func (s *GRPCServer) ExecuteWorkflow(ctx context.Context, req *pb.ExecuteRequest) (*pb.ExecuteResponse, error) {
+	wf, ok := s.orchestrator.GetWorkflowVersion(req.GetWorkflowName(), req.GetVersion())
+	if !ok {
+		if version := req.GetVersion(); version != "" {
+			return nil, status.Errorf(codes.NotFound, "workflow %s has no version %s loaded", req.GetWorkflowName(), version)
+		}
+		return nil, status.Errorf(codes.NotFound, "workflow %s not found", req.GetWorkflowName())
+	}
+	if version := req.GetVersion(); version != "" {
+		ctx = application.WithVersion(ctx, version)
+	}
+
+	if env := req.GetEnvironment(); env != "" {
+		if _, ok := wf.Environments[env]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "workflow %s has no environment %s", req.GetWorkflowName(), env)
+		}
+		ctx = application.WithEnvironment(ctx, env)
+	}
+	if tags := req.GetMetadata(); len(tags) > 0 {
+		if err := domain.ValidateTags(tags); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		ctx = application.WithTags(ctx, tags)
+	}
+	if key := req.GetIdempotencyKey(); key != "" {
+		ctx = application.WithIdempotencyKey(ctx, key)
+	}
+
+	input := req.GetInput().AsMap()
+	result, err := s.orchestrator.ExecuteWorkflow(ctx, req.GetWorkflowName(), input)
+	if result == nil {
+		var quarantined *domain.QuarantinedError
+		if errors.As(err, &quarantined) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		var breaker *domain.BreakerOpenError
+		if errors.As(err, &breaker) {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		var deferred *domain.CalendarError
+		if errors.As(err, &deferred) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		if errors.Is(err, domain.ErrIdempotencyConflict) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		var invalid *domain.InputError
+		if errors.As(err, &invalid) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		var preflight *domain.PreflightError
+		if errors.As(err, &preflight) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		var missing *domain.VersionNotFoundError
+		if errors.As(err, &missing) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, domain.ErrDraining) || errors.Is(err, domain.ErrStandby) {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return toExecuteResponse(result)
+}
+
+func (s *GRPCServer) GetWorkflowStatus(_ context.Context, req *pb.StatusRequest) (*pb.StatusResponse, error) {
+	execution, ok, err := s.orchestrator.GetExecution(req.GetWorkflowId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "execution %s not found", req.GetWorkflowId())
+	}
+
+	output, err := toStruct(execution.Result.Output)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode output: %v", err)
+	}
+
+	resp := &pb.StatusResponse{
+		WorkflowId: execution.Result.WorkflowID,
+		Status:     toProtoStatus(execution.Result.Status),
+		Output:     output,
+	}
+	if execution.Result.Error != nil {
+		resp.Error = execution.Result.Error.Error()
+	}
+	timings := make(map[string]domain.StepTiming)
+	for _, timing := range execution.Context.CopyTimings() {
+		timings[timing.StepID] = timing
+	}
+	for _, step := range execution.Context.CopyExecutedSteps() {
+		state := pb.StepState_STEP_STATE_SUCCESS
+		if step.Compensated {
+			state = pb.StepState_STEP_STATE_COMPENSATED
+		}
+		stepStatus := &pb.StepStatus{StepId: step.StepID, State: state}
+		if timing, ok := timings[step.StepID]; ok {
+			stepStatus.StartedAt = timestamppb.New(timing.ReadyAt)
+			stepStatus.CompletedAt = timestamppb.New(timing.FinishedAt)
+		}
+		resp.Steps = append(resp.Steps, stepStatus)
+	}
+
+	return resp, nil
+}
+
+func (s *GRPCServer) CancelWorkflow(_ context.Context, req *pb.CancelRequest) (*pb.CancelResponse, error) {
+	_, ok, err := s.orchestrator.GetExecution(req.GetWorkflowId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "execution %s not found", req.GetWorkflowId())
+	}
+
+	if err := s.orchestrator.CancelWorkflow(req.GetWorkflowId()); err != nil {
+		return &pb.CancelResponse{
+			Success: false,
+			Message: fmt.Sprintf("execution %s is not running", req.GetWorkflowId()),
+		}, nil
+	}
+
+	s.logger.Info().
+		Str("workflow_id", req.GetWorkflowId()).
+		Str("reason", req.GetReason()).
+		Msg("Execution cancelled via gRPC")
+
+	return &pb.CancelResponse{Success: true, Message: "cancelled"}, nil
+}
+
+func (s *GRPCServer) ListWorkflows(_ context.Context, _ *pb.Empty) (*pb.ListWorkflowsResponse, error) {
+	names := s.orchestrator.ListWorkflows()
+	slices.Sort(names)
+
+	resp := &pb.ListWorkflowsResponse{}
+	for _, name := range names {
+		wf, ok := s.orchestrator.GetWorkflow(name)
+		if !ok {
+			continue
+		}
+		resp.Workflows = append(resp.Workflows, &pb.WorkflowInfo{
+			Name:     wf.Name,
+			Version:  wf.Version,
+			Steps:    int32(len(wf.Steps)),
+			Versions: s.orchestrator.WorkflowVersions(name),
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *GRPCServer) RegisterWorkflow(_ context.Context, req *pb.RegisterWorkflowRequest) (*pb.RegisterWorkflowResponse, error) {
+	if len(req.GetDefinition()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "workflow definition is required")
+	}
+
+	wf, err := s.orchestrator.LoadWorkflowData(req.GetDefinition(), req.GetFormat())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &pb.RegisterWorkflowResponse{Name: wf.Name, Version: wf.Version}, nil
+}
+
+func toExecuteResponse(result *domain.WorkflowResult) (*pb.ExecuteResponse, error) {
+	output, err := toStruct(result.Output)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode output: %v", err)
+	}
+
+	resp := &pb.ExecuteResponse{
+		WorkflowId: result.WorkflowID,
+		Status:     toProtoStatus(result.Status),
+		Output:     output,
+		StartedAt:  timestamppb.New(result.StartedAt),
+	}
+	if result.Error != nil {
+		resp.Error = result.Error.Error()
+	}
+	if !result.CompletedAt.IsZero() {
+		resp.CompletedAt = timestamppb.New(result.CompletedAt)
+	}
+	return resp, nil
+}
+
+func toStruct(values map[string]interface{}) (*structpb.Struct, error) {
+	if values == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return structpb.NewStruct(normalized)
+}
+
+func toProtoStatus(s domain.WorkflowStatus) pb.WorkflowStatus {
+	switch s {
+	case domain.WorkflowStatusPending:
+		return pb.WorkflowStatus_WORKFLOW_STATUS_PENDING
+	case domain.WorkflowStatusRunning, domain.WorkflowStatusSuspended:
+		return pb.WorkflowStatus_WORKFLOW_STATUS_RUNNING
+	case domain.WorkflowStatusSuccess:
+		return pb.WorkflowStatus_WORKFLOW_STATUS_SUCCESS
+	case domain.WorkflowStatusFailed:
+		return pb.WorkflowStatus_WORKFLOW_STATUS_FAILED
+	case domain.WorkflowStatusCancelled:
+		return pb.WorkflowStatus_WORKFLOW_STATUS_CANCELLED
+	case domain.WorkflowStatusCompensating:
+		return pb.WorkflowStatus_WORKFLOW_STATUS_COMPENSATING
+	case domain.WorkflowStatusCompensated:
+		return pb.WorkflowStatus_WORKFLOW_STATUS_COMPENSATED
+	default:
+		return pb.WorkflowStatus_WORKFLOW_STATUS_UNKNOWN
+	}
+}
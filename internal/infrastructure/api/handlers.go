@@ -0,0 +1,444 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maestro/maestro.go/internal/application"
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/cluster"
+	"github.com/maestro/maestro.go/internal/infrastructure/tracing"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const maxWorkflowSize = 4 << 20
+
+var idempotencyNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("maestro/idempotency"))
+
+type executionResponse struct {
+	WorkflowID   string                          `json:"workflow_id"`
+	WorkflowName string                          `json:"workflow_name,omitempty"`
+	Status       string                          `json:"status"`
+	Output       map[string]interface{}          `json:"output,omitempty"`
+	Error        string                          `json:"error,omitempty"`
+	Template     *domain.TemplateError           `json:"template_error,omitempty"`
+	Unfinished   []domain.UnfinishedCompensation `json:"unfinished_compensations,omitempty"`
+	StartedAt    time.Time                       `json:"started_at"`
+	CompletedAt  *time.Time                      `json:"completed_at,omitempty"`
+	Plan         *domain.ExecutionPlan           `json:"plan,omitempty"`
+	Tags         map[string]string               `json:"tags,omitempty"`
+	RerunOf      string                          `json:"rerun_of,omitempty"`
+	RerunAttempt int                             `json:"rerun_attempt,omitempty"`
+	RerunID      string                          `json:"rerun_id,omitempty"`
+	HeldSteps    []string                        `json:"held_steps,omitempty"`
+	Audit        []domain.AuditEntry             `json:"audit,omitempty"`
+}
+
+type workflowResponse struct {
+	Name        string                   `json:"name"`
+	Version     string                   `json:"version"`
+	Versions    []string                 `json:"versions,omitempty"`
+	Steps       int                      `json:"steps"`
+	Quarantined *domain.QuarantinedError `json:"quarantined,omitempty"`
+	Breaker     *domain.BreakerOpenError `json:"breaker,omitempty"`
+}
+
+func newExecutionResponse(workflowName string, result *domain.WorkflowResult) executionResponse {
+	resp := executionResponse{
+		WorkflowID:   result.WorkflowID,
+		WorkflowName: workflowName,
+		Status:       result.Status.String(),
+		Output:       result.Output,
+		Unfinished:   result.UnfinishedCompensations,
+		StartedAt:    result.StartedAt,
+	}
+	if result.Error != nil {
+		resp.Error = result.Error.Error()
+		var tmplErr *domain.TemplateError
+		if errors.As(result.Error, &tmplErr) {
+			resp.Template = tmplErr
+		}
+	}
+	if !result.CompletedAt.IsZero() {
+		completedAt := result.CompletedAt
+		resp.CompletedAt = &completedAt
+	}
+	return resp
+}
+
+func (r *executionResponse) withRerun(execCtx *domain.ExecutionContext) {
+	r.RerunOf = execCtx.RerunOf
+	r.RerunAttempt = execCtx.RerunAttempt
+	r.RerunID = execCtx.RerunID
+}
+
+func (s *Server) handleListWorkflows(w http.ResponseWriter, _ *http.Request) {
+	names := s.orchestrator.ListWorkflows()
+	slices.Sort(names)
+
+	workflows := make([]workflowResponse, 0, len(names))
+	for _, name := range names {
+		wf, ok := s.orchestrator.GetWorkflow(name)
+		if !ok {
+			continue
+		}
+		quarantined, _ := s.orchestrator.Quarantined(name)
+		breaker, _ := s.orchestrator.BreakerOpen(name)
+		workflows = append(workflows, workflowResponse{
+			Name:        wf.Name,
+			Version:     wf.Version,
+			Versions:    s.orchestrator.WorkflowVersions(name),
+			Steps:       len(wf.Steps),
+			Quarantined: quarantined,
+			Breaker:     breaker,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"workflows": workflows})
+}
+
+func (s *Server) handleRegisterWorkflow(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWorkflowSize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "failed to read workflow definition: %v", err)
+		return
+	}
+
+	var format string
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "application/json" {
+		format = application.FormatJSON
+	}
+
+	wf, err := s.orchestrator.LoadWorkflowData(data, format)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+
+	s.logger.Info().
+		Str("workflow", wf.Name).
+		Str("version", wf.Version).
+		Msg("Workflow registered via API")
+
+	writeJSON(w, http.StatusOK, workflowResponse{
+		Name:    wf.Name,
+		Version: wf.Version,
+		Steps:   len(wf.Steps),
+	})
+}
+
+func (s *Server) handleUnloadWorkflow(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if err := s.orchestrator.UnloadWorkflow(name); err != nil {
+		writeError(w, http.StatusNotFound, "%v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleResumeWorkflow(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if _, ok := s.orchestrator.GetWorkflow(name); !ok {
+		writeError(w, http.StatusNotFound, "workflow %s not found", name)
+		return
+	}
+
+	if err := s.orchestrator.ResumeWorkflow(name); err != nil {
+		writeError(w, http.StatusConflict, "%v", err)
+		return
+	}
+
+	s.logger.Info().
+		Str("workflow", name).
+		Msg("Workflow resumed via API")
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeSubmissionError(w http.ResponseWriter, err error) {
+	var deferred *domain.CalendarError
+	if errors.As(err, &deferred) {
+		retryAfter := max(time.Until(deferred.RetryAt), time.Second)
+		w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Round(time.Second)/time.Second)))
+	}
+	writeError(w, submissionErrorStatus(err), "%v", err)
+}
+
+func submissionErrorStatus(err error) int {
+	var deferred *domain.CalendarError
+	if errors.As(err, &deferred) {
+		return http.StatusTooManyRequests
+	}
+	var quarantined *domain.QuarantinedError
+	if errors.As(err, &quarantined) {
+		return http.StatusServiceUnavailable
+	}
+	var breaker *domain.BreakerOpenError
+	if errors.As(err, &breaker) {
+		return http.StatusServiceUnavailable
+	}
+	var preflight *domain.PreflightError
+	if errors.As(err, &preflight) {
+		return http.StatusServiceUnavailable
+	}
+	if errors.Is(err, domain.ErrCallbackRejected) {
+		return http.StatusBadRequest
+	}
+	var invalid *domain.InputError
+	if errors.As(err, &invalid) {
+		return http.StatusBadRequest
+	}
+	var missingVersion *domain.VersionNotFoundError
+	if errors.As(err, &missingVersion) {
+		return http.StatusNotFound
+	}
+	if errors.Is(err, domain.ErrIdempotencyConflict) {
+		return http.StatusConflict
+	}
+	if errors.Is(err, domain.ErrDraining) || errors.Is(err, domain.ErrStandby) {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+func (s *Server) handleExecuteWorkflow(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	version := r.URL.Query().Get("version")
+	wf, ok := s.orchestrator.GetWorkflowVersion(name, version)
+	if !ok {
+		if version != "" {
+			writeError(w, http.StatusNotFound, "workflow %s has no version %s loaded", name, version)
+			return
+		}
+		writeError(w, http.StatusNotFound, "workflow %s not found", name)
+		return
+	}
+
+	env := r.URL.Query().Get("env")
+	if _, ok := wf.Environments[env]; env != "" && !ok {
+		writeError(w, http.StatusBadRequest, "workflow %s has no environment %s", name, env)
+		return
+	}
+
+	tags, err := domain.ParseTags(r.URL.Query()["tag"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+
+	idempotencyKey := r.Header.Get("Idempotency-Key")
+
+	var workflowID string
+	if s.cluster != nil {
+		workflowID = r.Header.Get(cluster.WorkflowIDHeader)
+		if workflowID == "" || !s.cluster.FromPeer(r) {
+			workflowID = uuid.New().String()
+			if idempotencyKey != "" {
+				workflowID = uuid.NewSHA1(idempotencyNamespace, []byte(name+"/"+idempotencyKey)).String()
+			}
+		}
+		if s.forward(w, r, workflowID) {
+			return
+		}
+	}
+
+	input := make(map[string]interface{})
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid input JSON: %v", err)
+		return
+	}
+
+	ctx := tracing.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	if env != "" {
+		ctx = application.WithEnvironment(ctx, env)
+	}
+	if version != "" {
+		ctx = application.WithVersion(ctx, version)
+	}
+	if workflowID != "" {
+		ctx = application.WithWorkflowID(ctx, workflowID)
+	}
+	if callback := r.URL.Query().Get("callback_url"); callback != "" {
+		ctx = application.WithCallback(ctx, callback)
+	}
+	if idempotencyKey != "" {
+		ctx = application.WithIdempotencyKey(ctx, idempotencyKey)
+	}
+	if len(tags) > 0 {
+		ctx = application.WithTags(ctx, tags)
+	}
+
+	if r.URL.Query().Get("async") == "true" {
+		workflowID, err := s.orchestrator.StartWorkflow(context.WithoutCancel(ctx), name, input)
+		if err != nil {
+			writeSubmissionError(w, err)
+			return
+		}
+
+		result, _, _ := s.orchestrator.GetWorkflowStatus(workflowID)
+		resp := newExecutionResponse(name, result)
+		resp.Tags = tags
+		w.Header().Set("Location", "/executions/"+workflowID)
+		writeJSON(w, http.StatusAccepted, resp)
+		return
+	}
+
+	result, err := s.orchestrator.ExecuteWorkflow(ctx, name, input)
+	if result == nil {
+		writeSubmissionError(w, err)
+		return
+	}
+
+	status := http.StatusOK
+	switch {
+	case err != nil:
+		status = http.StatusUnprocessableEntity
+	case result.Status == domain.WorkflowStatusSuspended:
+		status = http.StatusAccepted
+		w.Header().Set("Location", "/executions/"+result.WorkflowID)
+	}
+	resp := newExecutionResponse(name, result)
+	resp.Tags = tags
+	writeJSON(w, status, resp)
+}
+
+func (s *Server) handleListExecutions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.ExecutionFilter{
+		WorkflowName: query.Get("workflow"),
+		Status:       query.Get("status"),
+		RerunOf:      query.Get("rerun_of"),
+		Limit:        100,
+	}
+	tags, err := domain.ParseTags(query["tag"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	filter.Tags = tags
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit %s", limit)
+			return
+		}
+		filter.Limit = n
+	}
+
+	executions, err := s.orchestrator.ListExecutions(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	resp := make([]executionResponse, 0, len(executions))
+	for _, execution := range executions {
+		item := newExecutionResponse(execution.WorkflowName, execution.Result)
+		item.Tags = execution.Context.Tags
+		item.withRerun(execution.Context)
+		resp = append(resp, item)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"executions": resp})
+}
+
+func (s *Server) handleGetExecution(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if s.forwardExecution(w, r, id) {
+		return
+	}
+
+	execution, ok := s.lookupExecution(w, id)
+	if !ok {
+		return
+	}
+
+	resp := newExecutionResponse(execution.WorkflowName, execution.Result)
+	resp.Plan = domain.NewExecutionPlan(execution.Context.CopyTimings())
+	resp.Tags = execution.Context.Tags
+	resp.withRerun(execution.Context)
+	resp.HeldSteps = s.orchestrator.HeldSteps(id)
+	resp.Audit = execution.Context.CopyAudit()
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) lookupExecution(w http.ResponseWriter, id string) (*domain.Execution, bool) {
+	execution, ok, err := s.orchestrator.GetExecution(id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return nil, false
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "execution %s not found", id)
+		return nil, false
+	}
+	return execution, true
+}
+
+func (s *Server) handleCancelExecution(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if s.forwardExecution(w, r, id) {
+		return
+	}
+
+	execution, ok := s.lookupExecution(w, id)
+	if !ok {
+		return
+	}
+
+	if err := s.orchestrator.CancelWorkflow(id); err != nil {
+		writeError(w, http.StatusConflict, "execution %s is not running", id)
+		return
+	}
+
+	s.logger.Info().Str("workflow_id", id).Msg("Execution cancelled via API")
+	if result, ok, _ := s.orchestrator.GetWorkflowStatus(id); ok {
+		execution.Result = result
+	}
+	w.Header().Set("Location", "/executions/"+id)
+	writeJSON(w, http.StatusAccepted, newExecutionResponse(execution.WorkflowName, execution.Result))
+}
+
+func (s *Server) handleSignalExecution(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if s.forwardExecution(w, r, id) {
+		return
+	}
+
+	if _, ok := s.lookupExecution(w, id); !ok {
+		return
+	}
+
+	var payload interface{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid signal payload: %v", err)
+		return
+	}
+
+	if err := s.orchestrator.SignalWorkflow(id, r.PathValue("name"), payload); err != nil {
+		writeError(w, http.StatusConflict, "%v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	writeJSON(w, status, map[string]string{"error": fmt.Sprintf(format, args...)})
+}
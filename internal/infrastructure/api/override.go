@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type overrideRequest struct {
+	Output   interface{} `json:"output"`
+	Operator string      `json:"operator"`
+	Reason   string      `json:"reason"`
+}
+
+type overrideResponse struct {
+	WorkflowID string `json:"workflow_id"`
+	StepID     string `json:"step_id"`
+	Action     string `json:"action"`
+}
+
+func (s *Server) handleOverrideStep(w http.ResponseWriter, r *http.Request) {
+	s.overrideStep(w, r, false)
+}
+
+func (s *Server) handleFailStep(w http.ResponseWriter, r *http.Request) {
+	s.overrideStep(w, r, true)
+}
+
+func (s *Server) overrideStep(w http.ResponseWriter, r *http.Request, fail bool) {
+	id := r.PathValue("id")
+	if s.forwardExecution(w, r, id) {
+		return
+	}
+
+	if _, ok := s.lookupExecution(w, id); !ok {
+		return
+	}
+
+	var req overrideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid override: %v", err)
+		return
+	}
+	if req.Reason == "" {
+		writeError(w, http.StatusBadRequest, "a reason is required")
+		return
+	}
+
+	stepID := r.PathValue("step")
+	decision := domain.StepOverride{
+		Fail:     fail,
+		Output:   req.Output,
+		Operator: req.Operator,
+		Reason:   req.Reason,
+	}
+	if fail {
+		decision.Output = nil
+	}
+
+	err := s.orchestrator.OverrideStep(id, stepID, decision)
+	switch {
+	case errors.Is(err, domain.ErrStepNotActive):
+		writeError(w, http.StatusNotFound, "%v", err)
+		return
+	case err != nil:
+		writeError(w, http.StatusConflict, "%v", err)
+		return
+	}
+
+	action := domain.AuditStepOverridden
+	if fail {
+		action = domain.AuditStepFailed
+	}
+	writeJSON(w, http.StatusAccepted, overrideResponse{WorkflowID: id, StepID: stepID, Action: action})
+}
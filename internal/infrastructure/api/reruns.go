@@ -0,0 +1,36 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+func (s *Server) handleListReruns(w http.ResponseWriter, r *http.Request) {
+	reruns, err := s.orchestrator.ListReruns(r.Context(), r.URL.Query().Get("workflow"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	if reruns == nil {
+		reruns = []*domain.Rerun{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"reruns": reruns})
+}
+
+func (s *Server) handleCancelRerun(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if err := s.orchestrator.CancelRerun(r.Context(), id); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrRerunNotFound) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, "%v", err)
+		return
+	}
+
+	s.logger.Info().Str("rerun_id", id).Msg("Re-run cancelled via API")
+	w.WriteHeader(http.StatusNoContent)
+}
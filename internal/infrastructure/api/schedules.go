@@ -0,0 +1,7 @@
+package api
+
+import "net/http"
+
+func (s *Server) handleListSchedules(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"schedules": s.orchestrator.Schedules()})
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/application"
+	"github.com/maestro/maestro.go/internal/infrastructure/cluster"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog"
+)
+
+type Server struct {
+	orchestrator *application.Orchestrator
+	logger       zerolog.Logger
+	server       *http.Server
+	keys         keySet
+	reload       func(context.Context) error
+	cluster      *cluster.Cluster
+	streams      chan struct{}
+}
+
+func NewServer(orchestrator *application.Orchestrator, port int, logger zerolog.Logger) *Server {
+	s := &Server{
+		orchestrator: orchestrator,
+		logger:       logger,
+		streams:      make(chan struct{}),
+	}
+
+	s.server = &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           s.authenticate(s.routes()),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	s.server.RegisterOnShutdown(func() {
+		close(s.streams)
+	})
+
+	return s
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /schemas/workflow.json", s.handleWorkflowSchema)
+	mux.HandleFunc("GET /workflows", s.handleListWorkflows)
+	mux.HandleFunc("PUT /workflows", s.privileged(s.handleRegisterWorkflow))
+	mux.HandleFunc("DELETE /workflows/{name}", s.privileged(s.handleUnloadWorkflow))
+	mux.HandleFunc("POST /workflows/{name}/execute", s.handleExecuteWorkflow)
+	mux.HandleFunc("POST /workflows/{name}/resume", s.privileged(s.handleResumeWorkflow))
+	mux.HandleFunc("GET /executions", s.handleListExecutions)
+	mux.HandleFunc("GET /executions/{id}", s.handleGetExecution)
+	mux.HandleFunc("GET /executions/{id}/events", s.handleExecutionEvents)
+	mux.HandleFunc("POST /executions/{id}/cancel", s.handleCancelExecution)
+	mux.HandleFunc("GET /executions/{id}/snapshot", s.privileged(s.handleExportExecution))
+	mux.HandleFunc("POST /executions/import", s.privileged(s.handleImportExecution))
+	mux.HandleFunc("POST /executions/{id}/signals/{name}", s.handleSignalExecution)
+	mux.HandleFunc("POST /executions/{id}/steps/{step}/override", s.privileged(s.handleOverrideStep))
+	mux.HandleFunc("POST /executions/{id}/steps/{step}/fail", s.privileged(s.handleFailStep))
+	mux.HandleFunc("GET /transactions", s.handleGetTransaction)
+	mux.HandleFunc("GET /schedules", s.handleListSchedules)
+	mux.HandleFunc("GET /webhooks/deliveries", s.handleListDeliveries)
+	mux.HandleFunc("POST /webhooks/deliveries/{id}/redeliver", s.privileged(s.handleRedeliver))
+	mux.HandleFunc("GET /dead-letters", s.handleListDeadLetters)
+	mux.HandleFunc("GET /dead-letters/{id}", s.handleGetDeadLetter)
+	mux.HandleFunc("POST /dead-letters/{id}/replay", s.privileged(s.handleReplayDeadLetter))
+	mux.HandleFunc("DELETE /dead-letters/{id}", s.privileged(s.handleDeleteDeadLetter))
+	mux.HandleFunc("GET /reruns", s.handleListReruns)
+	mux.HandleFunc("DELETE /reruns/{id}", s.privileged(s.handleCancelRerun))
+	mux.HandleFunc("POST /admin/reload", s.privileged(s.handleReload))
+	mux.HandleFunc("POST /admin/drain", s.privileged(s.handleDrain))
+	mux.HandleFunc("GET /admin/standby", s.privileged(s.handleStandbyStatus))
+	mux.HandleFunc("GET "+cluster.HealthPath, s.handleClusterHealth)
+	mux.HandleFunc("POST "+cluster.HandoffPath, s.privileged(s.handleHandoff))
+	mux.Handle("GET /metrics", promhttp.HandlerFor(s.orchestrator.Metrics().Gatherer(), promhttp.HandlerOpts{}))
+	return mux
+}
+
+func (s *Server) SetReloader(reload func(context.Context) error) {
+	s.reload = reload
+}
+
+func (s *Server) Start() error {
+	s.logger.Info().Str("addr", s.server.Addr).Msg("HTTP API listening")
+	if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("HTTP server failed: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+func (s *Server) handleWorkflowSchema(w http.ResponseWriter, _ *http.Request) {
+	schema, err := s.orchestrator.WorkflowSchemaJSON()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/schema+json")
+	_, _ = w.Write(schema)
+}
+
+func (s *Server) handleReload(w http.ResponseWriter, r *http.Request) {
+	if s.reload == nil {
+		writeError(w, http.StatusNotImplemented, "server was started without a configuration file")
+		return
+	}
+
+	if err := s.reload(r.Context()); err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "reloaded"})
+}
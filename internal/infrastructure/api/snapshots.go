@@ -0,0 +1,61 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+const maxSnapshotSize = 16 << 20
+
+func (s *Server) handleExportExecution(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if s.forwardExecution(w, r, id) {
+		return
+	}
+
+	snapshot, err := s.orchestrator.ExportExecution(id)
+	switch {
+	case errors.Is(err, domain.ErrExecutionNotFound):
+		writeError(w, http.StatusNotFound, "%v", err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, snapshot)
+}
+
+func (s *Server) handleImportExecution(w http.ResponseWriter, r *http.Request) {
+	var snapshot domain.ExecutionSnapshot
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSnapshotSize)).Decode(&snapshot); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid snapshot: %v", err)
+		return
+	}
+
+	err := s.orchestrator.ImportExecution(r.Context(), &snapshot)
+	switch {
+	case errors.Is(err, domain.ErrDraining), errors.Is(err, domain.ErrStandby):
+		writeError(w, http.StatusServiceUnavailable, "%v", err)
+		return
+	case errors.Is(err, domain.ErrFenced):
+		writeError(w, http.StatusConflict, "%v", err)
+		return
+	case err != nil:
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+
+	s.logger.Info().
+		Str("workflow_id", snapshot.WorkflowID).
+		Str("status", snapshot.Status).
+		Msg("Execution imported via API")
+	w.Header().Set("Location", "/executions/"+snapshot.WorkflowID)
+	writeJSON(w, http.StatusCreated, map[string]string{
+		"workflow_id": snapshot.WorkflowID,
+		"status":      snapshot.Status,
+	})
+}
@@ -0,0 +1,12 @@
+package api
+
+import "net/http"
+
+func (s *Server) handleStandbyStatus(w http.ResponseWriter, _ *http.Request) {
+	status, ok := s.orchestrator.StandbyStatus()
+	if !ok {
+		writeError(w, http.StatusNotFound, "standby mode is not enabled")
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
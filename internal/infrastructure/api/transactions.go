@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+func (s *Server) handleGetTransaction(w http.ResponseWriter, r *http.Request) {
+	key, err := domain.ParseTags(r.URL.Query()["tag"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	if len(key) == 0 {
+		writeError(w, http.StatusBadRequest, "at least one tag is required, e.g. ?tag=order=12345")
+		return
+	}
+
+	tx, err := s.orchestrator.Transaction(r.Context(), key)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	if tx == nil {
+		writeError(w, http.StatusNotFound, "no executions tagged %s", strings.Join(r.URL.Query()["tag"], ", "))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tx)
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/maestro/maestro.go/internal/application"
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+func (s *Server) handleListDeliveries(w http.ResponseWriter, r *http.Request) {
+	deliveries, err := s.orchestrator.WebhookDeliveries(r.URL.Query().Get("workflow_id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "%v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"deliveries": deliveries})
+}
+
+func (s *Server) handleRedeliver(w http.ResponseWriter, r *http.Request) {
+	delivery, err := s.orchestrator.RedeliverWebhook(r.PathValue("id"))
+	switch {
+	case errors.Is(err, application.ErrWebhooksDisabled), errors.Is(err, domain.ErrDeliveryNotFound):
+		writeError(w, http.StatusNotFound, "%v", err)
+		return
+	case errors.Is(err, domain.ErrDeliveryInProgress):
+		writeError(w, http.StatusConflict, "%v", err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	s.logger.Info().
+		Str("delivery_id", delivery.ID).
+		Str("workflow_id", delivery.WorkflowID).
+		Msg("Webhook redelivery requested via API")
+
+	writeJSON(w, http.StatusAccepted, delivery)
+}
@@ -0,0 +1,283 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	HealthPath       = "/cluster/health"
+	HandoffPath      = "/cluster/handoff"
+	ForwardedHeader  = "X-Maestro-Forwarded-By"
+	WorkflowIDHeader = "X-Maestro-Workflow-ID"
+	PeerTokenHeader  = "X-Maestro-Peer-Token"
+
+	probeInterval  = 2 * time.Second
+	probeTimeout   = time.Second
+	failuresToDown = 3
+	handoffTimeout = 10 * time.Second
+)
+
+type Cluster struct {
+	self   Node
+	peers  []Node
+	secret string
+	client *http.Client
+	logger zerolog.Logger
+
+	mu       sync.RWMutex
+	ring     *Ring
+	failures map[string]int
+	down     map[string]bool
+	draining bool
+}
+
+func ParsePeers(spec string) ([]Node, error) {
+	var nodes []Node
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, url, ok := strings.Cut(entry, "=")
+		if !ok || id == "" || url == "" {
+			return nil, fmt.Errorf("invalid peer %q (expected id=url)", entry)
+		}
+		if slices.ContainsFunc(nodes, func(n Node) bool { return n.ID == id }) {
+			return nil, fmt.Errorf("duplicate peer %s", id)
+		}
+		nodes = append(nodes, Node{ID: id, URL: strings.TrimRight(url, "/")})
+	}
+	return nodes, nil
+}
+
+func New(selfID string, nodes []Node, secret string, logger zerolog.Logger) (*Cluster, error) {
+	i := slices.IndexFunc(nodes, func(n Node) bool { return n.ID == selfID })
+	if i < 0 {
+		return nil, fmt.Errorf("node %s is not in the peer list", selfID)
+	}
+	if secret == "" {
+		return nil, fmt.Errorf("a cluster secret is required so nodes can trust each other's forwarded requests")
+	}
+
+	c := &Cluster{
+		self:     nodes[i],
+		secret:   secret,
+		client:   &http.Client{Timeout: probeTimeout},
+		logger:   logger.With().Str("node_id", selfID).Logger(),
+		failures: make(map[string]int),
+		down:     make(map[string]bool),
+	}
+	for _, node := range nodes {
+		if node.ID != selfID {
+			c.peers = append(c.peers, node)
+		}
+	}
+	c.rebuild()
+
+	return c, nil
+}
+
+func (c *Cluster) Self() Node {
+	return c.self
+}
+
+// Sign marks an outgoing request as coming from this cluster's nodes.
+func (c *Cluster) Sign(header http.Header) {
+	header.Set(ForwardedHeader, c.self.ID)
+	header.Set(PeerTokenHeader, c.secret)
+}
+
+// FromPeer reports whether a request was forwarded by a node holding the
+// cluster secret, and so whether its routing headers can be trusted.
+func (c *Cluster) FromPeer(r *http.Request) bool {
+	token := r.Header.Get(PeerTokenHeader)
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(c.secret)) == 1
+}
+
+func (c *Cluster) Owner(workflowID string) Node {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	owner, ok := c.ring.Owner(workflowID)
+	if !ok {
+		return c.self
+	}
+	return owner
+}
+
+func (c *Cluster) MarkDown(nodeID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if nodeID == c.self.ID || c.down[nodeID] {
+		return
+	}
+	c.failures[nodeID] = failuresToDown
+	c.down[nodeID] = true
+	c.rebuildLocked()
+	c.logger.Warn().Str("peer", nodeID).Msg("Peer marked down, handing off its executions")
+}
+
+func (c *Cluster) SetDraining() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.draining {
+		return
+	}
+	c.draining = true
+	c.rebuildLocked()
+	c.logger.Warn().Msg("Node draining, leaving ring")
+}
+
+func (c *Cluster) HandOff(ctx context.Context, workflowID string, body []byte, header http.Header) (Node, error) {
+	for range c.peers {
+		owner := c.Owner(workflowID)
+		if owner.ID == c.self.ID {
+			break
+		}
+
+		err := c.handOff(ctx, owner, workflowID, body, header)
+		if err == nil {
+			return owner, nil
+		}
+		var rejected *rejectedError
+		if errors.As(err, &rejected) {
+			return Node{}, err
+		}
+
+		c.logger.Warn().
+			Err(err).
+			Str("workflow_id", workflowID).
+			Str("peer", owner.ID).
+			Msg("Peer unreachable for handoff")
+		c.MarkDown(owner.ID)
+	}
+	return Node{}, fmt.Errorf("no peer available to take over execution %s", workflowID)
+}
+
+type rejectedError struct {
+	peer   string
+	status int
+	body   string
+}
+
+func (e *rejectedError) Error() string {
+	return fmt.Sprintf("peer %s rejected handoff with HTTP %d: %s", e.peer, e.status, e.body)
+}
+
+func (c *Cluster) handOff(ctx context.Context, peer Node, workflowID string, body []byte, header http.Header) error {
+	ctx, cancel := context.WithTimeout(ctx, handoffTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, peer.URL+HandoffPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for _, name := range []string{"Authorization", "X-API-Key"} {
+		if value := header.Get(name); value != "" {
+			req.Header.Set(name, value)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	c.Sign(req.Header)
+	req.Header.Set(WorkflowIDHeader, workflowID)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		if resp.StatusCode == http.StatusServiceUnavailable {
+			return fmt.Errorf("peer %s is unavailable: %s", peer.ID, strings.TrimSpace(string(msg)))
+		}
+		return &rejectedError{peer: peer.ID, status: resp.StatusCode, body: strings.TrimSpace(string(msg))}
+	}
+	return nil
+}
+
+func (c *Cluster) Run(ctx context.Context) {
+	ticker := time.NewTicker(probeInterval)
+	defer ticker.Stop()
+
+	for {
+		c.probe(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Cluster) probe(ctx context.Context) {
+	for _, peer := range c.peers {
+		healthy := c.healthy(ctx, peer)
+
+		c.mu.Lock()
+		switch {
+		case healthy && c.down[peer.ID]:
+			delete(c.down, peer.ID)
+			c.failures[peer.ID] = 0
+			c.rebuildLocked()
+			c.logger.Info().Str("peer", peer.ID).Msg("Peer back up, rejoining ring")
+		case healthy:
+			c.failures[peer.ID] = 0
+		case !c.down[peer.ID]:
+			c.failures[peer.ID]++
+			if c.failures[peer.ID] >= failuresToDown {
+				c.down[peer.ID] = true
+				c.rebuildLocked()
+				c.logger.Warn().Str("peer", peer.ID).Msg("Peer marked down, handing off its executions")
+			}
+		}
+		c.mu.Unlock()
+	}
+}
+
+func (c *Cluster) healthy(ctx context.Context, peer Node) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, peer.URL+HealthPath, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+func (c *Cluster) rebuild() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rebuildLocked()
+}
+
+func (c *Cluster) rebuildLocked() {
+	var alive []Node
+	if !c.draining {
+		alive = append(alive, c.self)
+	}
+	for _, peer := range c.peers {
+		if !c.down[peer.ID] {
+			alive = append(alive, peer)
+		}
+	}
+	c.ring = NewRing(alive)
+}
@@ -0,0 +1,45 @@
+package cluster
+
+import (
+	"hash/crc32"
+	"slices"
+	"strconv"
+)
+
+const virtualNodes = 64
+
+type Node struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+type Ring struct {
+	hashes []uint32
+	owners map[uint32]Node
+}
+
+func NewRing(nodes []Node) *Ring {
+	r := &Ring{owners: make(map[uint32]Node, len(nodes)*virtualNodes)}
+	for _, node := range nodes {
+		for i := 0; i < virtualNodes; i++ {
+			h := crc32.ChecksumIEEE([]byte(node.ID + "#" + strconv.Itoa(i)))
+			r.hashes = append(r.hashes, h)
+			r.owners[h] = node
+		}
+	}
+	slices.Sort(r.hashes)
+	return r
+}
+
+func (r *Ring) Owner(key string) (Node, bool) {
+	if len(r.hashes) == 0 {
+		return Node{}, false
+	}
+
+	h := crc32.ChecksumIEEE([]byte(key))
+	i, _ := slices.BinarySearch(r.hashes, h)
+	if i == len(r.hashes) {
+		i = 0
+	}
+	return r.owners[r.hashes[i]], true
+}
@@ -0,0 +1,76 @@
+package deadletter
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type MemoryQueue struct {
+	mu      sync.Mutex
+	letters map[string]*domain.DeadLetter
+}
+
+func NewMemoryQueue() *MemoryQueue {
+	return &MemoryQueue{letters: make(map[string]*domain.DeadLetter)}
+}
+
+func (q *MemoryQueue) ParkDeadLetter(_ context.Context, letter *domain.DeadLetter) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	stored := *letter
+	q.letters[letter.ID] = &stored
+	return nil
+}
+
+func (q *MemoryQueue) ListDeadLetters(_ context.Context, filter domain.DeadLetterFilter) ([]*domain.DeadLetter, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	letters := make([]*domain.DeadLetter, 0, len(q.letters))
+	for _, letter := range q.letters {
+		if filter.Kind != "" && letter.Kind != filter.Kind {
+			continue
+		}
+		if filter.Source != "" && letter.Source != filter.Source {
+			continue
+		}
+		stored := *letter
+		letters = append(letters, &stored)
+	}
+
+	slices.SortFunc(letters, func(a, b *domain.DeadLetter) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	if filter.Limit > 0 && len(letters) > filter.Limit {
+		letters = letters[:filter.Limit]
+	}
+	return letters, nil
+}
+
+func (q *MemoryQueue) GetDeadLetter(_ context.Context, id string) (*domain.DeadLetter, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	letter, ok := q.letters[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrDeadLetterNotFound, id)
+	}
+	stored := *letter
+	return &stored, nil
+}
+
+func (q *MemoryQueue) DeleteDeadLetter(_ context.Context, id string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.letters[id]; !ok {
+		return fmt.Errorf("%w: %s", domain.ErrDeadLetterNotFound, id)
+	}
+	delete(q.letters, id)
+	return nil
+}
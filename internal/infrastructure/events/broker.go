@@ -0,0 +1,62 @@
+package events
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/ports"
+)
+
+type Broker struct {
+	mu          sync.RWMutex
+	subscribers map[chan domain.Event]struct{}
+	dropped     atomic.Int64
+}
+
+func NewBroker() *Broker {
+	return &Broker{subscribers: make(map[chan domain.Event]struct{})}
+}
+
+func (b *Broker) Subscribe(buffer int) (<-chan domain.Event, func()) {
+	ch := make(chan domain.Event, buffer)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+func (b *Broker) Emit(event domain.Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			b.dropped.Add(1)
+		}
+	}
+}
+
+func (b *Broker) Dropped() int64 {
+	return b.dropped.Load()
+}
+
+type Multi []ports.EventSink
+
+func (m Multi) Emit(event domain.Event) {
+	for _, sink := range m {
+		sink.Emit(event)
+	}
+}
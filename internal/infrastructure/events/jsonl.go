@@ -0,0 +1,36 @@
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/rs/zerolog"
+)
+
+type JSONLSink struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	logger  zerolog.Logger
+}
+
+func NewJSONLSink(w io.Writer, logger zerolog.Logger) *JSONLSink {
+	return &JSONLSink{
+		encoder: json.NewEncoder(w),
+		logger:  logger,
+	}
+}
+
+func (s *JSONLSink) Emit(event domain.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.encoder.Encode(event); err != nil {
+		s.logger.Warn().
+			Err(err).
+			Str("workflow_id", event.WorkflowID).
+			Str("event", string(event.Type)).
+			Msg("Failed to write event")
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package exec
+
+import (
+	osexec "os/exec"
+)
+
+func terminateGroup(cmd *osexec.Cmd) {}
@@ -0,0 +1,249 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+const (
+	defaultTimeout = 10 * time.Minute
+	terminateGrace = 5 * time.Second
+	maxOutput      = 8 << 20
+	maxStderr      = 2048
+	exitTempFail   = 75
+	inputEnvPrefix = "MAESTRO_INPUT_"
+	headerPrefix   = "MAESTRO_HEADER_"
+)
+
+var inheritedEnv = []string{"PATH", "HOME", "USER", "LANG", "LC_ALL", "TZ", "TMPDIR"}
+
+var (
+	ErrUnavailable = errors.New("command unavailable")
+	ErrTimeout     = errors.New("command timed out")
+)
+
+type Runner struct {
+	dir      string
+	commands map[string][]string
+	input    string
+	env      []string
+	timeout  time.Duration
+}
+
+func NewRunner(endpoint string, config *domain.ExecConfig) (*Runner, error) {
+	dir, err := filepath.Abs(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid working directory %s: %w", endpoint, err)
+	}
+
+	r := &Runner{dir: dir, input: domain.ExecInputStdin, timeout: defaultTimeout}
+	if config != nil {
+		r.commands = config.Commands
+		if config.Input != "" {
+			r.input = config.Input
+		}
+		if config.Timeout.Duration > 0 {
+			r.timeout = config.Timeout.Duration
+		}
+		for name, value := range config.Env {
+			r.env = append(r.env, name+"="+value)
+		}
+		sort.Strings(r.env)
+	}
+	return r, nil
+}
+
+func (r *Runner) Run(ctx context.Context, method string, input map[string]interface{}, headers map[string]string) (interface{}, error) {
+	argv, err := r.command(method)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode input: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cmd := osexec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Dir = r.dir
+	cmd.Env = append(hostEnv(), r.env...)
+	cmd.Env = append(cmd.Env, headerEnv(headers)...)
+	if r.input == domain.ExecInputEnv {
+		cmd.Env = append(cmd.Env, inputEnv(input)...)
+		cmd.Env = append(cmd.Env, "MAESTRO_INPUT="+string(encoded))
+	} else {
+		cmd.Stdin = bytes.NewReader(encoded)
+	}
+	terminateGroup(cmd)
+	cmd.WaitDelay = terminateGrace
+
+	stdout := &limitedBuffer{max: maxOutput}
+	stderr := &limitedBuffer{max: maxStderr}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, classify(ctx, method, err, stderr)
+	}
+	if stdout.truncated {
+		return nil, fmt.Errorf("%s: output exceeds %d bytes", method, maxOutput)
+	}
+
+	out := bytes.TrimSpace(stdout.Bytes())
+	if len(out) == 0 {
+		return nil, nil
+	}
+	var result interface{}
+	if err := json.Unmarshal(out, &result); err != nil {
+		return string(out), nil
+	}
+	return result, nil
+}
+
+func (r *Runner) command(method string) ([]string, error) {
+	argv, ok := r.commands[method]
+	if !ok {
+		return nil, fmt.Errorf("no command %s under exec.commands", method)
+	}
+	return argv, nil
+}
+
+func (r *Runner) Check() error {
+	info, err := os.Stat(r.dir)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%w: %s is not a directory", ErrUnavailable, r.dir)
+	}
+
+	names := make([]string, 0, len(r.commands))
+	for name := range r.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		program := r.commands[name][0]
+		if !strings.ContainsRune(program, filepath.Separator) {
+			if _, err := osexec.LookPath(program); err != nil {
+				return fmt.Errorf("%w: command %s: %w", ErrUnavailable, name, err)
+			}
+			continue
+		}
+		if !filepath.IsAbs(program) {
+			program = filepath.Join(r.dir, program)
+		}
+		if _, err := os.Stat(program); err != nil {
+			return fmt.Errorf("%w: command %s: %w", ErrUnavailable, name, err)
+		}
+	}
+	return nil
+}
+
+func classify(ctx context.Context, method string, err error, stderr *limitedBuffer) error {
+	var exitErr *osexec.ExitError
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return fmt.Errorf("%w: %s", ErrTimeout, method)
+	case errors.Is(err, osexec.ErrNotFound), errors.Is(err, fs.ErrNotExist), errors.Is(err, fs.ErrPermission):
+		return fmt.Errorf("%w: %s: %w", ErrUnavailable, method, err)
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == exitTempFail:
+		return fmt.Errorf("%w: %s exited with status %d: %s", ErrUnavailable, method, exitTempFail, stderr.String())
+	case errors.As(err, &exitErr) && exitErr.ExitCode() >= 0:
+		return fmt.Errorf("%s exited with status %d: %s", method, exitErr.ExitCode(), stderr.String())
+	}
+	return fmt.Errorf("failed to run %s: %w", method, err)
+}
+
+func hostEnv() []string {
+	var env []string
+	for _, name := range inheritedEnv {
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+value)
+		}
+	}
+	return env
+}
+
+func headerEnv(headers map[string]string) []string {
+	env := make([]string, 0, len(headers))
+	for name, value := range headers {
+		if strings.HasPrefix(strings.ToLower(name), "maestro-") {
+			env = append(env, envName(name)+"="+value)
+			continue
+		}
+		env = append(env, headerPrefix+envName(strings.TrimPrefix(name, "X-"))+"="+value)
+	}
+	sort.Strings(env)
+	return env
+}
+
+func inputEnv(input map[string]interface{}) []string {
+	env := make([]string, 0, len(input))
+	for key, value := range input {
+		s, ok := value.(string)
+		if !ok {
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				continue
+			}
+			s = string(encoded)
+		}
+		env = append(env, inputEnvPrefix+envName(key)+"="+s)
+	}
+	sort.Strings(env)
+	return env
+}
+
+func envName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+type limitedBuffer struct {
+	bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.Len(); len(p) > room {
+		b.truncated = true
+		if room > 0 {
+			b.Buffer.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
+
+func (b *limitedBuffer) String() string {
+	s := strings.TrimSpace(b.Buffer.String())
+	if b.truncated {
+		s += "..."
+	}
+	return s
+}
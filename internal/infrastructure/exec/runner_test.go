@@ -0,0 +1,133 @@
+//go:build unix
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+func TestRunnerRun(t *testing.T) {
+	commands := map[string][]string{
+		"echo":    {"/bin/sh", "-c", "cat"},
+		"env":     {"/bin/sh", "-c", `printf '{"order":"%s","region":"%s","tenant":"%s","trace":"%s","raw":%s}' "$MAESTRO_INPUT_ORDER_ID" "$REGION" "$MAESTRO_TENANT" "$MAESTRO_HEADER_TRACE_ID" "$MAESTRO_INPUT"`},
+		"leak":    {"/bin/sh", "-c", `printf '%s' "${MAESTRO_TEST_SECRET:-clean}"`},
+		"text":    {"/bin/sh", "-c", "echo shipped"},
+		"silent":  {"/bin/sh", "-c", "exit 0"},
+		"busy":    {"/bin/sh", "-c", "echo try later >&2; exit 75"},
+		"broken":  {"/bin/sh", "-c", "echo out of stock >&2; exit 1"},
+		"slow":    {"/bin/sh", "-c", "sleep 5"},
+		"missing": {"./does-not-exist"},
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		method  string
+		params  map[string]interface{}
+		headers map[string]string
+		want    interface{}
+		wantErr error
+		wantMsg string
+	}{
+		{
+			name:   "input on stdin and JSON output",
+			method: "echo",
+			params: map[string]interface{}{"order_id": "A-1"},
+			want:   map[string]interface{}{"order_id": "A-1"},
+		},
+		{
+			name:    "input, config and headers in the environment",
+			input:   domain.ExecInputEnv,
+			method:  "env",
+			params:  map[string]interface{}{"order_id": "A-1"},
+			headers: map[string]string{"maestro-tenant": "acme", "X-Trace-Id": "t-1"},
+			want: map[string]interface{}{
+				"order":  "A-1",
+				"region": "eu",
+				"tenant": "acme",
+				"trace":  "t-1",
+				"raw":    map[string]interface{}{"order_id": "A-1"},
+			},
+		},
+		{
+			name:   "only allowed variables are inherited",
+			method: "leak",
+			want:   "clean",
+		},
+		{
+			name:   "non-JSON output is returned as text",
+			method: "text",
+			want:   "shipped",
+		},
+		{
+			name:   "empty output",
+			method: "silent",
+		},
+		{
+			name:    "exit status 75 is unavailable",
+			method:  "busy",
+			wantErr: ErrUnavailable,
+			wantMsg: "try later",
+		},
+		{
+			name:    "other exit statuses fail with stderr",
+			method:  "broken",
+			wantMsg: "broken exited with status 1: out of stock",
+		},
+		{
+			name:    "timeout",
+			method:  "slow",
+			wantErr: ErrTimeout,
+		},
+		{
+			name:    "missing program is unavailable",
+			method:  "missing",
+			wantErr: ErrUnavailable,
+		},
+		{
+			name:    "unknown method",
+			method:  "refund",
+			wantMsg: "no command refund under exec.commands",
+		},
+	}
+
+	t.Setenv("MAESTRO_TEST_SECRET", "leaked")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, err := NewRunner(t.TempDir(), &domain.ExecConfig{
+				Commands: commands,
+				Input:    tt.input,
+				Env:      map[string]string{"REGION": "eu"},
+				Timeout:  domain.Duration{Duration: 200 * time.Millisecond},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := runner.Run(context.Background(), tt.method, tt.params, tt.headers)
+			if tt.wantErr != nil || tt.wantMsg != "" {
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Run() error = %v, want %v", err, tt.wantErr)
+				}
+				if err == nil || !strings.Contains(err.Error(), tt.wantMsg) {
+					t.Fatalf("Run() error = %v, want %q", err, tt.wantMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Run() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	pb "github.com/maestro/maestro.go/pkg/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	HTTPCancelPath = "/maestro/cancel"
+
+	cancelTimeout = 5 * time.Second
+)
+
+var errCancelUnsupported = errors.New("service does not support cancellation")
+
+type cancelNotice struct {
+	Method        string `json:"method"`
+	CorrelationID string `json:"correlation_id"`
+	WorkflowID    string `json:"workflow_id"`
+	StepID        string `json:"step_id"`
+	Reason        string `json:"reason"`
+}
+
+func cancelled(ctx context.Context) bool {
+	return errors.Is(ctx.Err(), context.Canceled)
+}
+
+func (c *DynamicClient) cancelInFlight(ctx context.Context, serviceName string, service *ServiceEntry, method, workflowID, stepID string) {
+	var send func(context.Context, cancelNotice) error
+	switch {
+	case service.Config.Type == "http":
+		send = func(ctx context.Context, notice cancelNotice) error {
+			return cancelHTTP(ctx, service.HTTP.Client(), service.Config.Endpoint, notice)
+		}
+	case service.Config.Type == "grpc" && !service.Config.Typed():
+		send = func(ctx context.Context, notice cancelNotice) error {
+			return c.cancelGRPC(ctx, serviceName, notice)
+		}
+	default:
+		return
+	}
+
+	notice := cancelNotice{
+		Method:        method,
+		CorrelationID: fmt.Sprintf("%s:%s", workflowID, stepID),
+		WorkflowID:    workflowID,
+		StepID:        stepID,
+		Reason:        "workflow cancelled",
+	}
+	if cause := context.Cause(ctx); cause != nil && !errors.Is(cause, context.Canceled) {
+		notice.Reason = cause.Error()
+	}
+
+	logger := c.logger.With().
+		Str("service", serviceName).
+		Str("method", method).
+		Str("workflow_id", workflowID).
+		Str("step_id", stepID).
+		Logger()
+
+	c.cancels.Add(1)
+	go func() {
+		defer c.cancels.Done()
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cancelTimeout)
+		defer cancel()
+
+		err := send(ctx, notice)
+		switch {
+		case errors.Is(err, errCancelUnsupported):
+			logger.Debug().Msg("Service does not support cancellation")
+		case err != nil:
+			logger.Warn().Err(err).Msg("Failed to cancel in-flight step")
+		default:
+			logger.Info().Str("reason", notice.Reason).Msg("Cancelled in-flight step")
+		}
+	}()
+}
+
+func (c *DynamicClient) FlushCancellations() {
+	c.cancels.Wait()
+}
+
+func (c *DynamicClient) cancelGRPC(ctx context.Context, serviceName string, notice cancelNotice) error {
+	conn, err := c.registry.GetConnection(serviceName)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+
+	resp, err := pb.NewMaestroServiceClient(conn).Cancel(ctx, &pb.CancelStepRequest{
+		Method:        notice.Method,
+		CorrelationId: notice.CorrelationID,
+		WorkflowId:    notice.WorkflowID,
+		StepId:        notice.StepID,
+		Reason:        notice.Reason,
+	})
+	if status.Code(err) == codes.Unimplemented {
+		return errCancelUnsupported
+	}
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("service returned error: %s", resp.Error)
+	}
+	return nil
+}
+
+func cancelHTTP(ctx context.Context, client *http.Client, endpoint string, notice cancelNotice) error {
+	body, err := json.Marshal(notice)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(endpoint, "/")+HTTPCancelPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound,
+		resp.StatusCode == http.StatusMethodNotAllowed,
+		resp.StatusCode == http.StatusNotImplemented:
+		return errCancelUnsupported
+	case resp.StatusCode >= 400:
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, bytes.TrimSpace(message))
+	}
+	return nil
+}
@@ -2,12 +2,28 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"strconv"
+	"sync"
 	"time"
 
 	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/amqp"
+	"github.com/maestro/maestro.go/internal/infrastructure/exec"
 	adapters "github.com/maestro/maestro.go/internal/infrastructure/http"
+	"github.com/maestro/maestro.go/internal/infrastructure/kafka"
+	"github.com/maestro/maestro.go/internal/infrastructure/lambda"
+	"github.com/maestro/maestro.go/internal/infrastructure/local"
+	"github.com/maestro/maestro.go/internal/infrastructure/nats"
+	"github.com/maestro/maestro.go/internal/infrastructure/redis"
+	"github.com/maestro/maestro.go/internal/infrastructure/sqldb"
+	"github.com/maestro/maestro.go/internal/infrastructure/tracing"
+	"github.com/maestro/maestro.go/internal/ports"
 	"github.com/rs/zerolog"
+	"github.com/sony/gobreaker"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -18,8 +34,10 @@ import (
 )
 
 type DynamicClient struct {
-	registry *ServiceRegistry
-	logger   zerolog.Logger
+	registry   *ServiceRegistry
+	logger     zerolog.Logger
+	cancels    sync.WaitGroup
+	onFailover func(service, region, reason string)
 }
 
 func NewDynamicClient(registry *ServiceRegistry, logger zerolog.Logger) *DynamicClient {
@@ -29,6 +47,13 @@ func NewDynamicClient(registry *ServiceRegistry, logger zerolog.Logger) *Dynamic
 	}
 }
 
+type CallOptions struct {
+	Headers      map[string]string
+	FullResponse bool
+	Key          string
+	Tombstone    bool
+}
+
 func (c *DynamicClient) InvokeMethod(
 	ctx context.Context,
 	serviceName string,
@@ -37,24 +62,120 @@ func (c *DynamicClient) InvokeMethod(
 	workflowID string,
 	stepID string,
 ) (interface{}, error) {
+	return c.Call(ctx, serviceName, method, input, workflowID, stepID, CallOptions{})
+}
+
+func (c *DynamicClient) Call(
+	ctx context.Context,
+	serviceName string,
+	method string,
+	input map[string]interface{},
+	workflowID string,
+	stepID string,
+	opts CallOptions,
+) (interface{}, error) {
+	if shadowed(ctx) {
+		return nil, nil
+	}
+
 	service, err := c.registry.GetService(serviceName)
 	if err != nil {
 		return nil, fmt.Errorf("service not found: %w", err)
 	}
 
-	if service.Config.Type == "http" {
-		return c.invokeHTTP(ctx, service, method, input, workflowID, stepID)
+	result, err := c.dispatch(ctx, serviceName, service, method, input, workflowID, stepID, opts)
+	if err != nil && service.Config.Failover != nil && !cancelled(ctx) {
+		result, err = c.failover(ctx, serviceName, service, method, input, workflowID, stepID, opts, err)
+	}
+	return result, err
+}
+
+func (c *DynamicClient) dispatch(
+	ctx context.Context,
+	serviceName string,
+	service *ServiceEntry,
+	method string,
+	input map[string]interface{},
+	workflowID string,
+	stepID string,
+	opts CallOptions,
+) (interface{}, error) {
+	ctx, span := startCallSpan(ctx, serviceName, service, method, stepID)
+	startedAt := time.Now()
+	headers := opts.Headers
+
+	var result interface{}
+	adapter, err := c.adapter(serviceName, service)
+	if err == nil {
+		result, err = adapter.Invoke(ctx, ports.ProtocolCall{
+			Service:      serviceName,
+			Config:       *service.Config,
+			Method:       method,
+			Input:        input,
+			Headers:      headers,
+			WorkflowID:   workflowID,
+			StepID:       stepID,
+			Key:          opts.Key,
+			Tombstone:    opts.Tombstone,
+			FullResponse: opts.FullResponse,
+		})
+	}
+
+	recordExchange(ctx, serviceName, service, method, input, headers, result, err, workflowID, stepID, startedAt)
+	tracing.End(span, err)
+
+	if err != nil && cancelled(ctx) {
+		c.cancelInFlight(ctx, serviceName, service, method, workflowID, stepID)
 	}
 
-	return c.invokeGRPC(ctx, serviceName, service, method, input, workflowID, stepID)
+	return result, err
+}
+
+func recordExchange(
+	ctx context.Context,
+	serviceName string,
+	service *ServiceEntry,
+	method string,
+	request any,
+	headers map[string]string,
+	response any,
+	err error,
+	workflowID string,
+	stepID string,
+	startedAt time.Time,
+) {
+	capture, ok := ctx.Value(ctxkeys.Capture).(*domain.Capture)
+	if !ok {
+		return
+	}
+
+	redactor, _ := ctx.Value(ctxkeys.Redactor).(*domain.Redactor)
+	exchange := domain.CapturedExchange{
+		WorkflowID: workflowID,
+		StepID:     stepID,
+		Service:    serviceName,
+		Type:       service.Config.Type,
+		Endpoint:   service.Config.Endpoint,
+		Method:     method,
+		Headers:    maps.Clone(redactor.Headers(headers)),
+		Request:    redactor.Value(request),
+		Response:   redactor.StepOutput(stepID, response),
+		StartedAt:  startedAt,
+		Duration:   domain.Duration{Duration: time.Since(startedAt)},
+	}
+	if err != nil {
+		exchange.Error = redactor.String(err.Error())
+	}
+	capture.Record(exchange)
 }
 
 func (c *DynamicClient) invokeGRPC(
 	ctx context.Context,
 	serviceName string,
-	_ *ServiceEntry,
+	service *ServiceEntry,
 	method string,
 	input map[string]interface{},
+	headers map[string]string,
 	workflowID string,
 	stepID string,
 ) (interface{}, error) {
@@ -68,6 +189,49 @@ func (c *DynamicClient) invokeGRPC(
 		return nil, fmt.Errorf("failed to get circuit breaker: %w", err)
 	}
 
+	req, err := newServiceRequest(ctx, method, input, headers, workflowID, stepID)
+	if err != nil {
+		return nil, err
+	}
+	ctx = outgoingContext(ctx, req)
+
+	var result interface{}
+	operation := func() (interface{}, error) {
+		client := pb.NewMaestroServiceClient(conn)
+		resp, err := client.Execute(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		if !resp.Success {
+			return nil, fmt.Errorf("service returned error: %s", resp.Error)
+		}
+
+		result = decodeServiceData(resp.Data)
+		return result, nil
+	}
+
+	resultInterface, err := cb.Execute(operation)
+	if err != nil {
+		c.markUnavailable(serviceName, err)
+		return nil, fmt.Errorf("gRPC invocation failed: %w", err)
+	}
+
+	if resultInterface != nil {
+		return resultInterface, nil
+	}
+
+	return result, nil
+}
+
+func newServiceRequest(
+	ctx context.Context,
+	method string,
+	input map[string]interface{},
+	headers map[string]string,
+	workflowID string,
+	stepID string,
+) (*pb.ServiceRequest, error) {
 	payload, err := structpb.NewStruct(input)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create struct payload: %w", err)
@@ -81,92 +245,549 @@ func (c *DynamicClient) invokeGRPC(
 	req := &pb.ServiceRequest{
 		Method:        method,
 		Payload:       payloadAny,
-		Headers:       make(map[string]string),
+		Headers:       make(map[string]string, len(headers)),
 		CorrelationId: fmt.Sprintf("%s:%s", workflowID, stepID),
 		WorkflowId:    workflowID,
 		StepId:        stepID,
 	}
 
+	maps.Copy(req.Headers, headers)
+	if token, ok := fencingToken(ctx); ok {
+		req.Headers[FencingTokenMetadataKey] = token
+	}
+
+	return req, nil
+}
+
+func outgoingContext(ctx context.Context, req *pb.ServiceRequest) context.Context {
 	md := metadata.New(map[string]string{
-		"workflow-id":    workflowID,
-		"step-id":        stepID,
+		"workflow-id":    req.WorkflowId,
+		"step-id":        req.StepId,
 		"correlation-id": req.CorrelationId,
 	})
-	ctx = metadata.NewOutgoingContext(ctx, md)
+	if token, ok := req.Headers[FencingTokenMetadataKey]; ok {
+		md.Set(FencingTokenMetadataKey, token)
+	}
+	if key, ok := req.Headers[IdempotencyKeyHeader]; ok {
+		md.Set(IdempotencyKeyMetadataKey, key)
+	}
+	tracing.InjectMetadata(ctx, md)
+	return metadata.NewOutgoingContext(ctx, md)
+}
 
-	var result interface{}
-	operation := func() (interface{}, error) {
-		client := pb.NewMaestroServiceClient(conn)
-		resp, err := client.Execute(ctx, req)
-		if err != nil {
-			return nil, err
+func decodeServiceData(data *anypb.Any) interface{} {
+	if data == nil {
+		return nil
+	}
+
+	var structData structpb.Struct
+	if err := data.UnmarshalTo(&structData); err != nil {
+		return data.String()
+	}
+	return structData.AsMap()
+}
+
+func (c *DynamicClient) markUnavailable(serviceName string, err error) {
+	if st, ok := status.FromError(err); ok {
+		if st.Code() == codes.Unavailable || st.Code() == codes.DeadlineExceeded {
+			c.registry.UpdateHealth(serviceName, false)
 		}
+	}
+}
 
-		if !resp.Success {
-			return nil, fmt.Errorf("service returned error: %s", resp.Error)
+func IsCircuitOpen(err error) bool {
+	return errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests)
+}
+
+func (c *DynamicClient) invokeHTTP(
+	ctx context.Context,
+	service *ServiceEntry,
+	method string,
+	input map[string]interface{},
+	headers map[string]string,
+	workflowID string,
+	stepID string,
+) (*adapters.Response, error) {
+	if token, ok := fencingToken(ctx); ok {
+		headers = maps.Clone(headers)
+		if headers == nil {
+			headers = make(map[string]string, 1)
 		}
+		headers[FencingTokenHTTPHeader] = token
+	}
+	headers = tracing.Inject(ctx, headers)
+
+	result, err := service.HTTP.InvokeHTTP(ctx, service.Config.Endpoint, method, input, headers)
+	if err != nil {
+		c.logger.Error().
+			Err(err).
+			Str("service_type", "http").
+			Str("method", method).
+			Str("workflow_id", workflowID).
+			Str("step_id", stepID).
+			Msg("HTTP invocation failed")
+		return nil, err
+	}
+
+	c.logger.Info().
+		Str("service_type", "http").
+		Str("method", method).
+		Str("workflow_id", workflowID).
+		Str("step_id", stepID).
+		Int("status", result.Status).
+		Interface("result", redacted(ctx, stepID, result.Body)).
+		Msg("HTTP invocation successful")
+
+	return result, nil
+}
+
+func (c *DynamicClient) invokeNATS(
+	ctx context.Context,
+	serviceName string,
+	service *ServiceEntry,
+	subject string,
+	input map[string]interface{},
+	headers map[string]string,
+	workflowID string,
+	stepID string,
+) (interface{}, error) {
+	if service.NATS == nil {
+		return nil, fmt.Errorf("no NATS connection for service %s", serviceName)
+	}
+
+	cb, err := c.registry.GetCircuitBreaker(serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get circuit breaker: %w", err)
+	}
 
-		if resp.Data != nil {
-			var structData structpb.Struct
-			if err := resp.Data.UnmarshalTo(&structData); err != nil {
-				result = resp.Data.String()
-			} else {
-				result = structData.AsMap()
-			}
+	msgHeaders := messageHeaders(ctx, headers, workflowID, stepID)
+	result, err := cb.Execute(func() (interface{}, error) {
+		result, err := service.NATS.Request(ctx, subject, input, msgHeaders)
+		switch {
+		case errors.Is(err, nats.ErrNoResponders):
+			return nil, status.Error(codes.Unavailable, err.Error())
+		case errors.Is(err, nats.ErrTimeout):
+			return nil, status.Error(codes.DeadlineExceeded, err.Error())
 		}
+		return result, err
+	})
+	if err != nil {
+		c.markUnavailable(serviceName, err)
+		c.logger.Error().
+			Err(err).
+			Str("service_type", "nats").
+			Str("subject", subject).
+			Str("workflow_id", workflowID).
+			Str("step_id", stepID).
+			Msg("NATS invocation failed")
+		return nil, fmt.Errorf("NATS invocation failed: %w", err)
+	}
 
-		return result, nil
+	c.logger.Info().
+		Str("service_type", "nats").
+		Str("subject", subject).
+		Str("workflow_id", workflowID).
+		Str("step_id", stepID).
+		Interface("result", redacted(ctx, stepID, result)).
+		Msg("NATS invocation successful")
+
+	return result, nil
+}
+
+func (c *DynamicClient) invokeKafka(
+	ctx context.Context,
+	serviceName string,
+	service *ServiceEntry,
+	topic string,
+	input map[string]interface{},
+	headers map[string]string,
+	opts CallOptions,
+	workflowID string,
+	stepID string,
+) (interface{}, error) {
+	if service.Kafka == nil {
+		return nil, fmt.Errorf("no Kafka producer for service %s", serviceName)
 	}
 
-	resultInterface, err := cb.Execute(operation)
+	cb, err := c.registry.GetCircuitBreaker(serviceName)
 	if err != nil {
-		if st, ok := status.FromError(err); ok {
-			if st.Code() == codes.Unavailable || st.Code() == codes.DeadlineExceeded {
-				c.registry.UpdateHealth(serviceName, false)
-			}
+		return nil, fmt.Errorf("failed to get circuit breaker: %w", err)
+	}
+
+	msgHeaders := messageHeaders(ctx, headers, workflowID, stepID)
+	result, err := cb.Execute(func() (interface{}, error) {
+		result, err := service.Kafka.Publish(ctx, topic, opts.Key, input, opts.Tombstone, msgHeaders)
+		switch {
+		case errors.Is(err, kafka.ErrUnavailable):
+			return nil, status.Error(codes.Unavailable, err.Error())
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, status.Error(codes.DeadlineExceeded, err.Error())
 		}
-		return nil, fmt.Errorf("gRPC invocation failed: %w", err)
+		return result, err
+	})
+	if err != nil {
+		c.markUnavailable(serviceName, err)
+		c.logger.Error().
+			Err(err).
+			Str("service_type", "kafka").
+			Str("topic", topic).
+			Str("workflow_id", workflowID).
+			Str("step_id", stepID).
+			Msg("Kafka publish failed")
+		return nil, fmt.Errorf("Kafka publish failed: %w", err)
 	}
 
-	if resultInterface != nil {
-		return resultInterface, nil
+	c.logger.Info().
+		Str("service_type", "kafka").
+		Str("topic", topic).
+		Str("workflow_id", workflowID).
+		Str("step_id", stepID).
+		Interface("result", redacted(ctx, stepID, result)).
+		Msg("Kafka publish successful")
+
+	return result, nil
+}
+
+func (c *DynamicClient) invokeAMQP(
+	ctx context.Context,
+	serviceName string,
+	service *ServiceEntry,
+	routingKey string,
+	input map[string]interface{},
+	headers map[string]string,
+	workflowID string,
+	stepID string,
+) (interface{}, error) {
+	if service.AMQP == nil {
+		return nil, fmt.Errorf("no AMQP client for service %s", serviceName)
+	}
+
+	cb, err := c.registry.GetCircuitBreaker(serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get circuit breaker: %w", err)
+	}
+
+	msgHeaders := messageHeaders(ctx, headers, workflowID, stepID)
+	result, err := cb.Execute(func() (interface{}, error) {
+		result, err := service.AMQP.Publish(ctx, routingKey, input, msgHeaders)
+		switch {
+		case errors.Is(err, amqp.ErrUnavailable), errors.Is(err, amqp.ErrReturned):
+			return nil, status.Error(codes.Unavailable, err.Error())
+		case errors.Is(err, amqp.ErrTimeout):
+			return nil, status.Error(codes.DeadlineExceeded, err.Error())
+		}
+		return result, err
+	})
+	if err != nil {
+		c.markUnavailable(serviceName, err)
+		c.logger.Error().
+			Err(err).
+			Str("service_type", "amqp").
+			Str("routing_key", routingKey).
+			Str("workflow_id", workflowID).
+			Str("step_id", stepID).
+			Msg("AMQP invocation failed")
+		return nil, fmt.Errorf("AMQP invocation failed: %w", err)
 	}
 
+	c.logger.Info().
+		Str("service_type", "amqp").
+		Str("routing_key", routingKey).
+		Str("workflow_id", workflowID).
+		Str("step_id", stepID).
+		Interface("result", redacted(ctx, stepID, result)).
+		Msg("AMQP invocation successful")
+
 	return result, nil
 }
 
-func (c *DynamicClient) invokeHTTP(
-	_ context.Context,
+func (c *DynamicClient) invokeSQL(
+	ctx context.Context,
+	serviceName string,
+	service *ServiceEntry,
+	statement string,
+	input map[string]interface{},
+	workflowID string,
+	stepID string,
+) (interface{}, error) {
+	if service.SQL == nil {
+		return nil, fmt.Errorf("no database for service %s", serviceName)
+	}
+
+	cb, err := c.registry.GetCircuitBreaker(serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get circuit breaker: %w", err)
+	}
+
+	result, err := cb.Execute(func() (interface{}, error) {
+		result, err := service.SQL.Run(ctx, statement, input)
+		switch {
+		case errors.Is(err, sqldb.ErrUnavailable):
+			return nil, status.Error(codes.Unavailable, err.Error())
+		case errors.Is(err, sqldb.ErrTimeout):
+			return nil, status.Error(codes.DeadlineExceeded, err.Error())
+		}
+		return result, err
+	})
+	if err != nil {
+		c.markUnavailable(serviceName, err)
+		c.logger.Error().
+			Err(err).
+			Str("service_type", "sql").
+			Str("statement", statement).
+			Str("workflow_id", workflowID).
+			Str("step_id", stepID).
+			Msg("SQL statement failed")
+		return nil, fmt.Errorf("SQL statement failed: %w", err)
+	}
+
+	c.logger.Info().
+		Str("service_type", "sql").
+		Str("statement", statement).
+		Str("workflow_id", workflowID).
+		Str("step_id", stepID).
+		Interface("result", redacted(ctx, stepID, result)).
+		Msg("SQL statement successful")
+
+	return result, nil
+}
+
+func (c *DynamicClient) invokeExec(
+	ctx context.Context,
+	serviceName string,
+	service *ServiceEntry,
+	command string,
+	input map[string]interface{},
+	headers map[string]string,
+	workflowID string,
+	stepID string,
+) (interface{}, error) {
+	if service.Exec == nil {
+		return nil, fmt.Errorf("no command runner for service %s", serviceName)
+	}
+
+	cb, err := c.registry.GetCircuitBreaker(serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get circuit breaker: %w", err)
+	}
+
+	env := messageHeaders(ctx, headers, workflowID, stepID)
+	result, err := cb.Execute(func() (interface{}, error) {
+		result, err := service.Exec.Run(ctx, command, input, env)
+		switch {
+		case errors.Is(err, exec.ErrUnavailable):
+			return nil, status.Error(codes.Unavailable, err.Error())
+		case errors.Is(err, exec.ErrTimeout):
+			return nil, status.Error(codes.DeadlineExceeded, err.Error())
+		}
+		return result, err
+	})
+	if err != nil {
+		c.markUnavailable(serviceName, err)
+		c.logger.Error().
+			Err(err).
+			Str("service_type", "exec").
+			Str("command", command).
+			Str("workflow_id", workflowID).
+			Str("step_id", stepID).
+			Msg("Command failed")
+		return nil, fmt.Errorf("command failed: %w", err)
+	}
+
+	c.logger.Info().
+		Str("service_type", "exec").
+		Str("command", command).
+		Str("workflow_id", workflowID).
+		Str("step_id", stepID).
+		Interface("result", redacted(ctx, stepID, result)).
+		Msg("Command successful")
+
+	return result, nil
+}
+
+func (c *DynamicClient) invokeLocal(
+	ctx context.Context,
+	serviceName string,
 	service *ServiceEntry,
 	method string,
 	input map[string]interface{},
+	headers map[string]string,
 	workflowID string,
 	stepID string,
 ) (interface{}, error) {
-	adapter := adapters.NewHTTPAdapter()
-	result, err := adapter.InvokeHTTP(service.Config.Endpoint, method, input)
+	cb, err := c.registry.GetCircuitBreaker(serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get circuit breaker: %w", err)
+	}
+
+	handler := service.Config.Endpoint
+	ctx = local.WithCall(ctx, local.Call{
+		Service:    handler,
+		Method:     method,
+		WorkflowID: workflowID,
+		StepID:     stepID,
+		Headers:    headers,
+	})
+	result, err := cb.Execute(func() (interface{}, error) {
+		result, err := c.registry.Handlers().Call(ctx, handler, method, input)
+		switch {
+		case errors.Is(err, local.ErrUnavailable):
+			return nil, status.Error(codes.Unavailable, err.Error())
+		case err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded):
+			return nil, status.Error(codes.DeadlineExceeded, err.Error())
+		}
+		return result, err
+	})
 	if err != nil {
+		c.markUnavailable(serviceName, err)
 		c.logger.Error().
 			Err(err).
-			Str("service_type", "http").
+			Str("service_type", "local").
+			Str("handler", handler).
 			Str("method", method).
 			Str("workflow_id", workflowID).
 			Str("step_id", stepID).
-			Msg("HTTP invocation failed")
-		return nil, err
+			Msg("Local handler failed")
+		return nil, fmt.Errorf("local handler failed: %w", err)
 	}
 
 	c.logger.Info().
-		Str("service_type", "http").
+		Str("service_type", "local").
+		Str("handler", handler).
 		Str("method", method).
 		Str("workflow_id", workflowID).
 		Str("step_id", stepID).
-		Interface("result", result).
-		Msg("HTTP invocation successful")
+		Interface("result", redacted(ctx, stepID, result)).
+		Msg("Local handler successful")
+
+	return result, nil
+}
+
+func (c *DynamicClient) invokeLambda(
+	ctx context.Context,
+	serviceName string,
+	service *ServiceEntry,
+	function string,
+	input map[string]interface{},
+	headers map[string]string,
+	workflowID string,
+	stepID string,
+) (interface{}, error) {
+	if service.Lambda == nil {
+		return nil, fmt.Errorf("no Lambda client for service %s", serviceName)
+	}
+
+	cb, err := c.registry.GetCircuitBreaker(serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get circuit breaker: %w", err)
+	}
+
+	msgHeaders := messageHeaders(ctx, headers, workflowID, stepID)
+	result, err := cb.Execute(func() (interface{}, error) {
+		result, err := service.Lambda.Invoke(ctx, function, input, msgHeaders)
+		switch {
+		case errors.Is(err, lambda.ErrUnavailable):
+			return nil, status.Error(codes.Unavailable, err.Error())
+		case errors.Is(err, lambda.ErrThrottled):
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		case errors.Is(err, lambda.ErrTimeout):
+			return nil, status.Error(codes.DeadlineExceeded, err.Error())
+		}
+		return result, err
+	})
+	if err != nil {
+		c.markUnavailable(serviceName, err)
+		c.logger.Error().
+			Err(err).
+			Str("service_type", "lambda").
+			Str("function", function).
+			Str("workflow_id", workflowID).
+			Str("step_id", stepID).
+			Msg("Lambda invocation failed")
+		return nil, fmt.Errorf("Lambda invocation failed: %w", err)
+	}
+
+	c.logger.Info().
+		Str("service_type", "lambda").
+		Str("function", function).
+		Str("workflow_id", workflowID).
+		Str("step_id", stepID).
+		Interface("result", redacted(ctx, stepID, result)).
+		Msg("Lambda invocation successful")
 
 	return result, nil
 }
 
+func (c *DynamicClient) invokeRedis(
+	ctx context.Context,
+	serviceName string,
+	service *ServiceEntry,
+	command string,
+	input map[string]interface{},
+	workflowID string,
+	stepID string,
+) (interface{}, error) {
+	if service.Redis == nil {
+		return nil, fmt.Errorf("no Redis client for service %s", serviceName)
+	}
+
+	cb, err := c.registry.GetCircuitBreaker(serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get circuit breaker: %w", err)
+	}
+
+	var held error
+	result, err := cb.Execute(func() (interface{}, error) {
+		result, err := service.Redis.Do(ctx, command, input, workflowID)
+		switch {
+		case errors.Is(err, redis.ErrLockHeld):
+			held = err
+			return nil, nil
+		case errors.Is(err, redis.ErrUnavailable):
+			return nil, status.Error(codes.Unavailable, err.Error())
+		case errors.Is(err, redis.ErrTimeout):
+			return nil, status.Error(codes.DeadlineExceeded, err.Error())
+		}
+		return result, err
+	})
+	if err == nil && held != nil {
+		err = status.Error(codes.ResourceExhausted, held.Error())
+	}
+	if err != nil {
+		c.markUnavailable(serviceName, err)
+		c.logger.Error().
+			Err(err).
+			Str("service_type", "redis").
+			Str("command", command).
+			Str("workflow_id", workflowID).
+			Str("step_id", stepID).
+			Msg("Redis command failed")
+		return nil, fmt.Errorf("Redis command failed: %w", err)
+	}
+
+	c.logger.Info().
+		Str("service_type", "redis").
+		Str("command", command).
+		Str("workflow_id", workflowID).
+		Str("step_id", stepID).
+		Interface("result", redacted(ctx, stepID, result)).
+		Msg("Redis command successful")
+
+	return result, nil
+}
+
+func messageHeaders(ctx context.Context, headers map[string]string, workflowID, stepID string) map[string]string {
+	msgHeaders := make(map[string]string, len(headers)+4)
+	maps.Copy(msgHeaders, headers)
+	msgHeaders[WorkflowIDHeader] = workflowID
+	msgHeaders[StepIDHeader] = stepID
+	msgHeaders[CorrelationIDHeader] = fmt.Sprintf("%s:%s", workflowID, stepID)
+	if token, ok := fencingToken(ctx); ok {
+		msgHeaders[FencingTokenHTTPHeader] = token
+	}
+	return tracing.Inject(ctx, msgHeaders)
+}
+
 type InvocationOptions struct {
 	Timeout        time.Duration
 	RetryAttempts  int
@@ -228,3 +849,36 @@ func isRetryableError(err error) bool {
 	}
 	return false
 }
+
+const (
+	FencingTokenMetadataKey = "fencing-token"
+	FencingTokenHTTPHeader  = "X-Fencing-Token"
+
+	WorkflowIDHeader    = "Maestro-Workflow-Id"
+	StepIDHeader        = "Maestro-Step-Id"
+	CorrelationIDHeader = "Maestro-Correlation-Id"
+
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyKeyMetadataKey = "idempotency-key"
+)
+
+func fencingToken(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(ctxkeys.FencingToken).(int64)
+	if !ok || token == 0 {
+		return "", false
+	}
+	return strconv.FormatInt(token, 10), true
+}
+
+func redacted(ctx context.Context, stepID string, value any) any {
+	redactor, _ := ctx.Value(ctxkeys.Redactor).(*domain.Redactor)
+	return redactor.StepOutput(stepID, value)
+}
+
+func shadowed(ctx context.Context) bool {
+	shadow, ok := ctx.Value(ctxkeys.Shadow).(*domain.ShadowRun)
+	if ok {
+		shadow.Suppress()
+	}
+	return ok
+}
@@ -0,0 +1,36 @@
+package grpc
+
+import (
+	"fmt"
+	"os"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func LoadDescriptorSet(path string) (*protoregistry.Files, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read descriptor set: %w", err)
+	}
+
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to decode descriptor set %s: %w", path, err)
+	}
+
+	registry := new(protoregistry.Files)
+	for _, fd := range set.GetFile() {
+		file, err := protodesc.NewFile(fd, fallbackResolver{registry})
+		if err != nil {
+			return nil, fmt.Errorf("invalid descriptor %s in %s: %w", fd.GetName(), path, err)
+		}
+		if err := registry.RegisterFile(file); err != nil {
+			return nil, fmt.Errorf("failed to register descriptor %s: %w", fd.GetName(), err)
+		}
+	}
+
+	return registry, nil
+}
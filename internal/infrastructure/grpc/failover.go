@@ -0,0 +1,99 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	adapters "github.com/maestro/maestro.go/internal/infrastructure/http"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c *DynamicClient) OnFailover(fn func(service, region, reason string)) {
+	c.onFailover = fn
+}
+
+func (c *DynamicClient) failover(
+	ctx context.Context,
+	serviceName string,
+	service *ServiceEntry,
+	method string,
+	input map[string]interface{},
+	workflowID string,
+	stepID string,
+	opts CallOptions,
+	err error,
+) (interface{}, error) {
+	policy := service.Config.Failover
+	from := service.Config.Region
+
+	for _, region := range policy.Regions {
+		reason := failoverReason(err)
+		if !policy.Triggers(reason) || cancelled(ctx) {
+			return nil, err
+		}
+
+		name := domain.RegionServiceName(serviceName, region.Name)
+		secondary, lookupErr := c.registry.GetService(name)
+		if lookupErr != nil {
+			continue
+		}
+
+		c.logger.Warn().
+			Err(err).
+			Str("service", serviceName).
+			Str("from_region", from).
+			Str("to_region", region.Name).
+			Str("reason", reason).
+			Str("workflow_id", workflowID).
+			Str("step_id", stepID).
+			Msg("Failing over to secondary region")
+		if c.onFailover != nil {
+			c.onFailover(serviceName, region.Name, reason)
+		}
+
+		var result interface{}
+		result, err = c.dispatch(ctx, name, secondary, method, input, workflowID, stepID, opts)
+		if err == nil {
+			return result, nil
+		}
+		from = region.Name
+	}
+	return nil, err
+}
+
+func failoverReason(err error) string {
+	var (
+		statusErr *adapters.StatusError
+		netErr    net.Error
+	)
+	switch {
+	case IsCircuitOpen(err):
+		return domain.FailoverCircuitOpen
+	case errors.As(err, &statusErr):
+		switch statusErr.Status {
+		case 502, 503:
+			return domain.FailoverUnavailable
+		case 504:
+			return domain.FailoverDeadlineExceeded
+		}
+		return ""
+	case errors.Is(err, context.DeadlineExceeded):
+		return domain.FailoverDeadlineExceeded
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return domain.FailoverDeadlineExceeded
+		}
+		return domain.FailoverUnavailable
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable:
+		return domain.FailoverUnavailable
+	case codes.DeadlineExceeded:
+		return domain.FailoverDeadlineExceeded
+	}
+	return ""
+}
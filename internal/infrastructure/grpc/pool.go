@@ -1,12 +1,14 @@
 package grpc
 
 import (
+	"crypto/tls"
 	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 )
@@ -16,10 +18,11 @@ type ConnectionPool struct {
 	current     int32
 	mu          sync.RWMutex
 	endpoint    string
+	tls         *tls.Config
 	size        int
 }
 
-func NewConnectionPool(endpoint string, size int) (*ConnectionPool, error) {
+func NewConnectionPool(endpoint string, size int, tlsConfig *tls.Config) (*ConnectionPool, error) {
 	if size <= 0 {
 		size = 5
 	}
@@ -27,11 +30,12 @@ func NewConnectionPool(endpoint string, size int) (*ConnectionPool, error) {
 	pool := &ConnectionPool{
 		connections: make([]*grpc.ClientConn, size),
 		endpoint:    endpoint,
+		tls:         tlsConfig,
 		size:        size,
 	}
 
 	for i := 0; i < size; i++ {
-		conn, err := createConnection(endpoint)
+		conn, err := createConnection(endpoint, tlsConfig)
 		if err != nil {
 			for j := 0; j < i; j++ {
 				_ = pool.connections[j].Close()
@@ -44,9 +48,14 @@ func NewConnectionPool(endpoint string, size int) (*ConnectionPool, error) {
 	return pool, nil
 }
 
-func createConnection(endpoint string) (*grpc.ClientConn, error) {
+func createConnection(endpoint string, tlsConfig *tls.Config) (*grpc.ClientConn, error) {
+	creds := insecure.NewCredentials()
+	if tlsConfig != nil {
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
 	opts := []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
 			Time:                30 * time.Second,
 			Timeout:             5 * time.Second,
@@ -92,7 +101,7 @@ func (p *ConnectionPool) RefreshConnection(idx int) error {
 		_ = p.connections[idx].Close()
 	}
 
-	conn, err := createConnection(p.endpoint)
+	conn, err := createConnection(p.endpoint, p.tls)
 	if err != nil {
 		return fmt.Errorf("failed to refresh connection: %w", err)
 	}
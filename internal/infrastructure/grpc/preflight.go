@@ -0,0 +1,259 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/maestro/maestro.go/pkg/proto"
+	"github.com/sony/gobreaker"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+var ErrHealthUnsupported = errors.New("service has no health check")
+
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+	"nats":  "4222",
+	"tls":   "4222",
+	"amqp":  "5672",
+	"amqps": "5671",
+}
+
+type probeCache struct {
+	mu    sync.Mutex
+	err   error
+	until time.Time
+}
+
+func (r *ServiceRegistry) CheckService(name string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.services[name]
+	if !ok {
+		return fmt.Errorf("service %s is not registered", name)
+	}
+	if _, ok := r.circuitBreakers[name]; !ok {
+		return fmt.Errorf("no circuit breaker for service %s", name)
+	}
+
+	if entry.Adapter != nil {
+		return nil
+	}
+
+	switch entry.Config.Type {
+	case "grpc":
+		if _, ok := r.connectionPools[name]; !ok {
+			return fmt.Errorf("no connection pool for service %s", name)
+		}
+	case "nats":
+		if entry.NATS == nil {
+			return fmt.Errorf("no NATS connection for service %s", name)
+		}
+	case "kafka":
+		if entry.Kafka == nil {
+			return fmt.Errorf("no Kafka producer for service %s", name)
+		}
+	case "amqp":
+		if entry.AMQP == nil {
+			return fmt.Errorf("no AMQP client for service %s", name)
+		}
+	case "sql":
+		if entry.SQL == nil {
+			return fmt.Errorf("no database for service %s", name)
+		}
+	case "redis":
+		if entry.Redis == nil {
+			return fmt.Errorf("no Redis client for service %s", name)
+		}
+	case "lambda":
+		if entry.Lambda == nil {
+			return fmt.Errorf("no Lambda client for service %s", name)
+		}
+	case "exec":
+		if entry.Exec == nil {
+			return fmt.Errorf("no command runner for service %s", name)
+		}
+	case "local":
+		return r.handlers.Check(entry.Config.Endpoint)
+	}
+	return nil
+}
+
+func (r *ServiceRegistry) ProbeService(ctx context.Context, name string, timeout, negativeTTL time.Duration) error {
+	r.mu.RLock()
+	entry, ok := r.services[name]
+	cb := r.circuitBreakers[name]
+	r.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("service %s is not registered", name)
+	}
+
+	if cb != nil && cb.State() == gobreaker.StateOpen {
+		return fmt.Errorf("circuit breaker is open")
+	}
+
+	entry.probe.mu.Lock()
+	defer entry.probe.mu.Unlock()
+	if entry.probe.err != nil && time.Now().Before(entry.probe.until) {
+		return entry.probe.err
+	}
+
+	var err error
+	switch {
+	case entry.Adapter != nil:
+		probeCtx, cancel := context.WithTimeout(ctx, timeout)
+		err = entry.Adapter.HealthCheck(probeCtx)
+		cancel()
+	case entry.SQL != nil, entry.Redis != nil:
+		err = pingDatabase(ctx, entry, timeout)
+	case entry.Lambda != nil:
+		err = dialEndpoint(ctx, entry.Lambda.Address(), timeout)
+	case entry.Exec != nil:
+		err = entry.Exec.Check()
+	case entry.Config.Type == "local":
+		err = r.handlers.Check(entry.Config.Endpoint)
+	default:
+		err = dialEndpoint(ctx, entry.Config.Endpoint, timeout)
+	}
+	entry.probe.err = err
+	entry.probe.until = time.Now().Add(negativeTTL)
+	return err
+}
+
+func (r *ServiceRegistry) CheckHealth(ctx context.Context, name string, timeout time.Duration) error {
+	entry, err := r.GetService(name)
+	if err != nil {
+		return err
+	}
+	if entry.SQL != nil || entry.Redis != nil {
+		err := pingDatabase(ctx, entry, timeout)
+		r.UpdateHealth(name, err == nil)
+		return err
+	}
+	if entry.Adapter != nil {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		err := entry.Adapter.HealthCheck(ctx)
+		r.UpdateHealth(name, err == nil)
+		return err
+	}
+	if entry.Config.Type != "grpc" {
+		return ErrHealthUnsupported
+	}
+
+	conn, err := r.GetConnection(name)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if entry.Config.Typed() {
+		resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		switch {
+		case status.Code(err) == codes.Unimplemented:
+			return ErrHealthUnsupported
+		case err != nil:
+			return fmt.Errorf("health check failed: %w", err)
+		case resp.Status != healthpb.HealthCheckResponse_SERVING:
+			r.UpdateHealth(name, false)
+			return fmt.Errorf("service reported %s", resp.Status)
+		}
+		r.UpdateHealth(name, true)
+		return nil
+	}
+
+	resp, err := pb.NewMaestroServiceClient(conn).HealthCheck(ctx, &pb.Empty{})
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		return ErrHealthUnsupported
+	case err != nil:
+		return fmt.Errorf("health check failed: %w", err)
+	case !resp.Healthy:
+		r.UpdateHealth(name, false)
+		return fmt.Errorf("service reported unhealthy: %s", resp.Message)
+	}
+	r.UpdateHealth(name, true)
+	return nil
+}
+
+func pingDatabase(ctx context.Context, entry *ServiceEntry, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var err error
+	if entry.SQL != nil {
+		err = entry.SQL.Ping(ctx)
+	} else {
+		err = entry.Redis.Ping(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("unreachable: %w", err)
+	}
+	return nil
+}
+
+func dialEndpoint(ctx context.Context, endpoint string, timeout time.Duration) error {
+	network, addresses := endpointAddresses(endpoint)
+	if len(addresses) == 0 {
+		return fmt.Errorf("no address to dial in endpoint %s", endpoint)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var (
+		dialer net.Dialer
+		errs   []error
+	)
+	for _, address := range addresses {
+		conn, err := dialer.DialContext(ctx, network, address)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return fmt.Errorf("unreachable: %w", errors.Join(errs...))
+}
+
+func endpointAddresses(endpoint string) (string, []string) {
+	if path, ok := strings.CutPrefix(endpoint, "unix:"); ok {
+		return "unix", []string{strings.TrimPrefix(path, "//")}
+	}
+
+	var addresses []string
+	for _, part := range strings.Split(endpoint, ",") {
+		part = strings.TrimSpace(part)
+		if !strings.Contains(part, "://") {
+			if part != "" {
+				addresses = append(addresses, part)
+			}
+			continue
+		}
+
+		u, err := url.Parse(part)
+		if err != nil {
+			continue
+		}
+		switch {
+		case u.Scheme == "dns" || u.Scheme == "passthrough":
+			addresses = append(addresses, strings.TrimPrefix(u.Path, "/"))
+		case u.Port() != "":
+			addresses = append(addresses, u.Host)
+		case defaultPorts[u.Scheme] != "":
+			addresses = append(addresses, net.JoinHostPort(u.Hostname(), defaultPorts[u.Scheme]))
+		}
+	}
+	return "tcp", addresses
+}
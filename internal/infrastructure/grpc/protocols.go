@@ -0,0 +1,242 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/maestro/maestro.go/internal/ports"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type protocolFunc func(c *DynamicClient, ctx context.Context, name string, service *ServiceEntry, call ports.ProtocolCall) (interface{}, error)
+
+var builtinProtocols = map[string]protocolFunc{
+	"grpc": func(c *DynamicClient, ctx context.Context, name string, service *ServiceEntry, call ports.ProtocolCall) (interface{}, error) {
+		if service.Config.Typed() {
+			return c.invokeTyped(ctx, name, service, call.Method, call.Input, call.Headers, call.WorkflowID, call.StepID)
+		}
+		return c.invokeGRPC(ctx, name, service, call.Method, call.Input, call.Headers, call.WorkflowID, call.StepID)
+	},
+	"http": func(c *DynamicClient, ctx context.Context, name string, service *ServiceEntry, call ports.ProtocolCall) (interface{}, error) {
+		resp, err := c.invokeHTTP(ctx, service, call.Method, call.Input, call.Headers, call.WorkflowID, call.StepID)
+		if err != nil {
+			return nil, err
+		}
+		if call.FullResponse {
+			return resp.Map(), nil
+		}
+		return resp.Body, nil
+	},
+	"nats": func(c *DynamicClient, ctx context.Context, name string, service *ServiceEntry, call ports.ProtocolCall) (interface{}, error) {
+		return c.invokeNATS(ctx, name, service, call.Method, call.Input, call.Headers, call.WorkflowID, call.StepID)
+	},
+	"kafka": func(c *DynamicClient, ctx context.Context, name string, service *ServiceEntry, call ports.ProtocolCall) (interface{}, error) {
+		opts := CallOptions{Key: call.Key, Tombstone: call.Tombstone}
+		return c.invokeKafka(ctx, name, service, call.Method, call.Input, call.Headers, opts, call.WorkflowID, call.StepID)
+	},
+	"amqp": func(c *DynamicClient, ctx context.Context, name string, service *ServiceEntry, call ports.ProtocolCall) (interface{}, error) {
+		return c.invokeAMQP(ctx, name, service, call.Method, call.Input, call.Headers, call.WorkflowID, call.StepID)
+	},
+	"sql": func(c *DynamicClient, ctx context.Context, name string, service *ServiceEntry, call ports.ProtocolCall) (interface{}, error) {
+		return c.invokeSQL(ctx, name, service, call.Method, call.Input, call.WorkflowID, call.StepID)
+	},
+	"redis": func(c *DynamicClient, ctx context.Context, name string, service *ServiceEntry, call ports.ProtocolCall) (interface{}, error) {
+		return c.invokeRedis(ctx, name, service, call.Method, call.Input, call.WorkflowID, call.StepID)
+	},
+	"lambda": func(c *DynamicClient, ctx context.Context, name string, service *ServiceEntry, call ports.ProtocolCall) (interface{}, error) {
+		return c.invokeLambda(ctx, name, service, call.Method, call.Input, call.Headers, call.WorkflowID, call.StepID)
+	},
+	"exec": func(c *DynamicClient, ctx context.Context, name string, service *ServiceEntry, call ports.ProtocolCall) (interface{}, error) {
+		return c.invokeExec(ctx, name, service, call.Method, call.Input, call.Headers, call.WorkflowID, call.StepID)
+	},
+	"local": func(c *DynamicClient, ctx context.Context, name string, service *ServiceEntry, call ports.ProtocolCall) (interface{}, error) {
+		return c.invokeLocal(ctx, name, service, call.Method, call.Input, call.Headers, call.WorkflowID, call.StepID)
+	},
+}
+
+func IsBuiltinProtocol(kind string) bool {
+	_, ok := builtinProtocols[kind]
+	return ok
+}
+
+func BuiltinProtocols() []string {
+	names := make([]string, 0, len(builtinProtocols))
+	for name := range builtinProtocols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func RequiresEndpoint(kind string) bool {
+	switch kind {
+	case "local", "lambda":
+		return false
+	default:
+		return IsBuiltinProtocol(kind)
+	}
+}
+
+type builtinAdapter struct {
+	client  *DynamicClient
+	name    string
+	service *ServiceEntry
+	invoke  protocolFunc
+}
+
+func (a builtinAdapter) Invoke(ctx context.Context, call ports.ProtocolCall) (interface{}, error) {
+	return a.invoke(a.client, ctx, a.name, a.service, call)
+}
+
+func (a builtinAdapter) HealthCheck(ctx context.Context) error {
+	return a.client.registry.CheckService(a.name)
+}
+
+func (a builtinAdapter) Close() error {
+	return nil
+}
+
+type registeredAdapter struct {
+	client  *DynamicClient
+	name    string
+	service *ServiceEntry
+}
+
+func (a registeredAdapter) Invoke(ctx context.Context, call ports.ProtocolCall) (interface{}, error) {
+	return a.client.invokeAdapter(ctx, a.name, a.service, call)
+}
+
+func (a registeredAdapter) HealthCheck(ctx context.Context) error {
+	return a.service.Adapter.HealthCheck(ctx)
+}
+
+func (a registeredAdapter) Close() error {
+	return a.service.Adapter.Close()
+}
+
+func (c *DynamicClient) adapter(name string, service *ServiceEntry) (ports.ProtocolAdapter, error) {
+	if service.Adapter != nil {
+		return registeredAdapter{client: c, name: name, service: service}, nil
+	}
+	kind := service.Config.Type
+	if kind == "" {
+		kind = "grpc"
+	}
+	invoke, ok := builtinProtocols[kind]
+	if !ok {
+		return nil, fmt.Errorf("no protocol adapter for service type %s", service.Config.Type)
+	}
+	return builtinAdapter{client: c, name: name, service: service, invoke: invoke}, nil
+}
+
+func (c *DynamicClient) invokeAdapter(
+	ctx context.Context,
+	serviceName string,
+	service *ServiceEntry,
+	call ports.ProtocolCall,
+) (interface{}, error) {
+	cb, err := c.registry.GetCircuitBreaker(serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get circuit breaker: %w", err)
+	}
+
+	result, err := cb.Execute(func() (interface{}, error) {
+		result, err := service.Adapter.Invoke(ctx, call)
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			if _, ok := status.FromError(err); !ok {
+				return nil, status.Error(codes.DeadlineExceeded, err.Error())
+			}
+		}
+		return result, err
+	})
+	if err != nil {
+		c.markUnavailable(serviceName, err)
+		c.logger.Error().
+			Err(err).
+			Str("service_type", service.Config.Type).
+			Str("service", serviceName).
+			Str("method", call.Method).
+			Str("workflow_id", call.WorkflowID).
+			Str("step_id", call.StepID).
+			Msg("Protocol adapter call failed")
+		return nil, fmt.Errorf("%s call failed: %w", service.Config.Type, err)
+	}
+
+	c.logger.Info().
+		Str("service_type", service.Config.Type).
+		Str("service", serviceName).
+		Str("method", call.Method).
+		Str("workflow_id", call.WorkflowID).
+		Str("step_id", call.StepID).
+		Interface("result", redacted(ctx, call.StepID, result)).
+		Msg("Protocol adapter call successful")
+
+	return result, nil
+}
+
+type protocolRegistry struct {
+	mu        sync.RWMutex
+	factories map[string]ports.ProtocolFactory
+}
+
+func newProtocolRegistry() *protocolRegistry {
+	return &protocolRegistry{factories: make(map[string]ports.ProtocolFactory)}
+}
+
+func (p *protocolRegistry) register(kind string, factory ports.ProtocolFactory) error {
+	if kind == "" {
+		return fmt.Errorf("protocol type is required")
+	}
+	if factory == nil {
+		return fmt.Errorf("protocol %s has no factory", kind)
+	}
+	if IsBuiltinProtocol(kind) {
+		return fmt.Errorf("protocol %s is built in", kind)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, exists := p.factories[kind]; exists {
+		return fmt.Errorf("protocol %s is already registered", kind)
+	}
+	p.factories[kind] = factory
+	return nil
+}
+
+func (p *protocolRegistry) factory(kind string) ports.ProtocolFactory {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.factories[kind]
+}
+
+func (p *protocolRegistry) names() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	names := make([]string, 0, len(p.factories))
+	for name := range p.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *ServiceRegistry) RegisterProtocol(kind string, factory ports.ProtocolFactory) error {
+	return r.protocols.register(kind, factory)
+}
+
+func (r *ServiceRegistry) Protocols() []string {
+	return r.protocols.names()
+}
+
+func (r *ServiceRegistry) ServiceTypes() []string {
+	return append(BuiltinProtocols(), r.Protocols()...)
+}
+
+func (r *ServiceRegistry) HasProtocol(kind string) bool {
+	return IsBuiltinProtocol(kind) || r.protocols.factory(kind) != nil
+}
@@ -0,0 +1,428 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+type reflectedMethod struct {
+	path       string
+	descriptor protoreflect.MethodDescriptor
+	types      *dynamicpb.Types
+}
+
+type methodCache struct {
+	mu      sync.Mutex
+	files   *protoregistry.Files
+	methods map[string]*reflectedMethod
+}
+
+func SplitReflectionMethod(method string) (service, name string, err error) {
+	service, name, ok := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	if !ok || service == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("method %q must be written as package.Service/Method", method)
+	}
+	return service, name, nil
+}
+
+func (c *methodCache) resolve(ctx context.Context, conn *grpc.ClientConn, method string) (*reflectedMethod, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if resolved, ok := c.methods[method]; ok {
+		return resolved, nil
+	}
+
+	serviceName, methodName, err := SplitReflectionMethod(method)
+	if err != nil {
+		return nil, err
+	}
+
+	files := c.files
+	if files == nil {
+		files, err = fetchDescriptors(ctx, conn, serviceName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, fmt.Errorf("service %s not found in descriptors: %w", serviceName, err)
+	}
+	serviceDesc, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", serviceName)
+	}
+
+	methodDesc := serviceDesc.Methods().ByName(protoreflect.Name(methodName))
+	if methodDesc == nil {
+		return nil, fmt.Errorf("service %s has no method %s", serviceName, methodName)
+	}
+	if methodDesc.IsStreamingClient() || methodDesc.IsStreamingServer() {
+		return nil, fmt.Errorf("method %s/%s is streaming, only unary methods can be invoked", serviceName, methodName)
+	}
+
+	resolved := &reflectedMethod{
+		path:       fmt.Sprintf("/%s/%s", serviceName, methodName),
+		descriptor: methodDesc,
+		types:      dynamicpb.NewTypes(files),
+	}
+
+	if c.methods == nil {
+		c.methods = make(map[string]*reflectedMethod)
+	}
+	c.methods[method] = resolved
+
+	return resolved, nil
+}
+
+func (c *methodCache) inputSchema(method string) (map[string]domain.FieldSchema, bool) {
+	c.mu.Lock()
+	resolved, ok := c.methods[method]
+	preloaded := c.files != nil
+	c.mu.Unlock()
+
+	if !ok {
+		if !preloaded {
+			return nil, false
+		}
+		var err error
+		resolved, err = c.resolve(context.Background(), nil, method)
+		if err != nil {
+			return nil, false
+		}
+	}
+
+	return messageFields(resolved.descriptor.Input()), true
+}
+
+func messageFields(message protoreflect.MessageDescriptor) map[string]domain.FieldSchema {
+	return messageSchema(message, map[protoreflect.FullName]bool{})
+}
+
+// messageSchema describes the fields of a message, descending into nested
+// messages. A message that contains itself is described once; deeper levels
+// are left as plain objects.
+func messageSchema(message protoreflect.MessageDescriptor, seen map[protoreflect.FullName]bool) map[string]domain.FieldSchema {
+	seen[message.FullName()] = true
+	defer delete(seen, message.FullName())
+
+	fields := make(map[string]domain.FieldSchema, message.Fields().Len())
+	for i := 0; i < message.Fields().Len(); i++ {
+		field := message.Fields().Get(i)
+		if field.IsMap() {
+			continue
+		}
+
+		schema, ok := fieldSchema(field, seen)
+		if !ok {
+			continue
+		}
+		if field.IsList() {
+			item := schema
+			schema = domain.FieldSchema{Type: "array", Items: &item}
+		}
+
+		fields[string(field.Name())] = schema
+		fields[field.JSONName()] = schema
+	}
+	return fields
+}
+
+func fieldSchema(field protoreflect.FieldDescriptor, seen map[protoreflect.FullName]bool) (domain.FieldSchema, bool) {
+	schema := domain.FieldSchema{}
+	switch field.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		schema.Type = "integer"
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		schema.Type = "number"
+	case protoreflect.BoolKind:
+		schema.Type = "boolean"
+	case protoreflect.EnumKind:
+		values := field.Enum().Values()
+		for j := 0; j < values.Len(); j++ {
+			value := values.Get(j)
+			schema.Enum = append(schema.Enum, string(value.Name()), strconv.Itoa(int(value.Number())))
+		}
+	case protoreflect.StringKind:
+		schema.Type = "string"
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		schema.Type = "object"
+		if !seen[field.Message().FullName()] {
+			schema.Properties = messageSchema(field.Message(), seen)
+		}
+	default:
+		return schema, false
+	}
+	return schema, true
+}
+
+type descriptorSource interface {
+	fileContainingSymbol(symbol string) ([][]byte, error)
+	fileByFilename(name string) ([][]byte, error)
+}
+
+func fetchDescriptors(ctx context.Context, conn *grpc.ClientConn, symbol string) (*protoregistry.Files, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var source descriptorSource
+	var files [][]byte
+
+	v1, err := reflectionv1.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err == nil {
+		source = &reflectionV1Source{stream: v1}
+		files, err = source.fileContainingSymbol(symbol)
+	}
+	if status.Code(err) == codes.Unimplemented {
+		v1alpha, alphaErr := reflectionv1alpha.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+		if alphaErr != nil {
+			return nil, fmt.Errorf("failed to open reflection stream: %w", alphaErr)
+		}
+		source = &reflectionV1AlphaSource{stream: v1alpha}
+		files, err = source.fileContainingSymbol(symbol)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reflection lookup of %s failed: %w", symbol, err)
+	}
+
+	protos := make(map[string]*descriptorpb.FileDescriptorProto)
+	if err := addDescriptors(protos, files); err != nil {
+		return nil, err
+	}
+
+	registry := new(protoregistry.Files)
+	var register func(name string) error
+	register = func(name string) error {
+		if _, err := registry.FindFileByPath(name); err == nil {
+			return nil
+		}
+
+		fd, ok := protos[name]
+		if !ok {
+			if _, err := protoregistry.GlobalFiles.FindFileByPath(name); err == nil {
+				return nil
+			}
+			more, err := source.fileByFilename(name)
+			if err != nil {
+				return fmt.Errorf("reflection lookup of %s failed: %w", name, err)
+			}
+			if err := addDescriptors(protos, more); err != nil {
+				return err
+			}
+			if fd, ok = protos[name]; !ok {
+				return fmt.Errorf("server did not return descriptor for %s", name)
+			}
+		}
+
+		for _, dep := range fd.GetDependency() {
+			if err := register(dep); err != nil {
+				return err
+			}
+		}
+
+		file, err := protodesc.NewFile(fd, fallbackResolver{registry})
+		if err != nil {
+			return fmt.Errorf("invalid descriptor %s: %w", name, err)
+		}
+		return registry.RegisterFile(file)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(protos)) {
+		if err := register(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
+
+func addDescriptors(protos map[string]*descriptorpb.FileDescriptorProto, files [][]byte) error {
+	for _, raw := range files {
+		fd := new(descriptorpb.FileDescriptorProto)
+		if err := proto.Unmarshal(raw, fd); err != nil {
+			return fmt.Errorf("failed to decode file descriptor: %w", err)
+		}
+		protos[fd.GetName()] = fd
+	}
+	return nil
+}
+
+type fallbackResolver struct {
+	files *protoregistry.Files
+}
+
+func (r fallbackResolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if fd, err := r.files.FindFileByPath(path); err == nil {
+		return fd, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+func (r fallbackResolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if d, err := r.files.FindDescriptorByName(name); err == nil {
+		return d, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
+
+type reflectionV1Source struct {
+	stream reflectionv1.ServerReflection_ServerReflectionInfoClient
+}
+
+func (s *reflectionV1Source) send(req *reflectionv1.ServerReflectionRequest) ([][]byte, error) {
+	if err := s.stream.Send(req); err != nil {
+		return nil, err
+	}
+	resp, err := s.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, status.Error(codes.Code(errResp.GetErrorCode()), errResp.GetErrorMessage())
+	}
+	return resp.GetFileDescriptorResponse().GetFileDescriptorProto(), nil
+}
+
+func (s *reflectionV1Source) fileContainingSymbol(symbol string) ([][]byte, error) {
+	return s.send(&reflectionv1.ServerReflectionRequest{
+		MessageRequest: &reflectionv1.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: symbol},
+	})
+}
+
+func (s *reflectionV1Source) fileByFilename(name string) ([][]byte, error) {
+	return s.send(&reflectionv1.ServerReflectionRequest{
+		MessageRequest: &reflectionv1.ServerReflectionRequest_FileByFilename{FileByFilename: name},
+	})
+}
+
+type reflectionV1AlphaSource struct {
+	stream reflectionv1alpha.ServerReflection_ServerReflectionInfoClient
+}
+
+func (s *reflectionV1AlphaSource) send(req *reflectionv1alpha.ServerReflectionRequest) ([][]byte, error) {
+	if err := s.stream.Send(req); err != nil {
+		return nil, err
+	}
+	resp, err := s.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, status.Error(codes.Code(errResp.GetErrorCode()), errResp.GetErrorMessage())
+	}
+	return resp.GetFileDescriptorResponse().GetFileDescriptorProto(), nil
+}
+
+func (s *reflectionV1AlphaSource) fileContainingSymbol(symbol string) ([][]byte, error) {
+	return s.send(&reflectionv1alpha.ServerReflectionRequest{
+		MessageRequest: &reflectionv1alpha.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: symbol},
+	})
+}
+
+func (s *reflectionV1AlphaSource) fileByFilename(name string) ([][]byte, error) {
+	return s.send(&reflectionv1alpha.ServerReflectionRequest{
+		MessageRequest: &reflectionv1alpha.ServerReflectionRequest_FileByFilename{FileByFilename: name},
+	})
+}
+
+func (c *DynamicClient) invokeTyped(
+	ctx context.Context,
+	serviceName string,
+	service *ServiceEntry,
+	method string,
+	input map[string]interface{},
+	headers map[string]string,
+	workflowID string,
+	stepID string,
+) (interface{}, error) {
+	conn, err := c.registry.GetConnection(serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+
+	cb, err := c.registry.GetCircuitBreaker(serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get circuit breaker: %w", err)
+	}
+
+	resolved, err := service.methods.resolve(ctx, conn, method)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", method, err)
+	}
+
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode input: %w", err)
+	}
+
+	req := dynamicpb.NewMessage(resolved.descriptor.Input())
+	if err := (protojson.UnmarshalOptions{Resolver: resolved.types}).Unmarshal(payload, req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "input does not match %s: %v", resolved.descriptor.Input().FullName(), err)
+	}
+
+	md := metadata.New(headers)
+	md.Set("workflow-id", workflowID)
+	md.Set("step-id", stepID)
+	md.Set("correlation-id", fmt.Sprintf("%s:%s", workflowID, stepID))
+	if token, ok := fencingToken(ctx); ok {
+		md.Set(FencingTokenMetadataKey, token)
+	}
+	tracing.InjectMetadata(ctx, md)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	resp := dynamicpb.NewMessage(resolved.descriptor.Output())
+	_, err = cb.Execute(func() (interface{}, error) {
+		return nil, conn.Invoke(ctx, resolved.path, req, resp)
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			if st.Code() == codes.Unavailable || st.Code() == codes.DeadlineExceeded {
+				c.registry.UpdateHealth(serviceName, false)
+			}
+		}
+		return nil, fmt.Errorf("gRPC invocation failed: %w", err)
+	}
+
+	data, err := protojson.MarshalOptions{
+		Resolver:        resolved.types,
+		UseProtoNames:   true,
+		EmitUnpopulated: true,
+	}.Marshal(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result, nil
+}
@@ -1,11 +1,25 @@
 package grpc
 
 import (
+	"context"
 	"fmt"
+	"reflect"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/amqp"
+	"github.com/maestro/maestro.go/internal/infrastructure/exec"
+	adapters "github.com/maestro/maestro.go/internal/infrastructure/http"
+	"github.com/maestro/maestro.go/internal/infrastructure/kafka"
+	"github.com/maestro/maestro.go/internal/infrastructure/lambda"
+	"github.com/maestro/maestro.go/internal/infrastructure/local"
+	"github.com/maestro/maestro.go/internal/infrastructure/nats"
+	"github.com/maestro/maestro.go/internal/infrastructure/openapi"
+	"github.com/maestro/maestro.go/internal/infrastructure/redis"
+	"github.com/maestro/maestro.go/internal/infrastructure/sqldb"
+	"github.com/maestro/maestro.go/internal/ports"
 	"github.com/sony/gobreaker"
 	"google.golang.org/grpc"
 )
@@ -15,6 +29,8 @@ type ServiceRegistry struct {
 	services        map[string]*ServiceEntry
 	connectionPools map[string]*ConnectionPool
 	circuitBreakers map[string]*gobreaker.CircuitBreaker
+	handlers        *local.Handlers
+	protocols       *protocolRegistry
 }
 
 type ServiceEntry struct {
@@ -22,6 +38,19 @@ type ServiceEntry struct {
 	Healthy         bool
 	LastHealthCheck time.Time
 	Connection      *grpc.ClientConn
+	OpenAPI         *openapi.Spec
+	NATS            *nats.Requester
+	Kafka           *kafka.Producer
+	AMQP            *amqp.Client
+	SQL             *sqldb.DB
+	Redis           *redis.Client
+	Lambda          *lambda.Client
+	Exec            *exec.Runner
+	HTTP            *adapters.HTTPAdapter
+	Adapter         ports.ProtocolAdapter
+
+	methods methodCache
+	probe   probeCache
 }
 
 func NewServiceRegistry() *ServiceRegistry {
@@ -29,29 +58,165 @@ func NewServiceRegistry() *ServiceRegistry {
 		services:        make(map[string]*ServiceEntry),
 		connectionPools: make(map[string]*ConnectionPool),
 		circuitBreakers: make(map[string]*gobreaker.CircuitBreaker),
+		handlers:        local.NewHandlers(),
+		protocols:       newProtocolRegistry(),
 	}
 }
 
+func (r *ServiceRegistry) Handlers() *local.Handlers {
+	return r.handlers
+}
+
+const retiredPoolGrace = time.Minute
+
 func (r *ServiceRegistry) RegisterService(name string, config *domain.Service) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.services[name]; exists {
-		return fmt.Errorf("service %s already registered", name)
+	if existing, exists := r.services[name]; exists {
+		if reflect.DeepEqual(*existing.Config, *config) {
+			return nil
+		}
+		return fmt.Errorf("service %s already registered with a different definition", name)
+	}
+
+	entry, pool, cb, err := newServiceEntry(name, config, r.protocols.factory(config.Type))
+	if err != nil {
+		return err
+	}
+
+	if pool != nil {
+		r.connectionPools[name] = pool
+	}
+	r.circuitBreakers[name] = cb
+	r.services[name] = entry
+
+	return nil
+}
+
+func (r *ServiceRegistry) ReplaceService(name string, config *domain.Service) (func(), error) {
+	entry, pool, cb, err := newServiceEntry(name, config, r.protocols.factory(config.Type))
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	retired := r.connectionPools[name]
+	retiredEntry := r.services[name]
+	if pool != nil {
+		r.connectionPools[name] = pool
+	} else {
+		delete(r.connectionPools, name)
+	}
+	r.circuitBreakers[name] = cb
+	r.services[name] = entry
+	r.mu.Unlock()
+
+	release := func() {
+		if retired != nil {
+			_ = retired.Close()
+		}
+		if retiredEntry != nil {
+			retiredEntry.closeClients()
+		}
 	}
+	return release, nil
+}
 
+func newServiceEntry(name string, config *domain.Service, factory ports.ProtocolFactory) (*ServiceEntry, *ConnectionPool, *gobreaker.CircuitBreaker, error) {
 	entry := &ServiceEntry{
 		Config:          config,
 		Healthy:         true,
 		LastHealthCheck: time.Now(),
 	}
 
+	if config.OpenAPI != "" {
+		spec, err := openapi.Load(config.OpenAPI)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to load OpenAPI document: %w", err)
+		}
+		entry.OpenAPI = spec
+	}
+
+	if config.Descriptor != "" {
+		files, err := LoadDescriptorSet(config.Descriptor)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		entry.methods.files = files
+	}
+
+	if config.Type == "nats" {
+		requester, err := nats.Connect(config.Endpoint, name)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		entry.NATS = requester
+	}
+
+	if config.Type == "kafka" {
+		entry.Kafka = kafka.NewProducer(strings.Split(config.Endpoint, ","))
+	}
+
+	if config.Type == "amqp" {
+		entry.AMQP = amqp.NewClient(config.Endpoint, name, config.AMQP)
+	}
+
+	if config.Type == "sql" {
+		db, err := sqldb.Open(config.Endpoint, name, config.SQL)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		entry.SQL = db
+	}
+
+	if config.Type == "redis" {
+		client, err := redis.NewClient(config.Endpoint, name, config.Redis)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		entry.Redis = client
+	}
+
+	if config.Type == "exec" {
+		runner, err := exec.NewRunner(config.Endpoint, config.Exec)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		entry.Exec = runner
+	}
+
+	if config.Type == "lambda" {
+		client, err := lambda.NewClient(context.Background(), config.Endpoint, config.Lambda)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		entry.Lambda = client
+	}
+
+	tlsConfig, err := clientTLS(config.TLS)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if config.Type == "http" {
+		entry.HTTP = adapters.NewHTTPAdapter(tlsConfig)
+	}
+
+	if factory != nil {
+		adapter, err := factory(name, *config)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to create %s adapter: %w", config.Type, err)
+		}
+		entry.Adapter = adapter
+	}
+
+	var pool *ConnectionPool
 	if config.Type == "grpc" {
-		pool, err := NewConnectionPool(config.Endpoint, 5)
+		pool, err = NewConnectionPool(config.Endpoint, 5, tlsConfig)
 		if err != nil {
-			return fmt.Errorf("failed to create connection pool: %w", err)
+			return nil, nil, nil, fmt.Errorf("failed to create connection pool: %w", err)
 		}
-		r.connectionPools[name] = pool
 	}
 
 	cbSettings := gobreaker.Settings{
@@ -73,12 +238,84 @@ func (r *ServiceRegistry) RegisterService(name string, config *domain.Service) e
 		},
 	}
 
-	r.circuitBreakers[name] = gobreaker.NewCircuitBreaker(cbSettings)
-	r.services[name] = entry
+	return entry, pool, gobreaker.NewCircuitBreaker(cbSettings), nil
+}
+
+func (e *ServiceEntry) retireClients() {
+	if e == nil {
+		return
+	}
+	time.AfterFunc(retiredPoolGrace, e.closeClients)
+}
+
+func (e *ServiceEntry) closeClients() {
+	if e.NATS != nil {
+		e.NATS.Close()
+	}
+	if e.Kafka != nil {
+		_ = e.Kafka.Close()
+	}
+	if e.AMQP != nil {
+		e.AMQP.Close()
+	}
+	if e.SQL != nil {
+		_ = e.SQL.Close()
+	}
+	if e.Redis != nil {
+		_ = e.Redis.Close()
+	}
+	if e.Adapter != nil {
+		_ = e.Adapter.Close()
+	}
+}
+
+func (r *ServiceRegistry) UnregisterService(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, exists := r.services[name]
+	if !exists {
+		return fmt.Errorf("service %s not found", name)
+	}
+
+	entry.closeClients()
+
+	if pool, ok := r.connectionPools[name]; ok {
+		if err := pool.Close(); err != nil {
+			return fmt.Errorf("failed to close pool for %s: %w", name, err)
+		}
+		delete(r.connectionPools, name)
+	}
+
+	delete(r.circuitBreakers, name)
+	delete(r.services, name)
 
 	return nil
 }
 
+func (r *ServiceRegistry) RetireService(name string) (pools, breakers int) {
+	r.mu.Lock()
+	retired := r.connectionPools[name]
+	retiredEntry := r.services[name]
+	if _, ok := r.circuitBreakers[name]; ok {
+		breakers = 1
+	}
+	delete(r.connectionPools, name)
+	delete(r.circuitBreakers, name)
+	delete(r.services, name)
+	r.mu.Unlock()
+
+	if retired != nil {
+		pools = 1
+		time.AfterFunc(retiredPoolGrace, func() {
+			_ = retired.Close()
+		})
+	}
+	retiredEntry.retireClients()
+
+	return pools, breakers
+}
+
 func (r *ServiceRegistry) GetService(name string) (*ServiceEntry, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -136,6 +373,25 @@ func (r *ServiceRegistry) IsHealthy(name string) bool {
 	return false
 }
 
+func (r *ServiceRegistry) InputSchema(serviceName, method string) (map[string]domain.FieldSchema, bool) {
+	r.mu.RLock()
+	entry, exists := r.services[serviceName]
+	r.mu.RUnlock()
+
+	if !exists {
+		return nil, false
+	}
+	if entry.Config.Typed() {
+		return entry.methods.inputSchema(method)
+	}
+	if entry.OpenAPI == nil {
+		return nil, false
+	}
+
+	httpMethod, path := adapters.ResolveRoute(method)
+	return entry.OpenAPI.InputSchema(httpMethod, path)
+}
+
 func (r *ServiceRegistry) ListServices() []string {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -164,4 +420,4 @@ func (r *ServiceRegistry) Close() error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
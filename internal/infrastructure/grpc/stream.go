@@ -0,0 +1,197 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/infrastructure/tracing"
+	"google.golang.org/grpc"
+
+	pb "github.com/maestro/maestro.go/pkg/proto"
+)
+
+func (c *DynamicClient) InvokeServerStream(
+	ctx context.Context,
+	serviceName string,
+	method string,
+	input map[string]interface{},
+	workflowID string,
+	stepID string,
+	opts CallOptions,
+) ([]interface{}, error) {
+	if shadowed(ctx) {
+		return nil, nil
+	}
+
+	service, conn, err := c.streamTarget(serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, span := startCallSpan(ctx, serviceName, service, method, stepID)
+	startedAt := time.Now()
+	headers := opts.Headers
+
+	req, err := newServiceRequest(ctx, method, input, headers, workflowID, stepID)
+	if err != nil {
+		tracing.End(span, err)
+		return nil, err
+	}
+
+	results := []interface{}{}
+	err = c.withBreaker(serviceName, func() error {
+		stream, err := pb.NewMaestroServiceClient(conn).ExecuteServerStream(outgoingContext(ctx, req), req)
+		if err != nil {
+			return err
+		}
+
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if !resp.Success {
+				return fmt.Errorf("service returned error after %d results: %s", len(results), resp.Error)
+			}
+			results = append(results, decodeServiceData(resp.Data))
+		}
+	})
+
+	recordExchange(ctx, serviceName, service, method, input, headers, results, err, workflowID, stepID, startedAt)
+	tracing.End(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("gRPC stream failed: %w", err)
+	}
+
+	c.logger.Info().
+		Str("service", serviceName).
+		Str("method", method).
+		Str("workflow_id", workflowID).
+		Str("step_id", stepID).
+		Int("results", len(results)).
+		Msg("gRPC server stream completed")
+
+	return results, nil
+}
+
+func (c *DynamicClient) InvokeClientStream(
+	ctx context.Context,
+	serviceName string,
+	method string,
+	inputs []map[string]interface{},
+	workflowID string,
+	stepID string,
+	opts CallOptions,
+) (interface{}, error) {
+	if shadowed(ctx) {
+		return nil, nil
+	}
+
+	service, conn, err := c.streamTarget(serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, span := startCallSpan(ctx, serviceName, service, method, stepID)
+	startedAt := time.Now()
+	headers := opts.Headers
+
+	requests := make([]*pb.ServiceRequest, len(inputs))
+	for i, input := range inputs {
+		requests[i], err = newServiceRequest(ctx, method, input, headers, workflowID, stepID)
+		if err != nil {
+			err = fmt.Errorf("message %d: %w", i, err)
+			tracing.End(span, err)
+			return nil, err
+		}
+	}
+
+	var result interface{}
+	err = c.withBreaker(serviceName, func() error {
+		header := &pb.ServiceRequest{
+			WorkflowId:    workflowID,
+			StepId:        stepID,
+			CorrelationId: fmt.Sprintf("%s:%s", workflowID, stepID),
+		}
+		if len(requests) > 0 {
+			header = requests[0]
+		}
+
+		stream, err := pb.NewMaestroServiceClient(conn).ExecuteClientStream(outgoingContext(ctx, header))
+		if err != nil {
+			return err
+		}
+
+		for _, req := range requests {
+			if err := stream.Send(req); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return err
+			}
+		}
+
+		resp, err := stream.CloseAndRecv()
+		if err != nil {
+			return err
+		}
+		if !resp.Success {
+			return fmt.Errorf("service returned error: %s", resp.Error)
+		}
+		result = decodeServiceData(resp.Data)
+		return nil
+	})
+
+	recordExchange(ctx, serviceName, service, method, inputs, headers, result, err, workflowID, stepID, startedAt)
+	tracing.End(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("gRPC stream failed: %w", err)
+	}
+
+	c.logger.Info().
+		Str("service", serviceName).
+		Str("method", method).
+		Str("workflow_id", workflowID).
+		Str("step_id", stepID).
+		Int("messages", len(requests)).
+		Msg("gRPC client stream completed")
+
+	return result, nil
+}
+
+func (c *DynamicClient) streamTarget(serviceName string) (*ServiceEntry, *grpc.ClientConn, error) {
+	service, err := c.registry.GetService(serviceName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("service not found: %w", err)
+	}
+	if service.Config.Type != "grpc" || service.Config.Typed() {
+		return nil, nil, fmt.Errorf("service %s does not support streaming, only maestro gRPC services do", serviceName)
+	}
+
+	conn, err := c.registry.GetConnection(serviceName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+	return service, conn, nil
+}
+
+func (c *DynamicClient) withBreaker(serviceName string, call func() error) error {
+	cb, err := c.registry.GetCircuitBreaker(serviceName)
+	if err != nil {
+		return fmt.Errorf("failed to get circuit breaker: %w", err)
+	}
+
+	_, err = cb.Execute(func() (interface{}, error) {
+		return nil, call()
+	})
+	if err != nil {
+		c.markUnavailable(serviceName, err)
+	}
+	return err
+}
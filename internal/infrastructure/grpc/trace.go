@@ -0,0 +1,23 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/maestro/maestro.go/internal/infrastructure/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func startCallSpan(ctx context.Context, serviceName string, service *ServiceEntry, method, stepID string) (context.Context, trace.Span) {
+	system := service.Config.Type
+	return tracing.Tracer().Start(ctx, "call "+serviceName+" "+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("rpc.system", system),
+			attribute.String("rpc.service", serviceName),
+			attribute.String("rpc.method", method),
+			attribute.String("server.address", service.Config.Endpoint),
+			attribute.String("maestro.step_id", stepID),
+		),
+	)
+}
@@ -2,6 +2,8 @@ package adapters
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -14,35 +16,72 @@ type HTTPAdapter struct {
 	client *http.Client
 }
 
-func NewHTTPAdapter() *HTTPAdapter {
-	return &HTTPAdapter{
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+type StatusError struct {
+	Status int
+	Body   string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.Status, e.Body)
+}
+
+type Response struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers"`
+	Body    interface{}       `json:"body"`
+}
+
+func (r *Response) Map() map[string]interface{} {
+	headers := make(map[string]interface{}, len(r.Headers))
+	for key, value := range r.Headers {
+		headers[key] = value
+	}
+	return map[string]interface{}{
+		"status":  r.Status,
+		"headers": headers,
+		"body":    r.Body,
 	}
 }
 
-func (a *HTTPAdapter) InvokeHTTP(endpoint, method string, input map[string]interface{}) (interface{}, error) {
-	parts := strings.SplitN(method, " ", 2)
-	httpMethod := "POST"
-	path := "/"
+func NewHTTPAdapter(tlsConfig *tls.Config) *HTTPAdapter {
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+	if tlsConfig != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		client.Transport = transport
+	}
+	return &HTTPAdapter{client: client}
+}
 
+func (a *HTTPAdapter) Client() *http.Client {
+	return a.client
+}
+
+func ResolveRoute(method string) (string, string) {
+	parts := strings.SplitN(method, " ", 2)
 	if len(parts) == 2 {
-		httpMethod = parts[0]
-		path = parts[1]
-	} else if strings.HasPrefix(method, "/") {
-		path = method
-	} else {
-		path = "/api/" + strings.ToLower(method)
+		return parts[0], parts[1]
 	}
+	if strings.HasPrefix(method, "/") {
+		return "POST", method
+	}
+	return "POST", "/api/" + strings.ToLower(method)
+}
 
+func (a *HTTPAdapter) InvokeHTTP(ctx context.Context, endpoint, method string, input map[string]interface{}, headers map[string]string) (*Response, error) {
+	httpMethod, path := ResolveRoute(method)
 	url := endpoint + path
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		url = path
+	}
 
 	var req *http.Request
 	var err error
 
 	if httpMethod == "GET" {
-		req, err = http.NewRequest(httpMethod, url, nil)
+		req, err = http.NewRequestWithContext(ctx, httpMethod, url, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -56,13 +95,17 @@ func (a *HTTPAdapter) InvokeHTTP(endpoint, method string, input map[string]inter
 		if err != nil {
 			return nil, err
 		}
-		req, err = http.NewRequest(httpMethod, url, bytes.NewBuffer(body))
+		req, err = http.NewRequestWithContext(ctx, httpMethod, url, bytes.NewBuffer(body))
 		if err != nil {
 			return nil, err
 		}
 		req.Header.Set("Content-Type", "application/json")
 	}
 
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
 	resp, err := a.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
@@ -75,12 +118,19 @@ func (a *HTTPAdapter) InvokeHTTP(endpoint, method string, input map[string]inter
 	}
 
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, &StatusError{Status: resp.StatusCode, Body: string(body)}
+	}
+
+	result := &Response{
+		Status:  resp.StatusCode,
+		Headers: make(map[string]string, len(resp.Header)),
+	}
+	for key, values := range resp.Header {
+		result.Headers[key] = strings.Join(values, ", ")
 	}
 
-	var result interface{}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return string(body), nil
+	if err := json.Unmarshal(body, &result.Body); err != nil {
+		result.Body = string(body)
 	}
 
 	return result, nil
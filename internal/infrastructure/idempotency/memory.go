@@ -0,0 +1,58 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]*domain.IdempotencyRecord
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[string]*domain.IdempotencyRecord)}
+}
+
+func (s *MemoryStore) ClaimIdempotencyKey(_ context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := record.Workflow + "/" + record.Key
+	if existing, ok := s.records[id]; ok && existing.ExpiresAt.After(time.Now()) {
+		stored := *existing
+		return &stored, false, nil
+	}
+
+	stored := *record
+	s.records[id] = &stored
+	return record, true, nil
+}
+
+func (s *MemoryStore) ReleaseIdempotencyKey(_ context.Context, workflow, key, workflowID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := workflow + "/" + key
+	if existing, ok := s.records[id]; ok && existing.WorkflowID == workflowID {
+		delete(s.records, id)
+	}
+	return nil
+}
+
+func (s *MemoryStore) PurgeIdempotencyKeys(_ context.Context, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	purged := 0
+	for id, record := range s.records {
+		if record.ExpiresAt.Before(before) {
+			delete(s.records, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
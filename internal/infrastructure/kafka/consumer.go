@@ -0,0 +1,73 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/ports"
+	"github.com/rs/zerolog"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+type Consumer struct {
+	brokers []string
+	logger  zerolog.Logger
+}
+
+func NewConsumer(brokers []string, logger zerolog.Logger) *Consumer {
+	return &Consumer{
+		brokers: brokers,
+		logger:  logger,
+	}
+}
+
+func (c *Consumer) Consume(ctx context.Context, topic, group string, handle ports.TriggerHandler) error {
+	reader := kafkago.NewReader(kafkago.ReaderConfig{
+		Brokers:  c.brokers,
+		Topic:    topic,
+		GroupID:  group,
+		MaxBytes: 10 << 20,
+		ErrorLogger: kafkago.LoggerFunc(func(format string, args ...interface{}) {
+			c.logger.Warn().
+				Str("topic", topic).
+				Str("group", group).
+				Msgf("Kafka reader: "+format, args...)
+		}),
+	})
+	defer reader.Close()
+
+	for {
+		message, err := reader.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return ctx.Err()
+			}
+			return fmt.Errorf("failed to fetch from topic %s: %w", topic, err)
+		}
+
+		headers := make(map[string]string, len(message.Headers))
+		for _, header := range message.Headers {
+			headers[header.Key] = string(header.Value)
+		}
+
+		msg := &domain.TriggerMessage{
+			Source:  "kafka:" + topic,
+			Payload: message.Value,
+			Headers: headers,
+		}
+		if err := handle(ctx, msg); err != nil {
+			return fmt.Errorf("failed to handle message at %s/%d offset %d: %w", topic, message.Partition, message.Offset, err)
+		}
+
+		if err := reader.CommitMessages(ctx, message); err != nil {
+			return fmt.Errorf("failed to commit offset %d on %s/%d: %w", message.Offset, topic, message.Partition, err)
+		}
+		c.logger.Debug().
+			Str("topic", topic).
+			Int("partition", message.Partition).
+			Int64("offset", message.Offset).
+			Msg("Kafka message handled")
+	}
+}
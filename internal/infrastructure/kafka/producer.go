@@ -0,0 +1,102 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+const messageIDHeader = "Maestro-Message-Id"
+
+var ErrUnavailable = errors.New("kafka unavailable")
+
+type Producer struct {
+	writer    *kafkago.Writer
+	delivered sync.Map
+}
+
+func NewProducer(brokers []string) *Producer {
+	p := &Producer{}
+	p.writer = &kafkago.Writer{
+		Addr:         kafkago.TCP(brokers...),
+		Balancer:     &kafkago.Hash{},
+		RequiredAcks: kafkago.RequireAll,
+		BatchTimeout: 5 * time.Millisecond,
+		Completion:   p.complete,
+	}
+	return p
+}
+
+func (p *Producer) Publish(
+	ctx context.Context,
+	topic, key string,
+	value map[string]interface{},
+	tombstone bool,
+	headers map[string]string,
+) (map[string]interface{}, error) {
+	msg := kafkago.Message{Topic: topic}
+	if key != "" {
+		msg.Key = []byte(key)
+	}
+	if !tombstone {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode message: %w", err)
+		}
+		msg.Value = data
+	}
+
+	id := uuid.New().String()
+	msg.Headers = append(msg.Headers, kafkago.Header{Key: messageIDHeader, Value: []byte(id)})
+	for name, value := range headers {
+		msg.Headers = append(msg.Headers, kafkago.Header{Key: name, Value: []byte(value)})
+	}
+
+	err := p.writer.WriteMessages(ctx, msg)
+	delivered, _ := p.delivered.LoadAndDelete(id)
+	if err != nil {
+		var (
+			kerr   kafkago.Error
+			netErr net.Error
+		)
+		if errors.As(err, &kerr) && kerr.Temporary() || errors.As(err, &netErr) {
+			return nil, fmt.Errorf("%w: publish to %s failed: %w", ErrUnavailable, topic, err)
+		}
+		return nil, fmt.Errorf("publish to %s failed: %w", topic, err)
+	}
+
+	result := map[string]interface{}{
+		"topic":     topic,
+		"key":       key,
+		"tombstone": tombstone,
+	}
+	if m, ok := delivered.(kafkago.Message); ok {
+		result["partition"] = m.Partition
+		result["offset"] = m.Offset
+	}
+	return result, nil
+}
+
+func (p *Producer) complete(messages []kafkago.Message, err error) {
+	if err != nil {
+		return
+	}
+	for _, m := range messages {
+		for _, header := range m.Headers {
+			if header.Key == messageIDHeader {
+				p.delivered.Store(string(header.Value), m)
+			}
+		}
+	}
+}
+
+func (p *Producer) Close() error {
+	return p.writer.Close()
+}
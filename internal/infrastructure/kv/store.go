@@ -0,0 +1,212 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type entry struct {
+	Value     any       `json:"value"`
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+}
+
+func (e entry) expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && now.After(e.ExpiresAt)
+}
+
+type Store struct {
+	mu         sync.Mutex
+	path       string
+	namespaces map[string]map[string]entry
+}
+
+func NewMemoryStore() *Store {
+	return &Store{
+		namespaces: make(map[string]map[string]entry),
+	}
+}
+
+func NewFileStore(path string) (*Store, error) {
+	s := NewMemoryStore()
+	s.path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kv store: %w", err)
+	}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &s.namespaces); err != nil {
+			return nil, fmt.Errorf("failed to decode kv store: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+func (s *Store) Get(_ context.Context, namespace, key string) (any, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.namespaces[namespace][key]
+	if !ok || e.expired(time.Now()) {
+		return nil, false, nil
+	}
+	return e.Value, true, nil
+}
+
+func (s *Store) Set(_ context.Context, namespace, key string, value any, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, isFloat := value.(float64); !isFloat {
+		if counter, ok := Counter(value); ok {
+			value = counter
+		}
+	}
+	e := entry{Value: value, ExpiresAt: expiry(ttl)}
+	return s.write(namespace, key, &e)
+}
+
+func (s *Store) Delete(_ context.Context, namespace, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.write(namespace, key, nil)
+}
+
+func (s *Store) Increment(_ context.Context, namespace, key string, delta int64, ttl time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var current int64
+	e, ok := s.namespaces[namespace][key]
+	if ok && !e.expired(time.Now()) {
+		var isCounter bool
+		if current, isCounter = Counter(e.Value); !isCounter {
+			return 0, fmt.Errorf("key %s/%s does not hold a counter", namespace, key)
+		}
+	} else {
+		e = entry{ExpiresAt: expiry(ttl)}
+	}
+
+	e.Value = current + delta
+	if err := s.write(namespace, key, &e); err != nil {
+		return 0, err
+	}
+	return current + delta, nil
+}
+
+// Counter reads a stored value as a counter. Values set from YAML or Go
+// arrive as any integer type, and values read back from the file as float64.
+func Counter(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint:
+		return int64(v), true
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint64:
+		return int64(v), true
+	case float64:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
+
+func (s *Store) write(namespace, key string, e *entry) error {
+	previous, existed := s.namespaces[namespace][key]
+	if e == nil {
+		delete(s.namespaces[namespace], key)
+	} else {
+		s.put(namespace, key, *e)
+	}
+
+	if err := s.persist(); err != nil {
+		if existed {
+			s.put(namespace, key, previous)
+		} else {
+			delete(s.namespaces[namespace], key)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *Store) put(namespace, key string, e entry) {
+	values, ok := s.namespaces[namespace]
+	if !ok {
+		values = make(map[string]entry)
+		s.namespaces[namespace] = values
+	}
+	values[key] = e
+}
+
+func (s *Store) persist() error {
+	if s.path == "" {
+		return nil
+	}
+
+	now := time.Now()
+	for _, values := range s.namespaces {
+		for key, e := range values {
+			if e.expired(now) {
+				delete(values, key)
+			}
+		}
+	}
+
+	data, err := json.Marshal(s.namespaces)
+	if err != nil {
+		return fmt.Errorf("failed to encode kv store: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".kv-*")
+	if err != nil {
+		return fmt.Errorf("failed to write kv store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write kv store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write kv store: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write kv store: %w", err)
+	}
+	return nil
+}
+
+func expiry(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
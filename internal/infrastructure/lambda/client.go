@@ -0,0 +1,162 @@
+package lambda
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	awslambda "github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/aws/smithy-go"
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+const maxClientContext = 3583
+
+var (
+	ErrUnavailable = errors.New("lambda unavailable")
+	ErrThrottled   = errors.New("lambda throttled")
+	ErrTimeout     = errors.New("lambda invocation timed out")
+)
+
+type Client struct {
+	client   *awslambda.Client
+	region   string
+	endpoint string
+	async    bool
+}
+
+func NewClient(ctx context.Context, endpoint string, settings *domain.LambdaConfig) (*Client, error) {
+	c := &Client{}
+	if settings != nil {
+		c.region = settings.Region
+		c.async = settings.Async
+	}
+	if strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://") {
+		c.endpoint = endpoint
+	} else if c.region == "" {
+		c.region = endpoint
+	}
+
+	opts := []func(*config.LoadOptions) error{config.WithRetryMaxAttempts(1)}
+	if c.region != "" {
+		opts = append(opts, config.WithRegion(c.region))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("no AWS region: set lambda.region, AWS_REGION or use the region as endpoint")
+	}
+	c.region = cfg.Region
+
+	c.client = awslambda.NewFromConfig(cfg, func(o *awslambda.Options) {
+		if c.endpoint != "" {
+			o.BaseEndpoint = aws.String(c.endpoint)
+		}
+	})
+	return c, nil
+}
+
+func (c *Client) Invoke(ctx context.Context, function string, input map[string]interface{}, headers map[string]string) (interface{}, error) {
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	params := &awslambda.InvokeInput{
+		FunctionName:   aws.String(function),
+		Payload:        payload,
+		InvocationType: types.InvocationTypeRequestResponse,
+	}
+	if c.async {
+		params.InvocationType = types.InvocationTypeEvent
+	} else if clientContext, ok := encodeClientContext(headers); ok {
+		params.ClientContext = aws.String(clientContext)
+	}
+
+	out, err := c.client.Invoke(ctx, params)
+	if err != nil {
+		return nil, c.classify(ctx, function, err)
+	}
+
+	if out.FunctionError != nil {
+		return nil, functionError(function, *out.FunctionError, out.Payload)
+	}
+
+	if c.async {
+		return map[string]interface{}{
+			"function":    function,
+			"status_code": out.StatusCode,
+		}, nil
+	}
+
+	if len(out.Payload) == 0 {
+		return nil, nil
+	}
+	var result interface{}
+	if err := json.Unmarshal(out.Payload, &result); err != nil {
+		return string(out.Payload), nil
+	}
+	return result, nil
+}
+
+func encodeClientContext(headers map[string]string) (string, bool) {
+	encoded, err := json.Marshal(map[string]interface{}{"custom": headers})
+	if err != nil {
+		return "", false
+	}
+	clientContext := base64.StdEncoding.EncodeToString(encoded)
+	return clientContext, len(clientContext) <= maxClientContext
+}
+
+func functionError(function, kind string, payload []byte) error {
+	var failure struct {
+		ErrorType    string `json:"errorType"`
+		ErrorMessage string `json:"errorMessage"`
+	}
+	if err := json.Unmarshal(payload, &failure); err != nil || failure.ErrorMessage == "" {
+		return fmt.Errorf("function %s failed (%s): %s", function, kind, string(payload))
+	}
+	if failure.ErrorType != "" {
+		return fmt.Errorf("function %s failed (%s): %s: %s", function, kind, failure.ErrorType, failure.ErrorMessage)
+	}
+	return fmt.Errorf("function %s failed (%s): %s", function, kind, failure.ErrorMessage)
+}
+
+func (c *Client) classify(ctx context.Context, function string, err error) error {
+	var (
+		apiErr smithy.APIError
+		netErr net.Error
+	)
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded), errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("%w: %s: %w", ErrTimeout, function, err)
+	case errors.As(err, &apiErr):
+		switch apiErr.ErrorCode() {
+		case "TooManyRequestsException", "EC2ThrottledException":
+			return fmt.Errorf("%w: %s: %w", ErrThrottled, function, err)
+		case "ServiceException", "ResourceNotReadyException", "EC2UnexpectedException", "ENILimitReachedException":
+			return fmt.Errorf("%w: %s: %w", ErrUnavailable, function, err)
+		}
+	case errors.As(err, &netErr):
+		return fmt.Errorf("%w: %s: %w", ErrUnavailable, function, err)
+	}
+	return fmt.Errorf("failed to invoke %s: %w", function, err)
+}
+
+func (c *Client) Address() string {
+	if c.endpoint != "" {
+		return c.endpoint
+	}
+	u := url.URL{Scheme: "https", Host: fmt.Sprintf("lambda.%s.amazonaws.com", c.region)}
+	return u.String()
+}
@@ -0,0 +1,99 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var ErrUnavailable = errors.New("no handler registered")
+
+type Handler func(ctx context.Context, method string, input map[string]interface{}) (interface{}, error)
+
+type Call struct {
+	Service    string
+	Method     string
+	WorkflowID string
+	StepID     string
+	Headers    map[string]string
+}
+
+type callKey struct{}
+
+func WithCall(ctx context.Context, call Call) context.Context {
+	return context.WithValue(ctx, callKey{}, call)
+}
+
+func CallFrom(ctx context.Context) (Call, bool) {
+	call, ok := ctx.Value(callKey{}).(Call)
+	return call, ok
+}
+
+type Handlers struct {
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+func NewHandlers() *Handlers {
+	return &Handlers{handlers: make(map[string]Handler)}
+}
+
+func (h *Handlers) Register(name string, handler Handler) error {
+	if name == "" {
+		return fmt.Errorf("handler name is required")
+	}
+	if handler == nil {
+		return fmt.Errorf("handler %s is nil", name)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[name] = handler
+	return nil
+}
+
+func (h *Handlers) Unregister(name string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.handlers, name)
+}
+
+func (h *Handlers) Names() []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	names := make([]string, 0, len(h.handlers))
+	for name := range h.handlers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (h *Handlers) Check(name string) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if _, ok := h.handlers[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnavailable, name)
+	}
+	return nil
+}
+
+func (h *Handlers) Call(ctx context.Context, name, method string, input map[string]interface{}) (result interface{}, err error) {
+	h.mu.RLock()
+	handler, ok := h.handlers[name]
+	h.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnavailable, name)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, fmt.Errorf("handler %s panicked: %v", name, r)
+		}
+	}()
+	return handler(ctx, method, input)
+}
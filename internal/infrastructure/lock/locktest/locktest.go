@@ -0,0 +1,121 @@
+// Package locktest holds the table every ports.LockManager is tested against.
+package locktest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/ports"
+)
+
+const takeoverTimeout = 5 * time.Second
+
+type Step struct {
+	Op    string
+	Owner string
+	TTL   time.Duration
+	// Takeover retries an acquire until the current holder's lease expires.
+	Takeover   bool
+	WantToken  int64
+	WantHeld   bool
+	WantErr    error
+	WantErrMsg string
+}
+
+type Case struct {
+	Name  string
+	Steps []Step
+}
+
+var Cases = []Case{
+	{
+		Name: "a held lock is not acquired by another owner",
+		Steps: []Step{
+			{Op: "acquire", Owner: "node-a", WantToken: 1, WantHeld: true},
+			{Op: "acquire", Owner: "node-b"},
+			{Op: "release", Owner: "node-b", WantErrMsg: "held by another owner"},
+			{Op: "renew", Owner: "node-a"},
+		},
+	},
+	{
+		Name: "re-acquiring keeps the token",
+		Steps: []Step{
+			{Op: "acquire", Owner: "node-a", WantToken: 1, WantHeld: true},
+			{Op: "acquire", Owner: "node-a", WantToken: 1, WantHeld: true},
+			{Op: "renew", Owner: "node-a"},
+		},
+	},
+	{
+		Name: "an expired lock is taken over with a new token",
+		Steps: []Step{
+			{Op: "acquire", Owner: "node-a", TTL: 50 * time.Millisecond, WantToken: 1, WantHeld: true},
+			{Op: "acquire", Owner: "node-b", Takeover: true, WantToken: 2, WantHeld: true},
+			{Op: "renew", Owner: "node-a", WantErr: domain.ErrLockLost},
+		},
+	},
+	{
+		Name: "a released lock is taken over",
+		Steps: []Step{
+			{Op: "acquire", Owner: "node-a", WantToken: 1, WantHeld: true},
+			{Op: "release", Owner: "node-a"},
+			{Op: "renew", Owner: "node-a", WantErr: domain.ErrLockLost},
+			{Op: "acquire", Owner: "node-b", WantToken: 2, WantHeld: true},
+			{Op: "release", Owner: "node-a", WantErrMsg: "held by another owner"},
+		},
+	},
+}
+
+func Run(t *testing.T, locks ports.LockManager, key string, steps []Step) {
+	t.Helper()
+	ctx := context.Background()
+	tokens := make(map[string]int64)
+
+	for i, step := range steps {
+		ttl := step.TTL
+		if ttl == 0 {
+			ttl = time.Minute
+		}
+
+		var err error
+		switch step.Op {
+		case "acquire":
+			var token int64
+			var held bool
+			token, held, err = acquire(ctx, locks, key, step.Owner, ttl, step.Takeover)
+			if held != step.WantHeld || token != step.WantToken {
+				t.Fatalf("step %d: acquire by %s = (%d, %v), want (%d, %v)", i, step.Owner, token, held, step.WantToken, step.WantHeld)
+			}
+			if held {
+				tokens[step.Owner] = token
+			}
+		case "renew":
+			err = locks.Renew(ctx, key, step.Owner, tokens[step.Owner], ttl)
+		case "release":
+			err = locks.Release(ctx, key, step.Owner)
+		}
+
+		switch {
+		case step.WantErrMsg != "":
+			if err == nil || !strings.Contains(err.Error(), step.WantErrMsg) {
+				t.Fatalf("step %d %s by %s: error = %v, want %q", i, step.Op, step.Owner, err, step.WantErrMsg)
+			}
+		case !errors.Is(err, step.WantErr):
+			t.Fatalf("step %d %s by %s: error = %v, want %v", i, step.Op, step.Owner, err, step.WantErr)
+		}
+	}
+}
+
+func acquire(ctx context.Context, locks ports.LockManager, key, owner string, ttl time.Duration, takeover bool) (int64, bool, error) {
+	deadline := time.Now().Add(takeoverTimeout)
+	for {
+		token, held, err := locks.Acquire(ctx, key, owner, ttl)
+		if held || err != nil || !takeover || time.Now().After(deadline) {
+			return token, held, err
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,81 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type lease struct {
+	owner     string
+	token     int64
+	expiresAt time.Time
+}
+
+type MemoryLockManager struct {
+	mu     sync.Mutex
+	leases map[string]lease
+}
+
+func NewMemoryLockManager() *MemoryLockManager {
+	return &MemoryLockManager{
+		leases: make(map[string]lease),
+	}
+}
+
+func (m *MemoryLockManager) Acquire(_ context.Context, key, owner string, ttl time.Duration) (int64, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	current := m.leases[key]
+	held := current.owner != "" && now.Before(current.expiresAt)
+	if held && current.owner != owner {
+		return 0, false, nil
+	}
+
+	token := current.token
+	if !held || current.owner != owner {
+		token++
+	}
+	m.leases[key] = lease{
+		owner:     owner,
+		token:     token,
+		expiresAt: now.Add(ttl),
+	}
+	return token, true, nil
+}
+
+func (m *MemoryLockManager) Renew(_ context.Context, key, owner string, token int64, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current := m.leases[key]
+	if current.owner != owner || current.token != token || time.Now().After(current.expiresAt) {
+		return fmt.Errorf("lock %s: %w", key, domain.ErrLockLost)
+	}
+
+	current.expiresAt = time.Now().Add(ttl)
+	m.leases[key] = current
+	return nil
+}
+
+func (m *MemoryLockManager) Release(_ context.Context, key, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, exists := m.leases[key]
+	if !exists || current.owner == "" || time.Now().After(current.expiresAt) {
+		return nil
+	}
+
+	if current.owner != owner {
+		return fmt.Errorf("lock %s is held by another owner", key)
+	}
+
+	m.leases[key] = lease{token: current.token}
+	return nil
+}
@@ -0,0 +1,22 @@
+package lock
+
+import (
+	"testing"
+
+	"github.com/maestro/maestro.go/internal/infrastructure/lock/locktest"
+)
+
+func TestMemoryLockManager(t *testing.T) {
+	tests := append(locktest.Cases, locktest.Case{
+		Name: "releasing an unheld lock is a no-op",
+		Steps: []locktest.Step{
+			{Op: "release", Owner: "node-a"},
+		},
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			locktest.Run(t, NewMemoryLockManager(), "orders", tt.Steps)
+		})
+	}
+}
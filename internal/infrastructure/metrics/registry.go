@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	MetricTypeCounter   = "counter"
+	MetricTypeGauge     = "gauge"
+	MetricTypeHistogram = "histogram"
+)
+
+type Registry struct {
+	mu         sync.Mutex
+	registry   *prometheus.Registry
+	counters   map[string]*prometheus.CounterVec
+	gauges     map[string]*prometheus.GaugeVec
+	histograms map[string]*prometheus.HistogramVec
+	labelNames map[string][]string
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		registry:   prometheus.NewRegistry(),
+		counters:   make(map[string]*prometheus.CounterVec),
+		gauges:     make(map[string]*prometheus.GaugeVec),
+		histograms: make(map[string]*prometheus.HistogramVec),
+		labelNames: make(map[string][]string),
+	}
+}
+
+func (r *Registry) Gatherer() prometheus.Gatherer {
+	return r.registry
+}
+
+func (r *Registry) Registerer() prometheus.Registerer {
+	return r.registry
+}
+
+func (r *Registry) Record(config *domain.MetricConfig, value float64, labels map[string]string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	if existing, ok := r.labelNames[config.Name]; ok && !slices.Equal(existing, names) {
+		return fmt.Errorf("metric %s: label set %v does not match registered labels %v", config.Name, names, existing)
+	}
+
+	help := config.Help
+	if help == "" {
+		help = fmt.Sprintf("Workflow metric %s", config.Name)
+	}
+
+	switch config.Type {
+	case MetricTypeCounter:
+		if value < 0 {
+			return fmt.Errorf("metric %s: counter cannot be decreased", config.Name)
+		}
+		vec, ok := r.counters[config.Name]
+		if !ok {
+			vec = prometheus.NewCounterVec(prometheus.CounterOpts{Name: config.Name, Help: help}, names)
+			if err := r.registry.Register(vec); err != nil {
+				return fmt.Errorf("failed to register metric %s: %w", config.Name, err)
+			}
+			r.counters[config.Name] = vec
+		}
+		vec.With(labels).Add(value)
+
+	case MetricTypeGauge:
+		vec, ok := r.gauges[config.Name]
+		if !ok {
+			vec = prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: config.Name, Help: help}, names)
+			if err := r.registry.Register(vec); err != nil {
+				return fmt.Errorf("failed to register metric %s: %w", config.Name, err)
+			}
+			r.gauges[config.Name] = vec
+		}
+		vec.With(labels).Set(value)
+
+	case MetricTypeHistogram:
+		vec, ok := r.histograms[config.Name]
+		if !ok {
+			buckets := config.Buckets
+			if len(buckets) == 0 {
+				buckets = prometheus.DefBuckets
+			}
+			vec = prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: config.Name, Help: help, Buckets: buckets}, names)
+			if err := r.registry.Register(vec); err != nil {
+				return fmt.Errorf("failed to register metric %s: %w", config.Name, err)
+			}
+			r.histograms[config.Name] = vec
+		}
+		vec.With(labels).Observe(value)
+
+	default:
+		return fmt.Errorf("metric %s: unknown type %s", config.Name, config.Type)
+	}
+
+	r.labelNames[config.Name] = names
+	return nil
+}
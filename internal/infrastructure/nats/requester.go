@@ -0,0 +1,89 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	natsgo "github.com/nats-io/nats.go"
+)
+
+const (
+	ServiceErrorHeader     = "Nats-Service-Error"
+	ServiceErrorCodeHeader = "Nats-Service-Error-Code"
+
+	defaultTimeout = 30 * time.Second
+)
+
+var (
+	ErrNoResponders = errors.New("no responders on subject")
+	ErrTimeout      = errors.New("request timed out")
+)
+
+type Requester struct {
+	conn *natsgo.Conn
+}
+
+func Connect(endpoint, name string) (*Requester, error) {
+	conn, err := natsgo.Connect(endpoint,
+		natsgo.Name("maestro/"+name),
+		natsgo.RetryOnFailedConnect(true),
+		natsgo.MaxReconnects(-1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS at %s: %w", endpoint, err)
+	}
+	return &Requester{conn: conn}, nil
+}
+
+func (r *Requester) Request(ctx context.Context, subject string, input map[string]interface{}, headers map[string]string) (interface{}, error) {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	msg := natsgo.NewMsg(subject)
+	msg.Data = body
+	for key, value := range headers {
+		msg.Header.Set(key, value)
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+
+	reply, err := r.conn.RequestMsgWithContext(ctx, msg)
+	switch {
+	case errors.Is(err, natsgo.ErrNoResponders):
+		return nil, fmt.Errorf("%w %s", ErrNoResponders, subject)
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, natsgo.ErrTimeout):
+		return nil, fmt.Errorf("%w waiting for a reply on %s", ErrTimeout, subject)
+	case err != nil:
+		return nil, fmt.Errorf("NATS request to %s failed: %w", subject, err)
+	}
+
+	if message := reply.Header.Get(ServiceErrorHeader); message != "" {
+		if code := reply.Header.Get(ServiceErrorCodeHeader); code != "" {
+			return nil, fmt.Errorf("service returned error %s: %s", code, message)
+		}
+		return nil, fmt.Errorf("service returned error: %s", message)
+	}
+
+	if len(reply.Data) == 0 {
+		return nil, nil
+	}
+
+	var result interface{}
+	if err := json.Unmarshal(reply.Data, &result); err != nil {
+		return string(reply.Data), nil
+	}
+	return result, nil
+}
+
+func (r *Requester) Close() {
+	r.conn.Close()
+}
@@ -0,0 +1,165 @@
+package openapi
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+type operation struct {
+	method string
+	path   []string
+	fields map[string]domain.FieldSchema
+}
+
+type Spec struct {
+	operations []operation
+}
+
+func Load(filename string) (*Spec, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI document: %w", err)
+	}
+
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+
+	spec := &Spec{}
+	paths, _ := doc["paths"].(map[string]any)
+	for path, item := range paths {
+		methods, _ := item.(map[string]any)
+		for method, op := range methods {
+			opMap, ok := op.(map[string]any)
+			if !ok {
+				continue
+			}
+			spec.operations = append(spec.operations, operation{
+				method: strings.ToUpper(method),
+				path:   splitPath(path),
+				fields: operationFields(doc, opMap),
+			})
+		}
+	}
+
+	return spec, nil
+}
+
+func (s *Spec) InputSchema(httpMethod, path string) (map[string]domain.FieldSchema, bool) {
+	segments := splitPath(path)
+	for _, op := range s.operations {
+		if op.method == strings.ToUpper(httpMethod) && matchPath(op.path, segments) {
+			return op.fields, true
+		}
+	}
+	return nil, false
+}
+
+func operationFields(doc, op map[string]any) map[string]domain.FieldSchema {
+	fields := make(map[string]domain.FieldSchema)
+
+	params, _ := op["parameters"].([]any)
+	for _, p := range params {
+		param, ok := resolveRef(doc, p).(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := param["name"].(string)
+		if name == "" {
+			continue
+		}
+		if schema, ok := resolveRef(doc, param["schema"]).(map[string]any); ok {
+			fields[name] = fieldSchema(doc, schema)
+		}
+	}
+
+	body, _ := resolveRef(doc, op["requestBody"]).(map[string]any)
+	content, _ := body["content"].(map[string]any)
+	media, _ := content["application/json"].(map[string]any)
+	schema, _ := resolveRef(doc, media["schema"]).(map[string]any)
+	for name, prop := range properties(doc, schema) {
+		fields[name] = prop
+	}
+
+	return fields
+}
+
+func properties(doc, schema map[string]any) map[string]domain.FieldSchema {
+	props, _ := schema["properties"].(map[string]any)
+	if len(props) == 0 {
+		return nil
+	}
+
+	fields := make(map[string]domain.FieldSchema, len(props))
+	for name, prop := range props {
+		if propSchema, ok := resolveRef(doc, prop).(map[string]any); ok {
+			fields[name] = fieldSchema(doc, propSchema)
+		}
+	}
+	return fields
+}
+
+func fieldSchema(doc, schema map[string]any) domain.FieldSchema {
+	field := domain.FieldSchema{}
+	field.Type, _ = schema["type"].(string)
+	if enum, ok := schema["enum"].([]any); ok {
+		for _, value := range enum {
+			field.Enum = append(field.Enum, fmt.Sprint(value))
+		}
+	}
+	field.Properties = properties(doc, schema)
+	if items, ok := resolveRef(doc, schema["items"]).(map[string]any); ok {
+		item := fieldSchema(doc, items)
+		field.Items = &item
+	}
+	return field
+}
+
+func resolveRef(doc map[string]any, value any) any {
+	m, ok := value.(map[string]any)
+	if !ok {
+		return value
+	}
+
+	ref, ok := m["$ref"].(string)
+	if !ok || !strings.HasPrefix(ref, "#/") {
+		return value
+	}
+
+	var current any = doc
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		node, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = node[part]
+	}
+	return current
+}
+
+func splitPath(path string) []string {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func matchPath(pattern, segments []string) bool {
+	if len(pattern) != len(segments) {
+		return false
+	}
+	for i, part := range pattern {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			continue
+		}
+		if part != segments[i] {
+			return false
+		}
+	}
+	return true
+}
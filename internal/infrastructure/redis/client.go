@@ -0,0 +1,258 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	redisgo "github.com/redis/go-redis/v9"
+)
+
+const (
+	CommandGet    = "GET"
+	CommandSet    = "SET"
+	CommandDel    = "DEL"
+	CommandIncr   = "INCR"
+	CommandLock   = "LOCK"
+	CommandUnlock = "UNLOCK"
+
+	defaultLockTTL = 30 * time.Second
+	defaultTimeout = 10 * time.Second
+)
+
+var (
+	ErrUnavailable = errors.New("redis unavailable")
+	ErrTimeout     = errors.New("redis command timed out")
+	ErrLockHeld    = errors.New("lock is held by another owner")
+)
+
+var unlockScript = redisgo.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+type Client struct {
+	client  *redisgo.Client
+	prefix  string
+	lockTTL time.Duration
+}
+
+func NewClient(endpoint, name string, config *domain.RedisConfig) (*Client, error) {
+	var opts *redisgo.Options
+	if strings.Contains(endpoint, "://") {
+		var err error
+		if opts, err = redisgo.ParseURL(endpoint); err != nil {
+			return nil, fmt.Errorf("invalid redis endpoint: %w", err)
+		}
+	} else {
+		opts = &redisgo.Options{Addr: endpoint}
+	}
+	opts.ClientName = "maestro/" + name
+
+	c := &Client{client: redisgo.NewClient(opts), lockTTL: defaultLockTTL}
+	if config != nil {
+		c.prefix = config.KeyPrefix
+		if config.LockTTL.Duration > 0 {
+			c.lockTTL = config.LockTTL.Duration
+		}
+	}
+	return c, nil
+}
+
+func ValidateCommand(command string) error {
+	switch strings.ToUpper(command) {
+	case CommandGet, CommandSet, CommandDel, CommandIncr, CommandLock, CommandUnlock:
+		return nil
+	}
+	return fmt.Errorf("invalid redis command %s (must be GET, SET, DEL, INCR, LOCK or UNLOCK)", command)
+}
+
+func (c *Client) Do(ctx context.Context, command string, input map[string]interface{}, owner string) (interface{}, error) {
+	key, err := stringField(input, "key")
+	if err != nil {
+		return nil, err
+	}
+	key = c.prefix + key
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+
+	result, err := c.do(ctx, strings.ToUpper(command), key, input, owner)
+	return result, classify(ctx, err)
+}
+
+func (c *Client) do(ctx context.Context, command, key string, input map[string]interface{}, owner string) (interface{}, error) {
+	switch command {
+	case CommandGet:
+		value, err := c.client.Get(ctx, key).Result()
+		if errors.Is(err, redisgo.Nil) {
+			return map[string]interface{}{"key": key, "found": false, "value": nil}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"key": key, "found": true, "value": decode(value)}, nil
+
+	case CommandSet:
+		value, ok := input["value"]
+		if !ok {
+			return nil, fmt.Errorf("SET needs a value")
+		}
+		encoded, err := encode(value)
+		if err != nil {
+			return nil, err
+		}
+		ttl, err := durationField(input, "ttl", 0)
+		if err != nil {
+			return nil, err
+		}
+		if nx, _ := input["nx"].(bool); nx {
+			set, err := c.client.SetNX(ctx, key, encoded, ttl).Result()
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{"key": key, "set": set}, nil
+		}
+		if err := c.client.Set(ctx, key, encoded, ttl).Err(); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"key": key, "set": true}, nil
+
+	case CommandDel:
+		deleted, err := c.client.Del(ctx, key).Result()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"key": key, "deleted": deleted}, nil
+
+	case CommandIncr:
+		by := int64(1)
+		if raw, ok := input["by"]; ok {
+			n, err := strconv.ParseInt(fmt.Sprint(raw), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("INCR by must be an integer: %w", err)
+			}
+			by = n
+		}
+		value, err := c.client.IncrBy(ctx, key, by).Result()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"key": key, "value": value}, nil
+
+	case CommandLock:
+		token := lockToken(input, owner)
+		ttl, err := durationField(input, "ttl", c.lockTTL)
+		if err != nil {
+			return nil, err
+		}
+		acquired, err := c.client.SetNX(ctx, key, token, ttl).Result()
+		if err != nil {
+			return nil, err
+		}
+		if !acquired {
+			return nil, fmt.Errorf("%w: %s", ErrLockHeld, key)
+		}
+		return map[string]interface{}{"key": key, "token": token, "acquired": true}, nil
+
+	case CommandUnlock:
+		released, err := unlockScript.Run(ctx, c.client, []string{key}, lockToken(input, owner)).Int64()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"key": key, "released": released == 1}, nil
+	}
+	return nil, ValidateCommand(command)
+}
+
+func (c *Client) Ping(ctx context.Context) error {
+	return classify(ctx, c.client.Ping(ctx).Err())
+}
+
+func (c *Client) Close() error {
+	return c.client.Close()
+}
+
+func classify(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, ErrLockHeld):
+		return err
+	case errors.Is(ctx.Err(), context.DeadlineExceeded), errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	case errors.As(err, &netErr), errors.Is(err, redisgo.ErrClosed):
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	return err
+}
+
+func lockToken(input map[string]interface{}, owner string) string {
+	if token, ok := input["token"]; ok && token != nil {
+		return fmt.Sprint(token)
+	}
+	return owner
+}
+
+func stringField(input map[string]interface{}, name string) (string, error) {
+	raw, ok := input[name]
+	if !ok || raw == nil || fmt.Sprint(raw) == "" {
+		return "", fmt.Errorf("input %s is required", name)
+	}
+	return fmt.Sprint(raw), nil
+}
+
+func durationField(input map[string]interface{}, name string, fallback time.Duration) (time.Duration, error) {
+	raw, ok := input[name]
+	if !ok || raw == nil {
+		return fallback, nil
+	}
+	switch v := raw.(type) {
+	case int:
+		return time.Duration(v) * time.Second, nil
+	case float64:
+		return time.Duration(v * float64(time.Second)), nil
+	}
+	d, err := time.ParseDuration(fmt.Sprint(raw))
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return d, nil
+}
+
+func encode(value interface{}) (string, error) {
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode value: %w", err)
+	}
+	return string(encoded), nil
+}
+
+func decode(value string) interface{} {
+	if strings.HasPrefix(value, "{") || strings.HasPrefix(value, "[") {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(value), &decoded); err == nil {
+			return decoded
+		}
+	}
+	return value
+}
@@ -0,0 +1,71 @@
+package rerun
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type MemoryQueue struct {
+	mu     sync.Mutex
+	reruns map[string]*domain.Rerun
+}
+
+func NewMemoryQueue() *MemoryQueue {
+	return &MemoryQueue{reruns: make(map[string]*domain.Rerun)}
+}
+
+func (q *MemoryQueue) EnqueueRerun(_ context.Context, rerun *domain.Rerun) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	stored := *rerun
+	q.reruns[rerun.ID] = &stored
+	return nil
+}
+
+func (q *MemoryQueue) DueReruns(_ context.Context, before time.Time) ([]*domain.Rerun, error) {
+	return q.list(func(rerun *domain.Rerun) bool {
+		return !rerun.DueAt.After(before)
+	}), nil
+}
+
+func (q *MemoryQueue) ListReruns(_ context.Context, workflow string) ([]*domain.Rerun, error) {
+	return q.list(func(rerun *domain.Rerun) bool {
+		return workflow == "" || rerun.Workflow == workflow
+	}), nil
+}
+
+func (q *MemoryQueue) DeleteRerun(_ context.Context, id string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.reruns[id]; !ok {
+		return fmt.Errorf("%w: %s", domain.ErrRerunNotFound, id)
+	}
+	delete(q.reruns, id)
+	return nil
+}
+
+func (q *MemoryQueue) list(match func(*domain.Rerun) bool) []*domain.Rerun {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	reruns := make([]*domain.Rerun, 0, len(q.reruns))
+	for _, rerun := range q.reruns {
+		if !match(rerun) {
+			continue
+		}
+		stored := *rerun
+		reruns = append(reruns, &stored)
+	}
+
+	slices.SortFunc(reruns, func(a, b *domain.Rerun) int {
+		return a.DueAt.Compare(b.DueAt)
+	})
+	return reruns
+}
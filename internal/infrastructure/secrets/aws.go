@@ -0,0 +1,65 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type AWSProvider struct {
+	region string
+	prefix string
+
+	once   sync.Once
+	client *secretsmanager.Client
+	err    error
+}
+
+func NewAWSProvider(region, prefix string) *AWSProvider {
+	return &AWSProvider{region: region, prefix: prefix}
+}
+
+func (p *AWSProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	client, err := p.connect(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	id := p.prefix + name
+	out, err := client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(id)})
+	var notFound *types.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return "", fmt.Errorf("secret %s: no AWS secret %s: %w", name, id, domain.ErrSecretNotFound)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret %s from AWS Secrets Manager: %w", name, err)
+	}
+
+	if out.SecretString != nil {
+		return *out.SecretString, nil
+	}
+	return string(out.SecretBinary), nil
+}
+
+func (p *AWSProvider) connect(ctx context.Context) (*secretsmanager.Client, error) {
+	p.once.Do(func() {
+		var opts []func(*config.LoadOptions) error
+		if p.region != "" {
+			opts = append(opts, config.WithRegion(p.region))
+		}
+		cfg, err := config.LoadDefaultConfig(ctx, opts...)
+		if err != nil {
+			p.err = fmt.Errorf("failed to load AWS configuration: %w", err)
+			return
+		}
+		p.client = secretsmanager.NewFromConfig(cfg)
+	})
+	return p.client, p.err
+}
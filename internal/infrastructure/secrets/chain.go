@@ -0,0 +1,63 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/ports"
+)
+
+const DefaultCacheTTL = 5 * time.Minute
+
+type Chain struct {
+	providers []ports.SecretProvider
+	ttl       time.Duration
+
+	mu     sync.Mutex
+	cached map[string]cachedSecret
+}
+
+type cachedSecret struct {
+	value     string
+	expiresAt time.Time
+}
+
+func NewChain(ttl time.Duration, providers ...ports.SecretProvider) *Chain {
+	return &Chain{
+		providers: providers,
+		ttl:       ttl,
+		cached:    make(map[string]cachedSecret),
+	}
+}
+
+func (c *Chain) GetSecret(ctx context.Context, name string) (string, error) {
+	c.mu.Lock()
+	cached, ok := c.cached[name]
+	c.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.value, nil
+	}
+
+	for _, provider := range c.providers {
+		value, err := provider.GetSecret(ctx, name)
+		if errors.Is(err, domain.ErrSecretNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if c.ttl > 0 {
+			c.mu.Lock()
+			c.cached[name] = cachedSecret{value: value, expiresAt: time.Now().Add(c.ttl)}
+			c.mu.Unlock()
+		}
+		return value, nil
+	}
+
+	return "", fmt.Errorf("secret %s: %w", name, domain.ErrSecretNotFound)
+}
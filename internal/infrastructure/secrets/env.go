@@ -0,0 +1,29 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+const DefaultEnvPrefix = "MAESTRO_SECRET_"
+
+type EnvProvider struct {
+	prefix string
+}
+
+func NewEnvProvider(prefix string) *EnvProvider {
+	return &EnvProvider{prefix: prefix}
+}
+
+func (p *EnvProvider) GetSecret(_ context.Context, name string) (string, error) {
+	variable := p.prefix + strings.ToUpper(name)
+	value, ok := os.LookupEnv(variable)
+	if !ok {
+		return "", fmt.Errorf("secret %s: no environment variable %s: %w", name, variable, domain.ErrSecretNotFound)
+	}
+	return value, nil
+}
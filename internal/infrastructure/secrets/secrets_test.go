@@ -0,0 +1,150 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type staticProvider struct {
+	secrets map[string]string
+	err     error
+	calls   int
+}
+
+func (p *staticProvider) GetSecret(_ context.Context, name string) (string, error) {
+	p.calls++
+	if p.err != nil {
+		return "", p.err
+	}
+	value, ok := p.secrets[name]
+	if !ok {
+		return "", domain.ErrSecretNotFound
+	}
+	return value, nil
+}
+
+func TestChain(t *testing.T) {
+	failure := errors.New("vault sealed")
+
+	tests := []struct {
+		name      string
+		providers []*staticProvider
+		want      string
+		wantErr   error
+	}{
+		{
+			name: "first provider wins",
+			providers: []*staticProvider{
+				{secrets: map[string]string{"token": "from-env"}},
+				{secrets: map[string]string{"token": "from-file"}},
+			},
+			want: "from-env",
+		},
+		{
+			name: "falls through on not found",
+			providers: []*staticProvider{
+				{secrets: map[string]string{}},
+				{secrets: map[string]string{"token": "from-file"}},
+			},
+			want: "from-file",
+		},
+		{
+			name: "stops at any other error",
+			providers: []*staticProvider{
+				{err: failure},
+				{secrets: map[string]string{"token": "from-file"}},
+			},
+			wantErr: failure,
+		},
+		{
+			name: "not found anywhere",
+			providers: []*staticProvider{
+				{secrets: map[string]string{}},
+				{secrets: map[string]string{}},
+			},
+			wantErr: domain.ErrSecretNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := NewChain(0)
+			for _, provider := range tt.providers {
+				chain.providers = append(chain.providers, provider)
+			}
+
+			got, err := chain.GetSecret(context.Background(), "token")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetSecret() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetSecret() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetSecret() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChainCachesValues(t *testing.T) {
+	provider := &staticProvider{secrets: map[string]string{"token": "s3cret"}}
+	chain := NewChain(DefaultCacheTTL, provider)
+
+	for range 2 {
+		if _, err := chain.GetSecret(context.Background(), "token"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if provider.calls != 1 {
+		t.Errorf("provider called %d times, want 1", provider.calls)
+	}
+}
+
+func TestFileProvider(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "token"), []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	provider := NewFileProvider(dir)
+
+	tests := []struct {
+		name    string
+		secret  string
+		want    string
+		wantErr string
+	}{
+		{name: "trailing newline is trimmed", secret: "token", want: "s3cret"},
+		{name: "missing file", secret: "missing", wantErr: "secret not found"},
+		{name: "parent directory", secret: "../outside", wantErr: "invalid name"},
+		{name: "absolute path", secret: "/etc/passwd", wantErr: "invalid name"},
+		{name: "empty name", secret: "", wantErr: "invalid name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := provider.GetSecret(context.Background(), tt.secret)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("GetSecret(%q) error = %v, want %q", tt.secret, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetSecret(%q) error = %v", tt.secret, err)
+			}
+			if got != tt.want {
+				t.Errorf("GetSecret(%q) = %q, want %q", tt.secret, got, tt.want)
+			}
+		})
+	}
+}
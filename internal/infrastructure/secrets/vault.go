@@ -0,0 +1,95 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+const (
+	defaultVaultMount = "secret"
+	vaultTimeout      = 10 * time.Second
+)
+
+type VaultProvider struct {
+	address string
+	token   string
+	mount   string
+	path    string
+	client  *http.Client
+}
+
+func NewVaultProvider(address, token, mount, path string) *VaultProvider {
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	if mount == "" {
+		mount = defaultVaultMount
+	}
+
+	return &VaultProvider{
+		address: strings.TrimSuffix(address, "/"),
+		token:   token,
+		mount:   strings.Trim(mount, "/"),
+		path:    strings.Trim(path, "/"),
+		client:  &http.Client{Timeout: vaultTimeout},
+	}
+}
+
+func (p *VaultProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	url := fmt.Sprintf("%s/v1/%s/data/%s", p.address, p.mount, p.path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid vault address %q: %w", p.address, err)
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+	if namespace := os.Getenv("VAULT_NAMESPACE"); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret %s from vault: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("secret %s: vault has no secret at %s/%s: %w", name, p.mount, p.path, domain.ErrSecretNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("failed to read secret %s from vault: %s: %s", name, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var secret struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return "", fmt.Errorf("failed to decode vault response: %w", err)
+	}
+
+	value, ok := secret.Data.Data[name]
+	if !ok {
+		return "", fmt.Errorf("secret %s: no key in vault secret %s/%s: %w", name, p.mount, p.path, domain.ErrSecretNotFound)
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode secret %s: %w", name, err)
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,311 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+const (
+	defaultMaxConnections = 5
+	defaultTimeout        = 30 * time.Second
+)
+
+var (
+	ErrUnavailable = errors.New("database unavailable")
+	ErrTimeout     = errors.New("query timed out")
+)
+
+type DB struct {
+	name    string
+	driver  string
+	db      *sql.DB
+	queries map[string]string
+}
+
+func Open(endpoint, name string, config *domain.SQLConfig) (*DB, error) {
+	d := &DB{name: name, driver: config.DriverFor(endpoint)}
+
+	dsn := endpoint
+	switch d.driver {
+	case domain.SQLDriverPostgres:
+	case domain.SQLDriverMySQL:
+		var err error
+		if dsn, err = mysqlDSN(endpoint); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported SQL driver %q", d.driver)
+	}
+
+	db, err := sql.Open(d.driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	maxConnections := defaultMaxConnections
+	if config != nil {
+		d.queries = config.Queries
+		if config.MaxConnections > 0 {
+			maxConnections = config.MaxConnections
+		}
+	}
+	db.SetMaxOpenConns(maxConnections)
+	db.SetMaxIdleConns(maxConnections)
+	db.SetConnMaxIdleTime(5 * time.Minute)
+	d.db = db
+
+	return d, nil
+}
+
+func mysqlDSN(endpoint string) (string, error) {
+	dsn := strings.TrimPrefix(endpoint, "mysql://")
+	if u, err := url.Parse("mysql://" + dsn); err == nil && u.Host != "" && !strings.Contains(dsn, "(") {
+		host := u.Host
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "3306")
+		}
+		dsn = fmt.Sprintf("%s@tcp(%s)%s", u.User.String(), host, u.Path)
+		if u.User == nil {
+			dsn = fmt.Sprintf("tcp(%s)%s", host, u.Path)
+		}
+		if u.RawQuery != "" {
+			dsn += "?" + u.RawQuery
+		}
+	}
+
+	if _, err := mysql.ParseDSN(dsn); err != nil {
+		return "", fmt.Errorf("invalid MySQL endpoint: %w", err)
+	}
+	return dsn, nil
+}
+
+func (d *DB) Run(ctx context.Context, method string, input map[string]interface{}) (interface{}, error) {
+	text := method
+	if query, ok := d.queries[method]; ok {
+		text = query
+	}
+
+	statement, args, err := bind(d.driver, text, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+
+	if returnsRows(statement) {
+		result, err := d.query(ctx, statement, args)
+		return result, d.classify(ctx, err)
+	}
+
+	res, err := d.db.ExecContext(ctx, statement, args...)
+	if err != nil {
+		return nil, d.classify(ctx, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read affected rows: %w", err)
+	}
+	result := map[string]interface{}{"rows_affected": affected}
+	if d.driver == domain.SQLDriverMySQL {
+		if id, err := res.LastInsertId(); err == nil && id != 0 {
+			result["last_insert_id"] = id
+		}
+	}
+	return result, nil
+}
+
+func (d *DB) query(ctx context.Context, statement string, args []interface{}) (interface{}, error) {
+	rows, err := d.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	records := []interface{}{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		record := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			if b, ok := values[i].([]byte); ok {
+				record[column] = string(b)
+			} else {
+				record[column] = values[i]
+			}
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := map[string]interface{}{
+		"rows":      records,
+		"row_count": len(records),
+	}
+	if len(records) > 0 {
+		result["row"] = records[0]
+	}
+	return result, nil
+}
+
+func (d *DB) classify(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var (
+		netErr net.Error
+		pqErr  *pq.Error
+	)
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded), errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("%w on %s: %w", ErrTimeout, d.name, err)
+	case errors.Is(err, driver.ErrBadConn), errors.Is(err, mysql.ErrInvalidConn), errors.As(err, &netErr):
+		return fmt.Errorf("%w: %s: %w", ErrUnavailable, d.name, err)
+	case errors.As(err, &pqErr) && pqErr.Code.Class() == "08":
+		return fmt.Errorf("%w: %s: %w", ErrUnavailable, d.name, err)
+	}
+	return err
+}
+
+func (d *DB) Ping(ctx context.Context) error {
+	if err := d.db.PingContext(ctx); err != nil {
+		return d.classify(ctx, err)
+	}
+	return nil
+}
+
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+func returnsRows(statement string) bool {
+	fields := strings.Fields(strings.ToLower(strings.TrimLeft(statement, " \t\r\n(")))
+	if len(fields) == 0 {
+		return false
+	}
+	switch fields[0] {
+	case "select", "with", "show", "values", "explain", "table", "describe":
+		return true
+	}
+	for _, field := range fields {
+		if field == "returning" {
+			return true
+		}
+	}
+	return false
+}
+
+func bind(driverName, text string, input map[string]interface{}) (string, []interface{}, error) {
+	var (
+		out      strings.Builder
+		args     []interface{}
+		position = make(map[string]int)
+	)
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := strings.IndexByte(text[i+1:], c)
+			if end < 0 {
+				out.WriteString(text[i:])
+				i = len(text)
+				continue
+			}
+			out.WriteString(text[i : i+end+2])
+			i += end + 1
+		case c == '-' && strings.HasPrefix(text[i:], "--"):
+			end := strings.IndexByte(text[i:], '\n')
+			if end < 0 {
+				end = len(text) - i
+			}
+			out.WriteString(text[i : i+end])
+			i += end - 1
+		case c == ':' && i+1 < len(text) && text[i+1] == ':':
+			out.WriteString("::")
+			i++
+		case c == ':' && i+1 < len(text) && isNameStart(text[i+1]) && (i == 0 || !isNamePart(text[i-1])):
+			j := i + 1
+			for j < len(text) && isNamePart(text[j]) {
+				j++
+			}
+			name := text[i+1 : j]
+			value, ok := input[name]
+			if !ok {
+				return "", nil, fmt.Errorf("query parameter :%s has no value in the step input", name)
+			}
+			value, err := argument(value)
+			if err != nil {
+				return "", nil, fmt.Errorf("query parameter :%s: %w", name, err)
+			}
+
+			if driverName == domain.SQLDriverPostgres {
+				n, seen := position[name]
+				if !seen {
+					args = append(args, value)
+					n = len(args)
+					position[name] = n
+				}
+				fmt.Fprintf(&out, "$%d", n)
+			} else {
+				args = append(args, value)
+				out.WriteByte('?')
+			}
+			i = j - 1
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return out.String(), args, nil
+}
+
+func argument(value interface{}) (interface{}, error) {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		return string(encoded), nil
+	}
+	return value, nil
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNamePart(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,158 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+const deadLetterColumns = `id, kind, source, workflow, workflow_id, namespace, payload, sealed, headers, reason, attempts, created_at, last_attempt_at`
+
+func (s *PostgresStore) ParkDeadLetter(ctx context.Context, letter *domain.DeadLetter) error {
+	payload, sealed, err := s.seal(letter.Namespace, letter.Payload, letter.ID, "dead_letter")
+	if err != nil {
+		return fmt.Errorf("failed to encrypt dead letter %s: %w", letter.ID, err)
+	}
+	headers, err := json.Marshal(letter.Headers)
+	if err != nil {
+		return fmt.Errorf("failed to encode headers of dead letter %s: %w", letter.ID, err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO maestro_dead_letters (`+deadLetterColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (id) DO UPDATE SET
+			reason = EXCLUDED.reason,
+			attempts = EXCLUDED.attempts,
+			last_attempt_at = EXCLUDED.last_attempt_at`,
+		letter.ID,
+		letter.Kind,
+		letter.Source,
+		letter.Workflow,
+		letter.WorkflowID,
+		letter.Namespace,
+		payload,
+		sealed,
+		headers,
+		letter.Reason,
+		letter.Attempts,
+		letter.CreatedAt,
+		letter.LastAttemptAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to park dead letter %s: %w", letter.ID, err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) ListDeadLetters(ctx context.Context, filter domain.DeadLetterFilter) ([]*domain.DeadLetter, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+	if filter.Kind != "" {
+		args = append(args, filter.Kind)
+		conditions = append(conditions, fmt.Sprintf("kind = $%d", len(args)))
+	}
+	if filter.Source != "" {
+		args = append(args, filter.Source)
+		conditions = append(conditions, fmt.Sprintf("source = $%d", len(args)))
+	}
+
+	query := `SELECT ` + deadLetterColumns + ` FROM maestro_dead_letters`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	var letters []*domain.DeadLetter
+	for rows.Next() {
+		letter, err := s.scanDeadLetter(rows)
+		if err != nil {
+			return nil, err
+		}
+		letters = append(letters, letter)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+
+	return letters, nil
+}
+
+func (s *PostgresStore) GetDeadLetter(ctx context.Context, id string) (*domain.DeadLetter, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+deadLetterColumns+` FROM maestro_dead_letters WHERE id = $1`,
+		id,
+	)
+	letter, err := s.scanDeadLetter(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrDeadLetterNotFound, id)
+	}
+	return letter, err
+}
+
+func (s *PostgresStore) DeleteDeadLetter(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM maestro_dead_letters WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete dead letter %s: %w", id, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrDeadLetterNotFound, id)
+	}
+	return nil
+}
+
+func (s *PostgresStore) scanDeadLetter(row interface{ Scan(...any) error }) (*domain.DeadLetter, error) {
+	var (
+		letter  domain.DeadLetter
+		payload []byte
+		sealed  bool
+		headers []byte
+	)
+	err := row.Scan(
+		&letter.ID,
+		&letter.Kind,
+		&letter.Source,
+		&letter.Workflow,
+		&letter.WorkflowID,
+		&letter.Namespace,
+		&payload,
+		&sealed,
+		&headers,
+		&letter.Reason,
+		&letter.Attempts,
+		&letter.CreatedAt,
+		&letter.LastAttemptAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dead letter row: %w", err)
+	}
+
+	if letter.Payload, _, err = s.open(payload, sealed, letter.ID, "dead_letter"); err != nil {
+		return nil, fmt.Errorf("failed to decrypt dead letter %s: %w", letter.ID, err)
+	}
+	if err := json.Unmarshal(headers, &letter.Headers); err != nil {
+		return nil, fmt.Errorf("failed to decode headers of dead letter %s: %w", letter.ID, err)
+	}
+	return &letter, nil
+}
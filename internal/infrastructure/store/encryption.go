@@ -0,0 +1,94 @@
+package store
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+func WithNamespaceKeys(keys map[string][]byte) Option {
+	return func(s *PostgresStore) {
+		s.namespaceKeys = keys
+	}
+}
+
+func newCiphers(keys map[string][]byte) (map[string]cipher.AEAD, error) {
+	ciphers := make(map[string]cipher.AEAD, len(keys))
+	for namespace, key := range keys {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key for namespace %s: %w", namespace, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key for namespace %s: %w", namespace, err)
+		}
+		ciphers[namespace] = aead
+	}
+	return ciphers, nil
+}
+
+type sealedValue struct {
+	Namespace  string `json:"namespace"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func (s *PostgresStore) seal(namespace string, data []byte, scope ...string) ([]byte, bool, error) {
+	if namespace == "" || len(s.ciphers) == 0 {
+		return data, false, nil
+	}
+
+	aead, ok := s.ciphers[namespace]
+	if !ok {
+		return nil, false, fmt.Errorf("no encryption key configured for namespace %s", namespace)
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, false, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed, err := json.Marshal(sealedValue{
+		Namespace:  namespace,
+		Ciphertext: aead.Seal(nonce, nonce, data, sealingContext(namespace, scope)),
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return sealed, true, nil
+}
+
+func (s *PostgresStore) open(data []byte, sealed bool, scope ...string) ([]byte, string, error) {
+	if !sealed {
+		return data, "", nil
+	}
+
+	var value sealedValue
+	if err := json.Unmarshal(data, &value); err != nil || value.Namespace == "" {
+		return nil, "", fmt.Errorf("encrypted value is malformed")
+	}
+
+	aead, ok := s.ciphers[value.Namespace]
+	if !ok {
+		return nil, "", fmt.Errorf("no encryption key configured for namespace %s", value.Namespace)
+	}
+	if len(value.Ciphertext) < aead.NonceSize() {
+		return nil, "", fmt.Errorf("ciphertext of namespace %s is truncated", value.Namespace)
+	}
+
+	nonce, ciphertext := value.Ciphertext[:aead.NonceSize()], value.Ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, sealingContext(value.Namespace, scope))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: data does not belong to namespace %s", domain.ErrNamespaceIsolation, value.Namespace)
+	}
+	return plaintext, value.Namespace, nil
+}
+
+func sealingContext(namespace string, scope []string) []byte {
+	return []byte(strings.Join(append([]string{namespace}, scope...), "/"))
+}
@@ -0,0 +1,159 @@
+package store
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+func testStore(t *testing.T, keys map[string][]byte) *PostgresStore {
+	t.Helper()
+	ciphers, err := newCiphers(keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &PostgresStore{namespaceKeys: keys, ciphers: ciphers}
+}
+
+func TestSealOpen(t *testing.T) {
+	keys := map[string][]byte{
+		"acme":   bytes.Repeat([]byte{1}, 32),
+		"globex": bytes.Repeat([]byte{2}, 32),
+	}
+	plaintext := []byte(`{"workflow_id":"wf-1"}`)
+	lookalike := []byte(`{"namespace":"acme","ciphertext":"AAAA"}`)
+
+	tests := []struct {
+		name       string
+		keys       map[string][]byte
+		namespace  string
+		data       []byte
+		sealScope  []string
+		openScope  []string
+		tamper     func(store *PostgresStore, sealed []byte) []byte
+		wantSealed bool
+		wantErr    error
+		wantErrMsg string
+	}{
+		{
+			name:       "round trip within the same scope",
+			keys:       keys,
+			namespace:  "acme",
+			data:       plaintext,
+			sealScope:  []string{"wf-1"},
+			openScope:  []string{"wf-1"},
+			wantSealed: true,
+		},
+		{
+			name:      "no namespace stays in clear",
+			keys:      keys,
+			data:      plaintext,
+			sealScope: []string{"wf-1"},
+			openScope: []string{"wf-1"},
+		},
+		{
+			name:      "no keys configured stays in clear",
+			namespace: "acme",
+			data:      plaintext,
+			sealScope: []string{"wf-1"},
+			openScope: []string{"wf-1"},
+		},
+		{
+			name:      "plain data shaped like a sealed value is returned as is",
+			keys:      keys,
+			data:      lookalike,
+			sealScope: []string{"wf-1"},
+			openScope: []string{"wf-1"},
+		},
+		{
+			name:       "namespace without a key",
+			keys:       keys,
+			namespace:  "initech",
+			data:       plaintext,
+			wantErrMsg: "no encryption key configured for namespace initech",
+		},
+		{
+			name:       "opened for another execution",
+			keys:       keys,
+			namespace:  "acme",
+			data:       plaintext,
+			sealScope:  []string{"wf-1"},
+			openScope:  []string{"wf-2"},
+			wantSealed: true,
+			wantErr:    domain.ErrNamespaceIsolation,
+		},
+		{
+			name:       "relabelled to another namespace",
+			keys:       keys,
+			namespace:  "acme",
+			data:       plaintext,
+			sealScope:  []string{"wf-1"},
+			openScope:  []string{"wf-1"},
+			wantSealed: true,
+			tamper: func(_ *PostgresStore, sealed []byte) []byte {
+				return bytes.Replace(sealed, []byte(`"acme"`), []byte(`"globex"`), 1)
+			},
+			wantErr: domain.ErrNamespaceIsolation,
+		},
+		{
+			name:       "malformed sealed value",
+			keys:       keys,
+			namespace:  "acme",
+			data:       plaintext,
+			wantSealed: true,
+			tamper: func(_ *PostgresStore, _ []byte) []byte {
+				return plaintext
+			},
+			wantErrMsg: "encrypted value is malformed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := testStore(t, tt.keys)
+
+			sealed, isSealed, err := store.seal(tt.namespace, tt.data, tt.sealScope...)
+			if err != nil {
+				if tt.wantErrMsg == "" || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("seal() error = %v, want %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if isSealed != tt.wantSealed {
+				t.Fatalf("seal() sealed = %v, want %v", isSealed, tt.wantSealed)
+			}
+			if isSealed && bytes.Contains(sealed, tt.data) {
+				t.Fatalf("seal() left the plaintext in %s", sealed)
+			}
+			if tt.tamper != nil {
+				sealed = tt.tamper(store, sealed)
+			}
+
+			opened, namespace, err := store.open(sealed, isSealed, tt.openScope...)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("open() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			case tt.wantErrMsg != "":
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("open() error = %v, want %q", err, tt.wantErrMsg)
+				}
+				return
+			case err != nil:
+				t.Fatalf("open() error = %v", err)
+			}
+
+			if !bytes.Equal(opened, tt.data) {
+				t.Errorf("open() = %s, want %s", opened, tt.data)
+			}
+			if isSealed && namespace != tt.namespace {
+				t.Errorf("open() namespace = %q, want %q", namespace, tt.namespace)
+			}
+		})
+	}
+}
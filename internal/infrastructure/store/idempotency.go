@@ -0,0 +1,81 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+func (s *PostgresStore) ClaimIdempotencyKey(ctx context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, bool, error) {
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO maestro_idempotency_keys (workflow, key, version, workflow_id, input_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (workflow, key) DO UPDATE SET
+			version = EXCLUDED.version,
+			workflow_id = EXCLUDED.workflow_id,
+			input_hash = EXCLUDED.input_hash,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at
+		WHERE maestro_idempotency_keys.expires_at < now()`,
+		record.Workflow,
+		record.Key,
+		record.Version,
+		record.WorkflowID,
+		record.InputHash,
+		record.CreatedAt,
+		record.ExpiresAt,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to claim idempotency key %s: %w", record.Key, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n > 0 {
+		return record, true, nil
+	}
+
+	existing := &domain.IdempotencyRecord{Workflow: record.Workflow, Key: record.Key}
+	err = s.db.QueryRowContext(ctx, `
+		SELECT version, workflow_id, input_hash, created_at, expires_at FROM maestro_idempotency_keys
+		WHERE workflow = $1 AND key = $2`,
+		record.Workflow,
+		record.Key,
+	).Scan(&existing.Version, &existing.WorkflowID, &existing.InputHash, &existing.CreatedAt, &existing.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return s.ClaimIdempotencyKey(ctx, record)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read idempotency key %s: %w", record.Key, err)
+	}
+
+	return existing, false, nil
+}
+
+func (s *PostgresStore) ReleaseIdempotencyKey(ctx context.Context, workflow, key, workflowID string) error {
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM maestro_idempotency_keys
+		WHERE workflow = $1 AND key = $2 AND workflow_id = $3`,
+		workflow,
+		key,
+		workflowID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM maestro_idempotency_keys WHERE expires_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge idempotency keys: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, nil
+	}
+	return int(n), nil
+}
@@ -0,0 +1,86 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+func (s *PostgresStore) Acquire(ctx context.Context, key, owner string, ttl time.Duration) (int64, bool, error) {
+	var token int64
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO maestro_locks (key, owner, token, expires_at)
+		VALUES ($1, $2, 1, now() + $3 * interval '1 millisecond')
+		ON CONFLICT (key) DO UPDATE SET
+			token = CASE
+				WHEN maestro_locks.owner = EXCLUDED.owner AND maestro_locks.expires_at >= now()
+				THEN maestro_locks.token
+				ELSE maestro_locks.token + 1
+			END,
+			owner = EXCLUDED.owner,
+			expires_at = EXCLUDED.expires_at
+		WHERE maestro_locks.owner = EXCLUDED.owner
+			OR maestro_locks.expires_at < now()
+		RETURNING token`,
+		key,
+		owner,
+		ttl.Milliseconds(),
+	).Scan(&token)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to acquire lock %s: %w", key, err)
+	}
+	return token, true, nil
+}
+
+func (s *PostgresStore) Renew(ctx context.Context, key, owner string, token int64, ttl time.Duration) error {
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE maestro_locks
+		SET expires_at = now() + $4 * interval '1 millisecond'
+		WHERE key = $1 AND owner = $2 AND token = $3 AND expires_at >= now()`,
+		key,
+		owner,
+		token,
+		ttl.Milliseconds(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to renew lock %s: %w", key, err)
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("lock %s: %w", key, domain.ErrLockLost)
+	}
+	return nil
+}
+
+func (s *PostgresStore) Release(ctx context.Context, key, owner string) error {
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE maestro_locks
+		SET owner = '', expires_at = now() - interval '1 millisecond'
+		WHERE key = $1 AND owner = $2`,
+		key,
+		owner,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to release lock %s: %w", key, err)
+	}
+	if n, err := res.RowsAffected(); err != nil || n > 0 {
+		return nil
+	}
+
+	var holder string
+	err = s.db.QueryRowContext(ctx,
+		`SELECT owner FROM maestro_locks WHERE key = $1 AND expires_at >= now()`,
+		key,
+	).Scan(&holder)
+	if err == nil && holder != "" {
+		return fmt.Errorf("lock %s is held by another owner", key)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+// MemoryStore keeps executions in process memory. Each save stores an
+// encoded snapshot, so callers never share state with what was saved.
+type MemoryStore struct {
+	mu          sync.RWMutex
+	executions  map[string][]byte
+	stepResults map[string]map[string]*domain.StepResult
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		executions:  make(map[string][]byte),
+		stepResults: make(map[string]map[string]*domain.StepResult),
+	}
+}
+
+func (s *MemoryStore) SaveExecution(_ context.Context, execution *domain.Execution) error {
+	snapshot := domain.NewExecutionSnapshot(execution)
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode execution %s: %w", snapshot.WorkflowID, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.executions[snapshot.WorkflowID] = data
+	return nil
+}
+
+func (s *MemoryStore) SaveStepResult(_ context.Context, workflowID string, result *domain.StepResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.executions[workflowID]; !ok {
+		return fmt.Errorf("failed to save result of step %s: execution %s not found", result.StepID, workflowID)
+	}
+
+	results, ok := s.stepResults[workflowID]
+	if !ok {
+		results = make(map[string]*domain.StepResult)
+		s.stepResults[workflowID] = results
+	}
+	saved := *result
+	results[result.StepID] = &saved
+	return nil
+}
+
+func (s *MemoryStore) LoadExecution(_ context.Context, workflowID string) (*domain.Execution, bool, error) {
+	s.mu.RLock()
+	data, ok := s.executions[workflowID]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, false, nil
+	}
+
+	execution, err := decodeSnapshot(data)
+	if err != nil {
+		return nil, false, err
+	}
+	return execution, true, nil
+}
+
+func (s *MemoryStore) ListExecutions(_ context.Context, filter domain.ExecutionFilter) ([]*domain.Execution, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var executions []*domain.Execution
+	for _, data := range s.executions {
+		execution, err := decodeSnapshot(data)
+		if err != nil {
+			return nil, err
+		}
+		if filter.WorkflowName != "" && execution.WorkflowName != filter.WorkflowName {
+			continue
+		}
+		if filter.Status != "" && execution.Result.Status.String() != filter.Status {
+			continue
+		}
+		executions = append(executions, execution)
+	}
+
+	slices.SortFunc(executions, func(a, b *domain.Execution) int {
+		return cmp.Compare(b.Result.StartedAt.UnixNano(), a.Result.StartedAt.UnixNano())
+	})
+	if filter.Limit > 0 && len(executions) > filter.Limit {
+		executions = executions[:filter.Limit]
+	}
+
+	return executions, nil
+}
+
+func decodeSnapshot(data []byte) (*domain.Execution, error) {
+	var snapshot domain.ExecutionSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode execution: %w", err)
+	}
+	return snapshot.Execution()
+}
@@ -0,0 +1,15 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/maestro/maestro.go/internal/infrastructure/store/storetest"
+)
+
+func TestMemoryStore(t *testing.T) {
+	for _, tt := range storetest.Cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.Run(t, NewMemoryStore())
+		})
+	}
+}
@@ -0,0 +1,573 @@
+package store
+
+import (
+	"context"
+	"crypto/cipher"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq"
+	ctxkeys "github.com/maestro/maestro.go/internal/context"
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS maestro_executions (
+	workflow_id      TEXT PRIMARY KEY,
+	workflow_name    TEXT NOT NULL,
+	workflow_version TEXT NOT NULL,
+	namespace        TEXT NOT NULL DEFAULT '',
+	status           TEXT NOT NULL,
+	snapshot         JSONB NOT NULL,
+	started_at       TIMESTAMPTZ NOT NULL,
+	updated_at       TIMESTAMPTZ NOT NULL
+);
+
+ALTER TABLE maestro_executions ADD COLUMN IF NOT EXISTS namespace TEXT NOT NULL DEFAULT '';
+ALTER TABLE maestro_executions ADD COLUMN IF NOT EXISTS sealed BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE maestro_executions ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE maestro_executions ADD COLUMN IF NOT EXISTS rerun_of TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS maestro_executions_workflow_name_idx
+	ON maestro_executions (workflow_name, started_at DESC);
+
+CREATE INDEX IF NOT EXISTS maestro_executions_tags_idx
+	ON maestro_executions USING GIN (tags);
+
+CREATE INDEX IF NOT EXISTS maestro_executions_rerun_of_idx
+	ON maestro_executions (rerun_of) WHERE rerun_of <> '';
+
+CREATE TABLE IF NOT EXISTS maestro_step_results (
+	workflow_id TEXT NOT NULL REFERENCES maestro_executions (workflow_id) ON DELETE CASCADE,
+	step_id     TEXT NOT NULL,
+	output      JSONB,
+	error       TEXT,
+	skipped     BOOLEAN NOT NULL DEFAULT false,
+	recorded_at TIMESTAMPTZ NOT NULL,
+	PRIMARY KEY (workflow_id, step_id)
+);
+
+ALTER TABLE maestro_step_results ADD COLUMN IF NOT EXISTS skipped BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE maestro_step_results ADD COLUMN IF NOT EXISTS sealed BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS maestro_field_retention (
+	workflow_id TEXT NOT NULL REFERENCES maestro_executions (workflow_id) ON DELETE CASCADE,
+	path        TEXT NOT NULL,
+	step_id     TEXT NOT NULL DEFAULT '',
+	expires_at  TIMESTAMPTZ NOT NULL,
+	PRIMARY KEY (workflow_id, path)
+);
+
+CREATE INDEX IF NOT EXISTS maestro_field_retention_expires_at_idx
+	ON maestro_field_retention (expires_at);
+
+CREATE TABLE IF NOT EXISTS maestro_saga_states (
+	workflow_id    TEXT PRIMARY KEY REFERENCES maestro_executions (workflow_id) ON DELETE CASCADE,
+	namespace      TEXT NOT NULL DEFAULT '',
+	status         TEXT NOT NULL,
+	executed_steps JSONB NOT NULL,
+	updated_at     TIMESTAMPTZ NOT NULL
+);
+
+ALTER TABLE maestro_saga_states ADD COLUMN IF NOT EXISTS sealed BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS maestro_saga_states_status_idx
+	ON maestro_saga_states (status, updated_at);
+
+CREATE TABLE IF NOT EXISTS maestro_dead_letters (
+	id              TEXT PRIMARY KEY,
+	kind            TEXT NOT NULL,
+	source          TEXT NOT NULL,
+	workflow        TEXT NOT NULL DEFAULT '',
+	workflow_id     TEXT NOT NULL DEFAULT '',
+	namespace       TEXT NOT NULL DEFAULT '',
+	payload         BYTEA NOT NULL,
+	headers         JSONB,
+	reason          TEXT NOT NULL,
+	attempts        INTEGER NOT NULL,
+	created_at      TIMESTAMPTZ NOT NULL,
+	last_attempt_at TIMESTAMPTZ NOT NULL
+);
+
+ALTER TABLE maestro_dead_letters ADD COLUMN IF NOT EXISTS sealed BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS maestro_dead_letters_created_at_idx
+	ON maestro_dead_letters (kind, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS maestro_execution_leases (
+	workflow_id TEXT PRIMARY KEY,
+	owner       TEXT NOT NULL,
+	token       BIGINT NOT NULL,
+	expires_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS maestro_locks (
+	key        TEXT PRIMARY KEY,
+	owner      TEXT NOT NULL,
+	token      BIGINT NOT NULL,
+	expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS maestro_idempotency_keys (
+	workflow    TEXT NOT NULL,
+	key         TEXT NOT NULL,
+	workflow_id TEXT NOT NULL,
+	input_hash  TEXT NOT NULL,
+	created_at  TIMESTAMPTZ NOT NULL,
+	expires_at  TIMESTAMPTZ NOT NULL,
+	PRIMARY KEY (workflow, key)
+);
+ALTER TABLE maestro_idempotency_keys ADD COLUMN IF NOT EXISTS version TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS maestro_primary (
+	id          TEXT PRIMARY KEY,
+	owner       TEXT NOT NULL,
+	token       BIGINT NOT NULL,
+	expires_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS maestro_reruns (
+	id          TEXT PRIMARY KEY,
+	workflow    TEXT NOT NULL,
+	namespace   TEXT NOT NULL DEFAULT '',
+	environment TEXT NOT NULL DEFAULT '',
+	tags        JSONB NOT NULL DEFAULT '{}',
+	input       BYTEA NOT NULL,
+	original_id TEXT NOT NULL,
+	previous_id TEXT NOT NULL,
+	attempt     INTEGER NOT NULL,
+	reason      TEXT NOT NULL,
+	due_at      TIMESTAMPTZ NOT NULL,
+	created_at  TIMESTAMPTZ NOT NULL
+);
+
+ALTER TABLE maestro_reruns ADD COLUMN IF NOT EXISTS sealed BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS maestro_reruns_due_at_idx
+	ON maestro_reruns (due_at);
+`
+
+type PostgresStore struct {
+	db            *sql.DB
+	namespaceKeys map[string][]byte
+	ciphers       map[string]cipher.AEAD
+}
+
+type Option func(*PostgresStore)
+
+func NewPostgresStore(ctx context.Context, dsn string, opts ...Option) (*PostgresStore, error) {
+	s := &PostgresStore{}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	ciphers, err := newCiphers(s.namespaceKeys)
+	if err != nil {
+		return nil, err
+	}
+	s.ciphers = ciphers
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres connection: %w", err)
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to postgres: %w", err)
+	}
+
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create execution tables: %w", err)
+	}
+
+	s.db = db
+	return s, nil
+}
+
+func (s *PostgresStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *PostgresStore) SaveExecution(ctx context.Context, execution *domain.Execution) error {
+	snapshot := domain.NewExecutionSnapshot(execution)
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode execution %s: %w", snapshot.WorkflowID, err)
+	}
+
+	completed := execution.Result.Status.IsTerminal() && len(snapshot.Retention) > 0
+	if completed {
+		var ephemeral []domain.FieldRetention
+		for _, rule := range snapshot.Retention {
+			if rule.Ephemeral() {
+				ephemeral = append(ephemeral, rule)
+			}
+		}
+		if data, err = scrubSnapshot(data, ephemeral); err != nil {
+			return fmt.Errorf("failed to scrub execution %s: %w", snapshot.WorkflowID, err)
+		}
+	}
+
+	data, sealed, err := s.seal(snapshot.Namespace, data, snapshot.WorkflowID)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt execution %s: %w", snapshot.WorkflowID, err)
+	}
+
+	tags, err := encodeTags(snapshot.Tags)
+	if err != nil {
+		return fmt.Errorf("failed to encode tags of execution %s: %w", snapshot.WorkflowID, err)
+	}
+
+	err = s.fencedWrite(ctx, snapshot.WorkflowID, `
+		INSERT INTO maestro_executions
+			(workflow_id, workflow_name, workflow_version, namespace, status, snapshot, sealed, started_at, updated_at, tags, rerun_of)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (workflow_id) DO UPDATE SET
+			status = EXCLUDED.status,
+			snapshot = EXCLUDED.snapshot,
+			sealed = EXCLUDED.sealed,
+			updated_at = EXCLUDED.updated_at`,
+		snapshot.WorkflowID,
+		snapshot.WorkflowName,
+		snapshot.WorkflowVersion,
+		snapshot.Namespace,
+		snapshot.Status,
+		data,
+		sealed,
+		snapshot.StartedAt,
+		time.Now(),
+		tags,
+		snapshot.RerunOf,
+	)
+	if errors.Is(err, domain.ErrFenced) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save execution %s: %w", snapshot.WorkflowID, err)
+	}
+
+	if completed {
+		if err := s.scheduleRetention(ctx, snapshot); err != nil {
+			return fmt.Errorf("failed to apply retention to execution %s: %w", snapshot.WorkflowID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *PostgresStore) SaveStepResult(ctx context.Context, workflowID string, result *domain.StepResult) error {
+	output, err := json.Marshal(result.Output)
+	if err != nil {
+		return fmt.Errorf("failed to encode output of step %s: %w", result.StepID, err)
+	}
+
+	namespace, _ := ctx.Value(ctxkeys.Namespace).(string)
+	output, sealed, err := s.seal(namespace, output, workflowID, result.StepID)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt output of step %s: %w", result.StepID, err)
+	}
+
+	var stepErr sql.NullString
+	if result.Error != nil {
+		message, _, err := s.seal(namespace, []byte(result.Error.Error()), workflowID, result.StepID, "error")
+		if err != nil {
+			return fmt.Errorf("failed to encrypt error of step %s: %w", result.StepID, err)
+		}
+		stepErr = sql.NullString{String: string(message), Valid: true}
+	}
+
+	err = s.fencedWrite(ctx, workflowID, `
+		INSERT INTO maestro_step_results (workflow_id, step_id, output, error, skipped, sealed, recorded_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (workflow_id, step_id) DO UPDATE SET
+			output = EXCLUDED.output,
+			error = EXCLUDED.error,
+			skipped = EXCLUDED.skipped,
+			sealed = EXCLUDED.sealed,
+			recorded_at = EXCLUDED.recorded_at`,
+		workflowID,
+		result.StepID,
+		output,
+		stepErr,
+		result.Skipped,
+		sealed,
+		time.Now(),
+	)
+	if errors.Is(err, domain.ErrFenced) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save result of step %s: %w", result.StepID, err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStore) LoadExecution(ctx context.Context, workflowID string) (*domain.Execution, bool, error) {
+	var (
+		data   []byte
+		sealed bool
+	)
+	err := s.db.QueryRowContext(ctx,
+		`SELECT snapshot, sealed FROM maestro_executions WHERE workflow_id = $1`,
+		workflowID,
+	).Scan(&data, &sealed)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load execution %s: %w", workflowID, err)
+	}
+
+	execution, err := s.decodeExecution(workflowID, data, sealed)
+	if err != nil {
+		return nil, false, err
+	}
+	return execution, true, nil
+}
+
+func (s *PostgresStore) LoadStepResults(ctx context.Context, workflowID string) ([]*domain.StepResult, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT step_id, output, error, skipped, sealed
+		FROM maestro_step_results
+		WHERE workflow_id = $1
+		ORDER BY recorded_at`,
+		workflowID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load step results of %s: %w", workflowID, err)
+	}
+	defer rows.Close()
+
+	var results []*domain.StepResult
+	for rows.Next() {
+		var (
+			result  domain.StepResult
+			output  []byte
+			stepErr sql.NullString
+			sealed  bool
+		)
+		if err := rows.Scan(&result.StepID, &output, &stepErr, &result.Skipped, &sealed); err != nil {
+			return nil, fmt.Errorf("failed to read step result row: %w", err)
+		}
+		if len(output) > 0 {
+			output, _, err := s.open(output, sealed, workflowID, result.StepID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt output of step %s: %w", result.StepID, err)
+			}
+			if err := json.Unmarshal(output, &result.Output); err != nil {
+				return nil, fmt.Errorf("failed to decode output of step %s: %w", result.StepID, err)
+			}
+		}
+		if stepErr.Valid {
+			message, _, err := s.open([]byte(stepErr.String), sealed, workflowID, result.StepID, "error")
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt error of step %s: %w", result.StepID, err)
+			}
+			result.Error = errors.New(string(message))
+		}
+		results = append(results, &result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load step results of %s: %w", workflowID, err)
+	}
+
+	return results, nil
+}
+
+func (s *PostgresStore) ListExecutions(ctx context.Context, filter domain.ExecutionFilter) ([]*domain.Execution, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+	if filter.WorkflowName != "" {
+		args = append(args, filter.WorkflowName)
+		conditions = append(conditions, fmt.Sprintf("workflow_name = $%d", len(args)))
+	}
+	if filter.Namespace != "" {
+		args = append(args, filter.Namespace)
+		conditions = append(conditions, fmt.Sprintf("namespace = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if len(filter.Tags) > 0 {
+		tags, err := encodeTags(filter.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode tag filter: %w", err)
+		}
+		args = append(args, tags)
+		conditions = append(conditions, fmt.Sprintf("tags @> $%d", len(args)))
+	}
+	if filter.RerunOf != "" {
+		args = append(args, filter.RerunOf)
+		conditions = append(conditions, fmt.Sprintf("rerun_of = $%d", len(args)))
+	}
+
+	query := `SELECT workflow_id, snapshot, sealed FROM maestro_executions`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY started_at DESC"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list executions: %w", err)
+	}
+	defer rows.Close()
+
+	var executions []*domain.Execution
+	for rows.Next() {
+		var (
+			workflowID string
+			data       []byte
+			sealed     bool
+		)
+		if err := rows.Scan(&workflowID, &data, &sealed); err != nil {
+			return nil, fmt.Errorf("failed to read execution row: %w", err)
+		}
+		execution, err := s.decodeExecution(workflowID, data, sealed)
+		if err != nil {
+			return nil, err
+		}
+		executions = append(executions, execution)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list executions: %w", err)
+	}
+
+	return executions, nil
+}
+
+func encodeTags(tags map[string]string) ([]byte, error) {
+	if tags == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(tags)
+}
+
+func (s *PostgresStore) ClaimExecution(ctx context.Context, workflowID, owner string, ttl time.Duration) (int64, error) {
+	var token int64
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO maestro_execution_leases (workflow_id, owner, token, expires_at)
+		VALUES ($1, $2, 1, now() + $3 * interval '1 millisecond')
+		ON CONFLICT (workflow_id) DO UPDATE SET
+			owner = EXCLUDED.owner,
+			token = maestro_execution_leases.token + 1,
+			expires_at = EXCLUDED.expires_at
+		WHERE maestro_execution_leases.owner = EXCLUDED.owner
+			OR maestro_execution_leases.expires_at < now()
+		RETURNING token`,
+		workflowID,
+		owner,
+		ttl.Milliseconds(),
+	).Scan(&token)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("execution %s is leased by another node: %w", workflowID, domain.ErrFenced)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim execution %s: %w", workflowID, err)
+	}
+
+	return token, nil
+}
+
+func (s *PostgresStore) RenewExecution(ctx context.Context, workflowID, owner string, token int64, ttl time.Duration) error {
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE maestro_execution_leases
+		SET expires_at = now() + $4 * interval '1 millisecond'
+		WHERE workflow_id = $1 AND owner = $2 AND token = $3`,
+		workflowID,
+		owner,
+		token,
+		ttl.Milliseconds(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to renew lease of execution %s: %w", workflowID, err)
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("lost lease of execution %s: %w", workflowID, domain.ErrFenced)
+	}
+
+	return nil
+}
+
+func (s *PostgresStore) ReleaseExecution(ctx context.Context, workflowID, owner string, token int64) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE maestro_execution_leases
+		SET expires_at = now() - interval '1 millisecond'
+		WHERE workflow_id = $1 AND owner = $2 AND token = $3`,
+		workflowID,
+		owner,
+		token,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to release lease of execution %s: %w", workflowID, err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) fencedWrite(ctx context.Context, workflowID, query string, args ...any) error {
+	token, ok := ctx.Value(ctxkeys.FencingToken).(int64)
+	if !ok || token == 0 {
+		_, err := s.db.ExecContext(ctx, query, args...)
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var current int64
+	err = tx.QueryRowContext(ctx,
+		`SELECT token FROM maestro_execution_leases WHERE workflow_id = $1 FOR SHARE`,
+		workflowID,
+	).Scan(&current)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if current > token {
+		return fmt.Errorf("write to execution %s with token %d rejected, current token is %d: %w",
+			workflowID, token, current, domain.ErrFenced)
+	}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *PostgresStore) decodeExecution(workflowID string, data []byte, sealed bool) (*domain.Execution, error) {
+	data, namespace, err := s.open(data, sealed, workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt execution %s: %w", workflowID, err)
+	}
+
+	var snapshot domain.ExecutionSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode execution: %w", err)
+	}
+	if namespace != "" {
+		if err := domain.CheckNamespace(namespace, snapshot.Namespace); err != nil {
+			return nil, fmt.Errorf("execution %s: %w", workflowID, err)
+		}
+	}
+	return snapshot.Execution()
+}
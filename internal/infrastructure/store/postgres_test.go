@@ -0,0 +1,154 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/lock/locktest"
+	"github.com/maestro/maestro.go/internal/infrastructure/store/storetest"
+)
+
+func postgresStore(t *testing.T) *PostgresStore {
+	t.Helper()
+	dsn := os.Getenv("MAESTRO_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("MAESTRO_TEST_POSTGRES_DSN is not set")
+	}
+
+	store, err := NewPostgresStore(context.Background(), dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestPostgresLock(t *testing.T) {
+	store := postgresStore(t)
+
+	for _, tt := range locktest.Cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			locktest.Run(t, store, uuid.NewString(), tt.Steps)
+		})
+	}
+}
+
+func TestPostgresExecutionStore(t *testing.T) {
+	store := postgresStore(t)
+
+	for _, tt := range storetest.Cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.Run(t, store)
+		})
+	}
+}
+
+type leaseStep struct {
+	op    string
+	owner string
+	ttl   time.Duration
+	stale bool
+	// takeover retries a claim until the current holder's lease expires.
+	takeover  bool
+	wantToken int64
+	wantErr   error
+}
+
+func TestExecutionLeaseFencing(t *testing.T) {
+	store := postgresStore(t)
+
+	tests := []struct {
+		name  string
+		steps []leaseStep
+	}{
+		{
+			name: "a live lease fences other owners",
+			steps: []leaseStep{
+				{op: "claim", owner: "node-a", wantToken: 1},
+				{op: "claim", owner: "node-b", wantErr: domain.ErrFenced},
+				{op: "renew", owner: "node-a"},
+			},
+		},
+		{
+			name: "claiming again bumps the token and fences the old one",
+			steps: []leaseStep{
+				{op: "claim", owner: "node-a", wantToken: 1},
+				{op: "claim", owner: "node-a", wantToken: 2},
+				{op: "renew", owner: "node-a", stale: true, wantErr: domain.ErrFenced},
+				{op: "renew", owner: "node-a"},
+			},
+		},
+		{
+			name: "an expired lease is taken over",
+			steps: []leaseStep{
+				{op: "claim", owner: "node-a", ttl: 50 * time.Millisecond, wantToken: 1},
+				{op: "claim", owner: "node-b", takeover: true, wantToken: 2},
+				{op: "renew", owner: "node-a", wantErr: domain.ErrFenced},
+			},
+		},
+		{
+			name: "a released lease is taken over",
+			steps: []leaseStep{
+				{op: "claim", owner: "node-a", wantToken: 1},
+				{op: "release", owner: "node-a"},
+				{op: "claim", owner: "node-b", wantToken: 2},
+				{op: "renew", owner: "node-a", wantErr: domain.ErrFenced},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			workflowID := uuid.NewString()
+			tokens := make(map[string]int64)
+
+			for i, step := range tt.steps {
+				ttl := step.ttl
+				if ttl == 0 {
+					ttl = time.Minute
+				}
+				token := tokens[step.owner]
+				if step.stale {
+					token--
+				}
+
+				var err error
+				switch step.op {
+				case "claim":
+					token, err = claim(ctx, store, workflowID, step.owner, ttl, step.takeover)
+					if err == nil {
+						tokens[step.owner] = token
+					}
+					if err == nil && token != step.wantToken {
+						t.Fatalf("step %d: token = %d, want %d", i, token, step.wantToken)
+					}
+				case "renew":
+					err = store.RenewExecution(ctx, workflowID, step.owner, token, ttl)
+				case "release":
+					err = store.ReleaseExecution(ctx, workflowID, step.owner, token)
+				}
+
+				if !errors.Is(err, step.wantErr) {
+					t.Fatalf("step %d %s by %s: error = %v, want %v", i, step.op, step.owner, err, step.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func claim(ctx context.Context, store *PostgresStore, workflowID, owner string, ttl time.Duration, takeover bool) (int64, error) {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		token, err := store.ClaimExecution(ctx, workflowID, owner, ttl)
+		if !takeover || !errors.Is(err, domain.ErrFenced) || time.Now().After(deadline) {
+			return token, err
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
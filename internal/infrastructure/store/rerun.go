@@ -0,0 +1,132 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+const rerunColumns = `id, workflow, namespace, environment, tags, input, sealed, original_id, previous_id, attempt, reason, due_at, created_at`
+
+func (s *PostgresStore) EnqueueRerun(ctx context.Context, rerun *domain.Rerun) error {
+	input, err := json.Marshal(rerun.Input)
+	if err != nil {
+		return fmt.Errorf("failed to encode input of rerun %s: %w", rerun.ID, err)
+	}
+	input, sealed, err := s.seal(rerun.Namespace, input, rerun.ID, "rerun")
+	if err != nil {
+		return fmt.Errorf("failed to encrypt rerun %s: %w", rerun.ID, err)
+	}
+	tags, err := encodeTags(rerun.Tags)
+	if err != nil {
+		return fmt.Errorf("failed to encode tags of rerun %s: %w", rerun.ID, err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO maestro_reruns (`+rerunColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (id) DO UPDATE SET
+			reason = EXCLUDED.reason,
+			due_at = EXCLUDED.due_at`,
+		rerun.ID,
+		rerun.Workflow,
+		rerun.Namespace,
+		rerun.Environment,
+		tags,
+		input,
+		sealed,
+		rerun.OriginalID,
+		rerun.PreviousID,
+		rerun.Attempt,
+		rerun.Reason,
+		rerun.DueAt,
+		rerun.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue rerun %s: %w", rerun.ID, err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) DueReruns(ctx context.Context, before time.Time) ([]*domain.Rerun, error) {
+	return s.queryReruns(ctx,
+		`SELECT `+rerunColumns+` FROM maestro_reruns WHERE due_at <= $1 ORDER BY due_at`,
+		before,
+	)
+}
+
+func (s *PostgresStore) ListReruns(ctx context.Context, workflow string) ([]*domain.Rerun, error) {
+	if workflow == "" {
+		return s.queryReruns(ctx, `SELECT `+rerunColumns+` FROM maestro_reruns ORDER BY due_at`)
+	}
+	return s.queryReruns(ctx,
+		`SELECT `+rerunColumns+` FROM maestro_reruns WHERE workflow = $1 ORDER BY due_at`,
+		workflow,
+	)
+}
+
+func (s *PostgresStore) DeleteRerun(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM maestro_reruns WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete rerun %s: %w", id, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrRerunNotFound, id)
+	}
+	return nil
+}
+
+func (s *PostgresStore) queryReruns(ctx context.Context, query string, args ...any) ([]*domain.Rerun, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reruns: %w", err)
+	}
+	defer rows.Close()
+
+	var reruns []*domain.Rerun
+	for rows.Next() {
+		var (
+			rerun  domain.Rerun
+			tags   []byte
+			input  []byte
+			sealed bool
+		)
+		err := rows.Scan(
+			&rerun.ID,
+			&rerun.Workflow,
+			&rerun.Namespace,
+			&rerun.Environment,
+			&tags,
+			&input,
+			&sealed,
+			&rerun.OriginalID,
+			&rerun.PreviousID,
+			&rerun.Attempt,
+			&rerun.Reason,
+			&rerun.DueAt,
+			&rerun.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rerun row: %w", err)
+		}
+
+		if input, _, err = s.open(input, sealed, rerun.ID, "rerun"); err != nil {
+			return nil, fmt.Errorf("failed to decrypt rerun %s: %w", rerun.ID, err)
+		}
+		if err := json.Unmarshal(input, &rerun.Input); err != nil {
+			return nil, fmt.Errorf("failed to decode input of rerun %s: %w", rerun.ID, err)
+		}
+		if err := json.Unmarshal(tags, &rerun.Tags); err != nil {
+			return nil, fmt.Errorf("failed to decode tags of rerun %s: %w", rerun.ID, err)
+		}
+		reruns = append(reruns, &rerun)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list reruns: %w", err)
+	}
+	return reruns, nil
+}
@@ -0,0 +1,217 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+const purgeBatchSize = 1000
+
+func (s *PostgresStore) scheduleRetention(ctx context.Context, snapshot *domain.ExecutionSnapshot) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	completedAt := snapshot.CompletedAt
+	if completedAt.IsZero() {
+		completedAt = time.Now()
+	}
+
+	for _, rule := range snapshot.Retention {
+		if rule.Ephemeral() {
+			if err := s.scrubStepResult(ctx, tx, snapshot.WorkflowID, rule); err != nil {
+				return err
+			}
+			continue
+		}
+
+		period, err := domain.RetentionPeriod(rule.Class)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO maestro_field_retention (workflow_id, path, step_id, expires_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (workflow_id, path) DO NOTHING`,
+			snapshot.WorkflowID,
+			rule.Path,
+			rule.StepID,
+			completedAt.Add(period),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to schedule purge of %s: %w", rule.Path, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *PostgresStore) PurgeExpiredFields(ctx context.Context, now time.Time) (int, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT workflow_id, path, step_id
+		FROM maestro_field_retention
+		WHERE expires_at <= $1
+		ORDER BY workflow_id
+		LIMIT $2`,
+		now,
+		purgeBatchSize,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list expired fields: %w", err)
+	}
+
+	expired := make(map[string][]domain.FieldRetention)
+	for rows.Next() {
+		var workflowID string
+		var rule domain.FieldRetention
+		if err := rows.Scan(&workflowID, &rule.Path, &rule.StepID); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to read expired field row: %w", err)
+		}
+		expired[workflowID] = append(expired[workflowID], rule)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to list expired fields: %w", err)
+	}
+
+	purged := 0
+	for workflowID, rules := range expired {
+		if err := s.purgeFields(ctx, workflowID, rules); err != nil {
+			return purged, err
+		}
+		purged += len(rules)
+	}
+
+	return purged, nil
+}
+
+func (s *PostgresStore) purgeFields(ctx context.Context, workflowID string, rules []domain.FieldRetention) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var (
+		data   []byte
+		sealed bool
+	)
+	err = tx.QueryRowContext(ctx,
+		`SELECT snapshot, sealed FROM maestro_executions WHERE workflow_id = $1 FOR UPDATE`,
+		workflowID,
+	).Scan(&data, &sealed)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to load execution %s: %w", workflowID, err)
+	}
+
+	if len(data) > 0 {
+		var namespace string
+		if data, namespace, err = s.open(data, sealed, workflowID); err != nil {
+			return fmt.Errorf("failed to decrypt execution %s: %w", workflowID, err)
+		}
+		if data, err = scrubSnapshot(data, rules); err != nil {
+			return fmt.Errorf("failed to scrub execution %s: %w", workflowID, err)
+		}
+		if data, sealed, err = s.seal(namespace, data, workflowID); err != nil {
+			return fmt.Errorf("failed to encrypt execution %s: %w", workflowID, err)
+		}
+		_, err = tx.ExecContext(ctx,
+			`UPDATE maestro_executions SET snapshot = $2, sealed = $3 WHERE workflow_id = $1`,
+			workflowID,
+			data,
+			sealed,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save execution %s: %w", workflowID, err)
+		}
+	}
+
+	for _, rule := range rules {
+		if err := s.scrubStepResult(ctx, tx, workflowID, rule); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx,
+			`DELETE FROM maestro_field_retention WHERE workflow_id = $1 AND path = $2`,
+			workflowID,
+			rule.Path,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to clear purge of %s: %w", rule.Path, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func scrubSnapshot(data []byte, rules []domain.FieldRetention) ([]byte, error) {
+	if len(rules) == 0 {
+		return data, nil
+	}
+
+	var snapshot domain.ExecutionSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		snapshot.Scrub(rule)
+	}
+	return json.Marshal(snapshot)
+}
+
+func (s *PostgresStore) scrubStepResult(ctx context.Context, tx *sql.Tx, workflowID string, rule domain.FieldRetention) error {
+	if rule.StepID == "" {
+		return nil
+	}
+
+	var (
+		data   []byte
+		sealed bool
+	)
+	err := tx.QueryRowContext(ctx,
+		`SELECT output, sealed FROM maestro_step_results WHERE workflow_id = $1 AND step_id = $2 FOR UPDATE`,
+		workflowID,
+		rule.StepID,
+	).Scan(&data, &sealed)
+	if errors.Is(err, sql.ErrNoRows) || len(data) == 0 {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load result of step %s: %w", rule.StepID, err)
+	}
+
+	data, namespace, err := s.open(data, sealed, workflowID, rule.StepID)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt output of step %s: %w", rule.StepID, err)
+	}
+
+	var output interface{}
+	if err := json.Unmarshal(data, &output); err != nil {
+		return fmt.Errorf("failed to decode output of step %s: %w", rule.StepID, err)
+	}
+	if data, err = json.Marshal(domain.ScrubStepOutput(output, rule)); err != nil {
+		return fmt.Errorf("failed to encode output of step %s: %w", rule.StepID, err)
+	}
+	if data, _, err = s.seal(namespace, data, workflowID, rule.StepID); err != nil {
+		return fmt.Errorf("failed to encrypt output of step %s: %w", rule.StepID, err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE maestro_step_results SET output = $3 WHERE workflow_id = $1 AND step_id = $2`,
+		workflowID,
+		rule.StepID,
+		data,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to scrub result of step %s: %w", rule.StepID, err)
+	}
+	return nil
+}
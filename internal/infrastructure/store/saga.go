@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+const stalledSagaBatchSize = 100
+
+func (s *PostgresStore) SaveSagaState(ctx context.Context, state *domain.SagaState) error {
+	steps, err := json.Marshal(state.ExecutedSteps)
+	if err != nil {
+		return fmt.Errorf("failed to encode saga state of %s: %w", state.WorkflowID, err)
+	}
+	steps, sealed, err := s.seal(state.Namespace, steps, state.WorkflowID, "saga")
+	if err != nil {
+		return fmt.Errorf("failed to encrypt saga state of %s: %w", state.WorkflowID, err)
+	}
+
+	err = s.fencedWrite(ctx, state.WorkflowID, `
+		INSERT INTO maestro_saga_states (workflow_id, namespace, status, executed_steps, sealed, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (workflow_id) DO UPDATE SET
+			status = EXCLUDED.status,
+			executed_steps = EXCLUDED.executed_steps,
+			sealed = EXCLUDED.sealed,
+			updated_at = EXCLUDED.updated_at`,
+		state.WorkflowID,
+		state.Namespace,
+		state.Status.String(),
+		steps,
+		sealed,
+		state.UpdatedAt,
+	)
+	if errors.Is(err, domain.ErrFenced) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save saga state of %s: %w", state.WorkflowID, err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStore) ListStalledSagas(ctx context.Context, status domain.SagaStatus) ([]*domain.SagaState, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT saga.workflow_id, saga.namespace, saga.executed_steps, saga.sealed, saga.updated_at
+		FROM maestro_saga_states saga
+		LEFT JOIN maestro_execution_leases lease ON lease.workflow_id = saga.workflow_id
+		WHERE saga.status = $1
+			AND (lease.expires_at IS NULL OR lease.expires_at < now())
+		ORDER BY saga.updated_at
+		LIMIT $2`,
+		status.String(),
+		stalledSagaBatchSize,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stalled sagas: %w", err)
+	}
+	defer rows.Close()
+
+	var states []*domain.SagaState
+	for rows.Next() {
+		state := &domain.SagaState{Status: status}
+		var (
+			steps  []byte
+			sealed bool
+		)
+		if err := rows.Scan(&state.WorkflowID, &state.Namespace, &steps, &sealed, &state.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to read saga state row: %w", err)
+		}
+		if steps, _, err = s.open(steps, sealed, state.WorkflowID, "saga"); err != nil {
+			return nil, fmt.Errorf("failed to decrypt saga state of %s: %w", state.WorkflowID, err)
+		}
+		if err := json.Unmarshal(steps, &state.ExecutedSteps); err != nil {
+			return nil, fmt.Errorf("failed to decode saga state of %s: %w", state.WorkflowID, err)
+		}
+		states = append(states, state)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list stalled sagas: %w", err)
+	}
+
+	return states, nil
+}
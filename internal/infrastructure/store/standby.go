@@ -0,0 +1,119 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+const primaryLeaseID = "primary"
+
+func (s *PostgresStore) ClaimPrimary(ctx context.Context, owner string, ttl time.Duration) (int64, error) {
+	var token int64
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO maestro_primary (id, owner, token, expires_at)
+		VALUES ($1, $2, 1, now() + $3 * interval '1 millisecond')
+		ON CONFLICT (id) DO UPDATE SET
+			owner = EXCLUDED.owner,
+			token = maestro_primary.token + 1,
+			expires_at = EXCLUDED.expires_at
+		WHERE maestro_primary.owner = EXCLUDED.owner
+			OR maestro_primary.expires_at < now()
+		RETURNING token`,
+		primaryLeaseID,
+		owner,
+		ttl.Milliseconds(),
+	).Scan(&token)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("primary is held by another node: %w", domain.ErrFenced)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim primary: %w", err)
+	}
+
+	return token, nil
+}
+
+func (s *PostgresStore) RenewPrimary(ctx context.Context, owner string, token int64, ttl time.Duration) error {
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE maestro_primary
+		SET expires_at = now() + $4 * interval '1 millisecond'
+		WHERE id = $1 AND owner = $2 AND token = $3`,
+		primaryLeaseID,
+		owner,
+		token,
+		ttl.Milliseconds(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to renew primary lease: %w", err)
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("lost primary lease: %w", domain.ErrFenced)
+	}
+
+	return nil
+}
+
+func (s *PostgresStore) ReleasePrimary(ctx context.Context, owner string, token int64) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE maestro_primary
+		SET expires_at = now() - interval '1 millisecond'
+		WHERE id = $1 AND owner = $2 AND token = $3`,
+		primaryLeaseID,
+		owner,
+		token,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to release primary lease: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) CurrentPrimary(ctx context.Context) (*domain.PrimaryLease, bool, error) {
+	var primary domain.PrimaryLease
+	err := s.db.QueryRowContext(ctx, `
+		SELECT owner, token, expires_at FROM maestro_primary
+		WHERE id = $1 AND expires_at > now()`,
+		primaryLeaseID,
+	).Scan(&primary.Owner, &primary.Token, &primary.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read primary lease: %w", err)
+	}
+
+	return &primary, true, nil
+}
+
+func (s *PostgresStore) SeizeExecution(ctx context.Context, workflowID, owner, from string, ttl time.Duration) (int64, error) {
+	var token int64
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO maestro_execution_leases (workflow_id, owner, token, expires_at)
+		VALUES ($1, $2, 1, now() + $4 * interval '1 millisecond')
+		ON CONFLICT (workflow_id) DO UPDATE SET
+			owner = EXCLUDED.owner,
+			token = maestro_execution_leases.token + 1,
+			expires_at = EXCLUDED.expires_at
+		WHERE maestro_execution_leases.owner IN (EXCLUDED.owner, $3)
+			OR maestro_execution_leases.expires_at < now()
+		RETURNING token`,
+		workflowID,
+		owner,
+		from,
+		ttl.Milliseconds(),
+	).Scan(&token)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("execution %s is leased by another node: %w", workflowID, domain.ErrFenced)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to seize execution %s: %w", workflowID, err)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,207 @@
+// Package storetest holds the cases every ports.ExecutionStore is tested against.
+package storetest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/ports"
+)
+
+type Case struct {
+	Name string
+	Run  func(t *testing.T, store ports.ExecutionStore)
+}
+
+var Cases = []Case{
+	{Name: "a saved execution loads back", Run: roundTrip},
+	{Name: "a later save replaces the status", Run: statusTransitions},
+	{Name: "executed steps keep their order", Run: stepOrder},
+	{Name: "an unknown execution is not found", Run: notFound},
+	{Name: "listing filters by status, newest first", Run: listing},
+}
+
+// newExecution starts an execution of a workflow named uniquely for the
+// test, so stores shared between runs only list what the test saved.
+func newExecution(workflowName string, startedAt time.Time) *domain.Execution {
+	workflowID := uuid.NewString()
+	return &domain.Execution{
+		WorkflowName:    workflowName,
+		WorkflowVersion: "1.0.0",
+		Context: &domain.ExecutionContext{
+			WorkflowID:    workflowID,
+			Input:         map[string]interface{}{"order_id": "o-1"},
+			Variables:     map[string]interface{}{},
+			StepOutputs:   map[string]interface{}{},
+			ExecutedSteps: []domain.ExecutedStep{},
+		},
+		Result: &domain.WorkflowResult{
+			WorkflowID: workflowID,
+			Status:     domain.WorkflowStatusRunning,
+			StartedAt:  startedAt.UTC().Truncate(time.Microsecond),
+		},
+	}
+}
+
+func executeStep(t *testing.T, store ports.ExecutionStore, execution *domain.Execution, stepID string, output interface{}) {
+	t.Helper()
+	ctx := context.Background()
+
+	step := &domain.Step{ID: stepID}
+	execution.Context.StepOutputs[stepID] = output
+	execution.Context.AppendExecutedStep(domain.NewExecutedStep(step, output))
+
+	result := &domain.StepResult{StepID: stepID, Output: output}
+	if err := store.SaveStepResult(ctx, execution.Context.WorkflowID, result); err != nil {
+		t.Fatalf("SaveStepResult(%s) error = %v", stepID, err)
+	}
+	if err := store.SaveExecution(ctx, execution); err != nil {
+		t.Fatalf("SaveExecution after %s error = %v", stepID, err)
+	}
+}
+
+func load(t *testing.T, store ports.ExecutionStore, workflowID string) *domain.Execution {
+	t.Helper()
+	execution, ok, err := store.LoadExecution(context.Background(), workflowID)
+	if err != nil {
+		t.Fatalf("LoadExecution(%s) error = %v", workflowID, err)
+	}
+	if !ok {
+		t.Fatalf("LoadExecution(%s) found nothing", workflowID)
+	}
+	return execution
+}
+
+func roundTrip(t *testing.T, store ports.ExecutionStore) {
+	execution := newExecution(uuid.NewString(), time.Now())
+	if err := store.SaveExecution(context.Background(), execution); err != nil {
+		t.Fatalf("SaveExecution error = %v", err)
+	}
+	executeStep(t, store, execution, "reserve", "r-1")
+
+	got := load(t, store, execution.Context.WorkflowID)
+	if got.WorkflowName != execution.WorkflowName || got.WorkflowVersion != execution.WorkflowVersion {
+		t.Fatalf("loaded workflow = %s@%s, want %s@%s", got.WorkflowName, got.WorkflowVersion, execution.WorkflowName, execution.WorkflowVersion)
+	}
+	if got.Context.Input["order_id"] != "o-1" {
+		t.Fatalf("loaded input = %v, want order_id o-1", got.Context.Input)
+	}
+	if got.Context.StepOutputs["reserve"] != "r-1" {
+		t.Fatalf("loaded step outputs = %v, want reserve r-1", got.Context.StepOutputs)
+	}
+	if got.Result.Status != domain.WorkflowStatusRunning {
+		t.Fatalf("loaded status = %s, want running", got.Result.Status)
+	}
+	if !got.Result.StartedAt.Equal(execution.Result.StartedAt) {
+		t.Fatalf("loaded started at = %s, want %s", got.Result.StartedAt, execution.Result.StartedAt)
+	}
+}
+
+func statusTransitions(t *testing.T, store ports.ExecutionStore) {
+	ctx := context.Background()
+	execution := newExecution(uuid.NewString(), time.Now())
+
+	transitions := []struct {
+		status domain.WorkflowStatus
+		err    error
+	}{
+		{status: domain.WorkflowStatusRunning},
+		{status: domain.WorkflowStatusCompensating, err: errors.New("charge declined")},
+		{status: domain.WorkflowStatusCompensated, err: errors.New("charge declined")},
+	}
+	for _, transition := range transitions {
+		execution.Result.Complete(transition.status, transition.err)
+		if err := store.SaveExecution(ctx, execution); err != nil {
+			t.Fatalf("SaveExecution(%s) error = %v", transition.status, err)
+		}
+
+		got := load(t, store, execution.Context.WorkflowID)
+		if got.Result.Status != transition.status {
+			t.Fatalf("loaded status = %s, want %s", got.Result.Status, transition.status)
+		}
+		if (got.Result.Error == nil) != (transition.err == nil) {
+			t.Fatalf("loaded error = %v, want %v", got.Result.Error, transition.err)
+		}
+	}
+}
+
+func stepOrder(t *testing.T, store ports.ExecutionStore) {
+	execution := newExecution(uuid.NewString(), time.Now())
+	if err := store.SaveExecution(context.Background(), execution); err != nil {
+		t.Fatalf("SaveExecution error = %v", err)
+	}
+
+	want := []string{"validate", "reserve", "charge", "notify"}
+	for _, stepID := range want {
+		executeStep(t, store, execution, stepID, stepID+"-done")
+	}
+
+	got := load(t, store, execution.Context.WorkflowID)
+	var steps []string
+	for _, step := range got.Context.ExecutedSteps {
+		steps = append(steps, step.StepID)
+	}
+	if !slices.Equal(steps, want) {
+		t.Fatalf("loaded executed steps = %v, want %v", steps, want)
+	}
+}
+
+func notFound(t *testing.T, store ports.ExecutionStore) {
+	execution, ok, err := store.LoadExecution(context.Background(), uuid.NewString())
+	if err != nil || ok || execution != nil {
+		t.Fatalf("LoadExecution(unknown) = (%v, %v, %v), want (nil, false, nil)", execution, ok, err)
+	}
+
+	result := &domain.StepResult{StepID: "reserve"}
+	if err := store.SaveStepResult(context.Background(), uuid.NewString(), result); err == nil {
+		t.Fatal("SaveStepResult for an unknown execution succeeded")
+	}
+}
+
+func listing(t *testing.T, store ports.ExecutionStore) {
+	ctx := context.Background()
+	workflowName := uuid.NewString()
+	now := time.Now()
+
+	var ids []string
+	for i, status := range []domain.WorkflowStatus{
+		domain.WorkflowStatusSuccess,
+		domain.WorkflowStatusFailed,
+		domain.WorkflowStatusSuccess,
+	} {
+		execution := newExecution(workflowName, now.Add(time.Duration(i)*time.Second))
+		execution.Result.Complete(status, nil)
+		if err := store.SaveExecution(ctx, execution); err != nil {
+			t.Fatalf("SaveExecution error = %v", err)
+		}
+		ids = append(ids, execution.Context.WorkflowID)
+	}
+
+	tests := []struct {
+		filter domain.ExecutionFilter
+		want   []string
+	}{
+		{filter: domain.ExecutionFilter{WorkflowName: workflowName}, want: []string{ids[2], ids[1], ids[0]}},
+		{filter: domain.ExecutionFilter{WorkflowName: workflowName, Status: "success"}, want: []string{ids[2], ids[0]}},
+		{filter: domain.ExecutionFilter{WorkflowName: workflowName, Limit: 1}, want: []string{ids[2]}},
+		{filter: domain.ExecutionFilter{WorkflowName: workflowName, Status: "running"}},
+	}
+	for _, tt := range tests {
+		executions, err := store.ListExecutions(ctx, tt.filter)
+		if err != nil {
+			t.Fatalf("ListExecutions(%+v) error = %v", tt.filter, err)
+		}
+		var got []string
+		for _, execution := range executions {
+			got = append(got, execution.Context.WorkflowID)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Fatalf("ListExecutions(%+v) = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
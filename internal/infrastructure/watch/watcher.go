@@ -0,0 +1,170 @@
+package watch
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog"
+)
+
+const settleDelay = 250 * time.Millisecond
+
+type Watcher struct {
+	fs     *fsnotify.Watcher
+	logger zerolog.Logger
+
+	mu      sync.Mutex
+	files   map[string]bool
+	dirs    map[string]func(file string) bool
+	watched map[string]bool
+	sums    map[string][sha256.Size]byte
+	pending map[string]*time.Timer
+}
+
+func New(logger zerolog.Logger) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start file watcher: %w", err)
+	}
+	return &Watcher{
+		fs:      fsw,
+		logger:  logger,
+		files:   make(map[string]bool),
+		dirs:    make(map[string]func(string) bool),
+		watched: make(map[string]bool),
+		sums:    make(map[string][sha256.Size]byte),
+		pending: make(map[string]*time.Timer),
+	}, nil
+}
+
+func (w *Watcher) AddFile(file string) error {
+	file, err := filepath.Abs(file)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.watch(filepath.Dir(file)); err != nil {
+		return err
+	}
+	w.files[file] = true
+	if _, ok := w.sums[file]; !ok {
+		w.remember(file)
+	}
+	return nil
+}
+
+func (w *Watcher) AddDir(dir string, accept func(file string) bool) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			w.dirs[path] = accept
+			return w.watch(path)
+		}
+		if accept(path) {
+			w.remember(path)
+		}
+		return nil
+	})
+}
+
+func (w *Watcher) Run(ctx context.Context, changed func(file string)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+			w.logger.Warn().Err(err).Msg("File watcher error")
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
+				continue
+			}
+			w.schedule(event.Name, changed)
+		}
+	}
+}
+
+func (w *Watcher) Close() error {
+	w.mu.Lock()
+	for _, timer := range w.pending {
+		timer.Stop()
+	}
+	w.mu.Unlock()
+	return w.fs.Close()
+}
+
+func (w *Watcher) schedule(file string, changed func(string)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.files[file] {
+		accept, ok := w.dirs[filepath.Dir(file)]
+		if !ok || !accept(file) {
+			return
+		}
+	}
+
+	if timer, ok := w.pending[file]; ok {
+		timer.Reset(settleDelay)
+		return
+	}
+	w.pending[file] = time.AfterFunc(settleDelay, func() {
+		w.mu.Lock()
+		delete(w.pending, file)
+		modified := w.remember(file)
+		w.mu.Unlock()
+
+		if modified {
+			changed(file)
+		}
+	})
+}
+
+func (w *Watcher) watch(dir string) error {
+	if w.watched[dir] {
+		return nil
+	}
+	if err := w.fs.Add(dir); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", dir, err)
+	}
+	w.watched[dir] = true
+	return nil
+}
+
+func (w *Watcher) remember(file string) bool {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return false
+	}
+	sum := sha256.Sum256(data)
+	if previous, ok := w.sums[file]; ok && previous == sum {
+		return false
+	}
+	w.sums[file] = sum
+	return true
+}
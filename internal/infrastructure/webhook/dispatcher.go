@@ -0,0 +1,391 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/ports"
+	"github.com/rs/zerolog"
+)
+
+const (
+	SignatureHeader = "X-Maestro-Signature"
+	TimestampHeader = "X-Maestro-Timestamp"
+	DeliveryHeader  = "X-Maestro-Delivery"
+	EventHeader     = "X-Maestro-Event"
+
+	maxAttempts   = 6
+	baseDelay     = time.Second
+	maxDelay      = time.Minute
+	maxDeliveries = 1000
+)
+
+type Endpoint struct {
+	URL    string
+	Secret string
+}
+
+type CallbackPolicy struct {
+	Secret       string
+	AllowedHosts []string
+}
+
+type delivery struct {
+	domain.WebhookDelivery
+	endpoint  Endpoint
+	namespace string
+	payload   []byte
+	running   bool
+}
+
+type Dispatcher struct {
+	endpoints  []Endpoint
+	callbacks  *CallbackPolicy
+	client     *http.Client
+	logger     zerolog.Logger
+	ctx        context.Context
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+	mu         sync.Mutex
+	deliveries map[string]*delivery
+	order      []string
+	queue      ports.DeadLetterQueue
+}
+
+func NewDispatcher(endpoints []Endpoint, callbacks *CallbackPolicy, logger zerolog.Logger) *Dispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Dispatcher{
+		endpoints:  endpoints,
+		callbacks:  callbacks,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		logger:     logger,
+		ctx:        ctx,
+		cancel:     cancel,
+		deliveries: make(map[string]*delivery),
+	}
+}
+
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (d *Dispatcher) Dispatch(event *domain.CompletionEvent, callbacks ...string) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		d.logger.Error().
+			Err(err).
+			Str("workflow_id", event.WorkflowID).
+			Msg("Failed to encode webhook payload")
+		return
+	}
+
+	for _, endpoint := range d.endpoints {
+		d.enqueue(endpoint, event.Event, event.WorkflowID, event.Namespace, payload)
+	}
+	for _, callback := range callbacks {
+		if err := d.AcceptsCallback(callback); err != nil {
+			d.logger.Warn().
+				Err(err).
+				Str("workflow_id", event.WorkflowID).
+				Msg("Skipping completion callback")
+			continue
+		}
+		d.enqueue(Endpoint{URL: callback, Secret: d.callbacks.Secret}, event.Event, event.WorkflowID, event.Namespace, payload)
+	}
+}
+
+func (d *Dispatcher) AcceptsCallback(rawURL string) error {
+	if d.callbacks == nil {
+		return fmt.Errorf("%w: callbacks are not enabled", domain.ErrCallbackRejected)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: invalid url %q", domain.ErrCallbackRejected, rawURL)
+	}
+	if len(d.callbacks.AllowedHosts) == 0 {
+		return nil
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range d.callbacks.AllowedHosts {
+		allowed = strings.ToLower(allowed)
+		if host == allowed {
+			return nil
+		}
+		if suffix, ok := strings.CutPrefix(allowed, "*"); ok && strings.HasPrefix(suffix, ".") && strings.HasSuffix(host, suffix) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: host %s is not allowed", domain.ErrCallbackRejected, u.Hostname())
+}
+
+func (d *Dispatcher) Resend(letter *domain.DeadLetter) (domain.WebhookDelivery, error) {
+	i := slices.IndexFunc(d.endpoints, func(endpoint Endpoint) bool {
+		return endpoint.URL == letter.Source
+	})
+	endpoint := Endpoint{URL: letter.Source}
+	switch {
+	case i >= 0:
+		endpoint = d.endpoints[i]
+	case d.AcceptsCallback(letter.Source) == nil:
+		endpoint.Secret = d.callbacks.Secret
+	default:
+		return domain.WebhookDelivery{}, fmt.Errorf("webhook endpoint %s is no longer configured", letter.Source)
+	}
+	return d.enqueue(endpoint, letter.Headers[EventHeader], letter.WorkflowID, letter.Namespace, letter.Payload), nil
+}
+
+func (d *Dispatcher) SetDeadLetterQueue(queue ports.DeadLetterQueue) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.queue = queue
+}
+
+func (d *Dispatcher) enqueue(endpoint Endpoint, event, workflowID, namespace string, payload []byte) domain.WebhookDelivery {
+	del := &delivery{
+		WebhookDelivery: domain.WebhookDelivery{
+			ID:         uuid.New().String(),
+			Endpoint:   endpoint.URL,
+			Event:      event,
+			WorkflowID: workflowID,
+			Status:     domain.DeliveryPending,
+			CreatedAt:  time.Now(),
+		},
+		endpoint:  endpoint,
+		namespace: namespace,
+		payload:   payload,
+		running:   true,
+	}
+
+	d.mu.Lock()
+	d.deliveries[del.ID] = del
+	d.order = append(d.order, del.ID)
+	d.evict()
+	snapshot := del.WebhookDelivery
+	d.mu.Unlock()
+
+	d.start(del)
+	return snapshot
+}
+
+func (d *Dispatcher) Deliveries(workflowID string) []domain.WebhookDelivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	deliveries := make([]domain.WebhookDelivery, 0, len(d.order))
+	for _, id := range slices.Backward(d.order) {
+		del := d.deliveries[id]
+		if workflowID != "" && del.WorkflowID != workflowID {
+			continue
+		}
+		deliveries = append(deliveries, del.WebhookDelivery)
+	}
+	return deliveries
+}
+
+func (d *Dispatcher) Redeliver(id string) (domain.WebhookDelivery, error) {
+	d.mu.Lock()
+	del, ok := d.deliveries[id]
+	if !ok {
+		d.mu.Unlock()
+		return domain.WebhookDelivery{}, fmt.Errorf("%w: %s", domain.ErrDeliveryNotFound, id)
+	}
+	if del.running {
+		d.mu.Unlock()
+		return domain.WebhookDelivery{}, fmt.Errorf("%w: %s", domain.ErrDeliveryInProgress, id)
+	}
+	del.running = true
+	del.Status = domain.DeliveryPending
+	del.Attempts = 0
+	del.LastError = ""
+	del.DeliveredAt = nil
+	snapshot := del.WebhookDelivery
+	d.mu.Unlock()
+
+	d.start(del)
+	return snapshot, nil
+}
+
+func (d *Dispatcher) Close(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		d.cancel()
+		<-done
+	}
+}
+
+func (d *Dispatcher) start(del *delivery) {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.deliver(del)
+	}()
+}
+
+func (d *Dispatcher) deliver(del *delivery) {
+	logger := d.logger.With().
+		Str("delivery_id", del.ID).
+		Str("workflow_id", del.WorkflowID).
+		Str("endpoint", del.endpoint.URL).
+		Logger()
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-time.After(backoff(attempt - 1)):
+			case <-d.ctx.Done():
+				d.finish(del, domain.DeliveryFailed, "delivery interrupted by shutdown")
+				d.park(del)
+				return
+			}
+		}
+
+		retryable, err := d.send(del)
+		d.mu.Lock()
+		del.Attempts++
+		d.mu.Unlock()
+
+		if err == nil {
+			d.finish(del, domain.DeliveryDelivered, "")
+			logger.Info().Int("attempts", attempt).Msg("Webhook delivered")
+			return
+		}
+
+		if !retryable || attempt == maxAttempts {
+			d.finish(del, domain.DeliveryFailed, err.Error())
+			logger.Error().Err(err).Int("attempts", attempt).Msg("Webhook delivery failed")
+			d.park(del)
+			return
+		}
+
+		d.mu.Lock()
+		del.LastError = err.Error()
+		d.mu.Unlock()
+		logger.Warn().Err(err).Int("attempt", attempt).Msg("Webhook delivery failed, will retry")
+	}
+}
+
+func (d *Dispatcher) send(del *delivery) (bool, error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, del.endpoint.URL, bytes.NewReader(del.payload))
+	if err != nil {
+		return false, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(DeliveryHeader, del.ID)
+	req.Header.Set(EventHeader, del.Event)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(del.endpoint.Secret, timestamp, del.payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		err := fmt.Errorf("webhook endpoint returned %d: %s", resp.StatusCode, bytes.TrimSpace(message))
+		return retryableStatus(resp.StatusCode), err
+	}
+	return false, nil
+}
+
+func (d *Dispatcher) finish(del *delivery, status, lastError string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	del.running = false
+	del.Status = status
+	del.LastError = lastError
+	if status == domain.DeliveryDelivered {
+		deliveredAt := time.Now()
+		del.DeliveredAt = &deliveredAt
+	}
+}
+
+func (d *Dispatcher) park(del *delivery) {
+	d.mu.Lock()
+	queue := d.queue
+	letter := &domain.DeadLetter{
+		ID:            del.ID,
+		Kind:          domain.DeadLetterWebhook,
+		Source:        del.endpoint.URL,
+		WorkflowID:    del.WorkflowID,
+		Namespace:     del.namespace,
+		Payload:       del.payload,
+		Headers:       map[string]string{EventHeader: del.Event},
+		Reason:        del.LastError,
+		Attempts:      del.Attempts,
+		CreatedAt:     del.CreatedAt,
+		LastAttemptAt: time.Now(),
+	}
+	d.mu.Unlock()
+
+	if queue == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := queue.ParkDeadLetter(ctx, letter); err != nil {
+		d.logger.Error().
+			Err(err).
+			Str("delivery_id", del.ID).
+			Str("workflow_id", del.WorkflowID).
+			Msg("Failed to park undeliverable webhook")
+		return
+	}
+	d.logger.Warn().
+		Str("delivery_id", del.ID).
+		Str("workflow_id", del.WorkflowID).
+		Msg("Undeliverable webhook parked in dead-letter queue")
+}
+
+func (d *Dispatcher) evict() {
+	for len(d.order) > maxDeliveries {
+		i := slices.IndexFunc(d.order, func(id string) bool {
+			return !d.deliveries[id].running
+		})
+		if i < 0 {
+			return
+		}
+		delete(d.deliveries, d.order[i])
+		d.order = slices.Delete(d.order, i, i+1)
+	}
+}
+
+func retryableStatus(code int) bool {
+	return code >= 500 || code == http.StatusRequestTimeout || code == http.StatusTooManyRequests
+}
+
+func backoff(retry int) time.Duration {
+	return min(baseDelay*time.Duration(1<<uint(min(retry-1, 30))), maxDelay)
+}
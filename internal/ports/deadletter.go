@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type DeadLetterQueue interface {
+	ParkDeadLetter(ctx context.Context, letter *domain.DeadLetter) error
+	ListDeadLetters(ctx context.Context, filter domain.DeadLetterFilter) ([]*domain.DeadLetter, error)
+	GetDeadLetter(ctx context.Context, id string) (*domain.DeadLetter, error)
+	DeleteDeadLetter(ctx context.Context, id string) error
+}
@@ -0,0 +1,7 @@
+package ports
+
+import "github.com/maestro/maestro.go/internal/domain"
+
+type EventSink interface {
+	Emit(event domain.Event)
+}
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type ExecutionLeaser interface {
+	ClaimExecution(ctx context.Context, workflowID, owner string, ttl time.Duration) (int64, error)
+	RenewExecution(ctx context.Context, workflowID, owner string, token int64, ttl time.Duration) error
+	ReleaseExecution(ctx context.Context, workflowID, owner string, token int64) error
+}
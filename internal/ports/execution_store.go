@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type ExecutionStore interface {
+	SaveExecution(ctx context.Context, execution *domain.Execution) error
+	SaveStepResult(ctx context.Context, workflowID string, result *domain.StepResult) error
+	LoadExecution(ctx context.Context, workflowID string) (*domain.Execution, bool, error)
+	ListExecutions(ctx context.Context, filter domain.ExecutionFilter) ([]*domain.Execution, error)
+}
+
+type RetentionPurger interface {
+	PurgeExpiredFields(ctx context.Context, now time.Time) (int, error)
+}
+
+type SagaStore interface {
+	SaveSagaState(ctx context.Context, state *domain.SagaState) error
+	ListStalledSagas(ctx context.Context, status domain.SagaStatus) ([]*domain.SagaState, error)
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type IdempotencyStore interface {
+	ClaimIdempotencyKey(ctx context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, bool, error)
+	ReleaseIdempotencyKey(ctx context.Context, workflow, key, workflowID string) error
+	PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int, error)
+}
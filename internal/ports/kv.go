@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type KVStore interface {
+	Get(ctx context.Context, namespace, key string) (any, bool, error)
+	Set(ctx context.Context, namespace, key string, value any, ttl time.Duration) error
+	Delete(ctx context.Context, namespace, key string) error
+	Increment(ctx context.Context, namespace, key string, delta int64, ttl time.Duration) (int64, error)
+}
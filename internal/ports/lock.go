@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type LockManager interface {
+	Acquire(ctx context.Context, key, owner string, ttl time.Duration) (int64, bool, error)
+	Renew(ctx context.Context, key, owner string, token int64, ttl time.Duration) error
+	Release(ctx context.Context, key, owner string) error
+}
@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type Notifier interface {
+	Notify(ctx context.Context, alert *domain.Alert) error
+}
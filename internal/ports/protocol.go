@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type ProtocolCall struct {
+	Service      string
+	Config       domain.Service
+	Method       string
+	Input        map[string]interface{}
+	Headers      map[string]string
+	WorkflowID   string
+	StepID       string
+	Key          string
+	Tombstone    bool
+	FullResponse bool
+}
+
+type ProtocolAdapter interface {
+	Invoke(ctx context.Context, call ProtocolCall) (interface{}, error)
+	HealthCheck(ctx context.Context) error
+	Close() error
+}
+
+type ProtocolFactory func(name string, config domain.Service) (ProtocolAdapter, error)
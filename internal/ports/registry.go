@@ -7,4 +7,4 @@ type ServiceRegistry interface {
 	GetService(name string) (*domain.Service, error)
 	IsHealthy(name string) bool
 	UpdateHealth(name string, healthy bool)
-}
\ No newline at end of file
+}
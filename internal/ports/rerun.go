@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type RerunQueue interface {
+	EnqueueRerun(ctx context.Context, rerun *domain.Rerun) error
+	DueReruns(ctx context.Context, before time.Time) ([]*domain.Rerun, error)
+	ListReruns(ctx context.Context, workflow string) ([]*domain.Rerun, error)
+	DeleteRerun(ctx context.Context, id string) error
+}
@@ -0,0 +1,7 @@
+package ports
+
+import "context"
+
+type SecretProvider interface {
+	GetSecret(ctx context.Context, name string) (string, error)
+}
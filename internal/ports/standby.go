@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type PrimaryLeaser interface {
+	ExecutionLeaser
+	ClaimPrimary(ctx context.Context, owner string, ttl time.Duration) (int64, error)
+	RenewPrimary(ctx context.Context, owner string, token int64, ttl time.Duration) error
+	ReleasePrimary(ctx context.Context, owner string, token int64) error
+	CurrentPrimary(ctx context.Context) (*domain.PrimaryLease, bool, error)
+	SeizeExecution(ctx context.Context, workflowID, owner, from string, ttl time.Duration) (int64, error)
+}
@@ -0,0 +1,18 @@
+package ports
+
+import "context"
+
+type StepCall struct {
+	WorkflowID   string
+	StepID       string
+	Service      string
+	Method       string
+	Attempt      int
+	Compensation bool
+	Input        map[string]interface{}
+	Headers      map[string]string
+}
+
+type StepInvoker func(ctx context.Context, call *StepCall) (interface{}, error)
+
+type StepMiddleware func(next StepInvoker) StepInvoker
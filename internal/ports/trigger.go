@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type TriggerHandler func(ctx context.Context, msg *domain.TriggerMessage) error
+
+type TriggerSource interface {
+	Consume(ctx context.Context, topic, group string, handle TriggerHandler) error
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type WebhookDispatcher interface {
+	Dispatch(event *domain.CompletionEvent, callbacks ...string)
+	AcceptsCallback(url string) error
+	Deliveries(workflowID string) []domain.WebhookDelivery
+	Redeliver(id string) (domain.WebhookDelivery, error)
+	Resend(letter *domain.DeadLetter) (domain.WebhookDelivery, error)
+	SetDeadLetterQueue(queue DeadLetterQueue)
+	Close(ctx context.Context)
+}
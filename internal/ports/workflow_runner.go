@@ -0,0 +1,10 @@
+package ports
+
+import (
+	"context"
+)
+
+type WorkflowRunner interface {
+	RunWorkflow(ctx context.Context, name string, input map[string]interface{}) (workflowID string, output map[string]interface{}, err error)
+	CompensateWorkflow(ctx context.Context, workflowID string) error
+}
@@ -0,0 +1,122 @@
+package scaffold
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	pb "github.com/maestro/maestro.go/pkg/proto"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var (
+	Languages = []string{"go", "python", "node"}
+
+	namePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+)
+
+type Options struct {
+	Lang   string
+	Name   string
+	OutDir string
+	Port   int
+}
+
+type templateData struct {
+	Name       string
+	Identifier string
+	Port       int
+}
+
+func Service(opts Options) ([]string, error) {
+	if !slices.Contains(Languages, opts.Lang) {
+		return nil, fmt.Errorf("unsupported language %s (must be one of %s)", opts.Lang, strings.Join(Languages, ", "))
+	}
+
+	if !namePattern.MatchString(opts.Name) {
+		return nil, fmt.Errorf("invalid service name %q (lowercase letters, digits, '-' and '_')", opts.Name)
+	}
+
+	if opts.OutDir == "" {
+		opts.OutDir = opts.Name
+	}
+	if opts.Port == 0 {
+		opts.Port = 50051
+	}
+
+	if entries, err := os.ReadDir(opts.OutDir); err == nil && len(entries) > 0 {
+		return nil, fmt.Errorf("output directory %s is not empty", opts.OutDir)
+	}
+
+	data := templateData{
+		Name:       opts.Name,
+		Identifier: strings.ReplaceAll(opts.Name, "-", "_"),
+		Port:       opts.Port,
+	}
+
+	var written []string
+
+	root := "templates/" + opts.Lang
+	err := fs.WalkDir(templates, root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		tmpl, err := template.ParseFS(templates, path)
+		if err != nil {
+			return fmt.Errorf("failed to parse template %s: %w", path, err)
+		}
+
+		target := filepath.Join(opts.OutDir, strings.TrimSuffix(strings.TrimPrefix(path, root+"/"), ".tmpl"))
+		if err := writeTemplate(target, tmpl, data); err != nil {
+			return err
+		}
+		written = append(written, target)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Lang != "go" {
+		for _, name := range []string{"maestro.proto", "common.proto"} {
+			content, err := pb.Files.ReadFile(name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", name, err)
+			}
+			target := filepath.Join(opts.OutDir, "proto", name)
+			if err := writeFile(target, content); err != nil {
+				return nil, err
+			}
+			written = append(written, target)
+		}
+	}
+
+	return written, nil
+}
+
+func writeTemplate(target string, tmpl *template.Template, data templateData) error {
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to render %s: %w", target, err)
+	}
+	return writeFile(target, []byte(buf.String()))
+}
+
+func writeFile(target string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", target, err)
+	}
+	if err := os.WriteFile(target, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", target, err)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package maestro
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/application"
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/infrastructure/kv"
+	"github.com/maestro/maestro.go/internal/infrastructure/store"
+	"github.com/rs/zerolog"
+)
+
+const (
+	defaultWorkers      = 10
+	storeConnectTimeout = 10 * time.Second
+	flushTimeout        = 10 * time.Second
+)
+
+type Engine struct {
+	orch  *application.Orchestrator
+	store *store.PostgresStore
+}
+
+func New(opts ...Option) (*Engine, error) {
+	cfg := config{
+		logger:  zerolog.Nop(),
+		workers: defaultWorkers,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	orchOpts := []application.Option{
+		application.WithWorkerPoolSize(cfg.workers),
+		application.WithCompensationPoolSize(cfg.compensationWorkers),
+		application.WithDefaultEnvironment(cfg.environment),
+		application.WithCommandHooks(cfg.commandHooks),
+		application.WithExecServices(cfg.execServices),
+		application.WithExecutionRetention(cfg.executionRetention),
+	}
+	if cfg.nodeID != "" {
+		orchOpts = append(orchOpts, application.WithNodeID(cfg.nodeID))
+	}
+	if cfg.events != nil {
+		orchOpts = append(orchOpts, application.WithEventSink(cfg.events))
+	}
+	if len(cfg.middleware) > 0 {
+		orchOpts = append(orchOpts, application.WithStepMiddleware(cfg.middleware...))
+	}
+	if cfg.secrets != nil {
+		orchOpts = append(orchOpts, application.WithSecretProvider(cfg.secrets))
+	}
+	if len(cfg.sensitiveFields) > 0 {
+		orchOpts = append(orchOpts, application.WithSensitiveFields(cfg.sensitiveFields...))
+	}
+	if cfg.serviceMerge != "" {
+		if !slices.Contains(domain.ServiceMergeModes, string(cfg.serviceMerge)) {
+			return nil, fmt.Errorf("invalid service merge mode %s", cfg.serviceMerge)
+		}
+		orchOpts = append(orchOpts, application.WithServiceMerge(string(cfg.serviceMerge)))
+	}
+	if cfg.kvFile != "" {
+		kvStore, err := kv.NewFileStore(cfg.kvFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open key-value store: %w", err)
+		}
+		orchOpts = append(orchOpts, application.WithKVStore(kvStore))
+	}
+
+	e := &Engine{}
+	if cfg.postgresDSN != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), storeConnectTimeout)
+		executionStore, err := store.NewPostgresStore(ctx, cfg.postgresDSN)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open execution store: %w", err)
+		}
+		e.store = executionStore
+		orchOpts = append(orchOpts, application.WithExecutionStore(executionStore))
+	}
+
+	e.orch = application.New(cfg.logger, orchOpts...)
+	return e, nil
+}
+
+func (e *Engine) LoadWorkflow(data []byte) (*Workflow, error) {
+	wf, err := e.orch.LoadWorkflowData(data, "")
+	if err != nil {
+		return nil, err
+	}
+	return newWorkflow(wf), nil
+}
+
+func (e *Engine) LoadWorkflowFile(path string) (*Workflow, error) {
+	wf, err := e.orch.LoadWorkflow(path)
+	if err != nil {
+		return nil, err
+	}
+	return newWorkflow(wf), nil
+}
+
+func (e *Engine) LoadWorkflowDir(path string) ([]*Workflow, error) {
+	loaded, err := e.orch.LoadWorkflowDir(path)
+	workflows := make([]*Workflow, len(loaded))
+	for i, wf := range loaded {
+		workflows[i] = newWorkflow(wf)
+	}
+	return workflows, err
+}
+
+func (e *Engine) UnloadWorkflow(name string) error {
+	return e.orch.UnloadWorkflow(name)
+}
+
+func (e *Engine) Workflows() []string {
+	return e.orch.ListWorkflows()
+}
+
+func (e *Engine) WorkflowVersions(name string) []string {
+	return e.orch.WorkflowVersions(name)
+}
+
+func (e *Engine) Execute(ctx context.Context, name string, input map[string]interface{}) (*Result, error) {
+	result, err := e.orch.ExecuteWorkflow(ctx, name, input)
+	return newResult(result), err
+}
+
+func (e *Engine) Start(ctx context.Context, name string, input map[string]interface{}) (string, error) {
+	return e.orch.StartWorkflow(ctx, name, input)
+}
+
+func (e *Engine) DryRun(ctx context.Context, name string, input map[string]interface{}) ([]PlannedStep, error) {
+	plan := &domain.DryRun{}
+	_, err := e.orch.ExecuteWorkflow(application.WithDryRun(ctx, plan), name, input)
+	return plan.Steps(), err
+}
+
+func (e *Engine) Status(workflowID string) (*Result, bool, error) {
+	result, ok, err := e.orch.GetWorkflowStatus(workflowID)
+	if !ok {
+		return nil, false, err
+	}
+	return newResult(result), true, nil
+}
+
+func (e *Engine) Cancel(workflowID string) error {
+	return e.orch.CancelWorkflow(workflowID)
+}
+
+func (e *Engine) Signal(workflowID, name string, payload interface{}) error {
+	return e.orch.SignalWorkflow(workflowID, name, payload)
+}
+
+func (e *Engine) Run(ctx context.Context) {
+	go e.orch.RunRetention(ctx)
+	go e.orch.RunSagaRecovery(ctx)
+	go e.orch.RunHandoffPickup(ctx)
+	go e.orch.RunReruns(ctx)
+	go e.orch.RunTriggers(ctx)
+	<-ctx.Done()
+}
+
+func (e *Engine) Close() error {
+	e.orch.FlushAlerts()
+	e.orch.FlushCancellations()
+
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	e.orch.FlushWebhooks(ctx)
+
+	if e.store != nil {
+		return e.store.Close()
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package maestro
+
+import (
+	"context"
+
+	"github.com/maestro/maestro.go/internal/infrastructure/local"
+)
+
+type Handler func(ctx context.Context, method string, input map[string]interface{}) (interface{}, error)
+
+type Call = local.Call
+
+func CallFromContext(ctx context.Context) (Call, bool) {
+	return local.CallFrom(ctx)
+}
+
+func (e *Engine) RegisterHandler(name string, handler Handler) error {
+	if handler == nil {
+		return e.orch.RegisterHandler(name, nil)
+	}
+	return e.orch.RegisterHandler(name, local.Handler(handler))
+}
+
+func (e *Engine) UnregisterHandler(name string) {
+	e.orch.UnregisterHandler(name)
+}
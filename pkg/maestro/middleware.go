@@ -0,0 +1,19 @@
+package maestro
+
+import "github.com/maestro/maestro.go/internal/ports"
+
+type StepCall = ports.StepCall
+
+type StepInvoker = ports.StepInvoker
+
+type StepMiddleware = ports.StepMiddleware
+
+func WithStepMiddleware(middleware ...StepMiddleware) Option {
+	return func(c *config) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+func (e *Engine) Use(middleware ...StepMiddleware) {
+	e.orch.UseStepMiddleware(middleware...)
+}
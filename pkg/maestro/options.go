@@ -0,0 +1,156 @@
+package maestro
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/maestro/maestro.go/internal/application"
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/ports"
+	"github.com/rs/zerolog"
+)
+
+type Event = domain.Event
+
+type EventType = domain.EventType
+
+type EventHandler func(Event)
+
+type SecretProvider = ports.SecretProvider
+
+type ServiceMerge string
+
+const (
+	ServiceMergeReuse     ServiceMerge = domain.ServiceMergeReuse
+	ServiceMergeError     ServiceMerge = domain.ServiceMergeError
+	ServiceMergeNamespace ServiceMerge = domain.ServiceMergeNamespace
+)
+
+func (h EventHandler) Emit(event domain.Event) {
+	h(event)
+}
+
+type config struct {
+	logger              zerolog.Logger
+	workers             int
+	compensationWorkers int
+	environment         string
+	nodeID              string
+	postgresDSN         string
+	kvFile              string
+	events              EventHandler
+	commandHooks        bool
+	execServices        bool
+	executionRetention  time.Duration
+	middleware          []StepMiddleware
+	secrets             SecretProvider
+	sensitiveFields     []*regexp.Regexp
+	serviceMerge        ServiceMerge
+}
+
+type Option func(*config)
+
+func WithCommandHooks() Option {
+	return func(c *config) {
+		c.commandHooks = true
+	}
+}
+
+func WithExecServices() Option {
+	return func(c *config) {
+		c.execServices = true
+	}
+}
+
+func WithExecutionRetention(retention time.Duration) Option {
+	return func(c *config) {
+		c.executionRetention = retention
+	}
+}
+
+func WithLogger(logger zerolog.Logger) Option {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+func WithWorkers(workers int) Option {
+	return func(c *config) {
+		c.workers = workers
+	}
+}
+
+func WithCompensationWorkers(workers int) Option {
+	return func(c *config) {
+		c.compensationWorkers = workers
+	}
+}
+
+func WithDefaultEnvironment(name string) Option {
+	return func(c *config) {
+		c.environment = name
+	}
+}
+
+func WithNodeID(id string) Option {
+	return func(c *config) {
+		c.nodeID = id
+	}
+}
+
+func WithPostgres(dsn string) Option {
+	return func(c *config) {
+		c.postgresDSN = dsn
+	}
+}
+
+func WithKVFile(path string) Option {
+	return func(c *config) {
+		c.kvFile = path
+	}
+}
+
+func WithSecretProvider(provider SecretProvider) Option {
+	return func(c *config) {
+		c.secrets = provider
+	}
+}
+
+func WithSensitiveFields(fields ...*regexp.Regexp) Option {
+	return func(c *config) {
+		c.sensitiveFields = append(c.sensitiveFields, fields...)
+	}
+}
+
+func WithServiceMerge(mode ServiceMerge) Option {
+	return func(c *config) {
+		c.serviceMerge = mode
+	}
+}
+
+func WithEventHandler(handler EventHandler) Option {
+	return func(c *config) {
+		c.events = handler
+	}
+}
+
+func WithEnvironment(ctx context.Context, name string) context.Context {
+	return application.WithEnvironment(ctx, name)
+}
+
+func WithVersion(ctx context.Context, version string) context.Context {
+	return application.WithVersion(ctx, version)
+}
+
+func WithWorkflowID(ctx context.Context, workflowID string) context.Context {
+	return application.WithWorkflowID(ctx, workflowID)
+}
+
+func WithTags(ctx context.Context, tags map[string]string) context.Context {
+	return application.WithTags(ctx, tags)
+}
+
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return application.WithIdempotencyKey(ctx, key)
+}
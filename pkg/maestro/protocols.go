@@ -0,0 +1,18 @@
+package maestro
+
+import (
+	"github.com/maestro/maestro.go/internal/domain"
+	"github.com/maestro/maestro.go/internal/ports"
+)
+
+type Service = domain.Service
+
+type ProtocolCall = ports.ProtocolCall
+
+type ProtocolAdapter = ports.ProtocolAdapter
+
+type ProtocolFactory = ports.ProtocolFactory
+
+func (e *Engine) RegisterProtocol(kind string, factory ProtocolFactory) error {
+	return e.orch.RegisterProtocol(kind, factory)
+}
@@ -0,0 +1,84 @@
+package maestro
+
+import (
+	"time"
+
+	"github.com/maestro/maestro.go/internal/domain"
+)
+
+type Status string
+
+type PlannedStep = domain.PlannedStep
+
+type TemplateError = domain.TemplateError
+
+type InputError = domain.InputError
+
+type ContractError = domain.ContractError
+
+type WorkflowDirError = domain.WorkflowDirError
+
+type VersionNotFoundError = domain.VersionNotFoundError
+
+const (
+	StatusPending      Status = "pending"
+	StatusRunning      Status = "running"
+	StatusSuccess      Status = "success"
+	StatusFailed       Status = "failed"
+	StatusCancelled    Status = "cancelled"
+	StatusCompensating Status = "compensating"
+	StatusCompensated  Status = "compensated"
+	StatusSuspended    Status = "suspended"
+)
+
+func (s Status) IsTerminal() bool {
+	switch s {
+	case StatusSuccess, StatusFailed, StatusCancelled, StatusCompensated:
+		return true
+	default:
+		return false
+	}
+}
+
+type UnfinishedCompensation = domain.UnfinishedCompensation
+
+type Result struct {
+	WorkflowID              string
+	Status                  Status
+	Output                  map[string]interface{}
+	Error                   error
+	UnfinishedCompensations []UnfinishedCompensation
+	StartedAt               time.Time
+	CompletedAt             time.Time
+}
+
+func newResult(result *domain.WorkflowResult) *Result {
+	if result == nil {
+		return nil
+	}
+	return &Result{
+		WorkflowID:              result.WorkflowID,
+		Status:                  Status(result.Status.String()),
+		Output:                  result.Output,
+		Error:                   result.Error,
+		UnfinishedCompensations: result.UnfinishedCompensations,
+		StartedAt:               result.StartedAt,
+		CompletedAt:             result.CompletedAt,
+	}
+}
+
+type Workflow struct {
+	Name      string
+	Version   string
+	Namespace string
+	Steps     int
+}
+
+func newWorkflow(wf *domain.Workflow) *Workflow {
+	return &Workflow{
+		Name:      wf.Name,
+		Version:   wf.Version,
+		Namespace: wf.Namespace,
+		Steps:     len(wf.Steps),
+	}
+}
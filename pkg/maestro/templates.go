@@ -0,0 +1,11 @@
+package maestro
+
+import "github.com/maestro/maestro.go/internal/application/templating"
+
+type TemplateEngine = templating.Engine
+
+type TemplateFuncs = templating.Funcs
+
+func RegisterTemplateEngine(name string, engine TemplateEngine) error {
+	return templating.Register(name, engine)
+}
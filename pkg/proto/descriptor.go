@@ -19,6 +19,17 @@ var MaestroServiceDesc = grpc.ServiceDesc{
 			Handler:    healthCheckHandler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExecuteServerStream",
+			Handler:       executeServerStreamHandler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExecuteClientStream",
+			Handler:       executeClientStreamHandler,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "maestro.proto",
 }
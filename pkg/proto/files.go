@@ -0,0 +1,6 @@
+package proto
+
+import "embed"
+
+//go:embed maestro.proto common.proto
+var Files embed.FS
@@ -59,3 +59,15 @@ func healthCheckHandler(srv interface{}, ctx context.Context, dec func(interface
 	}
 	return interceptor(ctx, in, info, handler)
 }
+
+func executeServerStreamHandler(srv interface{}, stream grpc.ServerStream) error {
+	in := new(ServiceRequest)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	return srv.(MaestroServiceServer).ExecuteServerStream(in, &grpc.GenericServerStream[ServiceRequest, ServiceResponse]{ServerStream: stream})
+}
+
+func executeClientStreamHandler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MaestroServiceServer).ExecuteClientStream(&grpc.GenericServerStream[ServiceRequest, ServiceResponse]{ServerStream: stream})
+}
@@ -264,6 +264,8 @@ type ExecuteRequest struct {
 	Input          *structpb.Struct       `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
 	IdempotencyKey string                 `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
 	Metadata       map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Environment    string                 `protobuf:"bytes,5,opt,name=environment,proto3" json:"environment,omitempty"`
+	Version        string                 `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -326,6 +328,20 @@ func (x *ExecuteRequest) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *ExecuteRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+func (x *ExecuteRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
 type ExecuteResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
@@ -718,6 +734,222 @@ func (x *CancelResponse) GetMessage() string {
 	return ""
 }
 
+type WorkflowInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Steps         int32                  `protobuf:"varint,3,opt,name=steps,proto3" json:"steps,omitempty"`
+	Versions      []string               `protobuf:"bytes,4,rep,name=versions,proto3" json:"versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowInfo) Reset() {
+	*x = WorkflowInfo{}
+	mi := &file_pkg_proto_maestro_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowInfo) ProtoMessage() {}
+
+func (x *WorkflowInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_maestro_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowInfo.ProtoReflect.Descriptor instead.
+func (*WorkflowInfo) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_maestro_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WorkflowInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkflowInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *WorkflowInfo) GetSteps() int32 {
+	if x != nil {
+		return x.Steps
+	}
+	return 0
+}
+
+func (x *WorkflowInfo) GetVersions() []string {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+type ListWorkflowsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workflows     []*WorkflowInfo        `protobuf:"bytes,1,rep,name=workflows,proto3" json:"workflows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkflowsResponse) Reset() {
+	*x = ListWorkflowsResponse{}
+	mi := &file_pkg_proto_maestro_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkflowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkflowsResponse) ProtoMessage() {}
+
+func (x *ListWorkflowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_maestro_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkflowsResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkflowsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_maestro_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListWorkflowsResponse) GetWorkflows() []*WorkflowInfo {
+	if x != nil {
+		return x.Workflows
+	}
+	return nil
+}
+
+type RegisterWorkflowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Definition    []byte                 `protobuf:"bytes,1,opt,name=definition,proto3" json:"definition,omitempty"`
+	Format        string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterWorkflowRequest) Reset() {
+	*x = RegisterWorkflowRequest{}
+	mi := &file_pkg_proto_maestro_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterWorkflowRequest) ProtoMessage() {}
+
+func (x *RegisterWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_maestro_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*RegisterWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_maestro_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RegisterWorkflowRequest) GetDefinition() []byte {
+	if x != nil {
+		return x.Definition
+	}
+	return nil
+}
+
+func (x *RegisterWorkflowRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type RegisterWorkflowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterWorkflowResponse) Reset() {
+	*x = RegisterWorkflowResponse{}
+	mi := &file_pkg_proto_maestro_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterWorkflowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterWorkflowResponse) ProtoMessage() {}
+
+func (x *RegisterWorkflowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_maestro_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterWorkflowResponse.ProtoReflect.Descriptor instead.
+func (*RegisterWorkflowResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_maestro_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RegisterWorkflowResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RegisterWorkflowResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
 type ServiceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Method        string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
@@ -732,7 +964,7 @@ type ServiceRequest struct {
 
 func (x *ServiceRequest) Reset() {
 	*x = ServiceRequest{}
-	mi := &file_pkg_proto_maestro_proto_msgTypes[8]
+	mi := &file_pkg_proto_maestro_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -744,7 +976,7 @@ func (x *ServiceRequest) String() string {
 func (*ServiceRequest) ProtoMessage() {}
 
 func (x *ServiceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_maestro_proto_msgTypes[8]
+	mi := &file_pkg_proto_maestro_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -757,7 +989,7 @@ func (x *ServiceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceRequest.ProtoReflect.Descriptor instead.
 func (*ServiceRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_maestro_proto_rawDescGZIP(), []int{8}
+	return file_pkg_proto_maestro_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ServiceRequest) GetMethod() string {
@@ -802,6 +1034,82 @@ func (x *ServiceRequest) GetStepId() string {
 	return ""
 }
 
+type CancelStepRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	CorrelationId string                 `protobuf:"bytes,2,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	WorkflowId    string                 `protobuf:"bytes,3,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	StepId        string                 `protobuf:"bytes,4,opt,name=step_id,json=stepId,proto3" json:"step_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelStepRequest) Reset() {
+	*x = CancelStepRequest{}
+	mi := &file_pkg_proto_maestro_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelStepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelStepRequest) ProtoMessage() {}
+
+func (x *CancelStepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_maestro_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelStepRequest.ProtoReflect.Descriptor instead.
+func (*CancelStepRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_maestro_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CancelStepRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *CancelStepRequest) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *CancelStepRequest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *CancelStepRequest) GetStepId() string {
+	if x != nil {
+		return x.StepId
+	}
+	return ""
+}
+
+func (x *CancelStepRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type ServiceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -814,7 +1122,7 @@ type ServiceResponse struct {
 
 func (x *ServiceResponse) Reset() {
 	*x = ServiceResponse{}
-	mi := &file_pkg_proto_maestro_proto_msgTypes[9]
+	mi := &file_pkg_proto_maestro_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -826,7 +1134,7 @@ func (x *ServiceResponse) String() string {
 func (*ServiceResponse) ProtoMessage() {}
 
 func (x *ServiceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_maestro_proto_msgTypes[9]
+	mi := &file_pkg_proto_maestro_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -839,7 +1147,7 @@ func (x *ServiceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceResponse.ProtoReflect.Descriptor instead.
 func (*ServiceResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_maestro_proto_rawDescGZIP(), []int{9}
+	return file_pkg_proto_maestro_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ServiceResponse) GetSuccess() bool {
@@ -881,7 +1189,7 @@ type HealthStatus struct {
 
 func (x *HealthStatus) Reset() {
 	*x = HealthStatus{}
-	mi := &file_pkg_proto_maestro_proto_msgTypes[10]
+	mi := &file_pkg_proto_maestro_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -893,7 +1201,7 @@ func (x *HealthStatus) String() string {
 func (*HealthStatus) ProtoMessage() {}
 
 func (x *HealthStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_maestro_proto_msgTypes[10]
+	mi := &file_pkg_proto_maestro_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -906,7 +1214,7 @@ func (x *HealthStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthStatus.ProtoReflect.Descriptor instead.
 func (*HealthStatus) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_maestro_proto_rawDescGZIP(), []int{10}
+	return file_pkg_proto_maestro_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *HealthStatus) GetHealthy() bool {
@@ -944,7 +1252,7 @@ type StepStatus struct {
 
 func (x *StepStatus) Reset() {
 	*x = StepStatus{}
-	mi := &file_pkg_proto_maestro_proto_msgTypes[11]
+	mi := &file_pkg_proto_maestro_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -956,7 +1264,7 @@ func (x *StepStatus) String() string {
 func (*StepStatus) ProtoMessage() {}
 
 func (x *StepStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_maestro_proto_msgTypes[11]
+	mi := &file_pkg_proto_maestro_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -969,7 +1277,7 @@ func (x *StepStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StepStatus.ProtoReflect.Descriptor instead.
 func (*StepStatus) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_maestro_proto_rawDescGZIP(), []int{11}
+	return file_pkg_proto_maestro_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *StepStatus) GetStepId() string {
@@ -1020,12 +1328,14 @@ const file_pkg_proto_maestro_proto_rawDesc = "" +
 	"\n" +
 	"\x17pkg/proto/maestro.proto\x12\n" +
 	"maestro.v1\x1a\x19google/protobuf/any.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\a\n" +
-	"\x05Empty\"\x90\x02\n" +
+	"\x05Empty\"\xcc\x02\n" +
 	"\x0eExecuteRequest\x12#\n" +
 	"\rworkflow_name\x18\x01 \x01(\tR\fworkflowName\x12-\n" +
 	"\x05input\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x05input\x12'\n" +
 	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\x12D\n" +
-	"\bmetadata\x18\x04 \x03(\v2(.maestro.v1.ExecuteRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x04 \x03(\v2(.maestro.v1.ExecuteRequest.MetadataEntryR\bmetadata\x12 \n" +
+	"\venvironment\x18\x05 \x01(\tR\venvironment\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\tR\aversion\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa7\x02\n" +
@@ -1062,7 +1372,22 @@ const file_pkg_proto_maestro_proto_rawDesc = "" +
 	"\x06reason\x18\x02 \x01(\tR\x06reason\"D\n" +
 	"\x0eCancelResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\xb8\x02\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"n\n" +
+	"\fWorkflowInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x14\n" +
+	"\x05steps\x18\x03 \x01(\x05R\x05steps\x12\x1a\n" +
+	"\bversions\x18\x04 \x03(\tR\bversions\"O\n" +
+	"\x15ListWorkflowsResponse\x126\n" +
+	"\tworkflows\x18\x01 \x03(\v2\x18.maestro.v1.WorkflowInfoR\tworkflows\"Q\n" +
+	"\x17RegisterWorkflowRequest\x12\x1e\n" +
+	"\n" +
+	"definition\x18\x01 \x01(\fR\n" +
+	"definition\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\"H\n" +
+	"\x18RegisterWorkflowResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"\xb8\x02\n" +
 	"\x0eServiceRequest\x12\x16\n" +
 	"\x06method\x18\x01 \x01(\tR\x06method\x12.\n" +
 	"\apayload\x18\x02 \x01(\v2\x14.google.protobuf.AnyR\apayload\x12A\n" +
@@ -1073,7 +1398,14 @@ const file_pkg_proto_maestro_proto_rawDesc = "" +
 	"\astep_id\x18\x06 \x01(\tR\x06stepId\x1a:\n" +
 	"\fHeadersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xef\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa4\x01\n" +
+	"\x11CancelStepRequest\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12%\n" +
+	"\x0ecorrelation_id\x18\x02 \x01(\tR\rcorrelationId\x12\x1f\n" +
+	"\vworkflow_id\x18\x03 \x01(\tR\n" +
+	"workflowId\x12\x17\n" +
+	"\astep_id\x18\x04 \x01(\tR\x06stepId\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"\xef\x01\n" +
 	"\x0fServiceResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12(\n" +
 	"\x04data\x18\x02 \x01(\v2\x14.google.protobuf.AnyR\x04data\x12\x14\n" +
@@ -1125,17 +1457,22 @@ const file_pkg_proto_maestro_proto_rawDesc = "" +
 	"\x16EVENT_TYPE_STEP_FAILED\x10\x06\x12\x19\n" +
 	"\x15EVENT_TYPE_STEP_RETRY\x10\a\x12#\n" +
 	"\x1fEVENT_TYPE_COMPENSATION_STARTED\x10\b\x12%\n" +
-	"!EVENT_TYPE_COMPENSATION_COMPLETED\x10\t2\xc0\x02\n" +
+	"!EVENT_TYPE_COMPENSATION_COMPLETED\x10\t2\xe6\x03\n" +
 	"\fOrchestrator\x12J\n" +
 	"\x0fExecuteWorkflow\x12\x1a.maestro.v1.ExecuteRequest\x1a\x1b.maestro.v1.ExecuteResponse\x12O\n" +
 	"\x15ExecuteWorkflowStream\x12\x1a.maestro.v1.ExecuteRequest\x1a\x18.maestro.v1.ExecuteEvent0\x01\x12J\n" +
 	"\x11GetWorkflowStatus\x12\x19.maestro.v1.StatusRequest\x1a\x1a.maestro.v1.StatusResponse\x12G\n" +
-	"\x0eCancelWorkflow\x12\x19.maestro.v1.CancelRequest\x1a\x1a.maestro.v1.CancelResponse2\xd7\x01\n" +
+	"\x0eCancelWorkflow\x12\x19.maestro.v1.CancelRequest\x1a\x1a.maestro.v1.CancelResponse\x12E\n" +
+	"\rListWorkflows\x12\x11.maestro.v1.Empty\x1a!.maestro.v1.ListWorkflowsResponse\x12]\n" +
+	"\x10RegisterWorkflow\x12#.maestro.v1.RegisterWorkflowRequest\x1a$.maestro.v1.RegisterWorkflowResponse2\xc1\x03\n" +
 	"\x0eMaestroService\x12B\n" +
 	"\aExecute\x12\x1a.maestro.v1.ServiceRequest\x1a\x1b.maestro.v1.ServiceResponse\x12E\n" +
 	"\n" +
 	"Compensate\x12\x1a.maestro.v1.ServiceRequest\x1a\x1b.maestro.v1.ServiceResponse\x12:\n" +
-	"\vHealthCheck\x12\x11.maestro.v1.Empty\x1a\x18.maestro.v1.HealthStatusB/Z-github.com/maestro/maestro.go/pkg/proto;protob\x06proto3"
+	"\vHealthCheck\x12\x11.maestro.v1.Empty\x1a\x18.maestro.v1.HealthStatus\x12P\n" +
+	"\x13ExecuteServerStream\x12\x1a.maestro.v1.ServiceRequest\x1a\x1b.maestro.v1.ServiceResponse0\x01\x12P\n" +
+	"\x13ExecuteClientStream\x12\x1a.maestro.v1.ServiceRequest\x1a\x1b.maestro.v1.ServiceResponse(\x01\x12D\n" +
+	"\x06Cancel\x12\x1d.maestro.v1.CancelStepRequest\x1a\x1b.maestro.v1.ServiceResponseB/Z-github.com/maestro/maestro.go/pkg/proto;protob\x06proto3"
 
 var (
 	file_pkg_proto_maestro_proto_rawDescOnce sync.Once
@@ -1150,70 +1487,86 @@ func file_pkg_proto_maestro_proto_rawDescGZIP() []byte {
 }
 
 var file_pkg_proto_maestro_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_pkg_proto_maestro_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_pkg_proto_maestro_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_pkg_proto_maestro_proto_goTypes = []any{
-	(WorkflowStatus)(0),           // 0: maestro.v1.WorkflowStatus
-	(StepState)(0),                // 1: maestro.v1.StepState
-	(EventType)(0),                // 2: maestro.v1.EventType
-	(*Empty)(nil),                 // 3: maestro.v1.Empty
-	(*ExecuteRequest)(nil),        // 4: maestro.v1.ExecuteRequest
-	(*ExecuteResponse)(nil),       // 5: maestro.v1.ExecuteResponse
-	(*ExecuteEvent)(nil),          // 6: maestro.v1.ExecuteEvent
-	(*StatusRequest)(nil),         // 7: maestro.v1.StatusRequest
-	(*StatusResponse)(nil),        // 8: maestro.v1.StatusResponse
-	(*CancelRequest)(nil),         // 9: maestro.v1.CancelRequest
-	(*CancelResponse)(nil),        // 10: maestro.v1.CancelResponse
-	(*ServiceRequest)(nil),        // 11: maestro.v1.ServiceRequest
-	(*ServiceResponse)(nil),       // 12: maestro.v1.ServiceResponse
-	(*HealthStatus)(nil),          // 13: maestro.v1.HealthStatus
-	(*StepStatus)(nil),            // 14: maestro.v1.StepStatus
-	nil,                           // 15: maestro.v1.ExecuteRequest.MetadataEntry
-	nil,                           // 16: maestro.v1.ServiceRequest.HeadersEntry
-	nil,                           // 17: maestro.v1.ServiceResponse.MetadataEntry
-	(*structpb.Struct)(nil),       // 18: google.protobuf.Struct
-	(*timestamppb.Timestamp)(nil), // 19: google.protobuf.Timestamp
-	(*anypb.Any)(nil),             // 20: google.protobuf.Any
+	(WorkflowStatus)(0),              // 0: maestro.v1.WorkflowStatus
+	(StepState)(0),                   // 1: maestro.v1.StepState
+	(EventType)(0),                   // 2: maestro.v1.EventType
+	(*Empty)(nil),                    // 3: maestro.v1.Empty
+	(*ExecuteRequest)(nil),           // 4: maestro.v1.ExecuteRequest
+	(*ExecuteResponse)(nil),          // 5: maestro.v1.ExecuteResponse
+	(*ExecuteEvent)(nil),             // 6: maestro.v1.ExecuteEvent
+	(*StatusRequest)(nil),            // 7: maestro.v1.StatusRequest
+	(*StatusResponse)(nil),           // 8: maestro.v1.StatusResponse
+	(*CancelRequest)(nil),            // 9: maestro.v1.CancelRequest
+	(*CancelResponse)(nil),           // 10: maestro.v1.CancelResponse
+	(*WorkflowInfo)(nil),             // 11: maestro.v1.WorkflowInfo
+	(*ListWorkflowsResponse)(nil),    // 12: maestro.v1.ListWorkflowsResponse
+	(*RegisterWorkflowRequest)(nil),  // 13: maestro.v1.RegisterWorkflowRequest
+	(*RegisterWorkflowResponse)(nil), // 14: maestro.v1.RegisterWorkflowResponse
+	(*ServiceRequest)(nil),           // 15: maestro.v1.ServiceRequest
+	(*CancelStepRequest)(nil),        // 16: maestro.v1.CancelStepRequest
+	(*ServiceResponse)(nil),          // 17: maestro.v1.ServiceResponse
+	(*HealthStatus)(nil),             // 18: maestro.v1.HealthStatus
+	(*StepStatus)(nil),               // 19: maestro.v1.StepStatus
+	nil,                              // 20: maestro.v1.ExecuteRequest.MetadataEntry
+	nil,                              // 21: maestro.v1.ServiceRequest.HeadersEntry
+	nil,                              // 22: maestro.v1.ServiceResponse.MetadataEntry
+	(*structpb.Struct)(nil),          // 23: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),    // 24: google.protobuf.Timestamp
+	(*anypb.Any)(nil),                // 25: google.protobuf.Any
 }
 var file_pkg_proto_maestro_proto_depIdxs = []int32{
-	18, // 0: maestro.v1.ExecuteRequest.input:type_name -> google.protobuf.Struct
-	15, // 1: maestro.v1.ExecuteRequest.metadata:type_name -> maestro.v1.ExecuteRequest.MetadataEntry
+	23, // 0: maestro.v1.ExecuteRequest.input:type_name -> google.protobuf.Struct
+	20, // 1: maestro.v1.ExecuteRequest.metadata:type_name -> maestro.v1.ExecuteRequest.MetadataEntry
 	0,  // 2: maestro.v1.ExecuteResponse.status:type_name -> maestro.v1.WorkflowStatus
-	18, // 3: maestro.v1.ExecuteResponse.output:type_name -> google.protobuf.Struct
-	19, // 4: maestro.v1.ExecuteResponse.started_at:type_name -> google.protobuf.Timestamp
-	19, // 5: maestro.v1.ExecuteResponse.completed_at:type_name -> google.protobuf.Timestamp
+	23, // 3: maestro.v1.ExecuteResponse.output:type_name -> google.protobuf.Struct
+	24, // 4: maestro.v1.ExecuteResponse.started_at:type_name -> google.protobuf.Timestamp
+	24, // 5: maestro.v1.ExecuteResponse.completed_at:type_name -> google.protobuf.Timestamp
 	2,  // 6: maestro.v1.ExecuteEvent.type:type_name -> maestro.v1.EventType
-	19, // 7: maestro.v1.ExecuteEvent.timestamp:type_name -> google.protobuf.Timestamp
-	18, // 8: maestro.v1.ExecuteEvent.data:type_name -> google.protobuf.Struct
+	24, // 7: maestro.v1.ExecuteEvent.timestamp:type_name -> google.protobuf.Timestamp
+	23, // 8: maestro.v1.ExecuteEvent.data:type_name -> google.protobuf.Struct
 	0,  // 9: maestro.v1.StatusResponse.status:type_name -> maestro.v1.WorkflowStatus
-	14, // 10: maestro.v1.StatusResponse.steps:type_name -> maestro.v1.StepStatus
-	18, // 11: maestro.v1.StatusResponse.output:type_name -> google.protobuf.Struct
-	20, // 12: maestro.v1.ServiceRequest.payload:type_name -> google.protobuf.Any
-	16, // 13: maestro.v1.ServiceRequest.headers:type_name -> maestro.v1.ServiceRequest.HeadersEntry
-	20, // 14: maestro.v1.ServiceResponse.data:type_name -> google.protobuf.Any
-	17, // 15: maestro.v1.ServiceResponse.metadata:type_name -> maestro.v1.ServiceResponse.MetadataEntry
-	19, // 16: maestro.v1.HealthStatus.checked_at:type_name -> google.protobuf.Timestamp
-	1,  // 17: maestro.v1.StepStatus.state:type_name -> maestro.v1.StepState
-	19, // 18: maestro.v1.StepStatus.started_at:type_name -> google.protobuf.Timestamp
-	19, // 19: maestro.v1.StepStatus.completed_at:type_name -> google.protobuf.Timestamp
-	4,  // 20: maestro.v1.Orchestrator.ExecuteWorkflow:input_type -> maestro.v1.ExecuteRequest
-	4,  // 21: maestro.v1.Orchestrator.ExecuteWorkflowStream:input_type -> maestro.v1.ExecuteRequest
-	7,  // 22: maestro.v1.Orchestrator.GetWorkflowStatus:input_type -> maestro.v1.StatusRequest
-	9,  // 23: maestro.v1.Orchestrator.CancelWorkflow:input_type -> maestro.v1.CancelRequest
-	11, // 24: maestro.v1.MaestroService.Execute:input_type -> maestro.v1.ServiceRequest
-	11, // 25: maestro.v1.MaestroService.Compensate:input_type -> maestro.v1.ServiceRequest
-	3,  // 26: maestro.v1.MaestroService.HealthCheck:input_type -> maestro.v1.Empty
-	5,  // 27: maestro.v1.Orchestrator.ExecuteWorkflow:output_type -> maestro.v1.ExecuteResponse
-	6,  // 28: maestro.v1.Orchestrator.ExecuteWorkflowStream:output_type -> maestro.v1.ExecuteEvent
-	8,  // 29: maestro.v1.Orchestrator.GetWorkflowStatus:output_type -> maestro.v1.StatusResponse
-	10, // 30: maestro.v1.Orchestrator.CancelWorkflow:output_type -> maestro.v1.CancelResponse
-	12, // 31: maestro.v1.MaestroService.Execute:output_type -> maestro.v1.ServiceResponse
-	12, // 32: maestro.v1.MaestroService.Compensate:output_type -> maestro.v1.ServiceResponse
-	13, // 33: maestro.v1.MaestroService.HealthCheck:output_type -> maestro.v1.HealthStatus
-	27, // [27:34] is the sub-list for method output_type
-	20, // [20:27] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	19, // 10: maestro.v1.StatusResponse.steps:type_name -> maestro.v1.StepStatus
+	23, // 11: maestro.v1.StatusResponse.output:type_name -> google.protobuf.Struct
+	11, // 12: maestro.v1.ListWorkflowsResponse.workflows:type_name -> maestro.v1.WorkflowInfo
+	25, // 13: maestro.v1.ServiceRequest.payload:type_name -> google.protobuf.Any
+	21, // 14: maestro.v1.ServiceRequest.headers:type_name -> maestro.v1.ServiceRequest.HeadersEntry
+	25, // 15: maestro.v1.ServiceResponse.data:type_name -> google.protobuf.Any
+	22, // 16: maestro.v1.ServiceResponse.metadata:type_name -> maestro.v1.ServiceResponse.MetadataEntry
+	24, // 17: maestro.v1.HealthStatus.checked_at:type_name -> google.protobuf.Timestamp
+	1,  // 18: maestro.v1.StepStatus.state:type_name -> maestro.v1.StepState
+	24, // 19: maestro.v1.StepStatus.started_at:type_name -> google.protobuf.Timestamp
+	24, // 20: maestro.v1.StepStatus.completed_at:type_name -> google.protobuf.Timestamp
+	4,  // 21: maestro.v1.Orchestrator.ExecuteWorkflow:input_type -> maestro.v1.ExecuteRequest
+	4,  // 22: maestro.v1.Orchestrator.ExecuteWorkflowStream:input_type -> maestro.v1.ExecuteRequest
+	7,  // 23: maestro.v1.Orchestrator.GetWorkflowStatus:input_type -> maestro.v1.StatusRequest
+	9,  // 24: maestro.v1.Orchestrator.CancelWorkflow:input_type -> maestro.v1.CancelRequest
+	3,  // 25: maestro.v1.Orchestrator.ListWorkflows:input_type -> maestro.v1.Empty
+	13, // 26: maestro.v1.Orchestrator.RegisterWorkflow:input_type -> maestro.v1.RegisterWorkflowRequest
+	15, // 27: maestro.v1.MaestroService.Execute:input_type -> maestro.v1.ServiceRequest
+	15, // 28: maestro.v1.MaestroService.Compensate:input_type -> maestro.v1.ServiceRequest
+	3,  // 29: maestro.v1.MaestroService.HealthCheck:input_type -> maestro.v1.Empty
+	15, // 30: maestro.v1.MaestroService.ExecuteServerStream:input_type -> maestro.v1.ServiceRequest
+	15, // 31: maestro.v1.MaestroService.ExecuteClientStream:input_type -> maestro.v1.ServiceRequest
+	16, // 32: maestro.v1.MaestroService.Cancel:input_type -> maestro.v1.CancelStepRequest
+	5,  // 33: maestro.v1.Orchestrator.ExecuteWorkflow:output_type -> maestro.v1.ExecuteResponse
+	6,  // 34: maestro.v1.Orchestrator.ExecuteWorkflowStream:output_type -> maestro.v1.ExecuteEvent
+	8,  // 35: maestro.v1.Orchestrator.GetWorkflowStatus:output_type -> maestro.v1.StatusResponse
+	10, // 36: maestro.v1.Orchestrator.CancelWorkflow:output_type -> maestro.v1.CancelResponse
+	12, // 37: maestro.v1.Orchestrator.ListWorkflows:output_type -> maestro.v1.ListWorkflowsResponse
+	14, // 38: maestro.v1.Orchestrator.RegisterWorkflow:output_type -> maestro.v1.RegisterWorkflowResponse
+	17, // 39: maestro.v1.MaestroService.Execute:output_type -> maestro.v1.ServiceResponse
+	17, // 40: maestro.v1.MaestroService.Compensate:output_type -> maestro.v1.ServiceResponse
+	18, // 41: maestro.v1.MaestroService.HealthCheck:output_type -> maestro.v1.HealthStatus
+	17, // 42: maestro.v1.MaestroService.ExecuteServerStream:output_type -> maestro.v1.ServiceResponse
+	17, // 43: maestro.v1.MaestroService.ExecuteClientStream:output_type -> maestro.v1.ServiceResponse
+	17, // 44: maestro.v1.MaestroService.Cancel:output_type -> maestro.v1.ServiceResponse
+	33, // [33:45] is the sub-list for method output_type
+	21, // [21:33] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_pkg_proto_maestro_proto_init() }
@@ -1227,7 +1580,7 @@ func file_pkg_proto_maestro_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_proto_maestro_proto_rawDesc), len(file_pkg_proto_maestro_proto_rawDesc)),
 			NumEnums:      3,
-			NumMessages:   15,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
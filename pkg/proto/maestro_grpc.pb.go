@@ -23,6 +23,8 @@ const (
 	Orchestrator_ExecuteWorkflowStream_FullMethodName = "/maestro.v1.Orchestrator/ExecuteWorkflowStream"
 	Orchestrator_GetWorkflowStatus_FullMethodName     = "/maestro.v1.Orchestrator/GetWorkflowStatus"
 	Orchestrator_CancelWorkflow_FullMethodName        = "/maestro.v1.Orchestrator/CancelWorkflow"
+	Orchestrator_ListWorkflows_FullMethodName         = "/maestro.v1.Orchestrator/ListWorkflows"
+	Orchestrator_RegisterWorkflow_FullMethodName      = "/maestro.v1.Orchestrator/RegisterWorkflow"
 )
 
 // OrchestratorClient is the client API for Orchestrator service.
@@ -33,6 +35,8 @@ type OrchestratorClient interface {
 	ExecuteWorkflowStream(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExecuteEvent], error)
 	GetWorkflowStatus(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
 	CancelWorkflow(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error)
+	ListWorkflows(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListWorkflowsResponse, error)
+	RegisterWorkflow(ctx context.Context, in *RegisterWorkflowRequest, opts ...grpc.CallOption) (*RegisterWorkflowResponse, error)
 }
 
 type orchestratorClient struct {
@@ -92,6 +96,26 @@ func (c *orchestratorClient) CancelWorkflow(ctx context.Context, in *CancelReque
 	return out, nil
 }
 
+func (c *orchestratorClient) ListWorkflows(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListWorkflowsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWorkflowsResponse)
+	err := c.cc.Invoke(ctx, Orchestrator_ListWorkflows_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorClient) RegisterWorkflow(ctx context.Context, in *RegisterWorkflowRequest, opts ...grpc.CallOption) (*RegisterWorkflowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterWorkflowResponse)
+	err := c.cc.Invoke(ctx, Orchestrator_RegisterWorkflow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrchestratorServer is the server API for Orchestrator service.
 // All implementations must embed UnimplementedOrchestratorServer
 // for forward compatibility.
@@ -100,6 +124,8 @@ type OrchestratorServer interface {
 	ExecuteWorkflowStream(*ExecuteRequest, grpc.ServerStreamingServer[ExecuteEvent]) error
 	GetWorkflowStatus(context.Context, *StatusRequest) (*StatusResponse, error)
 	CancelWorkflow(context.Context, *CancelRequest) (*CancelResponse, error)
+	ListWorkflows(context.Context, *Empty) (*ListWorkflowsResponse, error)
+	RegisterWorkflow(context.Context, *RegisterWorkflowRequest) (*RegisterWorkflowResponse, error)
 	mustEmbedUnimplementedOrchestratorServer()
 }
 
@@ -122,6 +148,12 @@ func (UnimplementedOrchestratorServer) GetWorkflowStatus(context.Context, *Statu
 func (UnimplementedOrchestratorServer) CancelWorkflow(context.Context, *CancelRequest) (*CancelResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelWorkflow not implemented")
 }
+func (UnimplementedOrchestratorServer) ListWorkflows(context.Context, *Empty) (*ListWorkflowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkflows not implemented")
+}
+func (UnimplementedOrchestratorServer) RegisterWorkflow(context.Context, *RegisterWorkflowRequest) (*RegisterWorkflowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterWorkflow not implemented")
+}
 func (UnimplementedOrchestratorServer) mustEmbedUnimplementedOrchestratorServer() {}
 func (UnimplementedOrchestratorServer) testEmbeddedByValue()                      {}
 
@@ -208,6 +240,42 @@ func _Orchestrator_CancelWorkflow_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Orchestrator_ListWorkflows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).ListWorkflows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Orchestrator_ListWorkflows_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).ListWorkflows(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orchestrator_RegisterWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).RegisterWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Orchestrator_RegisterWorkflow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).RegisterWorkflow(ctx, req.(*RegisterWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Orchestrator_ServiceDesc is the grpc.ServiceDesc for Orchestrator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -227,6 +295,14 @@ var Orchestrator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CancelWorkflow",
 			Handler:    _Orchestrator_CancelWorkflow_Handler,
 		},
+		{
+			MethodName: "ListWorkflows",
+			Handler:    _Orchestrator_ListWorkflows_Handler,
+		},
+		{
+			MethodName: "RegisterWorkflow",
+			Handler:    _Orchestrator_RegisterWorkflow_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -239,9 +315,12 @@ var Orchestrator_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	MaestroService_Execute_FullMethodName     = "/maestro.v1.MaestroService/Execute"
-	MaestroService_Compensate_FullMethodName  = "/maestro.v1.MaestroService/Compensate"
-	MaestroService_HealthCheck_FullMethodName = "/maestro.v1.MaestroService/HealthCheck"
+	MaestroService_Execute_FullMethodName             = "/maestro.v1.MaestroService/Execute"
+	MaestroService_Compensate_FullMethodName          = "/maestro.v1.MaestroService/Compensate"
+	MaestroService_HealthCheck_FullMethodName         = "/maestro.v1.MaestroService/HealthCheck"
+	MaestroService_ExecuteServerStream_FullMethodName = "/maestro.v1.MaestroService/ExecuteServerStream"
+	MaestroService_ExecuteClientStream_FullMethodName = "/maestro.v1.MaestroService/ExecuteClientStream"
+	MaestroService_Cancel_FullMethodName              = "/maestro.v1.MaestroService/Cancel"
 )
 
 // MaestroServiceClient is the client API for MaestroService service.
@@ -251,6 +330,9 @@ type MaestroServiceClient interface {
 	Execute(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
 	Compensate(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
 	HealthCheck(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HealthStatus, error)
+	ExecuteServerStream(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ServiceResponse], error)
+	ExecuteClientStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ServiceRequest, ServiceResponse], error)
+	Cancel(ctx context.Context, in *CancelStepRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
 }
 
 type maestroServiceClient struct {
@@ -291,6 +373,48 @@ func (c *maestroServiceClient) HealthCheck(ctx context.Context, in *Empty, opts
 	return out, nil
 }
 
+func (c *maestroServiceClient) ExecuteServerStream(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ServiceResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MaestroService_ServiceDesc.Streams[0], MaestroService_ExecuteServerStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ServiceRequest, ServiceResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MaestroService_ExecuteServerStreamClient = grpc.ServerStreamingClient[ServiceResponse]
+
+func (c *maestroServiceClient) ExecuteClientStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ServiceRequest, ServiceResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MaestroService_ServiceDesc.Streams[1], MaestroService_ExecuteClientStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ServiceRequest, ServiceResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MaestroService_ExecuteClientStreamClient = grpc.ClientStreamingClient[ServiceRequest, ServiceResponse]
+
+func (c *maestroServiceClient) Cancel(ctx context.Context, in *CancelStepRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceResponse)
+	err := c.cc.Invoke(ctx, MaestroService_Cancel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MaestroServiceServer is the server API for MaestroService service.
 // All implementations must embed UnimplementedMaestroServiceServer
 // for forward compatibility.
@@ -298,6 +422,9 @@ type MaestroServiceServer interface {
 	Execute(context.Context, *ServiceRequest) (*ServiceResponse, error)
 	Compensate(context.Context, *ServiceRequest) (*ServiceResponse, error)
 	HealthCheck(context.Context, *Empty) (*HealthStatus, error)
+	ExecuteServerStream(*ServiceRequest, grpc.ServerStreamingServer[ServiceResponse]) error
+	ExecuteClientStream(grpc.ClientStreamingServer[ServiceRequest, ServiceResponse]) error
+	Cancel(context.Context, *CancelStepRequest) (*ServiceResponse, error)
 	mustEmbedUnimplementedMaestroServiceServer()
 }
 
@@ -317,6 +444,15 @@ func (UnimplementedMaestroServiceServer) Compensate(context.Context, *ServiceReq
 func (UnimplementedMaestroServiceServer) HealthCheck(context.Context, *Empty) (*HealthStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HealthCheck not implemented")
 }
+func (UnimplementedMaestroServiceServer) ExecuteServerStream(*ServiceRequest, grpc.ServerStreamingServer[ServiceResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExecuteServerStream not implemented")
+}
+func (UnimplementedMaestroServiceServer) ExecuteClientStream(grpc.ClientStreamingServer[ServiceRequest, ServiceResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExecuteClientStream not implemented")
+}
+func (UnimplementedMaestroServiceServer) Cancel(context.Context, *CancelStepRequest) (*ServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
+}
 func (UnimplementedMaestroServiceServer) mustEmbedUnimplementedMaestroServiceServer() {}
 func (UnimplementedMaestroServiceServer) testEmbeddedByValue()                        {}
 
@@ -392,6 +528,42 @@ func _MaestroService_HealthCheck_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MaestroService_ExecuteServerStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ServiceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MaestroServiceServer).ExecuteServerStream(m, &grpc.GenericServerStream[ServiceRequest, ServiceResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MaestroService_ExecuteServerStreamServer = grpc.ServerStreamingServer[ServiceResponse]
+
+func _MaestroService_ExecuteClientStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MaestroServiceServer).ExecuteClientStream(&grpc.GenericServerStream[ServiceRequest, ServiceResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MaestroService_ExecuteClientStreamServer = grpc.ClientStreamingServer[ServiceRequest, ServiceResponse]
+
+func _MaestroService_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelStepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MaestroServiceServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MaestroService_Cancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MaestroServiceServer).Cancel(ctx, req.(*CancelStepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MaestroService_ServiceDesc is the grpc.ServiceDesc for MaestroService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -411,7 +583,22 @@ var MaestroService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HealthCheck",
 			Handler:    _MaestroService_HealthCheck_Handler,
 		},
+		{
+			MethodName: "Cancel",
+			Handler:    _MaestroService_Cancel_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExecuteServerStream",
+			Handler:       _MaestroService_ExecuteServerStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExecuteClientStream",
+			Handler:       _MaestroService_ExecuteClientStream_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/proto/maestro.proto",
 }